@@ -6,13 +6,18 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"kube-tide/configs"
 	"kube-tide/internal/api"
+	"kube-tide/internal/core/audit"
+	"kube-tide/internal/core/auth"
 	"kube-tide/internal/core/k8s"
+	"kube-tide/internal/core/recording"
 	"kube-tide/internal/utils/logger"
+	"kube-tide/internal/utils/secretbox"
 
 	"go.uber.org/zap/zapcore"
 )
@@ -40,8 +45,53 @@ func main() {
 		"日志滚动启用", config.Logging.RotateConfig.Enabled,
 	)
 
+	// 初始化本地敏感数据加密
+	secretBox, err := newSecretBox(config.Storage)
+	if err != nil {
+		logger.Fatal("初始化加密密钥失败", "error", err.Error())
+	}
+
 	// init kubernetes client
-	clientManager := k8s.NewClientManager()
+	var clientManagerOpts []func(*k8s.ClientManager)
+	if config.ClusterStore.Type != "memory" {
+		storePath := config.ClusterStore.Path
+		if storePath == "" {
+			storePath = filepath.Join(config.Storage.DataDir, "clusters.json")
+		}
+		clusterStore, err := k8s.NewFileClusterStore(storePath, secretBox)
+		if err != nil {
+			logger.Fatal("初始化集群存储失败", "error", err.Error())
+		}
+		clientManagerOpts = append(clientManagerOpts, k8s.WithClusterStore(clusterStore))
+		logger.Info("集群存储已启用", "路径", storePath)
+	}
+	clientManager := k8s.NewClientManager(clientManagerOpts...)
+	if err := clientManager.LoadClusters(); err != nil {
+		logger.Error("恢复集群失败", "error", err.Error())
+	}
+
+	// 初始化认证
+	authenticator, err := newAuthenticator(config)
+	if err != nil {
+		logger.Fatal("初始化认证失败", "error", err.Error())
+	}
+
+	// 初始化平台授权策略
+	policyFile := config.Auth.PolicyFile
+	if policyFile == "" {
+		policyFile = filepath.Join(config.Storage.DataDir, "policy.yaml")
+	}
+	authorizer, err := auth.NewAuthorizer(policyFile)
+	if err != nil {
+		logger.Fatal("加载授权策略失败", "error", err.Error())
+	}
+
+	// 初始化 ConfigMap / Secret 历史版本存储，Secret 数据加密保存
+	configHistoryStore, err := k8s.NewFileConfigHistoryStore(filepath.Join(config.Storage.DataDir, "config-history"), secretBox)
+	if err != nil {
+		logger.Fatal("初始化配置历史存储失败", "error", err.Error())
+	}
+	configHistory := k8s.NewConfigHistory(configHistoryStore)
 
 	// create services
 	nodePoolService := k8s.NewNodePoolService(clientManager)
@@ -55,21 +105,58 @@ func main() {
 	autoScalerService := k8s.NewAutoScalerService(clientManager)
 	hpaService := k8s.NewHPAService(clientManager)
 	daemonSetService := k8s.NewDaemonSetService(clientManager)
-	jobService := k8s.NewJobService(clientManager)
+	jobService := k8s.NewJobService(clientManager, podService)
 	cronJobService := k8s.NewCronJobService(clientManager)
 	networkPolicyService := k8s.NewNetworkPolicyService(clientManager)
 	pvcService := k8s.NewPVCService(clientManager)
 	pvService := k8s.NewPVService(clientManager)
 	storageClassService := k8s.NewStorageClassService(clientManager)
+	volumeSnapshotService := k8s.NewVolumeSnapshotService(clientManager, pvcService)
 	resourceQuotaService := k8s.NewResourceQuotaService(clientManager)
 	limitRangeService := k8s.NewLimitRangeService(clientManager)
 	pdbService := k8s.NewPDBService(clientManager)
 	rbacService := k8s.NewRBACService(clientManager)
 	prometheusService := k8s.NewPrometheusService(clientManager)
 	clusterEventService := k8s.NewClusterEventService(clientManager)
-	configMapService := k8s.NewConfigMapService(clientManager)
-	secretService := k8s.NewSecretService(clientManager)
+	clusterHistoryService := k8s.NewClusterHistoryService(clientManager, prometheusService)
+	configMapService := k8s.NewConfigMapService(clientManager, configHistory)
+	secretService := k8s.NewSecretService(clientManager, configHistory)
+	configConsumerService := k8s.NewConfigConsumerService(clientManager, deploymentService, statefulSetService, daemonSetService)
 	trafficTopologyService := k8s.NewTrafficTopologyService(clientManager, prometheusService)
+	manifestService := k8s.NewManifestService(clientManager)
+	customResourceService := k8s.NewCustomResourceService(clientManager)
+	resourceWatchService := k8s.NewResourceWatchService(clientManager)
+
+	// 初始化渐进式金丝雀与蓝绿发布控制器，进度保存在数据目录中以便重启后继续
+	canaryStore, err := k8s.NewFileCanaryStore(filepath.Join(config.Storage.DataDir, "canaries.json"))
+	if err != nil {
+		logger.Fatal("初始化金丝雀发布存储失败", "error", err.Error())
+	}
+	canaryController := k8s.NewCanaryController(clientManager, prometheusService, canaryStore)
+	blueGreenStore, err := k8s.NewFileBlueGreenStore(filepath.Join(config.Storage.DataDir, "bluegreen.json"))
+	if err != nil {
+		logger.Fatal("初始化蓝绿发布存储失败", "error", err.Error())
+	}
+	blueGreenController := k8s.NewBlueGreenController(clientManager, deploymentService, serviceManager, ingressManager, blueGreenStore)
+
+	// 初始化集群健康检查与告警规则
+	alertRulesPath := config.Health.RulesPath
+	if alertRulesPath == "" {
+		alertRulesPath = filepath.Join(config.Storage.DataDir, "alert-rules.json")
+	}
+	alertRuleStore, err := k8s.NewFileAlertRuleStore(alertRulesPath)
+	if err != nil {
+		logger.Fatal("初始化告警规则存储失败", "error", err.Error())
+	}
+	var alertNotifiers []k8s.AlertNotifier
+	if len(config.Health.WebhookURLs) > 0 {
+		alertNotifiers = append(alertNotifiers, k8s.NewWebhookNotifier(config.Health.WebhookURLs))
+	}
+	alertEngine, err := k8s.NewAlertEngine(alertRuleStore, alertNotifiers...)
+	if err != nil {
+		logger.Fatal("加载告警规则失败", "error", err.Error())
+	}
+	clusterHealthService := k8s.NewClusterHealthService(clientManager, alertEngine, config.Health.Interval)
 
 	// 初始化Pod指标服务，用于收集和缓存监控数据
 	podMetricsService := k8s.NewPodMetricsService(clientManager)
@@ -77,6 +164,18 @@ func main() {
 	// 启动后台任务，每1分钟定期收集所有集群的Pod指标
 	ctx, cancelCollect := context.WithCancel(context.Background())
 
+	// 加载落盘的Pod指标历史，并定期保存快照
+	if config.MetricsStore.Enabled {
+		metricsDir := config.MetricsStore.Dir
+		if metricsDir == "" {
+			metricsDir = filepath.Join(config.Storage.DataDir, "metrics")
+		}
+		if err := podMetricsService.EnableStorage(metricsDir, config.MetricsStore.Retention); err != nil {
+			logger.Error("加载Pod指标历史失败", "error", err.Error())
+		}
+		podMetricsService.StartPeriodicSnapshots(ctx, config.MetricsStore.SnapshotInterval)
+	}
+
 	// 获取所有集群并为每个集群启动指标收集
 	clusters := clientManager.ListClusters()
 	if len(clusters) > 0 {
@@ -88,6 +187,18 @@ func main() {
 		logger.Warn("无法启动Pod指标收集", "错误", nil)
 	}
 
+	// 启动集群历史指标采集（未配置 Prometheus 的集群使用本地采样）
+	clusterHistoryService.Start(ctx)
+
+	// 启动金丝雀与蓝绿发布控制器
+	canaryController.Start(ctx)
+	blueGreenController.Start(ctx)
+
+	// 启动集群健康检查，关闭时只在请求健康状况时检查
+	if config.Health.Enabled {
+		clusterHealthService.Start(ctx)
+	}
+
 	// 启动定期清理过期缓存的任务
 	go func() {
 		ticker := time.NewTicker(1 * time.Hour)
@@ -104,6 +215,33 @@ func main() {
 		}
 	}()
 
+	// 初始化终端录像
+	var recordingStore *recording.Store
+	if config.Recording.Enabled {
+		recordingDir := config.Recording.Dir
+		if recordingDir == "" {
+			recordingDir = filepath.Join(config.Storage.DataDir, "recordings")
+		}
+		recordingStore, err = recording.NewStore(recordingDir, config.Recording.RecordInput)
+		if err != nil {
+			logger.Fatal("初始化终端录像失败", "error", err.Error())
+		}
+		recordingStore.Prune(config.Recording.Retention)
+		go func() {
+			ticker := time.NewTicker(24 * time.Hour)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					recordingStore.Prune(config.Recording.Retention)
+				}
+			}
+		}()
+	}
+
 	// create API handlers
 	nodeHandler := api.NewNodeHandler(nodeService)
 	podHandler := api.NewPodHandler(podService)
@@ -111,9 +249,9 @@ func main() {
 	nodePoolHandler := api.NewNodePoolHandler(nodePoolService)
 	serviceHandler := api.NewServiceHandler(serviceManager)
 	ingressHandler := api.NewIngressHandler(ingressManager)
-	clusterHandler := api.NewClusterHandler(clientManager, clusterEventService)
-	healthHandler := api.NewHealthCheckHandler()
-	podTerminalHandler := api.NewPodTerminalHandler(podService)
+	clusterHandler := api.NewClusterHandler(clientManager, clusterEventService, clusterHistoryService)
+	healthHandler := api.NewHealthCheckHandler(clusterHealthService, alertEngine)
+	podTerminalHandler := api.NewPodTerminalHandler(podService, config.Auth.AllowedOrigins, recordingStore)
 	namespaceHandler := api.NewNamespaceHandler(namespaceService)       // 初始化命名空间处理器
 	statefulSetHandler := api.NewStatefulSetHandler(statefulSetService) // 初始化StatefulSet处理器
 	autoScalerHandler := api.NewAutoScalerHandler(autoScalerService)
@@ -125,6 +263,7 @@ func main() {
 	pvcHandler := api.NewPVCHandler(pvcService)
 	pvHandler := api.NewPVHandler(pvService)
 	storageClassHandler := api.NewStorageClassHandler(storageClassService)
+	volumeSnapshotHandler := api.NewVolumeSnapshotHandler(volumeSnapshotService)
 	resourceQuotaHandler := api.NewResourceQuotaHandler(resourceQuotaService)
 	limitRangeHandler := api.NewLimitRangeHandler(limitRangeService)
 	pdbHandler := api.NewPDBHandler(pdbService)
@@ -132,10 +271,35 @@ func main() {
 	prometheusHandler := api.NewPrometheusHandler(prometheusService)
 	configMapHandler := api.NewConfigMapHandler(configMapService)
 	secretHandler := api.NewSecretHandler(secretService)
+	configConsumerHandler := api.NewConfigConsumerHandler(configConsumerService)
 	trafficTopologyHandler := api.NewTrafficTopologyHandler(trafficTopologyService)
+	manifestHandler := api.NewManifestHandler(manifestService)
+	customResourceHandler := api.NewCustomResourceHandler(customResourceService)
+	watchHandler := api.NewWatchHandler(resourceWatchService, config.Auth.AllowedOrigins)
+	canaryHandler := api.NewCanaryHandler(canaryController)
+	blueGreenHandler := api.NewBlueGreenHandler(blueGreenController)
+	// 初始化审计日志
+	var auditLogger *audit.Logger
+	if config.Audit.Enabled {
+		auditLogger = audit.NewLogger(config.Audit.Path, config.Audit.Rotate)
+		logger.Info("审计日志已启用", "path", config.Audit.Path)
+	}
+
+	authHandler := api.NewAuthHandler(authenticator)
+	policyHandler := api.NewPolicyHandler(authorizer)
+	auditHandler := api.NewAuditHandler(auditLogger)
+	recordingHandler := api.NewRecordingHandler(recordingStore)
 
 	// Create an app instance and initialize the route
 	app := &api.App{
+		Authenticator:          authenticator,
+		Authorizer:             authorizer,
+		AllowedOrigins:         config.Auth.AllowedOrigins,
+		AuthHandler:            authHandler,
+		PolicyHandler:          policyHandler,
+		AuditLogger:            auditLogger,
+		AuditHandler:           auditHandler,
+		RecordingHandler:       recordingHandler,
 		ClusterHandler:         clusterHandler,
 		NodeHandler:            nodeHandler,
 		PodHandler:             podHandler,
@@ -156,6 +320,7 @@ func main() {
 		PVCHandler:             pvcHandler,
 		PVHandler:              pvHandler,
 		StorageClassHandler:    storageClassHandler,
+		VolumeSnapshotHandler:  volumeSnapshotHandler,
 		ResourceQuotaHandler:   resourceQuotaHandler,
 		LimitRangeHandler:      limitRangeHandler,
 		PDBHandler:             pdbHandler,
@@ -163,7 +328,13 @@ func main() {
 		PrometheusHandler:      prometheusHandler,
 		ConfigMapHandler:       configMapHandler,
 		SecretHandler:          secretHandler,
+		ConfigConsumerHandler:  configConsumerHandler,
 		TrafficTopologyHandler: trafficTopologyHandler,
+		ManifestHandler:        manifestHandler,
+		CustomResourceHandler:  customResourceHandler,
+		WatchHandler:           watchHandler,
+		CanaryHandler:          canaryHandler,
+		BlueGreenHandler:       blueGreenHandler,
 	}
 
 	// Initialize the router defined in router.go
@@ -175,6 +346,9 @@ func main() {
 		Handler: r,
 	}
 
+	// 存储已加载、后台服务已启动，开始接收流量
+	healthHandler.SetReady(true)
+
 	// Start the server in a separate goroutine
 	go func() {
 		logger.Info("服务器启动",
@@ -196,21 +370,94 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Shutting down the server...")
+	healthHandler.SetReady(false)
 
 	// 停止指标收集
 	cancelCollect()
 	logger.Info("已停止指标数据收集")
 
+	// 保存最后一次Pod指标快照
+	if err := podMetricsService.CloseStorage(); err != nil {
+		logger.Warn("保存Pod指标快照失败", "error", err.Error())
+	}
+
 	// Set a 5-second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Fatal("Server forced to shutdown", "error", err.Error())
 	}
+	if err := auditLogger.Close(); err != nil {
+		logger.Warn("failed to close audit log", "error", err.Error())
+	}
+	// 停止集群共享缓存
+	clientManager.StopInformers()
 
 	logger.Info("Server has exited safely")
 }
 
+// newSecretBox 根据存储配置创建加密器：优先使用配置的口令，否则使用数据目录下的密钥文件
+func newSecretBox(storage configs.StorageConfig) (*secretbox.Box, error) {
+	if storage.EncryptionKey != "" {
+		return secretbox.NewFromPassphrase(storage.EncryptionKey)
+	}
+	return secretbox.LoadOrCreate(filepath.Join(storage.DataDir, "secret.key"))
+}
+
+// newAuthenticator 根据认证配置创建认证器，并在用户库为空时创建初始管理员
+func newAuthenticator(config *configs.Config) (*auth.Authenticator, error) {
+	users, err := auth.NewUserStore(filepath.Join(config.Storage.DataDir, "users.json"))
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := auth.NewTokenStore(filepath.Join(config.Storage.DataDir, "tokens.json"))
+	if err != nil {
+		return nil, err
+	}
+	sessions := auth.NewSessionManager(config.Auth.SessionTTL)
+
+	if !config.Auth.Enabled {
+		logger.Warn("认证已关闭，所有请求将以管理员身份处理，请勿在生产环境使用")
+		return auth.NewAuthenticator(false, users, tokens, sessions, nil), nil
+	}
+
+	admin := config.Auth.BootstrapAdmin
+	generated, err := auth.EnsureBootstrapAdmin(users, admin.Username, admin.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bootstrap admin: %w", err)
+	}
+	if generated != "" {
+		// 随机密码不写入日志（日志可能落盘或被采集），只保存到仅属主可读的文件
+		passwordFile := filepath.Join(config.Storage.DataDir, "initial-admin-password")
+		if err := os.WriteFile(passwordFile, []byte(generated+"\n"), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write bootstrap admin password: %w", err)
+		}
+		logger.Warn("已创建初始管理员，密码保存在文件中，请登录后立即修改密码并删除该文件", "用户名", admin.Username, "文件", passwordFile)
+	}
+
+	var oidcProvider *auth.OIDCProvider
+	if config.Auth.OIDC.Enabled {
+		oidcConfig := config.Auth.OIDC
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		oidcProvider, err = auth.NewOIDCProvider(ctx, auth.OIDCConfig{
+			IssuerURL:     oidcConfig.IssuerURL,
+			ClientID:      oidcConfig.ClientID,
+			ClientSecret:  oidcConfig.ClientSecret,
+			RedirectURL:   oidcConfig.RedirectURL,
+			Scopes:        oidcConfig.Scopes,
+			UsernameClaim: oidcConfig.UsernameClaim,
+			GroupsClaim:   oidcConfig.GroupsClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("OIDC 登录已启用", "issuer", oidcConfig.IssuerURL)
+	}
+
+	return auth.NewAuthenticator(true, users, tokens, sessions, oidcProvider), nil
+}
+
 func getLogLevel(level string) zapcore.Level {
 	switch level {
 	case "debug":
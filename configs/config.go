@@ -3,6 +3,7 @@ package configs
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,8 +13,15 @@ var BuildMode string
 
 // Config application configuration structure
 type Config struct {
-	Server  ServerConfig  `mapstructure:"server"`
-	Logging LoggingConfig `mapstructure:"logging"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Logging      LoggingConfig      `mapstructure:"logging"`
+	Audit        AuditConfig        `mapstructure:"audit"`
+	Recording    RecordingConfig    `mapstructure:"recording"`
+	MetricsStore MetricsStoreConfig `mapstructure:"metrics_store"`
+	Health       HealthConfig       `mapstructure:"health"`
+	Storage      StorageConfig      `mapstructure:"storage"`
+	ClusterStore ClusterStoreConfig `mapstructure:"cluster_store"`
+	Auth         AuthConfig         `mapstructure:"auth"`
 }
 
 // ServerConfig Server configuration
@@ -22,6 +30,46 @@ type ServerConfig struct {
 	Host string `mapstructure:"host"`
 }
 
+// StorageConfig Local storage configuration
+type StorageConfig struct {
+	DataDir       string `mapstructure:"data_dir"`       // 本地数据目录
+	EncryptionKey string `mapstructure:"encryption_key"` // 敏感数据加密口令，为空时自动生成 <data_dir>/secret.key
+}
+
+// ClusterStoreConfig Cluster registry persistence configuration
+type ClusterStoreConfig struct {
+	Type string `mapstructure:"type"` // 存储类型："file"(默认) 或 "memory"(不持久化)
+	Path string `mapstructure:"path"` // 文件路径，为空时使用 <data_dir>/clusters.json
+}
+
+// AuthConfig Authentication configuration
+type AuthConfig struct {
+	Enabled        bool                 `mapstructure:"enabled"`         // 是否启用认证，关闭时所有请求以 anonymous 管理员身份处理
+	SessionTTL     time.Duration        `mapstructure:"session_ttl"`     // 浏览器会话有效期
+	AllowedOrigins []string             `mapstructure:"allowed_origins"` // 允许跨域访问 API 和终端 WebSocket 的来源，同源请求始终允许
+	PolicyFile     string               `mapstructure:"policy_file"`     // 授权策略文件，为空时使用 <data_dir>/policy.yaml
+	BootstrapAdmin BootstrapAdminConfig `mapstructure:"bootstrap_admin"`
+	OIDC           OIDCConfig           `mapstructure:"oidc"`
+}
+
+// BootstrapAdminConfig Initial administrator created when the user store is empty
+type BootstrapAdminConfig struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"` // 为空时生成随机密码，写入 <data_dir>/initial-admin-password（权限 0600），不输出到日志
+}
+
+// OIDCConfig OpenID Connect login configuration
+type OIDCConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	IssuerURL     string   `mapstructure:"issuer_url"`
+	ClientID      string   `mapstructure:"client_id"`
+	ClientSecret  string   `mapstructure:"client_secret"`
+	RedirectURL   string   `mapstructure:"redirect_url"` // 例如 https://kube-tide.example.com/api/auth/oidc/callback
+	Scopes        []string `mapstructure:"scopes"`
+	UsernameClaim string   `mapstructure:"username_claim"`
+	GroupsClaim   string   `mapstructure:"groups_claim"`
+}
+
 // LoggingConfig Logging configuration
 type LoggingConfig struct {
 	Level        string          `mapstructure:"level"`
@@ -29,6 +77,37 @@ type LoggingConfig struct {
 	RotateConfig LogRotateConfig `mapstructure:"rotate"`
 }
 
+// AuditConfig 审计日志配置
+type AuditConfig struct {
+	Enabled bool            `mapstructure:"enabled"` // 是否记录审计日志
+	Path    string          `mapstructure:"path"`    // JSON-lines 审计文件路径
+	Rotate  LogRotateConfig `mapstructure:"rotate"`  // 按大小滚动，使用 max_size / max_backups / max_age / compression
+}
+
+// RecordingConfig 终端录像配置，具体录制哪些集群/命名空间由录像规则决定
+type RecordingConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`      // 是否启用终端录像
+	Dir         string        `mapstructure:"dir"`          // 录像目录，为空时使用 <data_dir>/recordings
+	RecordInput bool          `mapstructure:"record_input"` // 是否录制用户输入（可能包含密码）
+	Retention   time.Duration `mapstructure:"retention"`    // 录像保留时长，0 表示不清理
+}
+
+// MetricsStoreConfig Pod 指标历史落盘配置
+type MetricsStoreConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`           // 是否将 Pod 指标历史写入磁盘
+	Dir              string        `mapstructure:"dir"`               // 存储目录，为空时使用 <data_dir>/metrics
+	Retention        time.Duration `mapstructure:"retention"`         // 历史数据保留时长
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"` // 快照间隔，关闭服务时也会保存一次
+}
+
+// HealthConfig 集群健康检查与告警配置
+type HealthConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`      // 是否定期检查已注册集群
+	Interval    time.Duration `mapstructure:"interval"`     // 检查间隔
+	RulesPath   string        `mapstructure:"rules_path"`   // 告警规则文件，为空时使用 <data_dir>/alert-rules.json
+	WebhookURLs []string      `mapstructure:"webhook_urls"` // 告警触发、升级与恢复时以 JSON POST 通知的地址
+}
+
 // LogFileConfig File logging configuration
 type LogFileConfig struct {
 	Enabled   bool   `mapstructure:"enabled"`    // 是否启用文件日志
@@ -73,6 +152,54 @@ func LoadConfig() *Config {
 	viper.SetDefault("logging.rotate.local_time", true)
 	viper.SetDefault("logging.rotate.rotation_time", "daily")
 
+	// Set default values for audit log
+	viper.SetDefault("audit.enabled", true)
+	viper.SetDefault("audit.path", "./logs/audit.jsonl")
+	viper.SetDefault("audit.rotate.max_size", 100)
+	viper.SetDefault("audit.rotate.max_age", 180)
+	viper.SetDefault("audit.rotate.max_backups", 30)
+	viper.SetDefault("audit.rotate.compression", "none")
+	viper.SetDefault("audit.rotate.local_time", true)
+
+	// Set default values for terminal recording
+	viper.SetDefault("recording.enabled", true)
+	viper.SetDefault("recording.dir", "")
+	viper.SetDefault("recording.record_input", false)
+	viper.SetDefault("recording.retention", "2160h")
+
+	// Set default values for pod metrics history storage
+	viper.SetDefault("metrics_store.enabled", true)
+	viper.SetDefault("metrics_store.dir", "")
+	viper.SetDefault("metrics_store.retention", "168h")
+	viper.SetDefault("metrics_store.snapshot_interval", "5m")
+
+	// Set default values for cluster health checks and alerting
+	viper.SetDefault("health.enabled", true)
+	viper.SetDefault("health.interval", "1m")
+	viper.SetDefault("health.rules_path", "")
+	viper.SetDefault("health.webhook_urls", []string{})
+
+	// Set default values for local storage
+	viper.SetDefault("storage.data_dir", "./data")
+	viper.SetDefault("storage.encryption_key", "")
+	_ = viper.BindEnv("storage.encryption_key", "KUBE_TIDE_ENCRYPTION_KEY")
+	viper.SetDefault("cluster_store.type", "file")
+	viper.SetDefault("cluster_store.path", "")
+
+	// Set default values for authentication
+	viper.SetDefault("auth.enabled", true)
+	viper.SetDefault("auth.session_ttl", "12h")
+	viper.SetDefault("auth.allowed_origins", []string{})
+	viper.SetDefault("auth.policy_file", "")
+	viper.SetDefault("auth.bootstrap_admin.username", "admin")
+	viper.SetDefault("auth.bootstrap_admin.password", "")
+	_ = viper.BindEnv("auth.bootstrap_admin.password", "KUBE_TIDE_ADMIN_PASSWORD")
+	viper.SetDefault("auth.oidc.enabled", false)
+	viper.SetDefault("auth.oidc.scopes", []string{"openid", "profile", "email"})
+	viper.SetDefault("auth.oidc.username_claim", "email")
+	viper.SetDefault("auth.oidc.groups_claim", "groups")
+	_ = viper.BindEnv("auth.oidc.client_secret", "KUBE_TIDE_OIDC_CLIENT_SECRET")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Printf("Warning: unable to read config file: %v", err)
 		log.Println("Using default configuration")
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"kube-tide/internal/core/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler 审计日志处理器
+type AuditHandler struct {
+	auditLogger *audit.Logger
+}
+
+// NewAuditHandler 创建审计日志处理器
+func NewAuditHandler(auditLogger *audit.Logger) *AuditHandler {
+	return &AuditHandler{auditLogger: auditLogger}
+}
+
+// QueryAuditLogs 查询审计日志（管理员）
+// 支持 user、cluster、namespace、resource、action、method、status、since、until（RFC3339）、page、limit 参数
+func (h *AuditHandler) QueryAuditLogs(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	if h.auditLogger == nil {
+		ResponseError(c, http.StatusNotFound, "audit.disabled")
+		return
+	}
+
+	filter := audit.Filter{
+		User:      c.Query("user"),
+		Cluster:   c.Query("cluster"),
+		Namespace: c.Query("namespace"),
+		Resource:  c.Query("resource"),
+		Action:    c.Query("action"),
+		Method:    c.Query("method"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if status := c.Query("status"); status != "" {
+		v, err := strconv.Atoi(status)
+		if err != nil {
+			ResponseError(c, http.StatusBadRequest, "audit.invalidFilter")
+			return
+		}
+		filter.Status = v
+	}
+	for param, target := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if value := c.Query(param); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				ResponseError(c, http.StatusBadRequest, "audit.invalidFilter")
+				return
+			}
+			*target = t
+		}
+	}
+
+	result, err := h.auditLogger.Query(filter)
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "audit.queryFailed", err)
+		return
+	}
+	ResponseSuccess(c, result)
+}
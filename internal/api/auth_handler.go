@@ -0,0 +1,287 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookieName OIDC 登录 state Cookie 名称
+const oidcStateCookieName = "kube_tide_oidc_state"
+
+// AuthHandler 认证处理器：登录/登出、OIDC、API Token 与本地用户管理
+type AuthHandler struct {
+	authenticator *auth.Authenticator
+}
+
+// NewAuthHandler 创建认证处理器
+func NewAuthHandler(authenticator *auth.Authenticator) *AuthHandler {
+	return &AuthHandler{authenticator: authenticator}
+}
+
+// isSecureRequest 判断请求是否经 HTTPS 到达（含反向代理）
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+func (h *AuthHandler) setSessionCookie(c *gin.Context, sessionID string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(auth.SessionCookieName, sessionID, maxAge, "/", "", isSecureRequest(c), true)
+}
+
+// GetAuthConfig 返回登录页需要的认证配置
+func (h *AuthHandler) GetAuthConfig(c *gin.Context) {
+	ResponseSuccess(c, gin.H{
+		"enabled":     h.authenticator.Enabled(),
+		"oidcEnabled": h.authenticator.OIDC() != nil,
+	})
+}
+
+// Login 本地用户登录
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req struct {
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidRequest")
+		return
+	}
+
+	identity, sessionID, err := h.authenticator.Login(req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			logger.Warn("login failed", "username", req.Username, "clientIP", c.ClientIP())
+			ResponseError(c, http.StatusUnauthorized, "auth.invalidCredentials")
+			return
+		}
+		FailWithError(c, http.StatusInternalServerError, "auth.loginFailed", err)
+		return
+	}
+
+	logger.Info("user logged in", "username", identity.Username, "method", identity.Method, "clientIP", c.ClientIP())
+	h.setSessionCookie(c, sessionID, int(h.authenticator.SessionTTL().Seconds()))
+	ResponseSuccess(c, gin.H{"user": identity})
+}
+
+// Logout 退出登录
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if sessionID, err := c.Cookie(auth.SessionCookieName); err == nil && sessionID != "" {
+		h.authenticator.Logout(sessionID)
+	}
+	h.setSessionCookie(c, "", -1)
+	ResponseSuccess(c, gin.H{"message": "auth.logoutSuccess"})
+}
+
+// OIDCLogin 跳转到 OIDC 提供方登录
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	provider := h.authenticator.OIDC()
+	if provider == nil {
+		ResponseError(c, http.StatusNotFound, "auth.oidcDisabled")
+		return
+	}
+	state, err := auth.NewState()
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "auth.oidcLoginFailed", err)
+		return
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookieName, state, int((10 * time.Minute).Seconds()), "/api/auth/oidc", "", isSecureRequest(c), true)
+	c.Redirect(http.StatusFound, provider.AuthCodeURL(state))
+}
+
+// OIDCCallback OIDC 授权码回调，校验 state 后创建会话并跳转到首页
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	if h.authenticator.OIDC() == nil {
+		ResponseError(c, http.StatusNotFound, "auth.oidcDisabled")
+		return
+	}
+	state, err := c.Cookie(oidcStateCookieName)
+	if err != nil || state == "" || state != c.Query("state") {
+		ResponseError(c, http.StatusBadRequest, "auth.oidcStateMismatch")
+		return
+	}
+	c.SetCookie(oidcStateCookieName, "", -1, "/api/auth/oidc", "", isSecureRequest(c), true)
+
+	if errMsg := c.Query("error"); errMsg != "" {
+		FailWithError(c, http.StatusUnauthorized, "auth.oidcLoginFailed", errors.New(errMsg))
+		return
+	}
+
+	identity, sessionID, err := h.authenticator.LoginOIDC(c.Request.Context(), c.Query("code"))
+	if err != nil {
+		logger.Warn("oidc login failed", "error", err.Error(), "clientIP", c.ClientIP())
+		FailWithError(c, http.StatusUnauthorized, "auth.oidcLoginFailed", err)
+		return
+	}
+
+	logger.Info("user logged in", "username", identity.Username, "method", identity.Method, "clientIP", c.ClientIP())
+	h.setSessionCookie(c, sessionID, int(h.authenticator.SessionTTL().Seconds()))
+	c.Redirect(http.StatusFound, "/")
+}
+
+// GetCurrentUser 返回当前登录身份
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
+	identity := middleware.GetIdentity(c)
+	ResponseSuccess(c, gin.H{"user": identity, "isAdmin": identity.IsAdmin()})
+}
+
+// ChangePassword 本地用户修改自己的密码
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	identity := middleware.GetIdentity(c)
+	if identity.Method != auth.MethodLocal {
+		ResponseError(c, http.StatusBadRequest, "auth.localUserOnly")
+		return
+	}
+	var req struct {
+		OldPassword string `json:"oldPassword" binding:"required"`
+		NewPassword string `json:"newPassword" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidRequest")
+		return
+	}
+	if _, err := h.authenticator.Users().Verify(identity.Username, req.OldPassword); err != nil {
+		ResponseError(c, http.StatusBadRequest, "auth.invalidCredentials")
+		return
+	}
+	if _, err := h.authenticator.UpdateUser(identity.Username, auth.UpdateUserRequest{Password: &req.NewPassword}); err != nil {
+		FailWithError(c, http.StatusBadRequest, "auth.passwordChangeFailed", err)
+		return
+	}
+	h.setSessionCookie(c, "", -1)
+	ResponseSuccess(c, nil)
+}
+
+// ListTokens 列出 API Token；管理员可通过 ?all=true 查看全部
+func (h *AuthHandler) ListTokens(c *gin.Context) {
+	identity := middleware.GetIdentity(c)
+	username := identity.Username
+	if identity.IsAdmin() && c.Query("all") == "true" {
+		username = ""
+	}
+	ResponseSuccess(c, gin.H{"tokens": h.authenticator.Tokens().List(username)})
+}
+
+// CreateToken 为当前用户签发 API Token，明文仅在响应中返回一次
+func (h *AuthHandler) CreateToken(c *gin.Context) {
+	var req struct {
+		Name           string `json:"name" binding:"required"`
+		ExpiresInHours int    `json:"expiresInHours"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidRequest")
+		return
+	}
+	identity := middleware.GetIdentity(c)
+	ttl := time.Duration(req.ExpiresInHours) * time.Hour
+	token, info, err := h.authenticator.Tokens().Create(identity, req.Name, ttl)
+	if err != nil {
+		FailWithError(c, http.StatusBadRequest, "auth.tokenCreateFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"token": token, "info": info})
+}
+
+// DeleteToken 吊销 API Token，非管理员只能吊销自己的 Token
+func (h *AuthHandler) DeleteToken(c *gin.Context) {
+	identity := middleware.GetIdentity(c)
+	info, err := h.authenticator.Tokens().Get(c.Param("id"))
+	if err != nil || (info.Username != identity.Username && !identity.IsAdmin()) {
+		ResponseError(c, http.StatusNotFound, "auth.tokenNotFound")
+		return
+	}
+	if err := h.authenticator.Tokens().Delete(info.ID); err != nil {
+		FailWithError(c, http.StatusInternalServerError, "auth.tokenDeleteFailed", err)
+		return
+	}
+	ResponseSuccess(c, nil)
+}
+
+// requireAdmin 仅允许平台管理员继续
+func requireAdmin(c *gin.Context) bool {
+	if !middleware.GetIdentity(c).IsAdmin() {
+		ResponseError(c, http.StatusForbidden, "auth.forbidden")
+		return false
+	}
+	return true
+}
+
+// ListUsers 列出本地用户（管理员）
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	ResponseSuccess(c, gin.H{"users": h.authenticator.Users().List()})
+}
+
+// CreateUser 创建本地用户（管理员）
+func (h *AuthHandler) CreateUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	var req struct {
+		Username string   `json:"username" binding:"required"`
+		Password string   `json:"password" binding:"required"`
+		Groups   []string `json:"groups"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidRequest")
+		return
+	}
+	user, err := h.authenticator.Users().Create(req.Username, req.Password, req.Groups)
+	if err != nil {
+		FailWithError(c, http.StatusBadRequest, "auth.userCreateFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"user": user})
+}
+
+// UpdateUser 更新本地用户密码、组或禁用状态（管理员）
+func (h *AuthHandler) UpdateUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	var req auth.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidRequest")
+		return
+	}
+	user, err := h.authenticator.UpdateUser(c.Param("username"), req)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			ResponseError(c, http.StatusNotFound, "auth.userNotFound")
+			return
+		}
+		FailWithError(c, http.StatusBadRequest, "auth.userUpdateFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"user": user})
+}
+
+// DeleteUser 删除本地用户（管理员）
+func (h *AuthHandler) DeleteUser(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	username := c.Param("username")
+	if username == middleware.GetIdentity(c).Username {
+		ResponseError(c, http.StatusBadRequest, "auth.cannotDeleteSelf")
+		return
+	}
+	if err := h.authenticator.DeleteUser(username); err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			ResponseError(c, http.StatusNotFound, "auth.userNotFound")
+			return
+		}
+		FailWithError(c, http.StatusInternalServerError, "auth.userDeleteFailed", err)
+		return
+	}
+	ResponseSuccess(c, nil)
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"kube-tide/internal/core/k8s"
 	"kube-tide/internal/utils/logger"
 	"net/http"
@@ -29,7 +28,7 @@ func (h *AutoScalerHandler) GetAutoScalerConfig(c *gin.Context) {
 		return
 	}
 
-	config, err := h.service.GetAutoScalerConfig(context.Background(), clusterName)
+	config, err := h.service.GetAutoScalerConfig(c.Request.Context(), clusterName)
 	if err != nil {
 		logger.Errorf("Failed to get autoscaler config: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "autoscaler.getConfigFailed", err.Error())
@@ -55,7 +54,7 @@ func (h *AutoScalerHandler) UpdateAutoScalerConfig(c *gin.Context) {
 		return
 	}
 
-	err := h.service.UpdateAutoScalerConfig(context.Background(), clusterName, &config)
+	err := h.service.UpdateAutoScalerConfig(c.Request.Context(), clusterName, &config)
 	if err != nil {
 		logger.Errorf("Failed to update autoscaler config: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "autoscaler.updateConfigFailed", err.Error())
@@ -75,7 +74,7 @@ func (h *AutoScalerHandler) GetAutoScalerStatus(c *gin.Context) {
 		return
 	}
 
-	status, err := h.service.GetAutoScalerStatus(context.Background(), clusterName)
+	status, err := h.service.GetAutoScalerStatus(c.Request.Context(), clusterName)
 	if err != nil {
 		logger.Errorf("Failed to get autoscaler status: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "autoscaler.getStatusFailed", err.Error())
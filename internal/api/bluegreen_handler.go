@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlueGreenHandler exposes blue/green deployments with Service selector switching
+type BlueGreenHandler struct {
+	controller *k8s.BlueGreenController
+}
+
+// NewBlueGreenHandler creates a blue/green deployment handler
+func NewBlueGreenHandler(controller *k8s.BlueGreenController) *BlueGreenHandler {
+	return &BlueGreenHandler{controller: controller}
+}
+
+// Preview lists the Services and Ingress paths a switch would affect
+func (h *BlueGreenHandler) Preview(c *gin.Context) {
+	preview, err := h.controller.Preview(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"), c.Query("colorLabelKey"))
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "blueGreen.previewFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"preview": preview})
+}
+
+// Prepare creates the parallel deployment of the other color
+func (h *BlueGreenHandler) Prepare(c *gin.Context) {
+	var req k8s.BlueGreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		FailWithError(c, http.StatusBadRequest, "blueGreen.invalidRequest", err)
+		return
+	}
+	record, err := h.controller.Prepare(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"), req)
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "blueGreen.startFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"blueGreen": record})
+}
+
+// Get returns the latest blue/green deployment started from a deployment
+func (h *BlueGreenHandler) Get(c *gin.Context) {
+	record, err := h.controller.Get(c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
+	if err != nil {
+		FailWithError(c, http.StatusNotFound, "blueGreen.notFound", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"blueGreen": record})
+}
+
+// Switch points the Services at the new color
+func (h *BlueGreenHandler) Switch(c *gin.Context) {
+	h.action(c, h.controller.Switch)
+}
+
+// Rollback points the Services back at the previous color
+func (h *BlueGreenHandler) Rollback(c *gin.Context) {
+	h.action(c, h.controller.Rollback)
+}
+
+// Abort deletes the new color before the switch
+func (h *BlueGreenHandler) Abort(c *gin.Context) {
+	h.action(c, h.controller.Abort)
+}
+
+func (h *BlueGreenHandler) action(c *gin.Context, fn func(ctx context.Context, cluster, namespace, name string) (*k8s.BlueGreenDeployment, error)) {
+	record, err := fn(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "blueGreen.actionFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"blueGreen": record})
+}
+
+// List lists the blue/green deployments of a cluster, optionally filtered by ?namespace=
+func (h *BlueGreenHandler) List(c *gin.Context) {
+	records := h.controller.List(c.Param("cluster"), namespaceFromRequest(c))
+	ResponseSuccess(c, gin.H{"blueGreens": records})
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CanaryHandler exposes the progressive canary rollout controller
+type CanaryHandler struct {
+	controller *k8s.CanaryController
+}
+
+// NewCanaryHandler creates a canary rollout handler
+func NewCanaryHandler(controller *k8s.CanaryController) *CanaryHandler {
+	return &CanaryHandler{controller: controller}
+}
+
+// StartRollout starts a progressive rollout of new images for a deployment
+func (h *CanaryHandler) StartRollout(c *gin.Context) {
+	var spec k8s.CanaryRolloutSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		FailWithError(c, http.StatusBadRequest, "canary.invalidRequest", err)
+		return
+	}
+	rollout, err := h.controller.StartRollout(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"), spec)
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "canary.startFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"rollout": rollout})
+}
+
+// GetRollout returns the latest rollout of a deployment with its step history
+func (h *CanaryHandler) GetRollout(c *gin.Context) {
+	rollout, err := h.controller.GetRollout(c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
+	if err != nil {
+		FailWithError(c, http.StatusNotFound, "canary.notFound", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"rollout": rollout})
+}
+
+// AbortRollout restores the stable deployment and removes the canary
+func (h *CanaryHandler) AbortRollout(c *gin.Context) {
+	rollout, err := h.controller.AbortRollout(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "canary.actionFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"rollout": rollout})
+}
+
+// PromoteRollout skips the remaining steps and promotes the canary
+func (h *CanaryHandler) PromoteRollout(c *gin.Context) {
+	rollout, err := h.controller.PromoteRollout(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "canary.actionFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"rollout": rollout})
+}
+
+// ListRollouts lists the rollouts of a cluster, optionally filtered by ?namespace=
+func (h *CanaryHandler) ListRollouts(c *gin.Context) {
+	rollouts := h.controller.ListRollouts(c.Param("cluster"), namespaceFromRequest(c))
+	ResponseSuccess(c, gin.H{"rollouts": rollouts})
+}
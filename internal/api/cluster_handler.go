@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"kube-tide/internal/api/middleware"
 	"kube-tide/internal/core/k8s"
 	"kube-tide/internal/utils/i18n"
 	"kube-tide/internal/utils/logger"
@@ -18,13 +19,15 @@ import (
 type ClusterHandler struct {
 	clientManager      *k8s.ClientManager
 	clusterEventService *k8s.ClusterEventService
+	historyService      *k8s.ClusterHistoryService
 }
 
 // NewClusterHandler 创建集群管理处理器
-func NewClusterHandler(clientManager *k8s.ClientManager, clusterEventService *k8s.ClusterEventService) *ClusterHandler {
+func NewClusterHandler(clientManager *k8s.ClientManager, clusterEventService *k8s.ClusterEventService, historyService *k8s.ClusterHistoryService) *ClusterHandler {
 	return &ClusterHandler{
 		clientManager:       clientManager,
 		clusterEventService: clusterEventService,
+		historyService:      historyService,
 	}
 }
 
@@ -32,7 +35,12 @@ func NewClusterHandler(clientManager *k8s.ClientManager, clusterEventService *k8
 func (h *ClusterHandler) ListClusters(c *gin.Context) {
 	// 使用通用操作日志记录
 	err := logger.LogOperation(i18n.T(c, "cluster.list.operation"), func() error {
-		clusters := h.clientManager.ListClusters()
+		clusters := make([]string, 0)
+		for _, name := range h.clientManager.ListClusters() {
+			if middleware.CanAccessCluster(c, name) {
+				clusters = append(clusters, name)
+			}
+		}
 		ResponseSuccess(c, gin.H{
 			"clusters": clusters,
 		})
@@ -169,7 +177,7 @@ func (h *ClusterHandler) GetClusterDetails(c *gin.Context) {
 	result, err := logger.LogFuncWithContext(c.Request.Context(), i18n.T(c, "cluster.details.get"), func(ctx context.Context) (interface{}, error) {
 		logger.Info(i18n.T(c, "cluster.details.getting"), "clusterName", clusterName)
 
-		client, err := h.clientManager.GetClient(clusterName)
+		client, err := h.clientManager.GetClientFor(ctx, clusterName)
 		if err != nil {
 			return nil, err
 		}
@@ -217,6 +225,7 @@ func (h *ClusterHandler) GetClusterDetails(c *gin.Context) {
 			"totalMemory":     fmt.Sprintf("%.2f GB", totalMemoryGB),
 			"platform":        version.Platform,
 			"addType":         addType, // 添加集群的方式
+			"impersonate":     h.clientManager.IsImpersonationEnabled(clusterName),
 		}, nil
 	})
 
@@ -241,17 +250,24 @@ func (h *ClusterHandler) GetClusterMetrics(c *gin.Context) {
 		logger.Info(i18n.T(c, "cluster.metrics.getting"), "clusterName", clusterName)
 
 		// 获取集群客户端
-		client, err := h.clientManager.GetClient(clusterName)
+		client, err := h.clientManager.GetClientFor(c.Request.Context(), clusterName)
 		if err != nil {
 			return nil, err
 		}
 
 		// 获取集群监控指标
-		config, err := h.clientManager.GetConfig(clusterName)
+		config, err := h.clientManager.GetConfigFor(c.Request.Context(), clusterName)
+		if err != nil {
+			return nil, err
+		}
+		metrics, err := k8s.GetClusterMetrics(c.Request.Context(), client, config)
 		if err != nil {
 			return nil, err
 		}
-		return k8s.GetClusterMetrics(client, config)
+
+		// 历史数据来自 Prometheus 或本地采样
+		metrics.HistoricalData, metrics.HistorySource = h.historyService.GetHistory(c.Request.Context(), clusterName)
+		return metrics, nil
 	})
 
 	if err != nil {
@@ -317,3 +333,41 @@ func (h *ClusterHandler) GetClusterAddType(c *gin.Context) {
 		"addType": addType,
 	})
 }
+
+// SetImpersonationRequest 用户模拟开关请求
+type SetImpersonationRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SetImpersonation 开启或关闭集群的用户模拟（管理员）。
+// 开启后平台以登录用户身份 Impersonate 访问集群，kubeconfig 身份需具备 impersonate 权限。
+func (h *ClusterHandler) SetImpersonation(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	var req SetImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidJSON")
+		return
+	}
+
+	if err := h.clientManager.SetImpersonation(clusterName, req.Enabled); err != nil {
+		FailWithError(c, http.StatusInternalServerError, "cluster.impersonationUpdateFailed", err)
+		return
+	}
+
+	logger.Info("cluster impersonation updated", "cluster", clusterName, "enabled", req.Enabled)
+	ResponseSuccess(c, gin.H{
+		"name":        clusterName,
+		"impersonate": req.Enabled,
+	})
+}
+
+// GetCacheStatus 获取集群共享缓存（informer）的同步状态
+func (h *ClusterHandler) GetCacheStatus(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	status, err := h.clientManager.GetInformerStatus(clusterName)
+	if err != nil {
+		FailWithError(c, http.StatusNotFound, "cluster.notFound", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"cache": status})
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigConsumerHandler ConfigMap / Secret 引用方查询与重启
+type ConfigConsumerHandler struct {
+	service *k8s.ConfigConsumerService
+}
+
+// NewConfigConsumerHandler 创建配置引用方处理器
+func NewConfigConsumerHandler(service *k8s.ConfigConsumerService) *ConfigConsumerHandler {
+	return &ConfigConsumerHandler{service: service}
+}
+
+func (h *ConfigConsumerHandler) listConsumers(c *gin.Context, kind string) {
+	items, err := h.service.FindConsumers(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), kind, c.Param("name"))
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "configConsumer.fetchFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"consumers": items})
+}
+
+func (h *ConfigConsumerHandler) restartConsumers(c *gin.Context, kind string) {
+	results, err := h.service.RestartConsumers(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), kind, c.Param("name"))
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "configConsumer.restartFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"results": results})
+}
+
+func (h *ConfigConsumerHandler) ListConfigMapConsumers(c *gin.Context) {
+	h.listConsumers(c, k8s.ConfigKindConfigMap)
+}
+
+func (h *ConfigConsumerHandler) RestartConfigMapConsumers(c *gin.Context) {
+	h.restartConsumers(c, k8s.ConfigKindConfigMap)
+}
+
+func (h *ConfigConsumerHandler) ListSecretConsumers(c *gin.Context) {
+	h.listConsumers(c, k8s.ConfigKindSecret)
+}
+
+func (h *ConfigConsumerHandler) RestartSecretConsumers(c *gin.Context) {
+	h.restartConsumers(c, k8s.ConfigKindSecret)
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleConfigVersionError 将 ConfigMap / Secret 历史版本相关的错误转换为响应，err 为 nil 时返回 true
+func handleConfigVersionError(c *gin.Context, err error, failedKey string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, k8s.ErrInvalidConfigVersion):
+		ResponseError(c, http.StatusBadRequest, "configHistory.invalidVersion", err.Error())
+	case errors.Is(err, k8s.ErrConfigVersionNotFound):
+		FailWithError(c, http.StatusNotFound, "configHistory.versionNotFound", err)
+	default:
+		FailWithError(c, http.StatusInternalServerError, failedKey, err)
+	}
+	return false
+}
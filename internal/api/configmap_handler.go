@@ -1,9 +1,10 @@
 package api
 
 import (
-	"context"
 	"net/http"
+	"strconv"
 
+	"kube-tide/internal/api/middleware"
 	"kube-tide/internal/core/k8s"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,7 @@ func (h *ConfigMapHandler) ListConfigMaps(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cluster.clusterNameEmpty")
 		return
 	}
-	items, err := h.service.ListConfigMaps(context.Background(), clusterName)
+	items, err := h.service.ListConfigMaps(c.Request.Context(), clusterName)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "configmap.fetchFailed", err)
 		return
@@ -40,7 +41,7 @@ func (h *ConfigMapHandler) ListConfigMapsByNamespace(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cluster.clusterNameEmpty")
 		return
 	}
-	items, err := h.service.ListConfigMapsByNamespace(context.Background(), clusterName, namespace)
+	items, err := h.service.ListConfigMapsByNamespace(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "configmap.fetchFailed", err)
 		return
@@ -52,7 +53,7 @@ func (h *ConfigMapHandler) GetConfigMap(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := c.Param("namespace")
 	name := c.Param("name")
-	item, err := h.service.GetConfigMap(context.Background(), clusterName, namespace, name)
+	item, err := h.service.GetConfigMap(c.Request.Context(), clusterName, namespace, name)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "configmap.fetchFailed", err)
 		return
@@ -68,7 +69,7 @@ func (h *ConfigMapHandler) CreateConfigMap(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
 		return
 	}
-	item, err := h.service.CreateConfigMap(context.Background(), clusterName, namespace, req)
+	item, err := h.service.CreateConfigMap(c.Request.Context(), clusterName, namespace, req)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "configmap.createFailed", err)
 		return
@@ -88,7 +89,7 @@ func (h *ConfigMapHandler) UpdateConfigMap(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
 		return
 	}
-	item, err := h.service.UpdateConfigMap(context.Background(), clusterName, namespace, name, req.Data, req.Labels)
+	item, err := h.service.UpdateConfigMap(c.Request.Context(), clusterName, namespace, name, req.Data, req.Labels)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "configmap.updateFailed", err)
 		return
@@ -100,9 +101,54 @@ func (h *ConfigMapHandler) DeleteConfigMap(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := c.Param("namespace")
 	name := c.Param("name")
-	if err := h.service.DeleteConfigMap(context.Background(), clusterName, namespace, name); err != nil {
+	if err := h.service.DeleteConfigMap(c.Request.Context(), clusterName, namespace, name); err != nil {
 		FailWithError(c, http.StatusInternalServerError, "configmap.deleteFailed", err)
 		return
 	}
 	ResponseSuccess(c, nil)
 }
+
+// ListConfigMapVersions 返回 ConfigMap 的历史版本列表
+func (h *ConfigMapHandler) ListConfigMapVersions(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	versions, err := h.service.ListConfigMapVersions(c.Request.Context(), clusterName, namespace, name)
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "configHistory.fetchFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"versions": versions})
+}
+
+// DiffConfigMapVersions 按键对比两个版本，?from= 与 ?to= 为版本号或 current，默认与当前版本对比
+func (h *ConfigMapHandler) DiffConfigMapVersions(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	diff, err := h.service.DiffConfigMapVersions(c.Request.Context(), clusterName, namespace, name, c.Query("from"), c.Query("to"))
+	if !handleConfigVersionError(c, err, "configHistory.diffFailed") {
+		return
+	}
+	ResponseSuccess(c, gin.H{"diff": diff})
+}
+
+// RestoreConfigMapVersion 将 ConfigMap 恢复到指定历史版本，恢复前的内容同样保存为历史版本
+func (h *ConfigMapHandler) RestoreConfigMapVersion(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	var req struct {
+		Version int `json:"version" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
+		return
+	}
+	c.Set(middleware.AuditDetailKey, "version="+strconv.Itoa(req.Version))
+	item, err := h.service.RestoreConfigMapVersion(c.Request.Context(), clusterName, namespace, name, req.Version)
+	if !handleConfigVersionError(c, err, "configHistory.restoreFailed") {
+		return
+	}
+	ResponseSuccess(c, gin.H{"configmap": item})
+}
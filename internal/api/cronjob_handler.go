@@ -1,8 +1,9 @@
 package api
 
 import (
-	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"kube-tide/internal/core/k8s"
 
@@ -19,7 +20,7 @@ func NewCronJobHandler(service *k8s.CronJobService) *CronJobHandler {
 
 func (h *CronJobHandler) ListCronJobs(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListCronJobs(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListCronJobs(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "cronjob.listFailed", err.Error())
 		return
@@ -28,7 +29,7 @@ func (h *CronJobHandler) ListCronJobs(c *gin.Context) {
 }
 
 func (h *CronJobHandler) GetCronJob(c *gin.Context) {
-	item, err := h.service.GetCronJob(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"))
+	item, err := h.service.GetCronJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "cronjob.getFailed", err.Error())
 		return
@@ -42,7 +43,11 @@ func (h *CronJobHandler) CreateCronJob(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cronjob.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateCronJob(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateCronJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
+	if errors.Is(err, k8s.ErrInvalidCronSchedule) {
+		ResponseError(c, http.StatusBadRequest, "cronjob.invalidSchedule", err.Error())
+		return
+	}
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "cronjob.createFailed", err.Error())
 		return
@@ -56,7 +61,11 @@ func (h *CronJobHandler) UpdateCronJob(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cronjob.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdateCronJob(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"), req)
+	item, err := h.service.UpdateCronJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"), req)
+	if errors.Is(err, k8s.ErrInvalidCronSchedule) {
+		ResponseError(c, http.StatusBadRequest, "cronjob.invalidSchedule", err.Error())
+		return
+	}
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "cronjob.updateFailed", err.Error())
 		return
@@ -72,7 +81,7 @@ func (h *CronJobHandler) SuspendCronJob(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cronjob.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.SuspendCronJob(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"), req.Suspend)
+	item, err := h.service.SuspendCronJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"), req.Suspend)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "cronjob.suspendFailed", err.Error())
 		return
@@ -81,9 +90,48 @@ func (h *CronJobHandler) SuspendCronJob(c *gin.Context) {
 }
 
 func (h *CronJobHandler) DeleteCronJob(c *gin.Context) {
-	if err := h.service.DeleteCronJob(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob")); err != nil {
+	if err := h.service.DeleteCronJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "cronjob.deleteFailed", err.Error())
 		return
 	}
 	ResponseSuccess(c, gin.H{"message": "CronJob deleted successfully"})
 }
+
+func (h *CronJobHandler) TriggerCronJob(c *gin.Context) {
+	var req k8s.TriggerCronJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			ResponseError(c, http.StatusBadRequest, "cronjob.invalidRequest", err.Error())
+			return
+		}
+	}
+	job, err := h.service.TriggerCronJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"), req)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "cronjob.triggerFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"job": job})
+}
+
+func (h *CronJobHandler) GetCronJobHistory(c *gin.Context) {
+	history, err := h.service.GetCronJobHistory(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("cronjob"))
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "cronjob.historyFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"history": history})
+}
+
+func (h *CronJobHandler) PreviewSchedule(c *gin.Context) {
+	var timeZone *string
+	if tz, ok := c.GetQuery("timeZone"); ok {
+		timeZone = &tz
+	}
+	count, _ := strconv.Atoi(c.Query("count"))
+	runs, err := h.service.PreviewSchedule(c.Query("schedule"), timeZone, count)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "cronjob.invalidSchedule", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"nextRuns": runs})
+}
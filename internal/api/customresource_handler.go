@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomResourceHandler struct {
+	service *k8s.CustomResourceService
+}
+
+func NewCustomResourceHandler(service *k8s.CustomResourceService) *CustomResourceHandler {
+	return &CustomResourceHandler{service: service}
+}
+
+func (h *CustomResourceHandler) ListCRDs(c *gin.Context) {
+	items, err := h.service.ListCRDs(c.Request.Context(), c.Param("cluster"))
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "customResource.listCRDsFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"crds": items})
+}
+
+func (h *CustomResourceHandler) GetCRD(c *gin.Context) {
+	item, err := h.service.GetCRD(c.Request.Context(), c.Param("cluster"), c.Param("crd"))
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "customResource.getCRDFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"crd": item})
+}
+
+// 集群级路由没有 :namespace 参数，c.Param 返回空字符串
+func (h *CustomResourceHandler) ListCustomResources(c *gin.Context) {
+	list, err := h.service.ListCustomResources(c.Request.Context(), c.Param("cluster"), c.Param("crd"), c.Query("version"), c.Param("namespace"))
+	if err != nil {
+		customResourceError(c, "customResource.listFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"customResources": list})
+}
+
+func (h *CustomResourceHandler) GetCustomResource(c *gin.Context) {
+	item, err := h.service.GetCustomResource(c.Request.Context(), c.Param("cluster"), c.Param("crd"), c.Query("version"), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		customResourceError(c, "customResource.getFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"customResource": item})
+}
+
+func (h *CustomResourceHandler) UpdateCustomResource(c *gin.Context) {
+	req, ok := bindManifestRequest(c)
+	if !ok {
+		return
+	}
+	item, err := h.service.UpdateCustomResource(c.Request.Context(), c.Param("cluster"), c.Param("crd"), c.Param("namespace"), c.Param("name"), req.Manifest)
+	if err != nil {
+		customResourceError(c, "customResource.updateFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"customResource": item})
+}
+
+func (h *CustomResourceHandler) DeleteCustomResource(c *gin.Context) {
+	if err := h.service.DeleteCustomResource(c.Request.Context(), c.Param("cluster"), c.Param("crd"), c.Param("namespace"), c.Param("name")); err != nil {
+		customResourceError(c, "customResource.deleteFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"message": "Custom resource deleted successfully"})
+}
+
+// customResourceError 在命名空间路由下访问集群级资源返回 404，其余错误返回 500
+func customResourceError(c *gin.Context, key string, err error) {
+	if errors.Is(err, k8s.ErrClusterScopedCustomResource) {
+		ResponseError(c, http.StatusNotFound, "customResource.clusterScoped", err.Error())
+		return
+	}
+	ResponseError(c, http.StatusInternalServerError, key, err.Error())
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeCRDServer 提供 CRD、API 发现与 example.com/v1 资源端点，并记录对实例的请求
+type fakeCRDServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	requests []string
+}
+
+func crdJSON(plural, kind, scope string) string {
+	return fmt.Sprintf(`{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition","metadata":{"name":"%[1]s.example.com"},
+"spec":{"group":"example.com","names":{"kind":"%[2]s","plural":"%[1]s"},"scope":"%[3]s","versions":[{"name":"v1","served":true,"storage":true}]}}`, plural, kind, scope)
+}
+
+func newFakeCRDServer() *fakeCRDServer {
+	s := &fakeCRDServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch path := r.URL.Path; {
+		case path == "/version":
+			fmt.Fprint(w, `{"major":"1","minor":"36","gitVersion":"v1.36.0"}`)
+		case path == "/api":
+			fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+		case path == "/api/v1":
+			fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[]}`)
+		case path == "/apis":
+			fmt.Fprint(w, `{"kind":"APIGroupList","groups":[{"name":"example.com","versions":[{"groupVersion":"example.com/v1","version":"v1"}],"preferredVersion":{"groupVersion":"example.com/v1","version":"v1"}}]}`)
+		case path == "/apis/example.com/v1":
+			fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"example.com/v1","resources":[
+{"name":"widgets","singularName":"widget","namespaced":false,"kind":"Widget","verbs":["get","list","update","delete"]},
+{"name":"gadgets","singularName":"gadget","namespaced":true,"kind":"Gadget","verbs":["get","list","update","delete"]}]}`)
+		case path == "/apis/apiextensions.k8s.io/v1/customresourcedefinitions/widgets.example.com":
+			fmt.Fprint(w, crdJSON("widgets", "Widget", "Cluster"))
+		case path == "/apis/apiextensions.k8s.io/v1/customresourcedefinitions/gadgets.example.com":
+			fmt.Fprint(w, crdJSON("gadgets", "Gadget", "Namespaced"))
+		case strings.HasPrefix(path, "/apis/example.com/v1/"):
+			s.mutex.Lock()
+			s.requests = append(s.requests, r.Method+" "+path)
+			s.mutex.Unlock()
+			if r.Method == http.MethodDelete {
+				fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+				return
+			}
+			fmt.Fprint(w, `{"kind":"GadgetList","apiVersion":"example.com/v1","items":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return s
+}
+
+func (s *fakeCRDServer) recorded() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func TestCustomResourceScopeAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := newFakeCRDServer()
+	defer server.Close()
+
+	cm := k8s.NewClientManager()
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: %s
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+current-context: dev
+users:
+- name: dev
+  user:
+    token: test
+`, server.URL)
+	if err := cm.AddClusterWithContent("dev", kubeconfig); err != nil {
+		t.Fatalf("AddClusterWithContent: %v", err)
+	}
+
+	authorizer, err := auth.NewAuthorizer(filepath.Join(t.TempDir(), "policy.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := authorizer.SaveBinding(auth.PolicyBinding{
+		Name:       "team-a",
+		Subjects:   []auth.PolicySubject{{Kind: auth.SubjectGroup, Name: "team-a"}},
+		Clusters:   []string{"dev"},
+		Namespaces: []string{"team-a"},
+		Verbs:      []string{auth.VerbRead, auth.VerbWrite},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewCustomResourceHandler(k8s.NewCustomResourceService(cm))
+	router := gin.New()
+	v1 := router.Group("/api", func(c *gin.Context) {
+		c.Set(middleware.IdentityKey, &auth.Identity{Username: "alice", Groups: []string{c.GetHeader("X-Group")}})
+	}, middleware.Authorize(authorizer))
+	v1.GET("/clusters/:cluster/customresources/:crd", handler.ListCustomResources)
+	v1.GET("/clusters/:cluster/customresources/:crd/:name", handler.GetCustomResource)
+	v1.DELETE("/clusters/:cluster/customresources/:crd/:name", handler.DeleteCustomResource)
+	v1.GET("/clusters/:cluster/namespaces/:namespace/customresources/:crd", handler.ListCustomResources)
+	v1.DELETE("/clusters/:cluster/namespaces/:namespace/customresources/:crd/:name", handler.DeleteCustomResource)
+
+	do := func(method, path, group string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Group", group)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	cases := []struct {
+		method, path, group string
+		want                int
+	}{
+		// 集群级资源不能经命名空间路由访问，即使调用方对该命名空间有写权限
+		{http.MethodDelete, "/api/clusters/dev/namespaces/team-a/customresources/widgets.example.com/w1", "team-a", http.StatusNotFound},
+		{http.MethodGet, "/api/clusters/dev/namespaces/team-a/customresources/widgets.example.com", "team-a", http.StatusNotFound},
+		// 集群级路由需要 namespaces: ["*"] 的绑定
+		{http.MethodGet, "/api/clusters/dev/customresources/gadgets.example.com", "team-a", http.StatusForbidden},
+		{http.MethodDelete, "/api/clusters/dev/customresources/widgets.example.com/w1", "team-a", http.StatusForbidden},
+		{http.MethodDelete, "/api/clusters/dev/namespaces/team-a/customresources/gadgets.example.com/g1", "team-a", http.StatusOK},
+		{http.MethodDelete, "/api/clusters/dev/customresources/widgets.example.com/w1", auth.AdminGroup, http.StatusOK},
+	}
+	for _, tc := range cases {
+		if got := do(tc.method, tc.path, tc.group); got != tc.want {
+			t.Errorf("%s %s as %s = %d, want %d", tc.method, tc.path, tc.group, got, tc.want)
+		}
+	}
+
+	want := []string{
+		"DELETE /apis/example.com/v1/namespaces/team-a/gadgets/g1",
+		"DELETE /apis/example.com/v1/widgets/w1",
+	}
+	if got := server.recorded(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected requests reached the API server:\n%s", strings.Join(got, "\n"))
+	}
+}
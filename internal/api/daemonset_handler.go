@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewDaemonSetHandler(service *k8s.DaemonSetService) *DaemonSetHandler {
 
 func (h *DaemonSetHandler) ListDaemonSets(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListDaemonSets(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListDaemonSets(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "daemonset.listFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *DaemonSetHandler) ListDaemonSets(c *gin.Context) {
 }
 
 func (h *DaemonSetHandler) GetDaemonSet(c *gin.Context) {
-	item, err := h.service.GetDaemonSet(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset"))
+	item, err := h.service.GetDaemonSet(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "daemonset.getFailed", err.Error())
 		return
@@ -42,7 +41,7 @@ func (h *DaemonSetHandler) CreateDaemonSet(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "daemonset.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateDaemonSet(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateDaemonSet(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "daemonset.createFailed", err.Error())
 		return
@@ -56,7 +55,7 @@ func (h *DaemonSetHandler) UpdateDaemonSet(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "daemonset.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdateDaemonSet(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset"), req)
+	item, err := h.service.UpdateDaemonSet(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "daemonset.updateFailed", err.Error())
 		return
@@ -65,7 +64,7 @@ func (h *DaemonSetHandler) UpdateDaemonSet(c *gin.Context) {
 }
 
 func (h *DaemonSetHandler) DeleteDaemonSet(c *gin.Context) {
-	if err := h.service.DeleteDaemonSet(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset")); err != nil {
+	if err := h.service.DeleteDaemonSet(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "daemonset.deleteFailed", err.Error())
 		return
 	}
@@ -73,7 +72,7 @@ func (h *DaemonSetHandler) DeleteDaemonSet(c *gin.Context) {
 }
 
 func (h *DaemonSetHandler) GetDaemonSetPods(c *gin.Context) {
-	pods, err := h.service.GetDaemonSetPods(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset"))
+	pods, err := h.service.GetDaemonSetPods(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("daemonset"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "daemonset.getPodsFailed", err.Error())
 		return
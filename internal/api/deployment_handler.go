@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 
@@ -34,7 +33,7 @@ func (h *DeploymentHandler) ListDeployments(c *gin.Context) {
 		return
 	}
 
-	deployments, err := h.service.ListDeployments(clusterName)
+	deployments, err := h.service.ListDeployments(c.Request.Context(), clusterName)
 	if err != nil {
 		logger.Error("Failed to list deployments: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -60,7 +59,7 @@ func (h *DeploymentHandler) ListDeploymentsByNamespace(c *gin.Context) {
 		return
 	}
 
-	deployments, err := h.service.ListDeploymentsByNamespace(clusterName, namespace)
+	deployments, err := h.service.ListDeploymentsByNamespace(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		logger.Error("Failed to list deployments: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -92,7 +91,7 @@ func (h *DeploymentHandler) GetDeploymentDetails(c *gin.Context) {
 		return
 	}
 
-	deployment, err := h.service.GetDeploymentDetails(clusterName, namespace, deploymentName)
+	deployment, err := h.service.GetDeploymentDetails(c.Request.Context(), clusterName, namespace, deploymentName)
 	if err != nil {
 		logger.Error("Failed to get deployment details: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -133,7 +132,7 @@ func (h *DeploymentHandler) ScaleDeployment(c *gin.Context) {
 	}
 
 	// Scale the deployment
-	err := h.service.ScaleDeployment(clusterName, namespace, deploymentName, req.Replicas)
+	err := h.service.ScaleDeployment(c.Request.Context(), clusterName, namespace, deploymentName, req.Replicas)
 	if err != nil {
 		logger.Errorf("Failed to scale deployment %s: %v", deploymentName, err)
 		FailWithError(c, http.StatusInternalServerError, "deployment.scaleFailed", err)
@@ -163,7 +162,7 @@ func (h *DeploymentHandler) RestartDeployment(c *gin.Context) {
 	}
 
 	// Restart the deployment
-	err := h.service.RestartDeployment(clusterName, namespace, deploymentName)
+	err := h.service.RestartDeployment(c.Request.Context(), clusterName, namespace, deploymentName)
 	if err != nil {
 		logger.Errorf("Failed to restart deployment %s: %v", deploymentName, err)
 		FailWithError(c, http.StatusInternalServerError, "deployment.restartFailed", err)
@@ -200,7 +199,7 @@ func (h *DeploymentHandler) UpdateDeployment(c *gin.Context) {
 		return
 	}
 
-	err := h.service.UpdateDeployment(clusterName, namespace, deploymentName, updateRequest)
+	err := h.service.UpdateDeployment(c.Request.Context(), clusterName, namespace, deploymentName, updateRequest)
 	if err != nil {
 		logger.Error("Failed to update deployment: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -243,7 +242,7 @@ func (h *DeploymentHandler) CreateDeployment(c *gin.Context) {
 		return
 	}
 
-	deployment, err := h.service.CreateDeployment(clusterName, namespace, createRequest)
+	deployment, err := h.service.CreateDeployment(c.Request.Context(), clusterName, namespace, createRequest)
 	if err != nil {
 		logger.Error("Failed to create deployment: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -276,7 +275,7 @@ func (h *DeploymentHandler) GetAllRelatedEvents(c *gin.Context) {
 		return
 	}
 
-	eventMap, err := h.service.GetAllDeploymentEvents(context.Background(), clusterName, namespace, deploymentName)
+	eventMap, err := h.service.GetAllDeploymentEvents(c.Request.Context(), clusterName, namespace, deploymentName)
 	if err != nil {
 		logger.Error("Failed to get all related events: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -308,7 +307,7 @@ func (h *DeploymentHandler) DeleteDeployment(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteDeployment(clusterName, namespace, deploymentName)
+	err := h.service.DeleteDeployment(c.Request.Context(), clusterName, namespace, deploymentName)
 	if err != nil {
 		logger.Errorf("删除Deployment %s 失败: %v", deploymentName, err)
 		FailWithError(c, http.StatusInternalServerError, "deployment.deleteFailed", err)
@@ -340,7 +339,7 @@ func (h *DeploymentHandler) GetDeploymentRolloutHistory(c *gin.Context) {
 		return
 	}
 
-	revisions, err := h.service.GetDeploymentRolloutHistory(clusterName, namespace, deploymentName)
+	revisions, err := h.service.GetDeploymentRolloutHistory(c.Request.Context(), clusterName, namespace, deploymentName)
 	if err != nil {
 		logger.Error("获取Deployment版本历史失败: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -384,7 +383,7 @@ func (h *DeploymentHandler) GetDeploymentRevisionDetails(c *gin.Context) {
 		return
 	}
 
-	revisionDetails, err := h.service.GetDeploymentRevisionDetails(clusterName, namespace, deploymentName, revision)
+	revisionDetails, err := h.service.GetDeploymentRevisionDetails(c.Request.Context(), clusterName, namespace, deploymentName, revision)
 	if err != nil {
 		logger.Error("获取Deployment版本详情失败: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -430,10 +429,10 @@ func (h *DeploymentHandler) RollbackDeployment(c *gin.Context) {
 	var err error
 	if rollbackRequest.Revision != nil {
 		// 回滚到指定版本
-		err = h.service.RollbackDeployment(clusterName, namespace, deploymentName, *rollbackRequest.Revision)
+		err = h.service.RollbackDeployment(c.Request.Context(), clusterName, namespace, deploymentName, *rollbackRequest.Revision)
 	} else {
 		// 回滚到上一个版本
-		err = h.service.RollbackToPreviousRevision(clusterName, namespace, deploymentName)
+		err = h.service.RollbackToPreviousRevision(c.Request.Context(), clusterName, namespace, deploymentName)
 	}
 
 	if err != nil {
@@ -466,7 +465,7 @@ func (h *DeploymentHandler) GetDeploymentMetrics(c *gin.Context) {
 		return
 	}
 
-	metrics, err := h.podMetricsService.GetDeploymentMetrics(context.Background(), clusterName, namespace, deploymentName)
+	metrics, err := h.podMetricsService.GetDeploymentMetrics(c.Request.Context(), clusterName, namespace, deploymentName)
 	if err != nil {
 		logger.Error("获取 Deployment 监控指标失败: " + err.Error())
 		ResponseError(c, http.StatusInternalServerError, err.Error())
@@ -480,7 +479,7 @@ func (h *DeploymentHandler) GetDeploymentMetrics(c *gin.Context) {
 
 // PauseRollout 暂停 Deployment 滚动更新
 func (h *DeploymentHandler) PauseRollout(c *gin.Context) {
-	if err := h.service.PauseRollout(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment")); err != nil {
+	if err := h.service.PauseRollout(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -489,7 +488,7 @@ func (h *DeploymentHandler) PauseRollout(c *gin.Context) {
 
 // ResumeRollout 恢复 Deployment 滚动更新
 func (h *DeploymentHandler) ResumeRollout(c *gin.Context) {
-	if err := h.service.ResumeRollout(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment")); err != nil {
+	if err := h.service.ResumeRollout(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -498,7 +497,7 @@ func (h *DeploymentHandler) ResumeRollout(c *gin.Context) {
 
 // GetRolloutStatus 获取 Deployment 滚动更新状态
 func (h *DeploymentHandler) GetRolloutStatus(c *gin.Context) {
-	status, err := h.service.GetRolloutStatus(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
+	status, err := h.service.GetRolloutStatus(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -513,7 +512,7 @@ func (h *DeploymentHandler) CreateCanaryDeployment(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "deployment.invalidRequest", err.Error())
 		return
 	}
-	deployment, err := h.service.CreateCanaryDeployment(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"), req)
+	deployment, err := h.service.CreateCanaryDeployment(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("deployment"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
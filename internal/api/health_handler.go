@@ -1,20 +1,126 @@
 package api
 
 import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"kube-tide/internal/core/k8s"
+
 	"github.com/gin-gonic/gin"
 )
 
 // HealthCheckHandler Health check handler
-type HealthCheckHandler struct{}
+type HealthCheckHandler struct {
+	clusterHealth *k8s.ClusterHealthService
+	alerts        *k8s.AlertEngine
+	ready         atomic.Bool
+}
 
 // NewHealthCheckHandler Create health check handler
-func NewHealthCheckHandler() *HealthCheckHandler {
-	return &HealthCheckHandler{}
+func NewHealthCheckHandler(clusterHealth *k8s.ClusterHealthService, alerts *k8s.AlertEngine) *HealthCheckHandler {
+	return &HealthCheckHandler{clusterHealth: clusterHealth, alerts: alerts}
 }
 
-// CheckHealth Check system health
+// CheckHealth Check system health; clusters counts registered clusters by their latest health status
+// (names are not exposed on this unauthenticated endpoint)
 func (h *HealthCheckHandler) CheckHealth(c *gin.Context) {
 	ResponseSuccess(c, gin.H{
-		"status": "system.healthCheck",
+		"status":   "system.healthCheck",
+		"clusters": h.clusterHealth.Summary(),
 	})
 }
+
+// SetReady marks kube-tide as ready to serve traffic (stores loaded and services started)
+// or not (shutting down)
+func (h *HealthCheckHandler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// CheckReady Readiness probe: 503 until startup has finished and again once shutdown begins.
+// It only reflects kube-tide's own state; cluster reachability is reported by /api/health and alerts,
+// so an unreachable cluster never takes the platform out of the load balancer
+func (h *HealthCheckHandler) CheckReady(c *gin.Context) {
+	if !h.ready.Load() {
+		ResponseError(c, http.StatusServiceUnavailable, "system.notReady")
+		return
+	}
+	ResponseSuccess(c, gin.H{"status": "ready"})
+}
+
+// GetClusterHealth 返回集群最近一次的健康检查结果，?refresh=true 时立即重新检查
+func (h *HealthCheckHandler) GetClusterHealth(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	if clusterName == "" {
+		ResponseError(c, http.StatusBadRequest, "cluster.clusterNameEmpty")
+		return
+	}
+	var health *k8s.ClusterHealth
+	if c.Query("refresh") == "true" {
+		health = h.clusterHealth.Check(c.Request.Context(), clusterName)
+	} else {
+		health = h.clusterHealth.GetHealth(c.Request.Context(), clusterName)
+	}
+	ResponseSuccess(c, gin.H{"health": health, "alerts": h.alerts.Alerts(clusterName)})
+}
+
+// ListClusterAlerts 返回集群正在触发与最近恢复的告警
+func (h *HealthCheckHandler) ListClusterAlerts(c *gin.Context) {
+	ResponseSuccess(c, gin.H{"alerts": h.alerts.Alerts(c.Param("cluster"))})
+}
+
+// ListAlerts 返回全部集群的告警（管理员）
+func (h *HealthCheckHandler) ListAlerts(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	ResponseSuccess(c, gin.H{"alerts": h.alerts.Alerts("")})
+}
+
+// ListAlertRules 返回告警规则与可用的检查项（管理员）
+func (h *HealthCheckHandler) ListAlertRules(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	ResponseSuccess(c, gin.H{"rules": h.alerts.Rules(), "checks": k8s.HealthChecks})
+}
+
+// SaveAlertRule 新增或覆盖告警规则（管理员），规则名取自路径
+func (h *HealthCheckHandler) SaveAlertRule(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	var rule k8s.AlertRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidJSON")
+		return
+	}
+	rule.Name = c.Param("name")
+	err := h.alerts.SaveRule(rule)
+	if errors.Is(err, k8s.ErrInvalidAlertRule) {
+		ResponseError(c, http.StatusBadRequest, "alert.invalidRule", err.Error())
+		return
+	}
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "alert.ruleSaveFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"rule": rule})
+}
+
+// DeleteAlertRule 删除告警规则（管理员）
+func (h *HealthCheckHandler) DeleteAlertRule(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	err := h.alerts.DeleteRule(c.Param("name"))
+	if errors.Is(err, k8s.ErrAlertRuleNotFound) {
+		ResponseError(c, http.StatusNotFound, "alert.ruleNotFound")
+		return
+	}
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "alert.ruleDeleteFailed", err)
+		return
+	}
+	ResponseSuccess(c, nil)
+}
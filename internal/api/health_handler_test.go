@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCheckReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewHealthCheckHandler(k8s.NewClusterHealthService(k8s.NewClientManager(), nil, 0), nil)
+	router := gin.New()
+	router.GET("/api/health/ready", handler.CheckReady)
+
+	probe := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+		return w.Code
+	}
+	if code := probe(); code != http.StatusServiceUnavailable {
+		t.Fatalf("before startup finished: got %d", code)
+	}
+	handler.SetReady(true)
+	if code := probe(); code != http.StatusOK {
+		t.Fatalf("after startup: got %d", code)
+	}
+	handler.SetReady(false)
+	if code := probe(); code != http.StatusServiceUnavailable {
+		t.Fatalf("during shutdown: got %d", code)
+	}
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -21,7 +20,7 @@ func NewHPAHandler(service *k8s.HPAService) *HPAHandler {
 func (h *HPAHandler) ListHPAs(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListHPAs(context.Background(), clusterName, namespace)
+	items, err := h.service.ListHPAs(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		logger.Errorf("获取 HPA 列表失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "hpa.listFailed", err.Error())
@@ -31,7 +30,7 @@ func (h *HPAHandler) ListHPAs(c *gin.Context) {
 }
 
 func (h *HPAHandler) GetHPA(c *gin.Context) {
-	item, err := h.service.GetHPA(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("hpa"))
+	item, err := h.service.GetHPA(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("hpa"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "hpa.getFailed", err.Error())
 		return
@@ -45,7 +44,7 @@ func (h *HPAHandler) CreateHPA(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "hpa.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateHPA(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateHPA(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "hpa.createFailed", err.Error())
 		return
@@ -59,7 +58,7 @@ func (h *HPAHandler) UpdateHPA(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "hpa.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdateHPA(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("hpa"), req)
+	item, err := h.service.UpdateHPA(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("hpa"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "hpa.updateFailed", err.Error())
 		return
@@ -68,7 +67,7 @@ func (h *HPAHandler) UpdateHPA(c *gin.Context) {
 }
 
 func (h *HPAHandler) DeleteHPA(c *gin.Context) {
-	if err := h.service.DeleteHPA(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("hpa")); err != nil {
+	if err := h.service.DeleteHPA(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("hpa")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "hpa.deleteFailed", err.Error())
 		return
 	}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 
@@ -59,7 +58,7 @@ func (h *IngressHandler) ListIngressesByNamespace(c *gin.Context) {
 		return
 	}
 
-	ingresses, err := h.manager.GetIngressesByNamespace(context.Background(), clusterName, namespace)
+	ingresses, err := h.manager.GetIngressesByNamespace(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -75,7 +74,7 @@ func (h *IngressHandler) ListIngressesByNamespace(c *gin.Context) {
 
 // GetIngress 获取 Ingress 详情
 func (h *IngressHandler) GetIngress(c *gin.Context) {
-	ing, err := h.manager.GetIngress(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("ingress"))
+	ing, err := h.manager.GetIngress(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("ingress"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -90,7 +89,7 @@ func (h *IngressHandler) CreateIngress(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "ingress.invalidRequest", err.Error())
 		return
 	}
-	ing, err := h.manager.CreateIngress(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	ing, err := h.manager.CreateIngress(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -105,7 +104,7 @@ func (h *IngressHandler) UpdateIngress(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "ingress.invalidRequest", err.Error())
 		return
 	}
-	ing, err := h.manager.UpdateIngress(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("ingress"), req)
+	ing, err := h.manager.UpdateIngress(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("ingress"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -115,7 +114,7 @@ func (h *IngressHandler) UpdateIngress(c *gin.Context) {
 
 // DeleteIngress 删除 Ingress
 func (h *IngressHandler) DeleteIngress(c *gin.Context) {
-	if err := h.manager.DeleteIngress(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("ingress")); err != nil {
+	if err := h.manager.DeleteIngress(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("ingress")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
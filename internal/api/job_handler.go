@@ -1,8 +1,8 @@
 package api
 
 import (
-	"context"
 	"net/http"
+	"strconv"
 
 	"kube-tide/internal/core/k8s"
 
@@ -19,7 +19,7 @@ func NewJobHandler(service *k8s.JobService) *JobHandler {
 
 func (h *JobHandler) ListJobs(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListJobs(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListJobs(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "job.listFailed", err.Error())
 		return
@@ -28,7 +28,7 @@ func (h *JobHandler) ListJobs(c *gin.Context) {
 }
 
 func (h *JobHandler) GetJob(c *gin.Context) {
-	item, err := h.service.GetJob(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("job"))
+	item, err := h.service.GetJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "job.getFailed", err.Error())
 		return
@@ -42,7 +42,7 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "job.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateJob(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "job.createFailed", err.Error())
 		return
@@ -51,9 +51,77 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 }
 
 func (h *JobHandler) DeleteJob(c *gin.Context) {
-	if err := h.service.DeleteJob(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("job")); err != nil {
+	if err := h.service.DeleteJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "job.deleteFailed", err.Error())
 		return
 	}
 	ResponseSuccess(c, gin.H{"message": "Job deleted successfully"})
 }
+
+func (h *JobHandler) GetJobLogs(c *gin.Context) {
+	tailLines, _ := strconv.ParseInt(c.DefaultQuery("tailLines", "100"), 10, 64)
+	if tailLines <= 0 {
+		tailLines = 100
+	}
+	logs, err := h.service.GetJobLogs(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job"), c.Query("container"), tailLines)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "job.logsFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"logs": logs})
+}
+
+func (h *JobHandler) RetryJob(c *gin.Context) {
+	var req k8s.RetryJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			ResponseError(c, http.StatusBadRequest, "job.invalidRequest", err.Error())
+			return
+		}
+	}
+	item, err := h.service.RetryJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job"), req)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "job.retryFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"job": item})
+}
+
+func (h *JobHandler) SuspendJob(c *gin.Context) {
+	var req struct {
+		Suspend bool `json:"suspend"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "job.invalidRequest", err.Error())
+		return
+	}
+	item, err := h.service.SuspendJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job"), req.Suspend)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "job.suspendFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"job": item})
+}
+
+func (h *JobHandler) UpdateJob(c *gin.Context) {
+	var req k8s.JobSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "job.invalidRequest", err.Error())
+		return
+	}
+	item, err := h.service.UpdateJob(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job"), req)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "job.updateFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"job": item})
+}
+
+func (h *JobHandler) GetJobIndexes(c *gin.Context) {
+	indexes, err := h.service.GetJobIndexes(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("job"))
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "job.indexesFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"indexes": indexes})
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewLimitRangeHandler(service *k8s.LimitRangeService) *LimitRangeHandler {
 
 func (h *LimitRangeHandler) ListLimitRanges(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListLimitRanges(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListLimitRanges(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "limitrange.listFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *LimitRangeHandler) ListLimitRanges(c *gin.Context) {
 }
 
 func (h *LimitRangeHandler) GetLimitRange(c *gin.Context) {
-	item, err := h.service.GetLimitRange(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("limitrange"))
+	item, err := h.service.GetLimitRange(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("limitrange"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "limitrange.getFailed", err.Error())
 		return
@@ -42,7 +41,7 @@ func (h *LimitRangeHandler) CreateLimitRange(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "limitrange.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateLimitRange(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateLimitRange(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "limitrange.createFailed", err.Error())
 		return
@@ -56,7 +55,7 @@ func (h *LimitRangeHandler) UpdateLimitRange(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "limitrange.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdateLimitRange(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("limitrange"), req)
+	item, err := h.service.UpdateLimitRange(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("limitrange"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "limitrange.updateFailed", err.Error())
 		return
@@ -65,7 +64,7 @@ func (h *LimitRangeHandler) UpdateLimitRange(c *gin.Context) {
 }
 
 func (h *LimitRangeHandler) DeleteLimitRange(c *gin.Context) {
-	if err := h.service.DeleteLimitRange(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("limitrange")); err != nil {
+	if err := h.service.DeleteLimitRange(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("limitrange")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "limitrange.deleteFailed", err.Error())
 		return
 	}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ManifestHandler struct {
+	service *k8s.ManifestService
+}
+
+func NewManifestHandler(service *k8s.ManifestService) *ManifestHandler {
+	return &ManifestHandler{service: service}
+}
+
+// bindManifestRequest 支持 JSON 请求体，或直接提交 YAML 原文（namespace、force 通过查询参数传入）
+func bindManifestRequest(c *gin.Context) (k8s.ApplyManifestRequest, bool) {
+	var req k8s.ApplyManifestRequest
+	if c.ContentType() == gin.MIMEJSON {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			ResponseError(c, http.StatusBadRequest, "manifest.invalidRequest", err.Error())
+			return req, false
+		}
+		return req, true
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(body) == 0 {
+		ResponseError(c, http.StatusBadRequest, "manifest.invalidRequest", "empty manifest")
+		return req, false
+	}
+	req.Manifest = string(body)
+	req.Namespace = c.Query("namespace")
+	req.Force, _ = strconv.ParseBool(c.Query("force"))
+	return req, true
+}
+
+// authorizeTargets 按每个对象解析后的命名空间检查权限，集群级资源需要全部命名空间的权限；
+// 任一对象无权限时拒绝整个请求
+func authorizeTargets(c *gin.Context, targets []k8s.ManifestTarget, verb string) bool {
+	for _, target := range targets {
+		if !middleware.CanAccess(c, c.Param("cluster"), target.Namespace, verb) {
+			object := target.Kind + " " + target.Name
+			if target.Namespace != "" {
+				object = target.Kind + " " + target.Namespace + "/" + target.Name
+			}
+			ResponseError(c, http.StatusForbidden, "manifest.forbidden", object)
+			return false
+		}
+	}
+	return true
+}
+
+// authorizeManifest 解析清单中的全部对象并检查 write 权限
+func (h *ManifestHandler) authorizeManifest(c *gin.Context, req k8s.ApplyManifestRequest) bool {
+	targets, err := h.service.ManifestTargets(c.Param("cluster"), req)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "manifest.invalidRequest", err.Error())
+		return false
+	}
+	return authorizeTargets(c, targets, auth.VerbWrite)
+}
+
+func (h *ManifestHandler) GetManifest(c *gin.Context) {
+	apiVersion, kind, name := c.Query("apiVersion"), c.Query("kind"), c.Query("name")
+	if apiVersion == "" || kind == "" || name == "" {
+		ResponseError(c, http.StatusBadRequest, "manifest.invalidRequest", "apiVersion, kind and name are required")
+		return
+	}
+	target, err := h.service.ObjectTarget(c.Param("cluster"), apiVersion, kind, c.Query("namespace"), name)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "manifest.invalidRequest", err.Error())
+		return
+	}
+	if !authorizeTargets(c, []k8s.ManifestTarget{target}, auth.VerbRead) {
+		return
+	}
+	manifest, err := h.service.GetManifest(c.Request.Context(), c.Param("cluster"), apiVersion, kind, c.Query("namespace"), name)
+	if errors.Is(err, k8s.ErrManifestSecret) {
+		ResponseError(c, http.StatusBadRequest, "manifest.secretNotAllowed")
+		return
+	}
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "manifest.getFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"manifest": manifest})
+}
+
+func (h *ManifestHandler) DiffManifest(c *gin.Context) {
+	req, ok := bindManifestRequest(c)
+	if !ok || !h.authorizeManifest(c, req) {
+		return
+	}
+	results, err := h.service.DiffManifest(c.Request.Context(), c.Param("cluster"), req)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "manifest.diffFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"results": results})
+}
+
+func (h *ManifestHandler) ApplyManifest(c *gin.Context) {
+	req, ok := bindManifestRequest(c)
+	if !ok || !h.authorizeManifest(c, req) {
+		return
+	}
+	results, err := h.service.ApplyManifest(c.Request.Context(), c.Param("cluster"), req)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "manifest.applyFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"results": results})
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"kube-tide/internal/core/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAuditBodySize 参与摘要计算的请求体上限，超出部分不读入内存
+const maxAuditBodySize = 10 << 20
+
+// AuditDetailKey handlers may set a short, non-sensitive detail for the audit
+// record under this context key (e.g. which Secret key was revealed)
+const AuditDetailKey = "auditDetail"
+
+// Audit middleware records every mutating request (POST/PUT/PATCH/DELETE) and
+// the opening and closing of pod exec sessions. Request bodies are only stored
+// as a SHA-256 digest, and not at all for routes whose bodies carry credentials
+// (see sensitiveBodyRoute), so that secrets and passwords never reach the audit file.
+func Audit(auditLogger *audit.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if auditLogger == nil {
+			c.Next()
+			return
+		}
+
+		method := c.Request.Method
+		exec := strings.HasSuffix(c.FullPath(), "/exec")
+		if !exec && (method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions) {
+			c.Next()
+			return
+		}
+
+		record := newAuditRecord(c)
+		start := time.Now()
+
+		if exec {
+			record.Action = audit.ActionExecOpen
+			record.Container = c.Query("container")
+			auditLogger.Log(record)
+
+			c.Next()
+
+			record.Action = audit.ActionExecClose
+			record.Time = time.Now()
+			record.Status = c.Writer.Status()
+			record.LatencyMs = time.Since(start).Milliseconds()
+			auditLogger.Log(record)
+			return
+		}
+
+		if c.Request.Body != nil {
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxAuditBodySize))
+			if err == nil && len(body) > 0 {
+				record.BodySize = int64(len(body))
+				if !sensitiveBodyRoute(c.FullPath()) {
+					sum := sha256.Sum256(body)
+					record.BodySHA256 = hex.EncodeToString(sum[:])
+				}
+			}
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+		}
+
+		c.Next()
+
+		record.Action = audit.ActionRequest
+		if secretRevealRoutes[c.FullPath()] {
+			record.Action = audit.ActionSecretReveal
+		}
+		record.Status = c.Writer.Status()
+		record.LatencyMs = time.Since(start).Milliseconds()
+		record.Detail = c.GetString(AuditDetailKey)
+		if len(c.Errors) > 0 {
+			record.Detail = strings.TrimSpace(record.Detail + " " + c.Errors.String())
+		}
+		auditLogger.Log(record)
+	}
+}
+
+// sensitiveBodyRoute reports whether the request body of route carries passwords,
+// tokens, kubeconfig credentials or Secret values. An unsalted digest of such a
+// body can be brute-forced offline, so only its size is recorded.
+func sensitiveBodyRoute(route string) bool {
+	return strings.HasPrefix(route, "/api/auth/") ||
+		route == "/api/clusters" ||
+		strings.Contains(route, "/secrets") ||
+		manifestRoutes[route]
+}
+
+func newAuditRecord(c *gin.Context) audit.Record {
+	record := audit.Record{
+		Time:      time.Now(),
+		ClientIP:  c.ClientIP(),
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.Path,
+		Route:     c.FullPath(),
+		Cluster:   c.Param("cluster"),
+		Namespace: c.Param("namespace"),
+	}
+	if identity := GetIdentity(c); identity != nil {
+		record.User = identity.Username
+		record.Groups = identity.Groups
+		record.AuthMethod = identity.Method
+	}
+	record.Resource, record.Name = auditResource(c)
+	return record
+}
+
+// auditResource derives the resource and object name from the matched route, e.g.
+// /api/clusters/:cluster/namespaces/:namespace/deployments/:deployment/scale
+// yields ("deployments/scale", <deployment>).
+func auditResource(c *gin.Context) (string, string) {
+	segments := strings.Split(strings.TrimPrefix(c.FullPath(), "/api/"), "/")
+	resource := make([]string, 0, len(segments))
+	name := ""
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if segment == "" {
+			continue
+		}
+		// 集群与命名空间作为独立字段记录，不计入资源路径
+		if (segment == "clusters" || segment == "namespaces") && i+1 < len(segments) &&
+			(segments[i+1] == ":cluster" || segments[i+1] == ":namespace") && i+2 < len(segments) {
+			i++
+			continue
+		}
+		if strings.HasPrefix(segment, ":") {
+			name = c.Param(segment[1:])
+			continue
+		}
+		resource = append(resource, segment)
+	}
+	return strings.Join(resource, "/"), name
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kube-tide/configs"
+	"kube-tide/internal/core/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuditSkipsDigestOfSensitiveBodies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auditLogger := audit.NewLogger(filepath.Join(t.TempDir(), "audit.jsonl"), configs.LogRotateConfig{MaxSize: 1})
+	defer auditLogger.Close()
+
+	router := gin.New()
+	api := router.Group("/api", Audit(auditLogger))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	api.POST("/auth/login", ok)
+	api.POST("/clusters", ok)
+	api.PUT("/clusters/:cluster/namespaces/:namespace/secrets/:name", ok)
+	api.POST("/clusters/:cluster/manifests/apply", ok)
+	api.PUT("/clusters/:cluster/namespaces/:namespace/configmaps/:name", ok)
+
+	for _, path := range []string{
+		"/api/auth/login",
+		"/api/clusters",
+		"/api/clusters/dev/namespaces/a/secrets/db",
+		"/api/clusters/dev/manifests/apply",
+		"/api/clusters/dev/namespaces/a/configmaps/app",
+	} {
+		method := http.MethodPost
+		if strings.Contains(path, "/namespaces/") {
+			method = http.MethodPut
+		}
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, strings.NewReader(`{"password":"hunter2"}`)))
+	}
+
+	result, err := auditLogger.Query(audit.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 5 {
+		t.Fatalf("expected 5 records, got %d", result.Total)
+	}
+	for _, record := range result.Items {
+		if record.BodySize == 0 {
+			t.Errorf("%s: body size should be recorded", record.Path)
+		}
+		if sensitive := record.Resource != "configmaps"; sensitive != (record.BodySHA256 == "") {
+			t.Errorf("%s: unexpected body digest %q", record.Path, record.BodySHA256)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdentityKey is the key used to store the authenticated identity in the context
+const IdentityKey = "identity"
+
+// Authenticate middleware resolves the caller identity from a bearer token
+// or session cookie and rejects the request with 401 when none is valid
+func Authenticate(authenticator *auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, err := authenticator.Authenticate(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    http.StatusUnauthorized,
+				"message": i18n.GetInstance().Translate(GetLanguage(c), "auth.unauthorized"),
+			})
+			return
+		}
+
+		c.Set(IdentityKey, identity)
+		c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// GetIdentity returns the authenticated identity from the context, nil if not authenticated
+func GetIdentity(c *gin.Context) *auth.Identity {
+	value, exists := c.Get(IdentityKey)
+	if !exists {
+		return nil
+	}
+	identity, _ := value.(*auth.Identity)
+	return identity
+}
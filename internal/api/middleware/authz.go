@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthorizerKey is the key used to store the authorizer in the context
+const AuthorizerKey = "authorizer"
+
+// readOnlyPostRoutes POST routes that only read data (complex query bodies)
+var readOnlyPostRoutes = map[string]bool{
+	"/api/clusters/:cluster/namespaces/:namespace/pods/selector":      true,
+	"/api/clusters/:cluster/namespaces/:namespace/pods/logs/selector": true,
+	"/api/clusters/:cluster/prometheus/query_range":                   true,
+}
+
+// drainRoutes node maintenance routes that evict or block workloads
+var drainRoutes = map[string]bool{
+	"/api/clusters/:cluster/nodes/:node/drain":    true,
+	"/api/clusters/:cluster/nodes/:node/cordon":   true,
+	"/api/clusters/:cluster/nodes/:node/uncordon": true,
+}
+
+// secretRevealRoutes routes that return decoded secret values
+var secretRevealRoutes = map[string]bool{
+	"/api/clusters/:cluster/namespaces/:namespace/secrets/:name/reveal": true,
+}
+
+// namespaceQueryRoutes cluster-wide list routes that may be narrowed with ?namespace=;
+// every other route without a :namespace param is authorized cluster-wide
+var namespaceQueryRoutes = map[string]bool{
+	"/api/clusters/:cluster/events":                true,
+	"/api/clusters/:cluster/traffic-topology":      true,
+	"/api/clusters/:cluster/canary-rollouts":       true,
+	"/api/clusters/:cluster/bluegreen-deployments": true,
+	"/api/clusters/:cluster/statefulsets":          true,
+	"/api/clusters/:cluster/hpas":                  true,
+	"/api/clusters/:cluster/daemonsets":            true,
+	"/api/clusters/:cluster/jobs":                  true,
+	"/api/clusters/:cluster/cronjobs":              true,
+	"/api/clusters/:cluster/networkpolicies":       true,
+	"/api/clusters/:cluster/pvcs":                  true,
+	"/api/clusters/:cluster/volumesnapshots":       true,
+	"/api/clusters/:cluster/resourcequotas":        true,
+	"/api/clusters/:cluster/limitranges":           true,
+	"/api/clusters/:cluster/pdbs":                  true,
+	"/api/clusters/:cluster/roles":                 true,
+	"/api/clusters/:cluster/rolebindings":          true,
+}
+
+// namespaceObjectRoutes routes that modify the Namespace object itself; Namespace is a
+// cluster-scoped resource, so they are authorized cluster-wide like creating one
+var namespaceObjectRoutes = map[string]bool{
+	"/api/clusters/:cluster/namespaces/:namespace":        true,
+	"/api/clusters/:cluster/namespaces/:namespace/labels": true,
+}
+
+// manifestRoutes generic manifest routes; the objects may span several namespaces or be
+// cluster-scoped, so the handler authorizes each of them
+var manifestRoutes = map[string]bool{
+	"/api/clusters/:cluster/manifests":       true,
+	"/api/clusters/:cluster/manifests/diff":  true,
+	"/api/clusters/:cluster/manifests/apply": true,
+}
+
+// RequestVerb maps a matched route to the kube-tide verb it requires
+func RequestVerb(c *gin.Context) string {
+	route := c.FullPath()
+	method := c.Request.Method
+	switch {
+	case strings.HasSuffix(route, "/exec"):
+		return auth.VerbExec
+	case drainRoutes[route]:
+		return auth.VerbDrain
+	case secretRevealRoutes[route]:
+		return auth.VerbSecretReveal
+	case method == http.MethodGet || method == http.MethodHead:
+		return auth.VerbRead
+	case method == http.MethodPost && readOnlyPostRoutes[route]:
+		return auth.VerbRead
+	default:
+		return auth.VerbWrite
+	}
+}
+
+// Authorize middleware enforces the kube-tide policy on every route using the
+// :cluster and :namespace route params (or ?namespace= on the cluster-wide lists in
+// namespaceQueryRoutes).
+// Routes outside a cluster are restricted to administrators, except the cluster
+// list and the /api/auth endpoints which check permissions themselves.
+func Authorize(authorizer *auth.Authorizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity := GetIdentity(c)
+		route := c.FullPath()
+		cluster := c.Param("cluster")
+
+		allowed := false
+		switch {
+		case strings.HasPrefix(route, "/api/auth/"):
+			allowed = true
+		case route == "/api/clusters" && c.Request.Method == http.MethodGet:
+			// the handler filters the list down to accessible clusters
+			allowed = true
+		case route == "/api/clusters/:cluster/namespaces" && c.Request.Method == http.MethodGet:
+			// namespace-scoped users need the list for navigation; the handler filters it
+			allowed = authorizer.CanAccessCluster(identity, cluster)
+		case route == "/api/clusters/:cluster/watch":
+			// a watch may span several namespaces; the handler checks each of them
+			allowed = authorizer.CanAccessCluster(identity, cluster)
+		case route == "/api/clusters/:cluster/cronjobs/schedule-preview":
+			// evaluates a cron expression locally without reading cluster resources
+			allowed = authorizer.CanAccessCluster(identity, cluster)
+		case manifestRoutes[route]:
+			// the handler checks every object against its resolved namespace
+			allowed = authorizer.CanAccessCluster(identity, cluster)
+		case route == "/api/clusters" || route == "/api/clusters/:cluster/impersonation" ||
+			(route == "/api/clusters/:cluster" && c.Request.Method == http.MethodDelete):
+			// registering, removing and reconfiguring clusters is a platform administration task
+			allowed = identity.IsAdmin()
+		case cluster == "":
+			allowed = identity.IsAdmin()
+		default:
+			namespace := c.Param("namespace")
+			if namespaceObjectRoutes[route] && c.Request.Method != http.MethodGet {
+				namespace = ""
+			}
+			if namespace == "" && c.Request.Method == http.MethodGet && namespaceQueryRoutes[route] {
+				namespace = c.Query("namespace")
+			}
+			allowed = authorizer.Authorize(identity, auth.Attributes{
+				Cluster:   cluster,
+				Namespace: namespace,
+				Verb:      RequestVerb(c),
+			})
+		}
+
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"code":    http.StatusForbidden,
+				"message": i18n.GetInstance().Translate(GetLanguage(c), "auth.forbidden"),
+			})
+			return
+		}
+		c.Set(AuthorizerKey, authorizer)
+		c.Next()
+	}
+}
+
+// CanAccess reports whether the current identity may perform verb on cluster/namespace.
+// It returns true when no authorizer is installed (authorization not configured).
+func CanAccess(c *gin.Context, cluster, namespace, verb string) bool {
+	value, _ := c.Get(AuthorizerKey)
+	authorizer, ok := value.(*auth.Authorizer)
+	if !ok {
+		return true
+	}
+	return authorizer.Authorize(GetIdentity(c), auth.Attributes{Cluster: cluster, Namespace: namespace, Verb: verb})
+}
+
+// CanAccessCluster reports whether the current identity has any binding on cluster
+func CanAccessCluster(c *gin.Context, cluster string) bool {
+	value, _ := c.Get(AuthorizerKey)
+	authorizer, ok := value.(*auth.Authorizer)
+	if !ok {
+		return true
+	}
+	return authorizer.CanAccessCluster(GetIdentity(c), cluster)
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"kube-tide/internal/core/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthorizeRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authorizer, err := auth.NewAuthorizer(filepath.Join(t.TempDir(), "policy.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []auth.PolicyBinding{
+		{
+			Name:       "team-a",
+			Subjects:   []auth.PolicySubject{{Kind: auth.SubjectGroup, Name: "team-a"}},
+			Clusters:   []string{"dev"},
+			Namespaces: []string{"team-a"},
+			Verbs:      []string{auth.VerbRead, auth.VerbWrite},
+		},
+		{
+			Name:       "ops",
+			Subjects:   []auth.PolicySubject{{Kind: auth.SubjectGroup, Name: "ops"}},
+			Clusters:   []string{"dev"},
+			Namespaces: []string{"*"},
+			Verbs:      []string{auth.VerbRead, auth.VerbWrite},
+		},
+	} {
+		if err := authorizer.SaveBinding(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := gin.New()
+	api := router.Group("/api", func(c *gin.Context) {
+		c.Set(IdentityKey, &auth.Identity{Username: "test", Groups: []string{c.GetHeader("X-Group")}})
+	}, Authorize(authorizer))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/auth/logout"},
+		{http.MethodGet, "/auth/users"},
+		{http.MethodGet, "/audit"},
+		{http.MethodGet, "/clusters"},
+		{http.MethodPost, "/clusters"},
+		{http.MethodDelete, "/clusters/:cluster"},
+		{http.MethodPut, "/clusters/:cluster/impersonation"},
+		{http.MethodGet, "/clusters/:cluster/watch"},
+		{http.MethodGet, "/clusters/:cluster/namespaces"},
+		{http.MethodPost, "/clusters/:cluster/namespaces"},
+		{http.MethodGet, "/clusters/:cluster/namespaces/:namespace"},
+		{http.MethodDelete, "/clusters/:cluster/namespaces/:namespace"},
+		{http.MethodPatch, "/clusters/:cluster/namespaces/:namespace/labels"},
+		{http.MethodGet, "/clusters/:cluster/jobs"},
+		{http.MethodGet, "/clusters/:cluster/cronjobs/schedule-preview"},
+		{http.MethodGet, "/clusters/:cluster/nodes"},
+		{http.MethodPost, "/clusters/:cluster/manifests/apply"},
+		{http.MethodPost, "/clusters/:cluster/namespaces/:namespace/pods/selector"},
+		{http.MethodDelete, "/clusters/:cluster/namespaces/:namespace/pods/:pod"},
+	} {
+		api.Handle(route.method, route.path, ok)
+	}
+
+	cases := []struct {
+		method, path, group string
+		want                int
+	}{
+		{http.MethodPost, "/api/auth/logout", "", http.StatusOK},
+		{http.MethodGet, "/api/auth/users", "", http.StatusOK},
+		{http.MethodGet, "/api/audit", "ops", http.StatusForbidden},
+		{http.MethodGet, "/api/audit", auth.AdminGroup, http.StatusOK},
+		{http.MethodGet, "/api/clusters", "", http.StatusOK},
+		{http.MethodPost, "/api/clusters", "ops", http.StatusForbidden},
+		{http.MethodPost, "/api/clusters", auth.AdminGroup, http.StatusOK},
+		{http.MethodDelete, "/api/clusters/dev", "ops", http.StatusForbidden},
+		{http.MethodPut, "/api/clusters/dev/impersonation", "ops", http.StatusForbidden},
+		{http.MethodGet, "/api/clusters/dev/watch", "team-a", http.StatusOK},
+		{http.MethodGet, "/api/clusters/prod/watch", "team-a", http.StatusForbidden},
+		{http.MethodGet, "/api/clusters/dev/namespaces", "team-a", http.StatusOK},
+		{http.MethodPost, "/api/clusters/dev/namespaces", "team-a", http.StatusForbidden},
+		{http.MethodPost, "/api/clusters/dev/namespaces", "ops", http.StatusOK},
+		// Namespace 是集群级对象：对命名空间内资源的写权限不能删除或修改命名空间本身
+		{http.MethodGet, "/api/clusters/dev/namespaces/team-a", "team-a", http.StatusOK},
+		{http.MethodDelete, "/api/clusters/dev/namespaces/team-a", "team-a", http.StatusForbidden},
+		{http.MethodDelete, "/api/clusters/dev/namespaces/team-a", "ops", http.StatusOK},
+		{http.MethodPatch, "/api/clusters/dev/namespaces/team-a/labels", "team-a", http.StatusForbidden},
+		{http.MethodPatch, "/api/clusters/dev/namespaces/team-a/labels", "ops", http.StatusOK},
+		{http.MethodGet, "/api/clusters/dev/jobs?namespace=team-a", "team-a", http.StatusOK},
+		{http.MethodGet, "/api/clusters/dev/jobs?namespace=team-b", "team-a", http.StatusForbidden},
+		{http.MethodGet, "/api/clusters/dev/jobs", "team-a", http.StatusForbidden},
+		{http.MethodGet, "/api/clusters/dev/nodes?namespace=team-a", "team-a", http.StatusForbidden},
+		{http.MethodGet, "/api/clusters/dev/cronjobs/schedule-preview?schedule=0+*+*+*+*", "team-a", http.StatusOK},
+		{http.MethodGet, "/api/clusters/prod/cronjobs/schedule-preview?schedule=0+*+*+*+*", "team-a", http.StatusForbidden},
+		{http.MethodGet, "/api/clusters/dev/nodes", "ops", http.StatusOK},
+		{http.MethodPost, "/api/clusters/dev/manifests/apply", "team-a", http.StatusOK},
+		{http.MethodPost, "/api/clusters/prod/manifests/apply", "team-a", http.StatusForbidden},
+		{http.MethodPost, "/api/clusters/dev/namespaces/team-a/pods/selector", "team-a", http.StatusOK},
+		{http.MethodDelete, "/api/clusters/dev/namespaces/team-a/pods/web", "team-a", http.StatusOK},
+		{http.MethodDelete, "/api/clusters/dev/namespaces/team-b/pods/web", "team-a", http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("X-Group", tc.group)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Errorf("%s %s as %q = %d, want %d", tc.method, tc.path, tc.group, w.Code, tc.want)
+		}
+	}
+}
+
+func TestRequestVerb(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var got string
+	record := func(c *gin.Context) { got = RequestVerb(c) }
+	cases := []struct {
+		method, route, path, want string
+	}{
+		{http.MethodGet, "/api/clusters/:cluster/nodes", "/api/clusters/dev/nodes", auth.VerbRead},
+		{http.MethodPost, "/api/clusters/:cluster/prometheus/query_range", "/api/clusters/dev/prometheus/query_range", auth.VerbRead},
+		{http.MethodPost, "/api/clusters/:cluster/namespaces/:namespace/pods/selector", "/api/clusters/dev/namespaces/a/pods/selector", auth.VerbRead},
+		{http.MethodGet, "/api/clusters/:cluster/namespaces/:namespace/pods/:pod/exec", "/api/clusters/dev/namespaces/a/pods/web/exec", auth.VerbExec},
+		{http.MethodPost, "/api/clusters/:cluster/nodes/:node/drain", "/api/clusters/dev/nodes/n1/drain", auth.VerbDrain},
+		{http.MethodPost, "/api/clusters/:cluster/nodes/:node/cordon", "/api/clusters/dev/nodes/n1/cordon", auth.VerbDrain},
+		{http.MethodPost, "/api/clusters/:cluster/namespaces/:namespace/secrets/:name/reveal", "/api/clusters/dev/namespaces/a/secrets/s/reveal", auth.VerbSecretReveal},
+		{http.MethodPost, "/api/clusters/:cluster/namespaces/:namespace/deployments", "/api/clusters/dev/namespaces/a/deployments", auth.VerbWrite},
+		{http.MethodDelete, "/api/clusters/:cluster/namespaces/:namespace", "/api/clusters/dev/namespaces/a", auth.VerbWrite},
+	}
+	for _, tc := range cases {
+		router.Handle(tc.method, tc.route, record)
+	}
+	for _, tc := range cases {
+		got = ""
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.path, nil))
+		if got != tc.want {
+			t.Errorf("RequestVerb(%s %s) = %q, want %q", tc.method, tc.route, got, tc.want)
+		}
+	}
+}
@@ -1,12 +1,13 @@
 package api
 
 import (
-	"context"
 	"kube-tide/internal/utils/logger"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/auth"
 	"kube-tide/internal/core/k8s"
 )
 
@@ -37,13 +38,27 @@ func (h *NamespaceHandler) ListNamespaces(c *gin.Context) {
 		return
 	}
 
-	result, err := h.namespaceService.ListNamespaces(clusterName)
+	result, err := h.namespaceService.ListNamespaces(c.Request.Context(), clusterName)
 	if err != nil {
 		logger.Error("Failed to list namespaces: " + err.Error())
 		FailWithError(c, http.StatusInternalServerError, "namespace.fetchFailed", err)
 		return
 	}
 
+	// Users with namespace-scoped access only see the namespaces they can read
+	if !middleware.CanAccess(c, clusterName, "", auth.VerbRead) {
+		namespaces := make([]string, 0)
+		items := make([]k8s.NamespaceInfo, 0)
+		for _, item := range result.Items {
+			if middleware.CanAccess(c, clusterName, item.Name, auth.VerbRead) {
+				namespaces = append(namespaces, item.Name)
+				items = append(items, item)
+			}
+		}
+		result.Namespaces = namespaces
+		result.Items = items
+	}
+
 	ResponseSuccess(c, ListNamespacesResponse{
 		Namespaces: result.Namespaces,
 		Items:      result.Items,
@@ -52,7 +67,7 @@ func (h *NamespaceHandler) ListNamespaces(c *gin.Context) {
 
 // GetNamespace 获取命名空间详情
 func (h *NamespaceHandler) GetNamespace(c *gin.Context) {
-	item, err := h.namespaceService.GetNamespace(context.Background(), c.Param("cluster"), c.Param("namespace"))
+	item, err := h.namespaceService.GetNamespace(c.Request.Context(), c.Param("cluster"), c.Param("namespace"))
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "namespace.fetchFailed", err)
 		return
@@ -67,7 +82,7 @@ func (h *NamespaceHandler) CreateNamespace(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "namespace.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.namespaceService.CreateNamespace(context.Background(), c.Param("cluster"), req)
+	item, err := h.namespaceService.CreateNamespace(c.Request.Context(), c.Param("cluster"), req)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "namespace.createFailed", err)
 		return
@@ -77,7 +92,7 @@ func (h *NamespaceHandler) CreateNamespace(c *gin.Context) {
 
 // DeleteNamespace 删除命名空间
 func (h *NamespaceHandler) DeleteNamespace(c *gin.Context) {
-	if err := h.namespaceService.DeleteNamespace(context.Background(), c.Param("cluster"), c.Param("namespace")); err != nil {
+	if err := h.namespaceService.DeleteNamespace(c.Request.Context(), c.Param("cluster"), c.Param("namespace")); err != nil {
 		FailWithError(c, http.StatusInternalServerError, "namespace.deleteFailed", err)
 		return
 	}
@@ -91,7 +106,7 @@ func (h *NamespaceHandler) PatchNamespaceLabels(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "namespace.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.namespaceService.PatchNamespaceLabels(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.namespaceService.PatchNamespaceLabels(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "namespace.patchFailed", err)
 		return
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewNetworkPolicyHandler(service *k8s.NetworkPolicyService) *NetworkPolicyHa
 
 func (h *NetworkPolicyHandler) ListNetworkPolicies(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListNetworkPolicies(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListNetworkPolicies(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "networkpolicy.listFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *NetworkPolicyHandler) ListNetworkPolicies(c *gin.Context) {
 }
 
 func (h *NetworkPolicyHandler) GetNetworkPolicy(c *gin.Context) {
-	item, err := h.service.GetNetworkPolicy(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("networkpolicy"))
+	item, err := h.service.GetNetworkPolicy(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("networkpolicy"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "networkpolicy.getFailed", err.Error())
 		return
@@ -42,7 +41,7 @@ func (h *NetworkPolicyHandler) CreateNetworkPolicy(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "networkpolicy.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateNetworkPolicy(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateNetworkPolicy(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "networkpolicy.createFailed", err.Error())
 		return
@@ -56,7 +55,7 @@ func (h *NetworkPolicyHandler) UpdateNetworkPolicy(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "networkpolicy.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdateNetworkPolicy(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("networkpolicy"), req)
+	item, err := h.service.UpdateNetworkPolicy(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("networkpolicy"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "networkpolicy.updateFailed", err.Error())
 		return
@@ -65,7 +64,7 @@ func (h *NetworkPolicyHandler) UpdateNetworkPolicy(c *gin.Context) {
 }
 
 func (h *NetworkPolicyHandler) DeleteNetworkPolicy(c *gin.Context) {
-	if err := h.service.DeleteNetworkPolicy(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("networkpolicy")); err != nil {
+	if err := h.service.DeleteNetworkPolicy(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("networkpolicy")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "networkpolicy.deleteFailed", err.Error())
 		return
 	}
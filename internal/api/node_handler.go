@@ -52,7 +52,7 @@ func (h *NodeHandler) ListNodes(c *gin.Context) {
 	}
 
 	// max limit for pagination nodes data
-	nodes, total, err := h.service.GetNodes(context.Background(), clusterName, limit, page)
+	nodes, total, err := h.service.GetNodes(c.Request.Context(), clusterName, limit, page)
 	if err != nil {
 		logger.Errorf("Failed to get nodes: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "node.fetchFailed")
@@ -84,7 +84,7 @@ func (h *NodeHandler) GetNodeDetails(c *gin.Context) {
 		return
 	}
 
-	node, err := h.service.GetNodeDetails(context.Background(), clusterName, nodeName)
+	node, err := h.service.GetNodeDetails(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to get node details: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.fetchFailed", err)
@@ -110,7 +110,7 @@ func (h *NodeHandler) GetNodeMetrics(c *gin.Context) {
 		return
 	}
 
-	metrics, err := h.service.GetNodeMetrics(context.Background(), clusterName, nodeName)
+	metrics, err := h.service.GetNodeMetrics(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to get node metrics: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.fetchFailed", err)
@@ -148,8 +148,9 @@ func (h *NodeHandler) DrainNode(c *gin.Context) {
 		params.GracePeriodSeconds = 300 // Default 5 minutes
 	}
 
+	// 驱逐过程不随客户端断开而中断，避免节点停留在半排空状态
 	err := h.service.DrainNode(
-		context.Background(),
+		context.WithoutCancel(c.Request.Context()),
 		clusterName,
 		nodeName,
 		params.GracePeriodSeconds,
@@ -177,7 +178,7 @@ func (h *NodeHandler) CordonNode(c *gin.Context) {
 		return
 	}
 
-	err := h.service.CordonNode(context.Background(), clusterName, nodeName)
+	err := h.service.CordonNode(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to cordon node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.cordonFailed", err)
@@ -198,7 +199,7 @@ func (h *NodeHandler) UncordonNode(c *gin.Context) {
 		return
 	}
 
-	err := h.service.UncordonNode(context.Background(), clusterName, nodeName)
+	err := h.service.UncordonNode(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to uncordon node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.uncordonFailed", err)
@@ -219,7 +220,7 @@ func (h *NodeHandler) GetNodeTaints(c *gin.Context) {
 		return
 	}
 
-	taints, err := h.service.GetNodeTaints(context.Background(), clusterName, nodeName)
+	taints, err := h.service.GetNodeTaints(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to get node taints: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.fetchFailed", err)
@@ -258,7 +259,7 @@ func (h *NodeHandler) AddNodeTaint(c *gin.Context) {
 		Effect: req.Effect,
 	}
 
-	if err := h.service.AddNodeTaint(context.Background(), clusterName, nodeName, taint); err != nil {
+	if err := h.service.AddNodeTaint(c.Request.Context(), clusterName, nodeName, taint); err != nil {
 		logger.Errorf("Failed to add taint to node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.cordonFailed", err)
 		return
@@ -289,7 +290,7 @@ func (h *NodeHandler) RemoveNodeTaint(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.RemoveNodeTaint(context.Background(), clusterName, nodeName, req.Key, req.Effect); err != nil {
+	if err := h.service.RemoveNodeTaint(c.Request.Context(), clusterName, nodeName, req.Key, req.Effect); err != nil {
 		logger.Errorf("Failed to remove taint from node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.cordonFailed", err)
 		return
@@ -310,7 +311,7 @@ func (h *NodeHandler) GetNodeLabels(c *gin.Context) {
 		return
 	}
 
-	labels, err := h.service.GetNodeLabels(context.Background(), clusterName, nodeName)
+	labels, err := h.service.GetNodeLabels(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to get node labels: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.fetchFailed", err)
@@ -343,7 +344,7 @@ func (h *NodeHandler) AddNodeLabel(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddNodeLabel(context.Background(), clusterName, nodeName, req.Key, req.Value); err != nil {
+	if err := h.service.AddNodeLabel(c.Request.Context(), clusterName, nodeName, req.Key, req.Value); err != nil {
 		logger.Errorf("Failed to add label to node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.labelAddFailed", err)
 		return
@@ -373,7 +374,7 @@ func (h *NodeHandler) RemoveNodeLabel(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.RemoveNodeLabel(context.Background(), clusterName, nodeName, req.Key); err != nil {
+	if err := h.service.RemoveNodeLabel(c.Request.Context(), clusterName, nodeName, req.Key); err != nil {
 		logger.Errorf("Failed to remove label from node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.labelRemoveFailed", err)
 		return
@@ -424,7 +425,7 @@ func (h *NodeHandler) AddNode(c *gin.Context) {
 		return
 	}
 
-	err := h.service.AddNode(context.Background(), clusterName, nodeConfig)
+	err := h.service.AddNode(c.Request.Context(), clusterName, nodeConfig)
 	if err != nil {
 		logger.Errorf("Failed to add node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.addFailed", err)
@@ -453,7 +454,7 @@ func (h *NodeHandler) RemoveNode(c *gin.Context) {
 		params.Force = false // Default to non-force delete
 	}
 
-	err := h.service.RemoveNode(context.Background(), clusterName, nodeName, params.Force)
+	err := h.service.RemoveNode(c.Request.Context(), clusterName, nodeName, params.Force)
 	if err != nil {
 		logger.Errorf("Failed to remove node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.deleteFailed", err)
@@ -474,7 +475,7 @@ func (h *NodeHandler) GetNodePods(c *gin.Context) {
 		return
 	}
 
-	pods, err := h.service.GetNodePods(context.Background(), clusterName, nodeName)
+	pods, err := h.service.GetNodePods(c.Request.Context(), clusterName, nodeName)
 	if err != nil {
 		logger.Errorf("Failed to get pods on node: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "node.podsFetchFailed", err)
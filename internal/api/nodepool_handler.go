@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -30,7 +29,7 @@ func (h *NodePoolHandler) ListNodePools(c *gin.Context) {
 		return
 	}
 
-	pools, err := h.service.ListNodePools(context.Background(), clusterName)
+	pools, err := h.service.ListNodePools(c.Request.Context(), clusterName)
 	if err != nil {
 		logger.Errorf("Failed to list node pools: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "nodepool.list.failed", err.Error())
@@ -61,7 +60,7 @@ func (h *NodePoolHandler) CreateNodePool(c *gin.Context) {
 		return
 	}
 
-	err := h.service.CreateNodePool(context.Background(), clusterName, pool)
+	err := h.service.CreateNodePool(c.Request.Context(), clusterName, pool)
 	if err != nil {
 		logger.Errorf("Failed to create node pool: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "nodepool.createFailed", err.Error())
@@ -91,7 +90,7 @@ func (h *NodePoolHandler) UpdateNodePool(c *gin.Context) {
 	// ensure path parameters and body names are consistent
 	pool.Name = poolName
 
-	err := h.service.UpdateNodePool(context.Background(), clusterName, pool)
+	err := h.service.UpdateNodePool(c.Request.Context(), clusterName, pool)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "nodepool.updateFailed", err.Error())
 		return
@@ -111,7 +110,7 @@ func (h *NodePoolHandler) DeleteNodePool(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteNodePool(context.Background(), clusterName, poolName)
+	err := h.service.DeleteNodePool(c.Request.Context(), clusterName, poolName)
 	if err != nil {
 		logger.Errorf("Failed to delete node pool: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "nodepool.deleteFailed", err.Error())
@@ -132,7 +131,7 @@ func (h *NodePoolHandler) GetNodePool(c *gin.Context) {
 		return
 	}
 
-	pool, err := h.service.GetNodePool(context.Background(), clusterName, poolName)
+	pool, err := h.service.GetNodePool(c.Request.Context(), clusterName, poolName)
 	if err != nil {
 		logger.Errorf("Failed to get node pool: %s", err.Error())
 		ResponseError(c, http.StatusInternalServerError, "nodepool.fetchFailed", err.Error())
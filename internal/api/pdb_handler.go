@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewPDBHandler(service *k8s.PDBService) *PDBHandler {
 
 func (h *PDBHandler) ListPDBs(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListPDBs(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListPDBs(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pdb.listFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *PDBHandler) ListPDBs(c *gin.Context) {
 }
 
 func (h *PDBHandler) GetPDB(c *gin.Context) {
-	item, err := h.service.GetPDB(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("pdb"))
+	item, err := h.service.GetPDB(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("pdb"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pdb.getFailed", err.Error())
 		return
@@ -42,7 +41,7 @@ func (h *PDBHandler) CreatePDB(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "pdb.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreatePDB(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreatePDB(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pdb.createFailed", err.Error())
 		return
@@ -56,7 +55,7 @@ func (h *PDBHandler) UpdatePDB(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "pdb.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdatePDB(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("pdb"), req)
+	item, err := h.service.UpdatePDB(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("pdb"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pdb.updateFailed", err.Error())
 		return
@@ -65,7 +64,7 @@ func (h *PDBHandler) UpdatePDB(c *gin.Context) {
 }
 
 func (h *PDBHandler) DeletePDB(c *gin.Context) {
-	if err := h.service.DeletePDB(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("pdb")); err != nil {
+	if err := h.service.DeletePDB(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("pdb")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pdb.deleteFailed", err.Error())
 		return
 	}
@@ -35,7 +35,7 @@ func (h *PodHandler) ListPods(c *gin.Context) {
 		return
 	}
 
-	pods, err := h.service.GetPods(context.Background(), clusterName)
+	pods, err := h.service.GetPods(c.Request.Context(), clusterName)
 	if err != nil {
 		logger.Errorf("Failed to get pods: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.fetchFailed", err)
@@ -61,7 +61,7 @@ func (h *PodHandler) ListPodsByNamespace(c *gin.Context) {
 		return
 	}
 
-	pods, err := h.service.GetPodsByNamespace(context.Background(), clusterName, namespace)
+	pods, err := h.service.GetPodsByNamespace(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		logger.Errorf("Failed to get pods by namespace: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.fetchFailed", err)
@@ -93,7 +93,7 @@ func (h *PodHandler) GetPodDetails(c *gin.Context) {
 		return
 	}
 
-	pod, err := h.service.GetPodDetails(context.Background(), clusterName, namespace, podName)
+	pod, err := h.service.GetPodDetails(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		if k8s.IsNotFoundError(err) {
 			ResponseError(c, http.StatusNotFound, "pod.notFound")
@@ -128,7 +128,7 @@ func (h *PodHandler) DeletePod(c *gin.Context) {
 	}
 
 	// Run deletion operation
-	err := h.service.DeletePod(context.Background(), clusterName, namespace, podName)
+	err := h.service.DeletePod(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		logger.Errorf("Failed to delete pod %s/%s: %v", namespace, podName, err)
 		FailWithError(c, http.StatusInternalServerError, "pod.deleteFailed", err)
@@ -165,7 +165,7 @@ func (h *PodHandler) GetPodLogs(c *gin.Context) {
 	tailInt, _ := strconv.ParseInt(tailLines, 10, 64)
 
 	// Get logs
-	logs, err := h.service.GetPodLogs(context.Background(), clusterName, namespace, podName, container, tailInt)
+	logs, err := h.service.GetPodLogs(c.Request.Context(), clusterName, namespace, podName, container, tailInt)
 	if err != nil {
 		logger.Errorf("Failed to get pod logs %s/%s: %v", namespace, podName, err)
 		FailWithError(c, http.StatusInternalServerError, "pod.logFailed", err)
@@ -221,7 +221,7 @@ func (h *PodHandler) StreamPodLogs(c *gin.Context) {
 	c.Writer.Flush()
 
 	// get the context with a timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour) // increase timeout to 1 hour
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Hour) // increase timeout to 1 hour
 	defer cancel()
 
 	// send initial message to the client
@@ -326,7 +326,7 @@ func (h *PodHandler) GetPodsBySelector(c *gin.Context) {
 		return
 	}
 
-	pods, err := h.service.GetPodsBySelector(context.Background(), clusterName, namespace, selector)
+	pods, err := h.service.GetPodsBySelector(c.Request.Context(), clusterName, namespace, selector)
 	if err != nil {
 		logger.Errorf("Failed to get pods by selector: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.fetchFailed", err)
@@ -357,7 +357,7 @@ func (h *PodHandler) CheckPodExists(c *gin.Context) {
 		return
 	}
 
-	podDetail, exists, err := h.service.CheckPodExists(context.Background(), clusterName, namespace, podName)
+	podDetail, exists, err := h.service.CheckPodExists(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "pod.fetchFailed", err)
 		return
@@ -389,7 +389,7 @@ func (h *PodHandler) GetPodEvents(c *gin.Context) {
 		return
 	}
 
-	events, err := h.service.GetPodEvents(context.Background(), clusterName, namespace, podName)
+	events, err := h.service.GetPodEvents(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		logger.Errorf("Failed to get pod events: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.eventsFetchFailed", err)
@@ -420,7 +420,7 @@ func (h *PodHandler) GetPodRestartPolicy(c *gin.Context) {
 		return
 	}
 
-	restartPolicy, err := h.service.GetPodRestartPolicy(context.Background(), clusterName, namespace, podName)
+	restartPolicy, err := h.service.GetPodRestartPolicy(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		if k8s.IsNotFoundError(err) {
 			ResponseError(c, http.StatusNotFound, "pod.notFound")
@@ -471,7 +471,7 @@ func (h *PodHandler) UpdatePodRestartPolicy(c *gin.Context) {
 	}
 
 	// 使用重新创建的方法
-	err := h.service.RecreatePodWithRestartPolicy(context.Background(), clusterName, namespace, podName, config.RestartPolicy, config.DeleteOriginal)
+	err := h.service.RecreatePodWithRestartPolicy(c.Request.Context(), clusterName, namespace, podName, config.RestartPolicy, config.DeleteOriginal)
 	if err != nil {
 		if k8s.IsNotFoundError(err) {
 			ResponseError(c, http.StatusNotFound, "pod.notFound")
@@ -535,7 +535,7 @@ func (h *PodHandler) ManagePodLifecycle(c *gin.Context) {
 	}
 
 	startTime := time.Now()
-	response, err := h.service.ManagePodLifecycle(context.Background(), clusterName, namespace, podName, &request)
+	response, err := h.service.ManagePodLifecycle(c.Request.Context(), clusterName, namespace, podName, &request)
 	duration := time.Since(startTime)
 
 	// 记录操作结果日志
@@ -616,7 +616,7 @@ func (h *PodHandler) GetPodLifecycleHistory(c *gin.Context) {
 		return
 	}
 
-	history, err := h.service.GetPodLifecycleHistory(context.Background(), clusterName, namespace, podName)
+	history, err := h.service.GetPodLifecycleHistory(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		logger.Errorf("Failed to get pod lifecycle history: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.lifecycleHistoryFailed", err)
@@ -647,7 +647,7 @@ func (h *PodHandler) GetPodLifecycleStatus(c *gin.Context) {
 		return
 	}
 
-	pod, err := h.service.GetPodDetails(context.Background(), clusterName, namespace, podName)
+	pod, err := h.service.GetPodDetails(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		logger.Errorf("Failed to get pod details: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.detailsFetchFailed", err)
@@ -686,7 +686,7 @@ func (h *PodHandler) GetLogsByLabelSelector(c *gin.Context) {
 		req.TailLines = 100
 	}
 
-	logs, err := h.service.GetLogsByLabelSelector(context.Background(), clusterName, namespace, req.LabelSelector, req.Container, req.TailLines, req.ConcurrencyLimit)
+	logs, err := h.service.GetLogsByLabelSelector(c.Request.Context(), clusterName, namespace, req.LabelSelector, req.Container, req.TailLines, req.ConcurrencyLimit)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "pod.logFailed", err)
 		return
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/utils/logger"
@@ -30,7 +29,7 @@ func (h *PodHandler) GetPodMetrics(c *gin.Context) {
 		return
 	}
 
-	metrics, err := h.service.GetPodMetrics(context.Background(), clusterName, namespace, podName)
+	metrics, err := h.service.GetPodMetrics(c.Request.Context(), clusterName, namespace, podName)
 	if err != nil {
 		logger.Errorf("Failed to get pod metrics: %s", err.Error())
 		FailWithError(c, http.StatusInternalServerError, "pod.metricsFetchFailed", err)
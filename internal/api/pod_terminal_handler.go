@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"time"
 
+	"kube-tide/internal/api/middleware"
 	"kube-tide/internal/core/k8s"
+	"kube-tide/internal/core/recording"
 	"kube-tide/internal/utils/logger"
 
 	"github.com/coder/websocket"
@@ -15,9 +18,20 @@ import (
 	"k8s.io/client-go/tools/remotecommand"
 )
 
-var upgradeOptions = websocket.AcceptOptions{
-	InsecureSkipVerify: true, // It should be set appropriately in production environment
-	// coder/websocket There is no direct HandshakeTimeout option
+// newUpgradeOptions builds the WebSocket accept options.
+// Same-origin upgrades are always accepted; cross-origin upgrades only from allowedOrigins.
+// coder/websocket There is no direct HandshakeTimeout option
+func newUpgradeOptions(allowedOrigins []string) *websocket.AcceptOptions {
+	patterns := make([]string, 0, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host == "" {
+			logger.Warn("ignoring invalid allowed origin", "origin", origin)
+			continue
+		}
+		patterns = append(patterns, u.Host)
+	}
+	return &websocket.AcceptOptions{OriginPatterns: patterns}
 }
 
 // TerminalMessage Define terminal message format
@@ -33,6 +47,7 @@ type TerminalSession struct {
 	sizeChan chan remotecommand.TerminalSize
 	doneChan chan struct{}
 	ctx      context.Context
+	recorder *recording.Recorder // nil when the session is not recorded
 }
 
 // TerminalSize implementation of remotecommand.TerminalSize
@@ -63,6 +78,9 @@ func (t *TerminalSession) Read(p []byte) (int, error) {
 				if data, ok := msg.Data.(map[string]interface{}); ok {
 					cols, _ := data["cols"].(float64)
 					rows, _ := data["rows"].(float64)
+					if t.recorder != nil {
+						t.recorder.Resize(uint16(cols), uint16(rows))
+					}
 
 					t.sizeChan <- remotecommand.TerminalSize{
 						Width:  uint16(cols),
@@ -77,6 +95,9 @@ func (t *TerminalSession) Read(p []byte) (int, error) {
 		}
 	}
 
+	if t.recorder != nil {
+		t.recorder.Input(message)
+	}
 	copy(p, message)
 	return len(message), nil
 }
@@ -94,6 +115,9 @@ func (t *TerminalSession) Write(p []byte) (int, error) {
 		logger.Errorf("Failed to write to websocket: %s", err.Error())
 		return 0, err
 	}
+	if t.recorder != nil {
+		t.recorder.Output(p)
+	}
 	return len(p), nil
 }
 
@@ -105,14 +129,36 @@ func (t *TerminalSession) Close() error {
 
 // PodTerminalHandler Pod terminal handler
 type PodTerminalHandler struct {
-	service *k8s.PodService
+	service        *k8s.PodService
+	upgradeOptions *websocket.AcceptOptions
+	recordings     *recording.Store // nil when terminal recording is disabled
 }
 
 // NewPodTerminalHandler create a new PodTerminalHandler
-func NewPodTerminalHandler(service *k8s.PodService) *PodTerminalHandler {
+func NewPodTerminalHandler(service *k8s.PodService, allowedOrigins []string, recordings *recording.Store) *PodTerminalHandler {
 	return &PodTerminalHandler{
-		service: service,
+		service:        service,
+		upgradeOptions: newUpgradeOptions(allowedOrigins),
+		recordings:     recordings,
+	}
+}
+
+// startRecording starts an asciicast recording when the cluster/namespace matches a recording rule
+func (h *PodTerminalHandler) startRecording(c *gin.Context, cluster, namespace, pod, container string) *recording.Recorder {
+	if h.recordings == nil || !h.recordings.ShouldRecord(cluster, namespace) {
+		return nil
+	}
+	meta := recording.Meta{Cluster: cluster, Namespace: namespace, Pod: pod, Container: container}
+	if identity := middleware.GetIdentity(c); identity != nil {
+		meta.User = identity.Username
+	}
+	recorder, err := h.recordings.Start(meta, 80, 24)
+	if err != nil {
+		logger.Error("failed to start terminal recording", "cluster", cluster, "namespace", namespace, "pod", pod, "error", err.Error())
+		return nil
 	}
+	logger.Info("terminal recording started", "id", recorder.ID(), "cluster", cluster, "namespace", namespace, "pod", pod)
+	return recorder
 }
 
 // Send error message to WebSocket client
@@ -145,8 +191,8 @@ func (h *PodTerminalHandler) HandleTerminal(c *gin.Context) {
 		return
 	}
 
-	// Upgrade HTTP connection to WebSocket
-	wsConn, err := websocket.Accept(c.Writer, c.Request, &upgradeOptions)
+	// Upgrade HTTP connection to WebSocket; the session was already checked by the auth middleware
+	wsConn, err := websocket.Accept(c.Writer, c.Request, h.upgradeOptions)
 	if err != nil {
 		logger.Errorf("WebSocket upgrade failed: %v", err)
 		return
@@ -162,6 +208,10 @@ func (h *PodTerminalHandler) HandleTerminal(c *gin.Context) {
 		sizeChan: make(chan remotecommand.TerminalSize),
 		doneChan: make(chan struct{}),
 		ctx:      ctx,
+		recorder: h.startRecording(c, clusterName, namespace, podName, containerName),
+	}
+	if terminal.recorder != nil {
+		defer terminal.recorder.Close()
 	}
 
 	// Send connection success message
@@ -170,9 +220,17 @@ func (h *PodTerminalHandler) HandleTerminal(c *gin.Context) {
 		logger.Errorf("Failed to send test message: %v", err)
 		return
 	}
+	if terminal.recorder != nil {
+		// 告知用户本次会话正在录制
+		notice := "This session is being recorded (" + terminal.recorder.ID() + ").\r\n"
+		if err := wsConn.Write(ctx, websocket.MessageText, []byte(notice)); err != nil {
+			logger.Errorf("Failed to send recording notice: %v", err)
+			return
+		}
+	}
 
 	// Start executing terminal
-	if err := h.service.ExecToPod(clusterName, namespace, podName, containerName, terminal); err != nil {
+	if err := h.service.ExecToPod(ctx, clusterName, namespace, podName, containerName, terminal); err != nil {
 		logger.Errorf("Failed to connect to Pod terminal: %v", err)
 		sendErrorMessage(wsConn, ctx, "exec_failed", "Can not connect to Pod terminal: "+err.Error())
 		return
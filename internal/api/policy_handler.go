@@ -0,0 +1,80 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyHandler 平台授权策略处理器
+type PolicyHandler struct {
+	authorizer *auth.Authorizer
+}
+
+// NewPolicyHandler 创建授权策略处理器
+func NewPolicyHandler(authorizer *auth.Authorizer) *PolicyHandler {
+	return &PolicyHandler{authorizer: authorizer}
+}
+
+// ListBindings 列出策略绑定（管理员）
+func (h *PolicyHandler) ListBindings(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	ResponseSuccess(c, gin.H{"bindings": h.authorizer.ListBindings(), "verbs": auth.Verbs})
+}
+
+// SaveBinding 创建或替换策略绑定（管理员）
+func (h *PolicyHandler) SaveBinding(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	var binding auth.PolicyBinding
+	if err := c.ShouldBindJSON(&binding); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidRequest")
+		return
+	}
+	binding.Name = c.Param("name")
+	if err := h.authorizer.SaveBinding(binding); err != nil {
+		FailWithError(c, http.StatusBadRequest, "auth.bindingSaveFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"binding": binding})
+}
+
+// DeleteBinding 删除策略绑定（管理员）
+func (h *PolicyHandler) DeleteBinding(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	if err := h.authorizer.DeleteBinding(c.Param("name")); err != nil {
+		if errors.Is(err, auth.ErrBindingNotFound) {
+			ResponseError(c, http.StatusNotFound, "auth.bindingNotFound")
+			return
+		}
+		FailWithError(c, http.StatusInternalServerError, "auth.bindingDeleteFailed", err)
+		return
+	}
+	ResponseSuccess(c, nil)
+}
+
+// GetPermissions 返回当前用户在 ?cluster=&namespace= 上被授予的操作，供前端控制按钮显示
+func (h *PolicyHandler) GetPermissions(c *gin.Context) {
+	identity := middleware.GetIdentity(c)
+	cluster := c.Query("cluster")
+	if cluster == "" {
+		ResponseError(c, http.StatusBadRequest, "cluster.clusterNameEmpty")
+		return
+	}
+	namespace := c.Query("namespace")
+	ResponseSuccess(c, gin.H{
+		"cluster":   cluster,
+		"namespace": namespace,
+		"verbs":     h.authorizer.AllowedVerbs(identity, cluster, namespace),
+		"isAdmin":   identity.IsAdmin(),
+	})
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 	"strconv"
 	"time"
@@ -53,7 +52,7 @@ func (h *PrometheusHandler) QueryRange(c *gin.Context) {
 		}
 	}
 
-	result, err := h.service.QueryRange(context.Background(), clusterName, params, timeout)
+	result, err := h.service.QueryRange(c.Request.Context(), clusterName, params, timeout)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "prometheus.queryFailed", err.Error())
 		return
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -18,7 +17,7 @@ func NewPVHandler(service *k8s.PVService) *PVHandler {
 }
 
 func (h *PVHandler) ListPVs(c *gin.Context) {
-	items, err := h.service.ListPVs(context.Background(), c.Param("cluster"))
+	items, err := h.service.ListPVs(c.Request.Context(), c.Param("cluster"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pv.listFailed", err.Error())
 		return
@@ -27,7 +26,7 @@ func (h *PVHandler) ListPVs(c *gin.Context) {
 }
 
 func (h *PVHandler) GetPV(c *gin.Context) {
-	item, err := h.service.GetPV(context.Background(), c.Param("cluster"), c.Param("pv"))
+	item, err := h.service.GetPV(c.Request.Context(), c.Param("cluster"), c.Param("pv"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pv.getFailed", err.Error())
 		return
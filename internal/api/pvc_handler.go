@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewPVCHandler(service *k8s.PVCService) *PVCHandler {
 
 func (h *PVCHandler) ListPVCs(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListPVCs(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListPVCs(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pvc.listFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *PVCHandler) ListPVCs(c *gin.Context) {
 }
 
 func (h *PVCHandler) GetPVC(c *gin.Context) {
-	item, err := h.service.GetPVC(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("pvc"))
+	item, err := h.service.GetPVC(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("pvc"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pvc.getFailed", err.Error())
 		return
@@ -42,7 +41,7 @@ func (h *PVCHandler) CreatePVC(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "pvc.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreatePVC(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreatePVC(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pvc.createFailed", err.Error())
 		return
@@ -51,9 +50,25 @@ func (h *PVCHandler) CreatePVC(c *gin.Context) {
 }
 
 func (h *PVCHandler) DeletePVC(c *gin.Context) {
-	if err := h.service.DeletePVC(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("pvc")); err != nil {
+	if err := h.service.DeletePVC(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("pvc")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "pvc.deleteFailed", err.Error())
 		return
 	}
 	ResponseSuccess(c, gin.H{"message": "PVC deleted successfully"})
 }
+
+func (h *PVCHandler) ResizePVC(c *gin.Context) {
+	var req struct {
+		Storage string `json:"storage" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "pvc.invalidRequest", err.Error())
+		return
+	}
+	item, err := h.service.ResizePVC(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("pvc"), req.Storage)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "pvc.resizeFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"pvc": item})
+}
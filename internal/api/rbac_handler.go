@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewRBACHandler(service *k8s.RBACService) *RBACHandler {
 
 func (h *RBACHandler) ListRoles(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListRoles(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListRoles(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.listRolesFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *RBACHandler) ListRoles(c *gin.Context) {
 }
 
 func (h *RBACHandler) GetRole(c *gin.Context) {
-	item, err := h.service.GetRole(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("role"))
+	item, err := h.service.GetRole(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("role"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.getRoleFailed", err.Error())
 		return
@@ -37,7 +36,7 @@ func (h *RBACHandler) GetRole(c *gin.Context) {
 }
 
 func (h *RBACHandler) ListClusterRoles(c *gin.Context) {
-	items, err := h.service.ListClusterRoles(context.Background(), c.Param("cluster"))
+	items, err := h.service.ListClusterRoles(c.Request.Context(), c.Param("cluster"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.listClusterRolesFailed", err.Error())
 		return
@@ -46,7 +45,7 @@ func (h *RBACHandler) ListClusterRoles(c *gin.Context) {
 }
 
 func (h *RBACHandler) GetClusterRole(c *gin.Context) {
-	item, err := h.service.GetClusterRole(context.Background(), c.Param("cluster"), c.Param("clusterrole"))
+	item, err := h.service.GetClusterRole(c.Request.Context(), c.Param("cluster"), c.Param("clusterrole"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.getClusterRoleFailed", err.Error())
 		return
@@ -56,7 +55,7 @@ func (h *RBACHandler) GetClusterRole(c *gin.Context) {
 
 func (h *RBACHandler) ListRoleBindings(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListRoleBindings(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListRoleBindings(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.listRoleBindingsFailed", err.Error())
 		return
@@ -65,7 +64,7 @@ func (h *RBACHandler) ListRoleBindings(c *gin.Context) {
 }
 
 func (h *RBACHandler) GetRoleBinding(c *gin.Context) {
-	item, err := h.service.GetRoleBinding(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("rolebinding"))
+	item, err := h.service.GetRoleBinding(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("rolebinding"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.getRoleBindingFailed", err.Error())
 		return
@@ -79,7 +78,7 @@ func (h *RBACHandler) CreateRoleBinding(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "rbac.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateRoleBinding(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateRoleBinding(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.createRoleBindingFailed", err.Error())
 		return
@@ -88,7 +87,7 @@ func (h *RBACHandler) CreateRoleBinding(c *gin.Context) {
 }
 
 func (h *RBACHandler) DeleteRoleBinding(c *gin.Context) {
-	if err := h.service.DeleteRoleBinding(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("rolebinding")); err != nil {
+	if err := h.service.DeleteRoleBinding(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("rolebinding")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.deleteRoleBindingFailed", err.Error())
 		return
 	}
@@ -96,7 +95,7 @@ func (h *RBACHandler) DeleteRoleBinding(c *gin.Context) {
 }
 
 func (h *RBACHandler) ListClusterRoleBindings(c *gin.Context) {
-	items, err := h.service.ListClusterRoleBindings(context.Background(), c.Param("cluster"))
+	items, err := h.service.ListClusterRoleBindings(c.Request.Context(), c.Param("cluster"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.listClusterRoleBindingsFailed", err.Error())
 		return
@@ -105,7 +104,7 @@ func (h *RBACHandler) ListClusterRoleBindings(c *gin.Context) {
 }
 
 func (h *RBACHandler) GetClusterRoleBinding(c *gin.Context) {
-	item, err := h.service.GetClusterRoleBinding(context.Background(), c.Param("cluster"), c.Param("clusterrolebinding"))
+	item, err := h.service.GetClusterRoleBinding(c.Request.Context(), c.Param("cluster"), c.Param("clusterrolebinding"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.getClusterRoleBindingFailed", err.Error())
 		return
@@ -119,7 +118,7 @@ func (h *RBACHandler) CreateClusterRoleBinding(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "rbac.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateClusterRoleBinding(context.Background(), c.Param("cluster"), req)
+	item, err := h.service.CreateClusterRoleBinding(c.Request.Context(), c.Param("cluster"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.createClusterRoleBindingFailed", err.Error())
 		return
@@ -128,7 +127,7 @@ func (h *RBACHandler) CreateClusterRoleBinding(c *gin.Context) {
 }
 
 func (h *RBACHandler) DeleteClusterRoleBinding(c *gin.Context) {
-	if err := h.service.DeleteClusterRoleBinding(context.Background(), c.Param("cluster"), c.Param("clusterrolebinding")); err != nil {
+	if err := h.service.DeleteClusterRoleBinding(c.Request.Context(), c.Param("cluster"), c.Param("clusterrolebinding")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "rbac.deleteClusterRoleBindingFailed", err.Error())
 		return
 	}
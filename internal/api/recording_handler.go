@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"kube-tide/internal/core/recording"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordingHandler 终端录像处理器
+type RecordingHandler struct {
+	store *recording.Store
+}
+
+// NewRecordingHandler 创建终端录像处理器
+func NewRecordingHandler(store *recording.Store) *RecordingHandler {
+	return &RecordingHandler{store: store}
+}
+
+// available 录像未启用时返回 404
+func (h *RecordingHandler) available(c *gin.Context) bool {
+	if !requireAdmin(c) {
+		return false
+	}
+	if h.store == nil {
+		ResponseError(c, http.StatusNotFound, "recording.disabled")
+		return false
+	}
+	return true
+}
+
+// ListRecordings 列出终端录像（管理员），支持 user、cluster、namespace、pod 过滤
+func (h *RecordingHandler) ListRecordings(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+	items, err := h.store.List(recording.Filter{
+		User:      c.Query("user"),
+		Cluster:   c.Query("cluster"),
+		Namespace: c.Query("namespace"),
+		Pod:       c.Query("pod"),
+	})
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "recording.listFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"recordings": items})
+}
+
+// GetRecording 获取录像元数据
+func (h *RecordingHandler) GetRecording(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+	meta, err := h.store.Get(c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"recording": meta})
+}
+
+// DownloadRecording 下载 asciicast v2 录像文件
+func (h *RecordingHandler) DownloadRecording(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+	id := c.Param("id")
+	path, err := h.store.CastPath(id)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	c.Header("Content-Type", "application/x-asciicast")
+	c.FileAttachment(path, id+".cast")
+}
+
+// GetRules 获取录像规则
+func (h *RecordingHandler) GetRules(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+	ResponseSuccess(c, gin.H{"rules": h.store.Rules()})
+}
+
+// UpdateRulesRequest 录像规则更新请求
+type UpdateRulesRequest struct {
+	Rules []recording.Rule `json:"rules"`
+}
+
+// UpdateRules 替换录像规则：按集群或命名空间开启终端录像
+func (h *RecordingHandler) UpdateRules(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+	var req UpdateRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "api.invalidJSON")
+		return
+	}
+	if req.Rules == nil {
+		req.Rules = []recording.Rule{}
+	}
+	if err := h.store.SetRules(req.Rules); err != nil {
+		FailWithError(c, http.StatusBadRequest, "recording.rulesUpdateFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"rules": req.Rules})
+}
+
+func (h *RecordingHandler) fail(c *gin.Context, err error) {
+	if errors.Is(err, recording.ErrRecordingNotFound) {
+		ResponseError(c, http.StatusNotFound, "recording.notFound")
+		return
+	}
+	FailWithError(c, http.StatusInternalServerError, "recording.getFailed", err)
+}
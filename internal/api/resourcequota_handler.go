@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -19,7 +18,7 @@ func NewResourceQuotaHandler(service *k8s.ResourceQuotaService) *ResourceQuotaHa
 
 func (h *ResourceQuotaHandler) ListResourceQuotas(c *gin.Context) {
 	namespace := namespaceFromRequest(c)
-	items, err := h.service.ListResourceQuotas(context.Background(), c.Param("cluster"), namespace)
+	items, err := h.service.ListResourceQuotas(c.Request.Context(), c.Param("cluster"), namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "resourcequota.listFailed", err.Error())
 		return
@@ -28,7 +27,7 @@ func (h *ResourceQuotaHandler) ListResourceQuotas(c *gin.Context) {
 }
 
 func (h *ResourceQuotaHandler) GetResourceQuota(c *gin.Context) {
-	item, err := h.service.GetResourceQuota(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("resourcequota"))
+	item, err := h.service.GetResourceQuota(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("resourcequota"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "resourcequota.getFailed", err.Error())
 		return
@@ -42,7 +41,7 @@ func (h *ResourceQuotaHandler) CreateResourceQuota(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "resourcequota.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.CreateResourceQuota(context.Background(), c.Param("cluster"), c.Param("namespace"), req)
+	item, err := h.service.CreateResourceQuota(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "resourcequota.createFailed", err.Error())
 		return
@@ -56,7 +55,7 @@ func (h *ResourceQuotaHandler) UpdateResourceQuota(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "resourcequota.invalidRequest", err.Error())
 		return
 	}
-	item, err := h.service.UpdateResourceQuota(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("resourcequota"), req)
+	item, err := h.service.UpdateResourceQuota(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("resourcequota"), req)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "resourcequota.updateFailed", err.Error())
 		return
@@ -65,7 +64,7 @@ func (h *ResourceQuotaHandler) UpdateResourceQuota(c *gin.Context) {
 }
 
 func (h *ResourceQuotaHandler) DeleteResourceQuota(c *gin.Context) {
-	if err := h.service.DeleteResourceQuota(context.Background(), c.Param("cluster"), c.Param("namespace"), c.Param("resourcequota")); err != nil {
+	if err := h.service.DeleteResourceQuota(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("resourcequota")); err != nil {
 		ResponseError(c, http.StatusInternalServerError, "resourcequota.deleteFailed", err.Error())
 		return
 	}
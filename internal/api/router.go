@@ -2,9 +2,12 @@ package api
 
 import (
 	"strings"
+	"time"
 
 	"kube-tide/configs"
 	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/audit"
+	"kube-tide/internal/core/auth"
 	"kube-tide/pkg/embed"
 
 	"github.com/gin-contrib/cors"
@@ -13,6 +16,15 @@ import (
 
 // App Application structure
 type App struct {
+	Authenticator    *auth.Authenticator
+	Authorizer       *auth.Authorizer
+	AllowedOrigins   []string // cross-origin callers allowed by CORS; same-origin is always allowed
+	AuthHandler      *AuthHandler
+	PolicyHandler    *PolicyHandler
+	AuditLogger      *audit.Logger // nil when auditing is disabled
+	AuditHandler     *AuditHandler
+	RecordingHandler *RecordingHandler
+
 	ClusterHandler         *ClusterHandler
 	NodeHandler            *NodeHandler
 	PodHandler             *PodHandler
@@ -33,6 +45,7 @@ type App struct {
 	PVCHandler             *PVCHandler
 	PVHandler              *PVHandler
 	StorageClassHandler    *StorageClassHandler
+	VolumeSnapshotHandler  *VolumeSnapshotHandler
 	ResourceQuotaHandler   *ResourceQuotaHandler
 	LimitRangeHandler      *LimitRangeHandler
 	PDBHandler             *PDBHandler
@@ -40,15 +53,29 @@ type App struct {
 	PrometheusHandler      *PrometheusHandler
 	ConfigMapHandler       *ConfigMapHandler
 	SecretHandler          *SecretHandler
+	ConfigConsumerHandler  *ConfigConsumerHandler
 	TrafficTopologyHandler *TrafficTopologyHandler
+	ManifestHandler        *ManifestHandler
+	CustomResourceHandler  *CustomResourceHandler
+	WatchHandler           *WatchHandler
+	CanaryHandler          *CanaryHandler
+	BlueGreenHandler       *BlueGreenHandler
 }
 
 // InitRouter Initialize router
 func InitRouter(app *App) *gin.Engine {
 	router := gin.Default()
 
-	// Cross-origin configuration
-	router.Use(cors.Default())
+	// Cross-origin configuration: only explicitly allowed origins, with credentials for the session cookie
+	if len(app.AllowedOrigins) > 0 {
+		router.Use(cors.New(cors.Config{
+			AllowOrigins:     app.AllowedOrigins,
+			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+			AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept-Language"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		}))
+	}
 
 	// Add language detection middleware
 	router.Use(middleware.DetectLanguage())
@@ -88,11 +115,61 @@ func InitRouter(app *App) *gin.Engine {
 		})
 	}
 
-	// API version grouping
-	v1 := router.Group("/api")
+	// Public endpoints that do not require authentication
+	public := router.Group("/api", middleware.Audit(app.AuditLogger))
 	{
 		// Health check
-		v1.GET("/health", app.HealthHandler.CheckHealth)
+		public.GET("/health", app.HealthHandler.CheckHealth)
+		public.GET("/health/ready", app.HealthHandler.CheckReady)
+		// Login / logout
+		public.GET("/auth/config", app.AuthHandler.GetAuthConfig)
+		public.POST("/auth/login", app.AuthHandler.Login)
+		public.POST("/auth/logout", app.AuthHandler.Logout)
+		public.GET("/auth/oidc/login", app.AuthHandler.OIDCLogin)
+		public.GET("/auth/oidc/callback", app.AuthHandler.OIDCCallback)
+	}
+
+	// API version grouping, every route requires an authenticated and authorized identity
+	// the audit middleware runs before authorization so denied changes are recorded too
+	v1 := router.Group("/api",
+		middleware.Authenticate(app.Authenticator),
+		middleware.Audit(app.AuditLogger),
+		middleware.Authorize(app.Authorizer),
+	)
+	{
+		// Current user and API tokens
+		v1.GET("/auth/me", app.AuthHandler.GetCurrentUser)
+		v1.PUT("/auth/me/password", app.AuthHandler.ChangePassword)
+		v1.GET("/auth/tokens", app.AuthHandler.ListTokens)
+		v1.POST("/auth/tokens", app.AuthHandler.CreateToken)
+		v1.DELETE("/auth/tokens/:id", app.AuthHandler.DeleteToken)
+		// Local user management (administrators)
+		v1.GET("/auth/users", app.AuthHandler.ListUsers)
+		v1.POST("/auth/users", app.AuthHandler.CreateUser)
+		v1.PUT("/auth/users/:username", app.AuthHandler.UpdateUser)
+		v1.DELETE("/auth/users/:username", app.AuthHandler.DeleteUser)
+		// Platform authorization policy
+		v1.GET("/auth/permissions", app.PolicyHandler.GetPermissions)
+		v1.GET("/auth/policy/bindings", app.PolicyHandler.ListBindings)
+		v1.PUT("/auth/policy/bindings/:name", app.PolicyHandler.SaveBinding)
+		v1.DELETE("/auth/policy/bindings/:name", app.PolicyHandler.DeleteBinding)
+
+		// Audit log
+		v1.GET("/audit", app.AuditHandler.QueryAuditLogs)
+
+		// Terminal recordings
+		v1.GET("/recordings", app.RecordingHandler.ListRecordings)
+		v1.GET("/recordings/rules", app.RecordingHandler.GetRules)
+		v1.PUT("/recordings/rules", app.RecordingHandler.UpdateRules)
+		v1.GET("/recordings/:id", app.RecordingHandler.GetRecording)
+		v1.GET("/recordings/:id/download", app.RecordingHandler.DownloadRecording)
+
+		// Alert rules and alerts across clusters (administrators)
+		v1.GET("/alerts", app.HealthHandler.ListAlerts)
+		v1.GET("/alerts/rules", app.HealthHandler.ListAlertRules)
+		v1.PUT("/alerts/rules/:name", app.HealthHandler.SaveAlertRule)
+		v1.DELETE("/alerts/rules/:name", app.HealthHandler.DeleteAlertRule)
+
 		// Cluster management
 		v1.GET("/clusters", app.ClusterHandler.ListClusters)
 		v1.POST("/clusters", app.ClusterHandler.AddCluster)
@@ -105,8 +182,16 @@ func InitRouter(app *App) *gin.Engine {
 		v1.GET("/clusters/:cluster/metrics", app.ClusterHandler.GetClusterMetrics)
 		// Cluster events
 		v1.GET("/clusters/:cluster/events", app.ClusterHandler.GetClusterEvents)
+		// Cluster health checks and alerts
+		v1.GET("/clusters/:cluster/health", app.HealthHandler.GetClusterHealth)
+		v1.GET("/clusters/:cluster/alerts", app.HealthHandler.ListClusterAlerts)
 		// Get cluster add type information
 		v1.GET("/clusters/:cluster/add-type", app.ClusterHandler.GetClusterAddType)
+		v1.PUT("/clusters/:cluster/impersonation", app.ClusterHandler.SetImpersonation)
+		// Shared informer cache sync status
+		v1.GET("/clusters/:cluster/cache", app.ClusterHandler.GetCacheStatus)
+		// Live resource changes (SSE or WebSocket)
+		v1.GET("/clusters/:cluster/watch", app.WatchHandler.Watch)
 
 		// Namespace management
 		v1.GET("/clusters/:cluster/namespaces", app.NamespaceHandler.ListNamespaces)
@@ -122,14 +207,26 @@ func InitRouter(app *App) *gin.Engine {
 		v1.POST("/clusters/:cluster/namespaces/:namespace/configmaps", app.ConfigMapHandler.CreateConfigMap)
 		v1.PUT("/clusters/:cluster/namespaces/:namespace/configmaps/:name", app.ConfigMapHandler.UpdateConfigMap)
 		v1.DELETE("/clusters/:cluster/namespaces/:namespace/configmaps/:name", app.ConfigMapHandler.DeleteConfigMap)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/configmaps/:name/versions", app.ConfigMapHandler.ListConfigMapVersions)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/configmaps/:name/versions/diff", app.ConfigMapHandler.DiffConfigMapVersions)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/configmaps/:name/restore", app.ConfigMapHandler.RestoreConfigMapVersion)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/configmaps/:name/consumers", app.ConfigConsumerHandler.ListConfigMapConsumers)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/configmaps/:name/consumers/restart", app.ConfigConsumerHandler.RestartConfigMapConsumers)
 
 		// Secret management
 		v1.GET("/clusters/:cluster/secrets", app.SecretHandler.ListSecrets)
+		v1.GET("/clusters/:cluster/secrets/certificates", app.SecretHandler.CertificateReport)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/secrets", app.SecretHandler.ListSecretsByNamespace)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/secrets/:name", app.SecretHandler.GetSecret)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/secrets/:name/reveal", app.SecretHandler.RevealSecretKey)
 		v1.POST("/clusters/:cluster/namespaces/:namespace/secrets", app.SecretHandler.CreateSecret)
 		v1.PUT("/clusters/:cluster/namespaces/:namespace/secrets/:name", app.SecretHandler.UpdateSecret)
 		v1.DELETE("/clusters/:cluster/namespaces/:namespace/secrets/:name", app.SecretHandler.DeleteSecret)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/secrets/:name/versions", app.SecretHandler.ListSecretVersions)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/secrets/:name/versions/diff", app.SecretHandler.DiffSecretVersions)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/secrets/:name/restore", app.SecretHandler.RestoreSecretVersion)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/secrets/:name/consumers", app.ConfigConsumerHandler.ListSecretConsumers)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/secrets/:name/consumers/restart", app.ConfigConsumerHandler.RestartSecretConsumers)
 
 		// Prometheus proxy
 		v1.GET("/clusters/:cluster/prometheus/query_range", app.PrometheusHandler.QueryRange)
@@ -139,6 +236,23 @@ func InitRouter(app *App) *gin.Engine {
 		v1.GET("/clusters/:cluster/traffic-topology", app.TrafficTopologyHandler.GetTrafficTopology)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/traffic-topology", app.TrafficTopologyHandler.GetTrafficTopology)
 
+		// Generic YAML/JSON manifests (server-side apply)
+		v1.GET("/clusters/:cluster/manifests", app.ManifestHandler.GetManifest)
+		v1.POST("/clusters/:cluster/manifests/diff", app.ManifestHandler.DiffManifest)
+		v1.POST("/clusters/:cluster/manifests/apply", app.ManifestHandler.ApplyManifest)
+
+		// Custom resources (discovery driven, dynamic client)
+		v1.GET("/clusters/:cluster/customresourcedefinitions", app.CustomResourceHandler.ListCRDs)
+		v1.GET("/clusters/:cluster/customresourcedefinitions/:crd", app.CustomResourceHandler.GetCRD)
+		v1.GET("/clusters/:cluster/customresources/:crd", app.CustomResourceHandler.ListCustomResources)
+		v1.GET("/clusters/:cluster/customresources/:crd/:name", app.CustomResourceHandler.GetCustomResource)
+		v1.PUT("/clusters/:cluster/customresources/:crd/:name", app.CustomResourceHandler.UpdateCustomResource)
+		v1.DELETE("/clusters/:cluster/customresources/:crd/:name", app.CustomResourceHandler.DeleteCustomResource)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/customresources/:crd", app.CustomResourceHandler.ListCustomResources)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/customresources/:crd/:name", app.CustomResourceHandler.GetCustomResource)
+		v1.PUT("/clusters/:cluster/namespaces/:namespace/customresources/:crd/:name", app.CustomResourceHandler.UpdateCustomResource)
+		v1.DELETE("/clusters/:cluster/namespaces/:namespace/customresources/:crd/:name", app.CustomResourceHandler.DeleteCustomResource)
+
 		// Node pool management
 		v1.GET("/clusters/:cluster/nodepools", app.NodePoolHandler.ListNodePools)
 		v1.POST("/clusters/:cluster/nodepools", app.NodePoolHandler.CreateNodePool)
@@ -230,6 +344,20 @@ func InitRouter(app *App) *gin.Engine {
 		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/resume", app.DeploymentHandler.ResumeRollout)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/rollout", app.DeploymentHandler.GetRolloutStatus)
 		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/canary", app.DeploymentHandler.CreateCanaryDeployment)
+		// 渐进式金丝雀发布
+		v1.GET("/clusters/:cluster/canary-rollouts", app.CanaryHandler.ListRollouts)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/canary/rollout", app.CanaryHandler.StartRollout)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/canary/rollout", app.CanaryHandler.GetRollout)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/canary/rollout/abort", app.CanaryHandler.AbortRollout)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/canary/rollout/promote", app.CanaryHandler.PromoteRollout)
+		// 蓝绿发布
+		v1.GET("/clusters/:cluster/bluegreen-deployments", app.BlueGreenHandler.List)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/bluegreen/preview", app.BlueGreenHandler.Preview)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/bluegreen", app.BlueGreenHandler.Prepare)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/bluegreen", app.BlueGreenHandler.Get)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/bluegreen/switch", app.BlueGreenHandler.Switch)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/bluegreen/rollback", app.BlueGreenHandler.Rollback)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/deployments/:deployment/bluegreen/abort", app.BlueGreenHandler.Abort)
 
 		// StatefulSet management
 		v1.GET("/clusters/:cluster/statefulsets", app.StatefulSetHandler.ListStatefulSets)
@@ -242,6 +370,14 @@ func InitRouter(app *App) *gin.Engine {
 		v1.PUT("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset", app.StatefulSetHandler.UpdateStatefulSet)
 		v1.PUT("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/scale", app.StatefulSetHandler.ScaleStatefulSet)
 		v1.POST("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/restart", app.StatefulSetHandler.RestartStatefulSet)
+		v1.PUT("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/update-strategy", app.StatefulSetHandler.UpdateStatefulSetStrategy)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/partition/step-down", app.StatefulSetHandler.StartPartitionStepDown)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/partition/step-down", app.StatefulSetHandler.GetPartitionStepDown)
+		v1.DELETE("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/partition/step-down", app.StatefulSetHandler.CancelPartitionStepDown)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/pods/:ordinal/restart", app.StatefulSetHandler.RestartStatefulSetOrdinal)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/storage", app.StatefulSetHandler.GetStatefulSetStorage)
+		v1.PUT("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/pvc-retention-policy", app.StatefulSetHandler.UpdatePVCRetentionPolicy)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset/storage/cleanup", app.StatefulSetHandler.CleanupOrphanedPVCs)
 		v1.DELETE("/clusters/:cluster/namespaces/:namespace/statefulsets/:statefulset", app.StatefulSetHandler.DeleteStatefulSet)
 
 		// HPA management
@@ -267,14 +403,22 @@ func InitRouter(app *App) *gin.Engine {
 		v1.GET("/clusters/:cluster/namespaces/:namespace/jobs/:job", app.JobHandler.GetJob)
 		v1.POST("/clusters/:cluster/namespaces/:namespace/jobs", app.JobHandler.CreateJob)
 		v1.DELETE("/clusters/:cluster/namespaces/:namespace/jobs/:job", app.JobHandler.DeleteJob)
+		v1.PUT("/clusters/:cluster/namespaces/:namespace/jobs/:job", app.JobHandler.UpdateJob)
+		v1.PUT("/clusters/:cluster/namespaces/:namespace/jobs/:job/suspend", app.JobHandler.SuspendJob)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/jobs/:job/retry", app.JobHandler.RetryJob)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/jobs/:job/logs", app.JobHandler.GetJobLogs)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/jobs/:job/indexes", app.JobHandler.GetJobIndexes)
 
 		// CronJob management
 		v1.GET("/clusters/:cluster/cronjobs", app.CronJobHandler.ListCronJobs)
+		v1.GET("/clusters/:cluster/cronjobs/schedule-preview", app.CronJobHandler.PreviewSchedule)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/cronjobs", app.CronJobHandler.ListCronJobs)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/cronjobs/:cronjob", app.CronJobHandler.GetCronJob)
 		v1.POST("/clusters/:cluster/namespaces/:namespace/cronjobs", app.CronJobHandler.CreateCronJob)
 		v1.PUT("/clusters/:cluster/namespaces/:namespace/cronjobs/:cronjob", app.CronJobHandler.UpdateCronJob)
 		v1.PUT("/clusters/:cluster/namespaces/:namespace/cronjobs/:cronjob/suspend", app.CronJobHandler.SuspendCronJob)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/cronjobs/:cronjob/trigger", app.CronJobHandler.TriggerCronJob)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/cronjobs/:cronjob/history", app.CronJobHandler.GetCronJobHistory)
 		v1.DELETE("/clusters/:cluster/namespaces/:namespace/cronjobs/:cronjob", app.CronJobHandler.DeleteCronJob)
 
 		// NetworkPolicy management
@@ -291,6 +435,7 @@ func InitRouter(app *App) *gin.Engine {
 		v1.GET("/clusters/:cluster/namespaces/:namespace/pvcs/:pvc", app.PVCHandler.GetPVC)
 		v1.POST("/clusters/:cluster/namespaces/:namespace/pvcs", app.PVCHandler.CreatePVC)
 		v1.DELETE("/clusters/:cluster/namespaces/:namespace/pvcs/:pvc", app.PVCHandler.DeletePVC)
+		v1.PUT("/clusters/:cluster/namespaces/:namespace/pvcs/:pvc/resize", app.PVCHandler.ResizePVC)
 
 		// PV management (read-only)
 		v1.GET("/clusters/:cluster/pvs", app.PVHandler.ListPVs)
@@ -300,6 +445,15 @@ func InitRouter(app *App) *gin.Engine {
 		v1.GET("/clusters/:cluster/storageclasses", app.StorageClassHandler.ListStorageClasses)
 		v1.GET("/clusters/:cluster/storageclasses/:storageclass", app.StorageClassHandler.GetStorageClass)
 
+		// VolumeSnapshot management (CSI snapshot CRDs)
+		v1.GET("/clusters/:cluster/volumesnapshotclasses", app.VolumeSnapshotHandler.ListVolumeSnapshotClasses)
+		v1.GET("/clusters/:cluster/volumesnapshots", app.VolumeSnapshotHandler.ListVolumeSnapshots)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/volumesnapshots", app.VolumeSnapshotHandler.ListVolumeSnapshots)
+		v1.GET("/clusters/:cluster/namespaces/:namespace/volumesnapshots/:snapshot", app.VolumeSnapshotHandler.GetVolumeSnapshot)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/volumesnapshots", app.VolumeSnapshotHandler.CreateVolumeSnapshot)
+		v1.POST("/clusters/:cluster/namespaces/:namespace/volumesnapshots/:snapshot/restore", app.VolumeSnapshotHandler.RestoreVolumeSnapshot)
+		v1.DELETE("/clusters/:cluster/namespaces/:namespace/volumesnapshots/:snapshot", app.VolumeSnapshotHandler.DeleteVolumeSnapshot)
+
 		// ResourceQuota management
 		v1.GET("/clusters/:cluster/resourcequotas", app.ResourceQuotaHandler.ListResourceQuotas)
 		v1.GET("/clusters/:cluster/namespaces/:namespace/resourcequotas", app.ResourceQuotaHandler.ListResourceQuotas)
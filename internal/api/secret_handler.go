@@ -1,9 +1,11 @@
 package api
 
 import (
-	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"kube-tide/internal/api/middleware"
 	"kube-tide/internal/core/k8s"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +27,7 @@ func (h *SecretHandler) ListSecrets(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cluster.clusterNameEmpty")
 		return
 	}
-	items, err := h.service.ListSecrets(context.Background(), clusterName)
+	items, err := h.service.ListSecrets(c.Request.Context(), clusterName)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "secret.fetchFailed", err)
 		return
@@ -40,7 +42,7 @@ func (h *SecretHandler) ListSecretsByNamespace(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "cluster.clusterNameEmpty")
 		return
 	}
-	items, err := h.service.ListSecretsByNamespace(context.Background(), clusterName, namespace)
+	items, err := h.service.ListSecretsByNamespace(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "secret.fetchFailed", err)
 		return
@@ -48,11 +50,25 @@ func (h *SecretHandler) ListSecretsByNamespace(c *gin.Context) {
 	ResponseSuccess(c, gin.H{"secrets": items})
 }
 
+func (h *SecretHandler) CertificateReport(c *gin.Context) {
+	withinDays, err := strconv.Atoi(c.DefaultQuery("withinDays", "0"))
+	if err != nil || withinDays < 0 {
+		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
+		return
+	}
+	items, err := h.service.CertificateReport(c.Request.Context(), c.Param("cluster"), withinDays)
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "secret.certificateReportFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"certificates": items})
+}
+
 func (h *SecretHandler) GetSecret(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := c.Param("namespace")
 	name := c.Param("name")
-	item, err := h.service.GetSecret(context.Background(), clusterName, namespace, name)
+	item, err := h.service.GetSecret(c.Request.Context(), clusterName, namespace, name)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "secret.fetchFailed", err)
 		return
@@ -60,6 +76,31 @@ func (h *SecretHandler) GetSecret(c *gin.Context) {
 	ResponseSuccess(c, gin.H{"secret": item})
 }
 
+// RevealSecretKey 返回单个键的明文；需要 secret-reveal 权限，并以 secret.reveal 记录审计
+func (h *SecretHandler) RevealSecretKey(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	var req struct {
+		Key string `json:"key" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
+		return
+	}
+	c.Set(middleware.AuditDetailKey, "key="+req.Key)
+	value, err := h.service.RevealSecretKey(c.Request.Context(), clusterName, namespace, name, req.Key)
+	if errors.Is(err, k8s.ErrSecretKeyNotFound) {
+		FailWithError(c, http.StatusNotFound, "secret.keyNotFound", err)
+		return
+	}
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "secret.fetchFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"key": req.Key, "value": value})
+}
+
 func (h *SecretHandler) CreateSecret(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := c.Param("namespace")
@@ -68,7 +109,11 @@ func (h *SecretHandler) CreateSecret(c *gin.Context) {
 		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
 		return
 	}
-	item, err := h.service.CreateSecret(context.Background(), clusterName, namespace, req)
+	item, err := h.service.CreateSecret(c.Request.Context(), clusterName, namespace, req)
+	if errors.Is(err, k8s.ErrInvalidSecretData) {
+		ResponseError(c, http.StatusBadRequest, "secret.invalidData", err.Error())
+		return
+	}
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "secret.createFailed", err)
 		return
@@ -80,16 +125,12 @@ func (h *SecretHandler) UpdateSecret(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := c.Param("namespace")
 	name := c.Param("name")
-	var req struct {
-		StringData map[string]string `json:"stringData"`
-		Labels     map[string]string `json:"labels,omitempty"`
-		Type       string            `json:"type,omitempty"`
-	}
+	var req k8s.UpdateSecretRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
 		return
 	}
-	item, err := h.service.UpdateSecret(context.Background(), clusterName, namespace, name, req.StringData, req.Labels, req.Type)
+	item, err := h.service.UpdateSecret(c.Request.Context(), clusterName, namespace, name, req)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "secret.updateFailed", err)
 		return
@@ -101,9 +142,54 @@ func (h *SecretHandler) DeleteSecret(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := c.Param("namespace")
 	name := c.Param("name")
-	if err := h.service.DeleteSecret(context.Background(), clusterName, namespace, name); err != nil {
+	if err := h.service.DeleteSecret(c.Request.Context(), clusterName, namespace, name); err != nil {
 		FailWithError(c, http.StatusInternalServerError, "secret.deleteFailed", err)
 		return
 	}
 	ResponseSuccess(c, nil)
 }
+
+// ListSecretVersions 返回 Secret 的历史版本列表
+func (h *SecretHandler) ListSecretVersions(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	versions, err := h.service.ListSecretVersions(c.Request.Context(), clusterName, namespace, name)
+	if err != nil {
+		FailWithError(c, http.StatusInternalServerError, "configHistory.fetchFailed", err)
+		return
+	}
+	ResponseSuccess(c, gin.H{"versions": versions})
+}
+
+// DiffSecretVersions 按键对比两个版本，?from= 与 ?to= 为版本号或 current，默认与当前版本对比
+func (h *SecretHandler) DiffSecretVersions(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	diff, err := h.service.DiffSecretVersions(c.Request.Context(), clusterName, namespace, name, c.Query("from"), c.Query("to"))
+	if !handleConfigVersionError(c, err, "configHistory.diffFailed") {
+		return
+	}
+	ResponseSuccess(c, gin.H{"diff": diff})
+}
+
+// RestoreSecretVersion 将 Secret 恢复到指定历史版本，恢复前的内容同样保存为历史版本
+func (h *SecretHandler) RestoreSecretVersion(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	var req struct {
+		Version int `json:"version" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "common.invalidRequest")
+		return
+	}
+	c.Set(middleware.AuditDetailKey, "version="+strconv.Itoa(req.Version))
+	item, err := h.service.RestoreSecretVersion(c.Request.Context(), clusterName, namespace, name, req.Version)
+	if !handleConfigVersionError(c, err, "configHistory.restoreFailed") {
+		return
+	}
+	ResponseSuccess(c, gin.H{"secret": item})
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -49,7 +48,7 @@ func (h *ServiceHandler) ListServices(c *gin.Context) {
 		return
 	}
 
-	services, err := h.manager.GetServices(context.Background(), clusterName)
+	services, err := h.manager.GetServices(c.Request.Context(), clusterName)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -69,7 +68,7 @@ func (h *ServiceHandler) ListServicesByNamespace(c *gin.Context) {
 		return
 	}
 
-	services, err := h.manager.GetServicesByNamespace(context.Background(), clusterName, namespace)
+	services, err := h.manager.GetServicesByNamespace(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -90,7 +89,7 @@ func (h *ServiceHandler) GetServiceDetails(c *gin.Context) {
 		return
 	}
 
-	service, err := h.manager.GetServiceDetails(context.Background(), clusterName, namespace, serviceName)
+	service, err := h.manager.GetServiceDetails(c.Request.Context(), clusterName, namespace, serviceName)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -111,7 +110,7 @@ func (h *ServiceHandler) GetServiceEndpoints(c *gin.Context) {
 		return
 	}
 
-	endpoints, err := h.manager.GetServiceEndpoints(context.Background(), clusterName, namespace, serviceName)
+	endpoints, err := h.manager.GetServiceEndpoints(c.Request.Context(), clusterName, namespace, serviceName)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -195,7 +194,7 @@ func (h *ServiceHandler) CreateService(c *gin.Context) {
 		},
 	}
 
-	if err := h.manager.CreateService(context.Background(), clusterName, service); err != nil {
+	if err := h.manager.CreateService(c.Request.Context(), clusterName, service); err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -228,7 +227,7 @@ func (h *ServiceHandler) UpdateService(c *gin.Context) {
 	}
 
 	// Get existing Service
-	existingService, err := h.manager.GetServiceDetails(context.Background(), clusterName, namespace, serviceName)
+	existingService, err := h.manager.GetServiceDetails(c.Request.Context(), clusterName, namespace, serviceName)
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -248,7 +247,7 @@ func (h *ServiceHandler) UpdateService(c *gin.Context) {
 		existingService.Spec.Ports = convertToPorts(req.Ports)
 	}
 
-	if err := h.manager.UpdateService(context.Background(), clusterName, existingService); err != nil {
+	if err := h.manager.UpdateService(c.Request.Context(), clusterName, existingService); err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -268,7 +267,7 @@ func (h *ServiceHandler) DeleteService(c *gin.Context) {
 		return
 	}
 
-	if err := h.manager.DeleteService(context.Background(), clusterName, namespace, serviceName); err != nil {
+	if err := h.manager.DeleteService(c.Request.Context(), clusterName, namespace, serviceName); err != nil {
 		ResponseError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
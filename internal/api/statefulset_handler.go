@@ -1,9 +1,10 @@
 package api
 
 import (
-	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"kube-tide/internal/core/k8s"
 	"kube-tide/internal/utils/logger"
@@ -37,7 +38,7 @@ func (h *StatefulSetHandler) ListStatefulSets(c *gin.Context) {
 		return
 	}
 
-	statefulsets, err := h.service.GetStatefulSets(context.Background(), clusterName, namespace)
+	statefulsets, err := h.service.GetStatefulSets(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		logger.Errorf("获取StatefulSet列表失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.listFailed", err.Error())
@@ -68,7 +69,7 @@ func (h *StatefulSetHandler) GetStatefulSetDetails(c *gin.Context) {
 		return
 	}
 
-	statefulset, err := h.service.GetStatefulSetDetails(context.Background(), clusterName, namespace, statefulsetName)
+	statefulset, err := h.service.GetStatefulSetDetails(c.Request.Context(), clusterName, namespace, statefulsetName)
 	if err != nil {
 		logger.Errorf("获取StatefulSet详情失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.detailsFailed", err.Error())
@@ -239,7 +240,7 @@ func (h *StatefulSetHandler) CreateStatefulSet(c *gin.Context) {
 	}
 
 	// 创建StatefulSet
-	result, err := h.service.CreateStatefulSet(context.Background(), clusterName, sts)
+	result, err := h.service.CreateStatefulSet(c.Request.Context(), clusterName, sts)
 	if err != nil {
 		logger.Errorf("创建StatefulSet失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.createFailed", err.Error())
@@ -281,7 +282,7 @@ func (h *StatefulSetHandler) UpdateStatefulSet(c *gin.Context) {
 	}
 
 	// 执行更新
-	result, err := h.service.UpdateStatefulSet(context.Background(), clusterName, namespace, statefulsetName, updateData)
+	result, err := h.service.UpdateStatefulSet(c.Request.Context(), clusterName, namespace, statefulsetName, updateData)
 	if err != nil {
 		logger.Errorf("更新StatefulSet失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.updateFailed", err.Error())
@@ -316,7 +317,7 @@ func (h *StatefulSetHandler) DeleteStatefulSet(c *gin.Context) {
 		return
 	}
 
-	err := h.service.DeleteStatefulSet(context.Background(), clusterName, namespace, statefulsetName)
+	err := h.service.DeleteStatefulSet(c.Request.Context(), clusterName, namespace, statefulsetName)
 	if err != nil {
 		logger.Errorf("删除StatefulSet失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.deleteFailed", err.Error())
@@ -361,16 +362,24 @@ func (h *StatefulSetHandler) ScaleStatefulSet(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.ScaleStatefulSet(context.Background(), clusterName, namespace, statefulsetName, request.Replicas)
+	result, err := h.service.ScaleStatefulSet(c.Request.Context(), clusterName, namespace, statefulsetName, request.Replicas)
 	if err != nil {
 		logger.Errorf("扩缩容StatefulSet失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.scaleFailed", err.Error())
 		return
 	}
 
+	// 缩容后保留的 PVC 一并返回，供用户选择是否清理
+	orphanedPVCs, err := h.service.ListOrphanedPVCs(c.Request.Context(), clusterName, namespace, statefulsetName)
+	if err != nil {
+		logger.Warnf("获取StatefulSet遗留PVC失败: %v", err)
+		orphanedPVCs = []string{}
+	}
+
 	ResponseSuccess(c, gin.H{
-		"message":  "StatefulSet scaled successfully",
-		"replicas": *result.Spec.Replicas,
+		"message":      "StatefulSet scaled successfully",
+		"replicas":     *result.Spec.Replicas,
+		"orphanedPvcs": orphanedPVCs,
 	})
 }
 
@@ -393,7 +402,7 @@ func (h *StatefulSetHandler) RestartStatefulSet(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.RestartStatefulSet(context.Background(), clusterName, namespace, statefulsetName)
+	result, err := h.service.RestartStatefulSet(c.Request.Context(), clusterName, namespace, statefulsetName)
 	if err != nil {
 		logger.Errorf("重启StatefulSet失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.restartFailed", err.Error())
@@ -428,7 +437,7 @@ func (h *StatefulSetHandler) GetStatefulSetPods(c *gin.Context) {
 		return
 	}
 
-	pods, err := h.service.GetStatefulSetPods(context.Background(), clusterName, namespace, statefulsetName)
+	pods, revisions, err := h.service.GetStatefulSetPodsWithRevisions(c.Request.Context(), clusterName, namespace, statefulsetName)
 	if err != nil {
 		logger.Errorf("获取StatefulSet相关Pod失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.getPodsFaileds", err.Error())
@@ -436,7 +445,144 @@ func (h *StatefulSetHandler) GetStatefulSetPods(c *gin.Context) {
 	}
 
 	ResponseSuccess(c, gin.H{
-		"pods": pods,
+		"pods":      pods,
+		"revisions": revisions,
+	})
+}
+
+// statefulSetParams 读取并校验集群、命名空间与 StatefulSet 名称参数
+func statefulSetParams(c *gin.Context) (string, string, string, bool) {
+	clusterName := c.Param("cluster")
+	namespace := c.Param("namespace")
+	statefulsetName := c.Param("statefulset")
+
+	if clusterName == "" {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.clusterNameEmpty")
+		return "", "", "", false
+	}
+	if namespace == "" {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.namespaceEmpty")
+		return "", "", "", false
+	}
+	if statefulsetName == "" {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.nameEmpty")
+		return "", "", "", false
+	}
+	return clusterName, namespace, statefulsetName, true
+}
+
+// UpdateStatefulSetStrategy 修改StatefulSet更新策略（RollingUpdate/OnDelete、partition、maxUnavailable）
+func (h *StatefulSetHandler) UpdateStatefulSetStrategy(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	var request k8s.StatefulSetUpdateStrategyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.invalidRequestFormat", err.Error())
+		return
+	}
+
+	revisions, err := h.service.UpdateStatefulSetStrategy(c.Request.Context(), clusterName, namespace, statefulsetName, request)
+	if err != nil {
+		logger.Errorf("更新StatefulSet更新策略失败: %v", err)
+		ResponseError(c, http.StatusInternalServerError, "statefulsets.updateStrategyFailed", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"revisions": revisions,
+	})
+}
+
+// StartPartitionStepDown 将partition逐个序号下调到目标值，每一步等待Pod更新并就绪
+func (h *StatefulSetHandler) StartPartitionStepDown(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Target             int32 `json:"target"`
+		StepTimeoutSeconds int   `json:"stepTimeoutSeconds"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.invalidRequestFormat", err.Error())
+		return
+	}
+
+	task, err := h.service.StartPartitionStepDown(c.Request.Context(), clusterName, namespace, statefulsetName,
+		request.Target, time.Duration(request.StepTimeoutSeconds)*time.Second)
+	if err != nil {
+		logger.Errorf("启动StatefulSet分区下调失败: %v", err)
+		ResponseError(c, http.StatusBadRequest, "statefulsets.stepDownFailed", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"stepDown": task,
+	})
+}
+
+// GetPartitionStepDown 获取分区下调任务进度
+func (h *StatefulSetHandler) GetPartitionStepDown(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.GetPartitionStepDown(clusterName, namespace, statefulsetName)
+	if err != nil {
+		ResponseError(c, http.StatusNotFound, "statefulsets.stepDownNotFound", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"stepDown": task,
+	})
+}
+
+// CancelPartitionStepDown 取消进行中的分区下调
+func (h *StatefulSetHandler) CancelPartitionStepDown(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.service.CancelPartitionStepDown(clusterName, namespace, statefulsetName)
+	if err != nil {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.stepDownNotFound", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"stepDown": task,
+	})
+}
+
+// RestartStatefulSetOrdinal 重启指定序号的Pod
+func (h *StatefulSetHandler) RestartStatefulSetOrdinal(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	ordinal, err := strconv.Atoi(c.Param("ordinal"))
+	if err != nil || ordinal < 0 {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.invalidOrdinal")
+		return
+	}
+
+	if err := h.service.RestartStatefulSetOrdinal(c.Request.Context(), clusterName, namespace, statefulsetName, ordinal); err != nil {
+		logger.Errorf("重启StatefulSet序号失败: %v", err)
+		ResponseError(c, http.StatusInternalServerError, "statefulsets.restartOrdinalFailed", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"message": "StatefulSet pod restarted successfully",
+		"pod":     fmt.Sprintf("%s-%d", statefulsetName, ordinal),
 	})
 }
 
@@ -459,7 +605,7 @@ func (h *StatefulSetHandler) GetStatefulSetEvents(c *gin.Context) {
 		return
 	}
 
-	events, err := h.service.GetStatefulSetEvents(context.Background(), clusterName, namespace, statefulsetName)
+	events, err := h.service.GetStatefulSetEvents(c.Request.Context(), clusterName, namespace, statefulsetName)
 	if err != nil {
 		logger.Errorf("获取StatefulSet事件失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.getEventsFaileds", err.Error())
@@ -490,7 +636,7 @@ func (h *StatefulSetHandler) GetAllStatefulSetEvents(c *gin.Context) {
 		return
 	}
 
-	eventMap, err := h.service.GetAllStatefulSetEvents(context.Background(), clusterName, namespace, statefulsetName)
+	eventMap, err := h.service.GetAllStatefulSetEvents(c.Request.Context(), clusterName, namespace, statefulsetName)
 	if err != nil {
 		logger.Errorf("获取StatefulSet相关所有事件失败: %v", err)
 		ResponseError(c, http.StatusInternalServerError, "statefulsets.getAllEventsFaileds", err.Error())
@@ -619,3 +765,74 @@ func parsePorts(ports []interface{}) []corev1.ContainerPort {
 
 	return result
 }
+
+// GetStatefulSetStorage 获取StatefulSet各序号的PVC、绑定的PV、容量与用量
+func (h *StatefulSetHandler) GetStatefulSetStorage(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	storage, err := h.service.GetStatefulSetStorage(c.Request.Context(), clusterName, namespace, statefulsetName)
+	if err != nil {
+		logger.Errorf("获取StatefulSet存储失败: %v", err)
+		ResponseError(c, http.StatusInternalServerError, "statefulsets.getStorageFailed", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"storage": storage,
+	})
+}
+
+// UpdatePVCRetentionPolicy 设置StatefulSet的PVC保留策略
+func (h *StatefulSetHandler) UpdatePVCRetentionPolicy(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	var request k8s.StatefulSetPVCRetentionPolicy
+	if err := c.ShouldBindJSON(&request); err != nil {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.invalidRequestFormat", err.Error())
+		return
+	}
+
+	policy, err := h.service.UpdatePVCRetentionPolicy(c.Request.Context(), clusterName, namespace, statefulsetName, request)
+	if err != nil {
+		logger.Errorf("更新StatefulSet PVC保留策略失败: %v", err)
+		ResponseError(c, http.StatusInternalServerError, "statefulsets.updateRetentionPolicyFailed", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"retentionPolicy": policy,
+	})
+}
+
+// CleanupOrphanedPVCs 删除缩容后遗留的PVC
+func (h *StatefulSetHandler) CleanupOrphanedPVCs(c *gin.Context) {
+	clusterName, namespace, statefulsetName, ok := statefulSetParams(c)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		PVCs []string `json:"pvcs" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		ResponseError(c, http.StatusBadRequest, "statefulsets.invalidRequestFormat", err.Error())
+		return
+	}
+
+	deleted, err := h.service.DeleteOrphanedPVCs(c.Request.Context(), clusterName, namespace, statefulsetName, request.PVCs)
+	if err != nil {
+		logger.Errorf("清理StatefulSet遗留PVC失败: %v", err)
+		ResponseError(c, http.StatusBadRequest, "statefulsets.cleanupPvcsFailed", err.Error())
+		return
+	}
+
+	ResponseSuccess(c, gin.H{
+		"deleted": deleted,
+	})
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -18,7 +17,7 @@ func NewStorageClassHandler(service *k8s.StorageClassService) *StorageClassHandl
 }
 
 func (h *StorageClassHandler) ListStorageClasses(c *gin.Context) {
-	items, err := h.service.ListStorageClasses(context.Background(), c.Param("cluster"))
+	items, err := h.service.ListStorageClasses(c.Request.Context(), c.Param("cluster"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "storageclass.listFailed", err.Error())
 		return
@@ -27,7 +26,7 @@ func (h *StorageClassHandler) ListStorageClasses(c *gin.Context) {
 }
 
 func (h *StorageClassHandler) GetStorageClass(c *gin.Context) {
-	item, err := h.service.GetStorageClass(context.Background(), c.Param("cluster"), c.Param("storageclass"))
+	item, err := h.service.GetStorageClass(c.Request.Context(), c.Param("cluster"), c.Param("storageclass"))
 	if err != nil {
 		ResponseError(c, http.StatusInternalServerError, "storageclass.getFailed", err.Error())
 		return
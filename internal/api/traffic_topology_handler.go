@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"net/http"
 
 	"kube-tide/internal/core/k8s"
@@ -20,7 +19,7 @@ func NewTrafficTopologyHandler(service *k8s.TrafficTopologyService) *TrafficTopo
 func (h *TrafficTopologyHandler) GetTrafficTopology(c *gin.Context) {
 	clusterName := c.Param("cluster")
 	namespace := namespaceFromRequest(c)
-	topology, err := h.service.GetTrafficTopology(context.Background(), clusterName, namespace)
+	topology, err := h.service.GetTrafficTopology(c.Request.Context(), clusterName, namespace)
 	if err != nil {
 		FailWithError(c, http.StatusInternalServerError, "trafficTopology.fetchFailed", err)
 		return
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"kube-tide/internal/core/k8s"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VolumeSnapshotHandler struct {
+	service *k8s.VolumeSnapshotService
+}
+
+func NewVolumeSnapshotHandler(service *k8s.VolumeSnapshotService) *VolumeSnapshotHandler {
+	return &VolumeSnapshotHandler{service: service}
+}
+
+func (h *VolumeSnapshotHandler) ListVolumeSnapshotClasses(c *gin.Context) {
+	items, err := h.service.ListVolumeSnapshotClasses(c.Request.Context(), c.Param("cluster"))
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "volumeSnapshot.listClassesFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"volumeSnapshotClasses": items})
+}
+
+func (h *VolumeSnapshotHandler) ListVolumeSnapshots(c *gin.Context) {
+	namespace := namespaceFromRequest(c)
+	items, err := h.service.ListVolumeSnapshots(c.Request.Context(), c.Param("cluster"), namespace)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "volumeSnapshot.listFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"volumeSnapshots": items})
+}
+
+func (h *VolumeSnapshotHandler) GetVolumeSnapshot(c *gin.Context) {
+	item, err := h.service.GetVolumeSnapshot(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("snapshot"))
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "volumeSnapshot.getFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"volumeSnapshot": item})
+}
+
+func (h *VolumeSnapshotHandler) CreateVolumeSnapshot(c *gin.Context) {
+	var req k8s.CreateVolumeSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "volumeSnapshot.invalidRequest", err.Error())
+		return
+	}
+	item, err := h.service.CreateVolumeSnapshot(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), req)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "volumeSnapshot.createFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"volumeSnapshot": item})
+}
+
+func (h *VolumeSnapshotHandler) RestoreVolumeSnapshot(c *gin.Context) {
+	var req k8s.RestoreVolumeSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ResponseError(c, http.StatusBadRequest, "volumeSnapshot.invalidRequest", err.Error())
+		return
+	}
+	item, err := h.service.RestoreVolumeSnapshot(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("snapshot"), req)
+	if err != nil {
+		ResponseError(c, http.StatusInternalServerError, "volumeSnapshot.restoreFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"pvc": item})
+}
+
+func (h *VolumeSnapshotHandler) DeleteVolumeSnapshot(c *gin.Context) {
+	if err := h.service.DeleteVolumeSnapshot(c.Request.Context(), c.Param("cluster"), c.Param("namespace"), c.Param("snapshot")); err != nil {
+		ResponseError(c, http.StatusInternalServerError, "volumeSnapshot.deleteFailed", err.Error())
+		return
+	}
+	ResponseSuccess(c, gin.H{"message": "VolumeSnapshot deleted successfully"})
+}
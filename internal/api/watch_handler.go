@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"kube-tide/internal/api/middleware"
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/core/k8s"
+	"kube-tide/internal/utils/logger"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/gin-gonic/gin"
+)
+
+// watchHeartbeatInterval keeps idle SSE connections open through proxies
+const watchHeartbeatInterval = 30 * time.Second
+
+// WatchHandler streams live resource changes
+type WatchHandler struct {
+	service        *k8s.ResourceWatchService
+	upgradeOptions *websocket.AcceptOptions
+}
+
+// NewWatchHandler creates a resource watch handler
+func NewWatchHandler(service *k8s.ResourceWatchService, allowedOrigins []string) *WatchHandler {
+	return &WatchHandler{
+		service:        service,
+		upgradeOptions: newUpgradeOptions(allowedOrigins),
+	}
+}
+
+// splitQueryList reads a repeated or comma-separated query parameter
+func splitQueryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// Watch streams ADDED/MODIFIED/DELETED events for the requested kinds and namespaces.
+// Query: kinds=pods,deployments (required), namespaces=a,b (optional, all when empty).
+// WebSocket upgrade requests receive one JSON message per event, other requests receive SSE.
+func (h *WatchHandler) Watch(c *gin.Context) {
+	clusterName := c.Param("cluster")
+	kinds := splitQueryList(c, "kinds")
+	namespaces := splitQueryList(c, "namespaces")
+	if len(namespaces) == 0 {
+		namespaces = splitQueryList(c, "namespace")
+	}
+	if len(namespaces) == 1 && namespaces[0] == "all" {
+		namespaces = nil
+	}
+
+	if len(kinds) == 0 {
+		ResponseError(c, http.StatusBadRequest, "watch.invalidKinds")
+		return
+	}
+	// the router only checked cluster access, every kind/namespace pair needs read permission
+	for _, kind := range kinds {
+		supported, namespaced := h.service.LookupKind(kind)
+		if !supported {
+			FailWithError(c, http.StatusBadRequest, "watch.invalidKinds", fmt.Errorf("%s (supported: %s)", kind, strings.Join(h.service.Kinds(), ", ")))
+			return
+		}
+		scopes := namespaces
+		if !namespaced || len(scopes) == 0 {
+			scopes = []string{""}
+		}
+		for _, namespace := range scopes {
+			if !middleware.CanAccess(c, clusterName, namespace, auth.VerbRead) {
+				ResponseError(c, http.StatusForbidden, "auth.forbidden")
+				return
+			}
+		}
+	}
+
+	watcher, err := h.service.Watch(c.Request.Context(), clusterName, kinds, namespaces)
+	if err != nil {
+		logger.Errorf("Failed to watch resources: %s", err.Error())
+		FailWithError(c, http.StatusInternalServerError, "watch.startFailed", err)
+		return
+	}
+	defer watcher.Stop()
+
+	if c.IsWebsocket() {
+		h.serveWebSocket(c, watcher)
+		return
+	}
+	h.serveSSE(c, watcher)
+}
+
+// serveSSE writes each event as an SSE data line until the client disconnects or the watch ends
+func (h *WatchHandler) serveSSE(c *gin.Context, watcher *k8s.ResourceWatch) {
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.Header().Set("X-Accel-Buffering", "no") // Disable Nginx buffering if using Nginx
+	c.Writer.WriteHeader(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprintf(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, ok := <-watcher.Events():
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Errorf("Failed to encode watch event: %v", err)
+				continue
+			}
+			fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+			c.Writer.Flush()
+		}
+	}
+}
+
+// serveWebSocket writes each event as a JSON text message until either side closes
+func (h *WatchHandler) serveWebSocket(c *gin.Context, watcher *k8s.ResourceWatch) {
+	wsConn, err := websocket.Accept(c.Writer, c.Request, h.upgradeOptions)
+	if err != nil {
+		logger.Errorf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer wsConn.Close(websocket.StatusInternalError, "Connection closed")
+
+	// the client never sends data; CloseRead cancels ctx once the client goes away
+	ctx := wsConn.CloseRead(c.Request.Context())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events():
+			if !ok {
+				wsConn.Close(websocket.StatusNormalClosure, "Watch ended")
+				return
+			}
+			writeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			err := wsjson.Write(writeCtx, wsConn, event)
+			cancel()
+			if err != nil {
+				logger.Debug("Failed to send watch event", "error", err)
+				return
+			}
+		}
+	}
+}
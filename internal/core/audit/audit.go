@@ -0,0 +1,237 @@
+package audit
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kube-tide/configs"
+	"kube-tide/internal/utils/logger"
+)
+
+// 审计事件类型
+const (
+	ActionRequest      = "request"       // 变更类 API 调用
+	ActionExecOpen     = "exec.open"     // 打开 Pod 终端
+	ActionExecClose    = "exec.close"    // 关闭 Pod 终端
+	ActionSecretReveal = "secret.reveal" // 查看 Secret 明文
+)
+
+// Record 一条审计记录，以 JSON-lines 形式写入审计文件
+type Record struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user"`
+	Groups     []string  `json:"groups,omitempty"`
+	AuthMethod string    `json:"authMethod,omitempty"`
+	ClientIP   string    `json:"clientIp,omitempty"`
+	Action     string    `json:"action"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Route      string    `json:"route,omitempty"`
+	Cluster    string    `json:"cluster,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Resource   string    `json:"resource,omitempty"` // 如 deployments/scale、nodes/drain
+	Name       string    `json:"name,omitempty"`
+	Container  string    `json:"container,omitempty"`
+	BodySHA256 string    `json:"bodySha256,omitempty"` // 请求体摘要，不记录明文
+	BodySize   int64     `json:"bodySize,omitempty"`
+	Status     int       `json:"status,omitempty"`
+	LatencyMs  int64     `json:"latencyMs"`
+	Detail     string    `json:"detail,omitempty"`
+}
+
+// Filter 审计查询条件，空字段表示不过滤
+type Filter struct {
+	User      string
+	Cluster   string
+	Namespace string
+	Resource  string // 前缀匹配，如 deployments 匹配 deployments/scale
+	Action    string
+	Method    string
+	Status    int
+	Since     time.Time
+	Until     time.Time
+	Page      int
+	Limit     int
+}
+
+// QueryResult 分页查询结果，按时间倒序
+type QueryResult struct {
+	Items []Record `json:"items"`
+	Total int      `json:"total"`
+	Page  int      `json:"page"`
+	Limit int      `json:"limit"`
+}
+
+// Logger 审计日志写入与查询
+type Logger struct {
+	path   string
+	writer io.WriteCloser
+	mutex  sync.Mutex
+}
+
+// NewLogger 创建审计日志，文件按 rotate 配置滚动
+func NewLogger(path string, rotate configs.LogRotateConfig) *Logger {
+	return &Logger{
+		path:   path,
+		writer: logger.NewRotatingFile(path, rotate),
+	}
+}
+
+// Log 写入一条审计记录，写入失败只记录错误日志，不影响业务请求
+func (l *Logger) Log(record Record) {
+	if l == nil {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		logger.Error("failed to encode audit record", "error", err.Error())
+		return
+	}
+	data = append(data, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if _, err := l.writer.Write(data); err != nil {
+		logger.Error("failed to write audit record", "error", err.Error())
+	}
+}
+
+// Close 关闭审计文件
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.writer.Close()
+}
+
+// Query 扫描当前文件与滚动备份，返回满足条件的记录（按时间倒序分页）
+func (l *Logger) Query(filter Filter) (*QueryResult, error) {
+	if filter.Limit <= 0 || filter.Limit > 500 {
+		filter.Limit = 50
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	files, err := l.files()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]Record, 0)
+	for _, file := range files {
+		if err := scanFile(file, func(r Record) {
+			if filter.matches(r) {
+				matched = append(matched, r)
+			}
+		}); err != nil {
+			logger.Warn("failed to read audit file", "file", file, "error", err.Error())
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Time.After(matched[j].Time)
+	})
+
+	result := &QueryResult{Items: []Record{}, Total: len(matched), Page: filter.Page, Limit: filter.Limit}
+	start := (filter.Page - 1) * filter.Limit
+	if start < len(matched) {
+		end := min(start+filter.Limit, len(matched))
+		result.Items = matched[start:end]
+	}
+	return result, nil
+}
+
+// files 返回当前审计文件与 lumberjack 备份（<name>-<时间戳><ext>[.gz]）
+func (l *Logger) files() ([]string, error) {
+	dir := filepath.Dir(l.path)
+	ext := filepath.Ext(l.path)
+	prefix := strings.TrimSuffix(filepath.Base(l.path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read audit directory: %w", err)
+	}
+	files := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		if name == filepath.Base(l.path) ||
+			(strings.HasPrefix(name, prefix) && (strings.HasSuffix(name, ext) || strings.HasSuffix(name, ext+".gz"))) {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	return files, nil
+}
+
+func scanFile(path string, fn func(Record)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		fn(record)
+	}
+	return scanner.Err()
+}
+
+func (f Filter) matches(r Record) bool {
+	switch {
+	case f.User != "" && r.User != f.User:
+		return false
+	case f.Cluster != "" && r.Cluster != f.Cluster:
+		return false
+	case f.Namespace != "" && r.Namespace != f.Namespace:
+		return false
+	case f.Resource != "" && !strings.HasPrefix(r.Resource, f.Resource):
+		return false
+	case f.Action != "" && r.Action != f.Action:
+		return false
+	case f.Method != "" && !strings.EqualFold(r.Method, f.Method):
+		return false
+	case f.Status != 0 && r.Status != f.Status:
+		return false
+	case !f.Since.IsZero() && r.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && r.Time.After(f.Until):
+		return false
+	}
+	return true
+}
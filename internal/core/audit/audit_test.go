@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"kube-tide/configs"
+)
+
+func TestLoggerQuery(t *testing.T) {
+	l := NewLogger(filepath.Join(t.TempDir(), "audit.jsonl"), configs.LogRotateConfig{MaxSize: 1})
+	defer l.Close()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.Log(Record{Time: base, User: "alice", Cluster: "dev", Namespace: "a", Resource: "deployments/scale", Action: ActionRequest, Method: "PUT", Status: 200})
+	l.Log(Record{Time: base.Add(time.Minute), User: "bob", Cluster: "dev", Namespace: "b", Resource: "deployments", Action: ActionRequest, Method: "DELETE", Status: 403})
+	l.Log(Record{Time: base.Add(2 * time.Minute), User: "alice", Cluster: "prod", Resource: "nodes/drain", Action: ActionRequest, Method: "POST", Status: 200})
+	l.Log(Record{Time: base.Add(3 * time.Minute), User: "alice", Cluster: "dev", Namespace: "a", Resource: "pods/exec", Action: ActionExecOpen, Method: "GET"})
+
+	all, err := l.Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if all.Total != 4 || all.Items[0].Action != ActionExecOpen {
+		t.Fatalf("expected 4 records newest first, got %+v", all)
+	}
+
+	deployments, _ := l.Query(Filter{Cluster: "dev", Resource: "deployments"})
+	if deployments.Total != 2 {
+		t.Errorf("resource prefix filter: got %d, want 2", deployments.Total)
+	}
+
+	denied, _ := l.Query(Filter{Status: 403})
+	if denied.Total != 1 || denied.Items[0].User != "bob" {
+		t.Errorf("status filter: got %+v", denied.Items)
+	}
+
+	paged, _ := l.Query(Filter{User: "alice", Since: base.Add(30 * time.Second), Page: 2, Limit: 1})
+	if paged.Total != 2 || len(paged.Items) != 1 || paged.Items[0].Resource != "nodes/drain" {
+		t.Errorf("paging: got %+v", paged)
+	}
+}
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrUnauthenticated 请求未携带有效凭证
+var ErrUnauthenticated = errors.New("authentication required")
+
+// Authenticator 组合本地用户、API Token、OIDC 三种认证方式
+type Authenticator struct {
+	enabled  bool
+	users    *UserStore
+	tokens   *TokenStore
+	sessions *SessionManager
+	oidc     *OIDCProvider
+}
+
+// NewAuthenticator 创建认证器。enabled 为 false 时所有请求都以 anonymous 管理员身份通过。
+// oidc 可为 nil，表示未启用 OIDC 登录。
+func NewAuthenticator(enabled bool, users *UserStore, tokens *TokenStore, sessions *SessionManager, oidc *OIDCProvider) *Authenticator {
+	return &Authenticator{
+		enabled:  enabled,
+		users:    users,
+		tokens:   tokens,
+		sessions: sessions,
+		oidc:     oidc,
+	}
+}
+
+// Enabled 是否启用认证
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// OIDC 返回 OIDC 提供方，未启用时为 nil
+func (a *Authenticator) OIDC() *OIDCProvider {
+	return a.oidc
+}
+
+// Users 本地用户存储
+func (a *Authenticator) Users() *UserStore {
+	return a.users
+}
+
+// Tokens API Token 存储
+func (a *Authenticator) Tokens() *TokenStore {
+	return a.tokens
+}
+
+// SessionTTL 会话有效期
+func (a *Authenticator) SessionTTL() time.Duration {
+	return a.sessions.TTL()
+}
+
+// Authenticate 从请求中解析身份：优先 Authorization: Bearer，其次会话 Cookie
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	if !a.enabled {
+		return anonymousIdentity(), nil
+	}
+
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, credential, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || credential == "" {
+			return nil, ErrUnauthenticated
+		}
+		return a.authenticateBearer(r.Context(), strings.TrimSpace(credential))
+	}
+
+	if cookie, err := r.Cookie(SessionCookieName); err == nil && cookie.Value != "" {
+		if identity := a.sessions.Get(cookie.Value); identity != nil {
+			return identity, nil
+		}
+	}
+	return nil, ErrUnauthenticated
+}
+
+func (a *Authenticator) authenticateBearer(ctx context.Context, credential string) (*Identity, error) {
+	if IsAPIToken(credential) {
+		identity, err := a.tokens.Verify(credential)
+		if err != nil {
+			return nil, ErrUnauthenticated
+		}
+		// 本地用户的 Token 按用户当前的组授权，调整组（如撤销管理员）后立即生效；
+		// 用户被禁用后其 Token 同样失效
+		if info, err := a.users.Get(identity.Username); err == nil {
+			if info.Disabled {
+				return nil, ErrUnauthenticated
+			}
+			identity.Groups = info.Groups
+		}
+		return identity, nil
+	}
+	if a.oidc != nil {
+		identity, err := a.oidc.VerifyIDToken(ctx, credential)
+		if err != nil {
+			return nil, ErrUnauthenticated
+		}
+		return identity, nil
+	}
+	return nil, ErrUnauthenticated
+}
+
+// Login 校验本地用户密码并创建会话
+func (a *Authenticator) Login(username, password string) (*Identity, string, error) {
+	info, err := a.users.Verify(username, password)
+	if err != nil {
+		return nil, "", err
+	}
+	identity := &Identity{Username: info.Username, Groups: info.Groups, Method: MethodLocal}
+	sessionID, err := a.sessions.Create(identity)
+	if err != nil {
+		return nil, "", err
+	}
+	return identity, sessionID, nil
+}
+
+// LoginOIDC 用 OIDC 授权码完成登录并创建会话
+func (a *Authenticator) LoginOIDC(ctx context.Context, code string) (*Identity, string, error) {
+	if a.oidc == nil {
+		return nil, "", errors.New("oidc is not enabled")
+	}
+	identity, err := a.oidc.Exchange(ctx, code)
+	if err != nil {
+		return nil, "", err
+	}
+	sessionID, err := a.sessions.Create(identity)
+	if err != nil {
+		return nil, "", err
+	}
+	return identity, sessionID, nil
+}
+
+// Logout 结束会话
+func (a *Authenticator) Logout(sessionID string) {
+	a.sessions.Delete(sessionID)
+}
+
+// UpdateUser 更新本地用户；禁用或修改密码时使其现有会话失效
+func (a *Authenticator) UpdateUser(username string, req UpdateUserRequest) (UserInfo, error) {
+	info, err := a.users.Update(username, req)
+	if err != nil {
+		return UserInfo{}, err
+	}
+	if req.Password != nil || req.Groups != nil || (req.Disabled != nil && *req.Disabled) {
+		a.sessions.DeleteByUser(username)
+	}
+	return info, nil
+}
+
+// DeleteUser 删除本地用户，同时吊销其会话和 API Token
+func (a *Authenticator) DeleteUser(username string) error {
+	if err := a.users.Delete(username); err != nil {
+		return err
+	}
+	a.sessions.DeleteByUser(username)
+	return a.tokens.DeleteByUser(username)
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAPITokenUsesCurrentGroups(t *testing.T) {
+	dir := t.TempDir()
+	users, err := NewUserStore(filepath.Join(dir, "users.json"))
+	if err != nil {
+		t.Fatalf("NewUserStore: %v", err)
+	}
+	tokens, err := NewTokenStore(filepath.Join(dir, "tokens.json"))
+	if err != nil {
+		t.Fatalf("NewTokenStore: %v", err)
+	}
+	a := NewAuthenticator(true, users, tokens, NewSessionManager(time.Hour), nil)
+
+	if _, err := users.Create("alice", "password123", []string{AdminGroup}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	token, _, err := tokens.Create(&Identity{Username: "alice", Groups: []string{AdminGroup}}, "ci", 0)
+	if err != nil {
+		t.Fatalf("Create token: %v", err)
+	}
+	authenticate := func() (*Identity, error) {
+		r := httptest.NewRequest("GET", "/api/v1/clusters", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return a.Authenticate(r)
+	}
+
+	if identity, err := authenticate(); err != nil || !identity.IsAdmin() {
+		t.Fatalf("Authenticate = %+v, %v; want admin", identity, err)
+	}
+
+	demoted := []string{"team-a"}
+	if _, err := a.UpdateUser("alice", UpdateUserRequest{Groups: &demoted}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	identity, err := authenticate()
+	if err != nil || identity.IsAdmin() || len(identity.Groups) != 1 || identity.Groups[0] != "team-a" {
+		t.Fatalf("Authenticate after demotion = %+v, %v; want groups [team-a]", identity, err)
+	}
+
+	disabled := true
+	if _, err := a.UpdateUser("alice", UpdateUserRequest{Disabled: &disabled}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if _, err := authenticate(); err != ErrUnauthenticated {
+		t.Fatalf("Authenticate for disabled user = %v, want ErrUnauthenticated", err)
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// AdminGroup 平台管理员组，拥有用户管理等全部权限
+const AdminGroup = "kube-tide:admins"
+
+// 认证方式
+const (
+	MethodLocal     = "local"
+	MethodToken     = "token"
+	MethodOIDC      = "oidc"
+	MethodAnonymous = "anonymous"
+)
+
+// Identity 已认证的调用者身份
+type Identity struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+	Method   string   `json:"method"`
+}
+
+// IsAdmin 是否为平台管理员
+func (i *Identity) IsAdmin() bool {
+	return i != nil && slices.Contains(i.Groups, AdminGroup)
+}
+
+// anonymousIdentity 认证关闭时使用的身份，保持与未引入认证前相同的全部权限
+func anonymousIdentity() *Identity {
+	return &Identity{
+		Username: "anonymous",
+		Groups:   []string{AdminGroup},
+		Method:   MethodAnonymous,
+	}
+}
+
+type identityContextKey struct{}
+
+// WithIdentity 将调用者身份写入 context，供下游按用户访问集群
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext 从 context 读取调用者身份，未设置时返回 nil
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return identity
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestOIDCVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 server.URL,
+				"authorization_endpoint": server.URL + "/auth",
+				"token_endpoint":         server.URL + "/token",
+				"jwks_uri":               server.URL + "/keys",
+			})
+		case "/keys":
+			json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+				"kid": "k1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{IssuerURL: server.URL, ClientID: "kube-tide"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := map[string]any{
+		"iss":    server.URL,
+		"aud":    "kube-tide",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"email":  "alice@example.com",
+		"groups": []string{"dev", "ops"},
+	}
+	identity, err := provider.VerifyIDToken(context.Background(), signTestToken(t, key, "k1", valid))
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if identity.Username != "alice@example.com" || len(identity.Groups) != 2 || identity.Method != MethodOIDC {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	cases := map[string]map[string]any{
+		"expired":      {"iss": server.URL, "aud": "kube-tide", "exp": time.Now().Add(-time.Hour).Unix(), "email": "a"},
+		"wrong issuer": {"iss": "https://evil.example.com", "aud": "kube-tide", "exp": time.Now().Add(time.Hour).Unix(), "email": "a"},
+		"wrong aud":    {"iss": server.URL, "aud": "other", "exp": time.Now().Add(time.Hour).Unix(), "email": "a"},
+	}
+	for name, claims := range cases {
+		if _, err := provider.VerifyIDToken(context.Background(), signTestToken(t, key, "k1", claims)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := provider.VerifyIDToken(context.Background(), signTestToken(t, other, "k1", valid)); err == nil {
+		t.Error("token signed by another key accepted")
+	}
+}
@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// 平台内的操作类型（与 Kubernetes verbs 无关，粒度按 kube-tide 功能划分）
+const (
+	VerbRead         = "read"
+	VerbWrite        = "write"
+	VerbExec         = "exec"
+	VerbDrain        = "drain"
+	VerbSecretReveal = "secret-reveal"
+	// Wildcard 匹配所有集群、命名空间或操作
+	Wildcard = "*"
+)
+
+// Verbs 所有可授权的操作
+var Verbs = []string{VerbRead, VerbWrite, VerbExec, VerbDrain, VerbSecretReveal}
+
+// 授权主体类型
+const (
+	SubjectUser  = "user"
+	SubjectGroup = "group"
+)
+
+// ErrBindingNotFound 策略绑定不存在
+var ErrBindingNotFound = errors.New("policy binding not found")
+
+// PolicySubject 绑定的主体
+type PolicySubject struct {
+	Kind string `json:"kind"` // user 或 group
+	Name string `json:"name"`
+}
+
+// PolicyBinding 将用户/组映射到集群、命名空间与操作。
+// Namespaces 为 "*" 时同时覆盖集群级资源（节点、全命名空间列表等）。
+type PolicyBinding struct {
+	Name       string          `json:"name"`
+	Subjects   []PolicySubject `json:"subjects"`
+	Clusters   []string        `json:"clusters"`
+	Namespaces []string        `json:"namespaces"`
+	Verbs      []string        `json:"verbs"`
+}
+
+// Attributes 一次授权检查的请求属性，Namespace 为空表示集群级请求
+type Attributes struct {
+	Cluster   string
+	Namespace string
+	Verb      string
+}
+
+type policyFile struct {
+	Bindings []PolicyBinding `json:"bindings"`
+}
+
+// Authorizer 基于策略文件的授权器，管理员组始终放行
+type Authorizer struct {
+	path     string
+	bindings map[string]PolicyBinding
+	mutex    sync.RWMutex
+}
+
+// NewAuthorizer 从策略文件（YAML 或 JSON）加载授权器，文件不存在时没有任何绑定
+func NewAuthorizer(path string) (*Authorizer, error) {
+	a := &Authorizer{path: path, bindings: make(map[string]PolicyBinding)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var file policyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+	for _, b := range file.Bindings {
+		if err := validateBinding(b); err != nil {
+			return nil, fmt.Errorf("invalid policy binding %q: %w", b.Name, err)
+		}
+		a.bindings[b.Name] = b
+	}
+	return a, nil
+}
+
+func validateBinding(b PolicyBinding) error {
+	if b.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+	if len(b.Subjects) == 0 {
+		return errors.New("at least one subject is required")
+	}
+	for _, s := range b.Subjects {
+		if (s.Kind != SubjectUser && s.Kind != SubjectGroup) || s.Name == "" {
+			return fmt.Errorf("invalid subject %s/%s", s.Kind, s.Name)
+		}
+	}
+	if len(b.Clusters) == 0 || len(b.Namespaces) == 0 || len(b.Verbs) == 0 {
+		return errors.New("clusters, namespaces and verbs cannot be empty")
+	}
+	for _, v := range b.Verbs {
+		if v != Wildcard && !slices.Contains(Verbs, v) {
+			return fmt.Errorf("unknown verb %q", v)
+		}
+	}
+	return nil
+}
+
+func (a *Authorizer) flushLocked() error {
+	file := policyFile{Bindings: a.listLocked()}
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to encode policy: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		return fmt.Errorf("failed to create policy directory: %w", err)
+	}
+	tmp := a.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write policy file: %w", err)
+	}
+	if err := os.Rename(tmp, a.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace policy file: %w", err)
+	}
+	return nil
+}
+
+func (a *Authorizer) listLocked() []PolicyBinding {
+	result := make([]PolicyBinding, 0, len(a.bindings))
+	for _, b := range a.bindings {
+		result = append(result, b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// ListBindings 列出所有策略绑定
+func (a *Authorizer) ListBindings() []PolicyBinding {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.listLocked()
+}
+
+// SaveBinding 创建或替换同名策略绑定
+func (a *Authorizer) SaveBinding(b PolicyBinding) error {
+	if err := validateBinding(b); err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	previous, existed := a.bindings[b.Name]
+	a.bindings[b.Name] = b
+	if err := a.flushLocked(); err != nil {
+		if existed {
+			a.bindings[b.Name] = previous
+		} else {
+			delete(a.bindings, b.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteBinding 删除策略绑定
+func (a *Authorizer) DeleteBinding(name string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	previous, exists := a.bindings[name]
+	if !exists {
+		return ErrBindingNotFound
+	}
+	delete(a.bindings, name)
+	if err := a.flushLocked(); err != nil {
+		a.bindings[name] = previous
+		return err
+	}
+	return nil
+}
+
+func matches(values []string, value string) bool {
+	return slices.Contains(values, Wildcard) || slices.Contains(values, value)
+}
+
+func (b PolicyBinding) appliesTo(identity *Identity) bool {
+	for _, s := range b.Subjects {
+		switch s.Kind {
+		case SubjectUser:
+			if s.Name == identity.Username {
+				return true
+			}
+		case SubjectGroup:
+			if slices.Contains(identity.Groups, s.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b PolicyBinding) allows(attrs Attributes) bool {
+	if !matches(b.Clusters, attrs.Cluster) || !matches(b.Verbs, attrs.Verb) {
+		return false
+	}
+	// 集群级请求只允许拥有全部命名空间权限的绑定
+	if attrs.Namespace == "" {
+		return slices.Contains(b.Namespaces, Wildcard)
+	}
+	return matches(b.Namespaces, attrs.Namespace)
+}
+
+// Authorize 判断身份是否可以执行请求
+func (a *Authorizer) Authorize(identity *Identity, attrs Attributes) bool {
+	if identity == nil {
+		return false
+	}
+	if identity.IsAdmin() {
+		return true
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	for _, b := range a.bindings {
+		if b.appliesTo(identity) && b.allows(attrs) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedVerbs 返回身份在指定集群/命名空间上被授予的操作
+func (a *Authorizer) AllowedVerbs(identity *Identity, cluster, namespace string) []string {
+	result := make([]string, 0, len(Verbs))
+	for _, verb := range Verbs {
+		if a.Authorize(identity, Attributes{Cluster: cluster, Namespace: namespace, Verb: verb}) {
+			result = append(result, verb)
+		}
+	}
+	return result
+}
+
+// CanAccessCluster 身份在集群上是否有任何绑定（任意命名空间、任意操作）
+func (a *Authorizer) CanAccessCluster(identity *Identity, cluster string) bool {
+	if identity.IsAdmin() {
+		return true
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	for _, b := range a.bindings {
+		if b.appliesTo(identity) && matches(b.Clusters, cluster) {
+			return true
+		}
+	}
+	return false
+}
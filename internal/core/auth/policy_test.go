@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAuthorizerBindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	a, err := NewAuthorizer(path)
+	if err != nil {
+		t.Fatalf("NewAuthorizer: %v", err)
+	}
+	if err := a.SaveBinding(PolicyBinding{
+		Name:       "dev",
+		Subjects:   []PolicySubject{{Kind: SubjectGroup, Name: "team-a"}},
+		Clusters:   []string{"dev"},
+		Namespaces: []string{"team-a"},
+		Verbs:      []string{VerbRead, VerbExec},
+	}); err != nil {
+		t.Fatalf("SaveBinding: %v", err)
+	}
+
+	alice := &Identity{Username: "alice", Groups: []string{"team-a"}}
+	cases := []struct {
+		attrs Attributes
+		want  bool
+	}{
+		{Attributes{Cluster: "dev", Namespace: "team-a", Verb: VerbRead}, true},
+		{Attributes{Cluster: "dev", Namespace: "team-a", Verb: VerbExec}, true},
+		{Attributes{Cluster: "dev", Namespace: "team-a", Verb: VerbWrite}, false},
+		{Attributes{Cluster: "dev", Namespace: "team-b", Verb: VerbRead}, false},
+		{Attributes{Cluster: "dev", Namespace: "", Verb: VerbRead}, false},
+		{Attributes{Cluster: "prod", Namespace: "team-a", Verb: VerbRead}, false},
+	}
+	for _, tc := range cases {
+		if got := a.Authorize(alice, tc.attrs); got != tc.want {
+			t.Errorf("Authorize(%+v) = %v, want %v", tc.attrs, got, tc.want)
+		}
+	}
+	if a.Authorize(&Identity{Username: "bob"}, cases[0].attrs) {
+		t.Error("unbound user must be denied")
+	}
+	if !a.Authorize(&Identity{Username: "root", Groups: []string{AdminGroup}}, cases[5].attrs) {
+		t.Error("admin must always be allowed")
+	}
+
+	// 重新加载后绑定应保留
+	reloaded, err := NewAuthorizer(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !reloaded.Authorize(alice, cases[0].attrs) {
+		t.Error("binding lost after reload")
+	}
+	if err := reloaded.DeleteBinding("dev"); err != nil {
+		t.Fatalf("DeleteBinding: %v", err)
+	}
+	if err := reloaded.DeleteBinding("dev"); err != ErrBindingNotFound {
+		t.Errorf("second delete = %v, want ErrBindingNotFound", err)
+	}
+}
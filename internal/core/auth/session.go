@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// SessionCookieName 浏览器会话 Cookie 名称
+const SessionCookieName = "kube_tide_session"
+
+type session struct {
+	identity  *Identity
+	expiresAt time.Time
+}
+
+// SessionManager 内存会话管理，进程重启后需重新登录
+type SessionManager struct {
+	ttl      time.Duration
+	sessions map[string]session
+	mutex    sync.Mutex
+}
+
+// NewSessionManager 创建会话管理器
+func NewSessionManager(ttl time.Duration) *SessionManager {
+	if ttl <= 0 {
+		ttl = 12 * time.Hour
+	}
+	return &SessionManager{ttl: ttl, sessions: make(map[string]session)}
+}
+
+// TTL 会话有效期
+func (m *SessionManager) TTL() time.Duration {
+	return m.ttl
+}
+
+// Create 为身份创建会话，返回会话 ID
+func (m *SessionManager) Create(identity *Identity) (string, error) {
+	id, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	for sid, s := range m.sessions {
+		if now.After(s.expiresAt) {
+			delete(m.sessions, sid)
+		}
+	}
+	m.sessions[id] = session{identity: identity, expiresAt: now.Add(m.ttl)}
+	return id, nil
+}
+
+// Get 获取会话身份，过期或不存在时返回 nil
+func (m *SessionManager) Get(id string) *Identity {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s, exists := m.sessions[id]
+	if !exists {
+		return nil
+	}
+	if time.Now().After(s.expiresAt) {
+		delete(m.sessions, id)
+		return nil
+	}
+	return s.identity
+}
+
+// Delete 删除会话
+func (m *SessionManager) Delete(id string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.sessions, id)
+}
+
+// DeleteByUser 删除某用户的全部会话
+func (m *SessionManager) DeleteByUser(username string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for id, s := range m.sessions {
+		if s.identity.Username == username {
+			delete(m.sessions, id)
+		}
+	}
+}
+
+// NewState 生成 OIDC 登录使用的随机 state
+func NewState() (string, error) {
+	return randomHex(16)
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiTokenPrefix API Token 前缀，便于识别和扫描泄露
+const apiTokenPrefix = "kt_"
+
+// ErrTokenNotFound API Token 不存在
+var ErrTokenNotFound = errors.New("token not found")
+
+// APIToken Bearer API Token 记录，只保存 Token 的 SHA-256 哈希
+type APIToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Username   string     `json:"username"`
+	Groups     []string   `json:"groups"` // 签发时的组；本地用户认证时以用户当前的组为准
+	Hash       string     `json:"hash"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// APITokenInfo 对外返回的 Token 信息（不含哈希）
+type APITokenInfo struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Username   string     `json:"username"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+func (t APIToken) info() APITokenInfo {
+	return APITokenInfo{
+		ID:         t.ID,
+		Name:       t.Name,
+		Username:   t.Username,
+		CreatedAt:  t.CreatedAt,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+	}
+}
+
+type tokenFile struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+// TokenStore 基于本地 JSON 文件的 API Token 存储
+type TokenStore struct {
+	path   string
+	tokens map[string]APIToken // key: token ID
+	mutex  sync.RWMutex
+}
+
+// NewTokenStore 创建 Token 存储
+func NewTokenStore(path string) (*TokenStore, error) {
+	s := &TokenStore{path: path, tokens: make(map[string]APIToken)}
+	var file tokenFile
+	if err := readJSONFile(path, &file); err != nil {
+		return nil, err
+	}
+	for _, t := range file.Tokens {
+		s.tokens[t.ID] = t
+	}
+	return s, nil
+}
+
+func (s *TokenStore) flushLocked() error {
+	file := tokenFile{Tokens: make([]APIToken, 0, len(s.tokens))}
+	for _, t := range s.tokens {
+		file.Tokens = append(file.Tokens, t)
+	}
+	sort.Slice(file.Tokens, func(i, j int) bool { return file.Tokens[i].CreatedAt.Before(file.Tokens[j].CreatedAt) })
+	return writeJSONFile(s.path, file)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// IsAPIToken 判断 Bearer 凭证是否为 kube-tide API Token
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, apiTokenPrefix)
+}
+
+// Create 为身份签发新 Token，返回明文 Token（仅此一次）
+func (s *TokenStore) Create(identity *Identity, name string, ttl time.Duration) (string, APITokenInfo, error) {
+	if name == "" {
+		return "", APITokenInfo{}, errors.New("token name cannot be empty")
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return "", APITokenInfo{}, fmt.Errorf("failed to generate token id: %w", err)
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", APITokenInfo{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+	plaintext := apiTokenPrefix + secret
+
+	t := APIToken{
+		ID:        id,
+		Name:      name,
+		Username:  identity.Username,
+		Groups:    identity.Groups,
+		Hash:      hashToken(plaintext),
+		CreatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		expiresAt := t.CreatedAt.Add(ttl)
+		t.ExpiresAt = &expiresAt
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tokens[id] = t
+	if err := s.flushLocked(); err != nil {
+		delete(s.tokens, id)
+		return "", APITokenInfo{}, err
+	}
+	return plaintext, t.info(), nil
+}
+
+// List 列出 Token，username 为空时返回全部
+func (s *TokenStore) List(username string) []APITokenInfo {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]APITokenInfo, 0)
+	for _, t := range s.tokens {
+		if username == "" || t.Username == username {
+			result = append(result, t.info())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result
+}
+
+// Get 获取 Token 信息
+func (s *TokenStore) Get(id string) (APITokenInfo, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	t, exists := s.tokens[id]
+	if !exists {
+		return APITokenInfo{}, ErrTokenNotFound
+	}
+	return t.info(), nil
+}
+
+// Delete 吊销 Token
+func (s *TokenStore) Delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, exists := s.tokens[id]
+	if !exists {
+		return ErrTokenNotFound
+	}
+	delete(s.tokens, id)
+	if err := s.flushLocked(); err != nil {
+		s.tokens[id] = previous
+		return err
+	}
+	return nil
+}
+
+// DeleteByUser 吊销某用户的全部 Token
+func (s *TokenStore) DeleteByUser(username string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	removed := make(map[string]APIToken)
+	for id, t := range s.tokens {
+		if t.Username == username {
+			removed[id] = t
+			delete(s.tokens, id)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	if err := s.flushLocked(); err != nil {
+		for id, t := range removed {
+			s.tokens[id] = t
+		}
+		return err
+	}
+	return nil
+}
+
+// Verify 校验明文 Token，成功时返回对应身份并记录最后使用时间（仅内存）
+func (s *TokenStore) Verify(plaintext string) (*Identity, error) {
+	hash := hashToken(plaintext)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Hash), []byte(hash)) != 1 {
+			continue
+		}
+		now := time.Now()
+		if t.ExpiresAt != nil && now.After(*t.ExpiresAt) {
+			return nil, errors.New("token expired")
+		}
+		t.LastUsedAt = &now
+		s.tokens[id] = t
+		return &Identity{Username: t.Username, Groups: t.Groups, Method: MethodToken}, nil
+	}
+	return nil, ErrTokenNotFound
+}
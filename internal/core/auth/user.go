@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength 本地用户密码最小长度
+const minPasswordLength = 8
+
+var (
+	// ErrInvalidCredentials 用户名或密码错误
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	// ErrUserNotFound 用户不存在
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// dummyHash 用户不存在时仍做一次 bcrypt 比较，避免通过响应时间枚举用户名
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("kube-tide-dummy-password"), bcrypt.DefaultCost)
+
+// User 本地用户
+type User struct {
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"passwordHash"`
+	Groups       []string  `json:"groups"`
+	Disabled     bool      `json:"disabled"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// UserInfo 对外返回的用户信息（不含密码哈希）
+type UserInfo struct {
+	Username  string    `json:"username"`
+	Groups    []string  `json:"groups"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (u User) info() UserInfo {
+	return UserInfo{
+		Username:  u.Username,
+		Groups:    u.Groups,
+		Disabled:  u.Disabled,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// UpdateUserRequest 更新用户请求，nil 字段保持不变
+type UpdateUserRequest struct {
+	Password *string   `json:"password,omitempty"`
+	Groups   *[]string `json:"groups,omitempty"`
+	Disabled *bool     `json:"disabled,omitempty"`
+}
+
+type userFile struct {
+	Users []User `json:"users"`
+}
+
+// UserStore 基于本地 JSON 文件的用户存储，密码使用 bcrypt 哈希
+type UserStore struct {
+	path  string
+	users map[string]User
+	mutex sync.RWMutex
+}
+
+// NewUserStore 创建用户存储
+func NewUserStore(path string) (*UserStore, error) {
+	s := &UserStore{path: path, users: make(map[string]User)}
+	var file userFile
+	if err := readJSONFile(path, &file); err != nil {
+		return nil, err
+	}
+	for _, u := range file.Users {
+		s.users[u.Username] = u
+	}
+	return s, nil
+}
+
+func (s *UserStore) flushLocked() error {
+	file := userFile{Users: make([]User, 0, len(s.users))}
+	for _, u := range s.users {
+		file.Users = append(file.Users, u)
+	}
+	sort.Slice(file.Users, func(i, j int) bool { return file.Users[i].Username < file.Users[j].Username })
+	return writeJSONFile(s.path, file)
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// Count 用户数量
+func (s *UserStore) Count() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.users)
+}
+
+// List 列出所有用户
+func (s *UserStore) List() []UserInfo {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]UserInfo, 0, len(s.users))
+	for _, u := range s.users {
+		result = append(result, u.info())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Username < result[j].Username })
+	return result
+}
+
+// Get 获取用户
+func (s *UserStore) Get(username string) (UserInfo, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	u, exists := s.users[username]
+	if !exists {
+		return UserInfo{}, ErrUserNotFound
+	}
+	return u.info(), nil
+}
+
+// Create 创建用户
+func (s *UserStore) Create(username, password string, groups []string) (UserInfo, error) {
+	if username == "" {
+		return UserInfo{}, errors.New("username cannot be empty")
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.users[username]; exists {
+		return UserInfo{}, fmt.Errorf("user %s already exists", username)
+	}
+	now := time.Now()
+	u := User{
+		Username:     username,
+		PasswordHash: hash,
+		Groups:       groups,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	s.users[username] = u
+	if err := s.flushLocked(); err != nil {
+		delete(s.users, username)
+		return UserInfo{}, err
+	}
+	return u.info(), nil
+}
+
+// Update 更新用户密码、组或禁用状态
+func (s *UserStore) Update(username string, req UpdateUserRequest) (UserInfo, error) {
+	var hash string
+	if req.Password != nil {
+		var err error
+		if hash, err = hashPassword(*req.Password); err != nil {
+			return UserInfo{}, err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, exists := s.users[username]
+	if !exists {
+		return UserInfo{}, ErrUserNotFound
+	}
+	u := previous
+	if hash != "" {
+		u.PasswordHash = hash
+	}
+	if req.Groups != nil {
+		u.Groups = *req.Groups
+	}
+	if req.Disabled != nil {
+		u.Disabled = *req.Disabled
+	}
+	u.UpdatedAt = time.Now()
+	s.users[username] = u
+	if err := s.flushLocked(); err != nil {
+		s.users[username] = previous
+		return UserInfo{}, err
+	}
+	return u.info(), nil
+}
+
+// Delete 删除用户
+func (s *UserStore) Delete(username string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, exists := s.users[username]
+	if !exists {
+		return ErrUserNotFound
+	}
+	delete(s.users, username)
+	if err := s.flushLocked(); err != nil {
+		s.users[username] = previous
+		return err
+	}
+	return nil
+}
+
+// Verify 校验用户名和密码，成功时返回用户信息
+func (s *UserStore) Verify(username, password string) (UserInfo, error) {
+	s.mutex.RLock()
+	u, exists := s.users[username]
+	s.mutex.RUnlock()
+
+	if !exists {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return UserInfo{}, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+	if u.Disabled {
+		return UserInfo{}, ErrInvalidCredentials
+	}
+	return u.info(), nil
+}
+
+// EnsureBootstrapAdmin 用户库为空时创建初始管理员。
+// password 为空时生成随机密码并返回，调用方负责提示给运维人员。
+func EnsureBootstrapAdmin(users *UserStore, username, password string) (string, error) {
+	if users.Count() > 0 {
+		return "", nil
+	}
+	if username == "" {
+		username = "admin"
+	}
+	generated := ""
+	if password == "" {
+		random, err := randomHex(12)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate admin password: %w", err)
+		}
+		password = random
+		generated = random
+	}
+	if _, err := users.Create(username, password, []string{AdminGroup}); err != nil {
+		return "", err
+	}
+	return generated, nil
+}
@@ -0,0 +1,40 @@
+package k8s
+
+// AlertRuleStore 告警规则的持久化接口
+type AlertRuleStore interface {
+	// List 按名称返回所有规则
+	List() ([]AlertRule, error)
+	// Save 新增或覆盖规则（按名称唯一）
+	Save(rule AlertRule) error
+	// Delete 删除规则
+	Delete(name string) error
+}
+
+// FileAlertRuleStore 基于本地 JSON 文件的 AlertRuleStore 默认实现
+type FileAlertRuleStore struct {
+	file *recordFile[AlertRule]
+}
+
+// NewFileAlertRuleStore 创建文件告警规则存储，文件不存在时在首次写入时创建
+func NewFileAlertRuleStore(path string) (*FileAlertRuleStore, error) {
+	file, err := openRecordFile(path, "rules", func(r AlertRule) string { return r.Name })
+	if err != nil {
+		return nil, err
+	}
+	return &FileAlertRuleStore{file: file}, nil
+}
+
+// List 按名称返回所有规则
+func (s *FileAlertRuleStore) List() ([]AlertRule, error) {
+	return s.file.list(), nil
+}
+
+// Save 新增或覆盖规则
+func (s *FileAlertRuleStore) Save(rule AlertRule) error {
+	return s.file.save(rule)
+}
+
+// Delete 删除规则
+func (s *FileAlertRuleStore) Delete(name string) error {
+	return s.file.remove(name)
+}
@@ -48,7 +48,7 @@ type NodeGroupConfig struct {
 
 // GetAutoScalerConfig 获取集群自动扩缩容配置
 func (s *AutoScalerService) GetAutoScalerConfig(ctx context.Context, clusterName string) (*AutoScalerConfig, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +74,7 @@ func (s *AutoScalerService) GetAutoScalerConfig(ctx context.Context, clusterName
 
 // UpdateAutoScalerConfig 更新集群自动扩缩容配置
 func (s *AutoScalerService) UpdateAutoScalerConfig(ctx context.Context, clusterName string, config *AutoScalerConfig) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -428,7 +428,7 @@ func (s *AutoScalerService) createDeployment(ctx context.Context, client *kubern
 
 // GetAutoScalerStatus 获取自动扩缩容状态
 func (s *AutoScalerService) GetAutoScalerStatus(ctx context.Context, clusterName string) (*AutoScalerStatus, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,753 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/logger"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// 蓝绿发布阶段
+const (
+	BlueGreenPhasePinning    = "Pinning"    // 为当前版本的 Pod 补充颜色标签，完成后把 Service 固定到当前颜色
+	BlueGreenPhaseDeploying  = "Deploying"  // 新颜色的 Deployment 已创建，等待全部副本就绪
+	BlueGreenPhaseReady      = "Ready"      // 新颜色已就绪，等待切换
+	BlueGreenPhaseSwitched   = "Switched"   // Service 已指向新颜色，旧颜色保留到 RetainUntil
+	BlueGreenPhaseRolledBack = "RolledBack" // 已切回旧颜色，新颜色保留到 RetainUntil
+	BlueGreenPhaseCompleted  = "Completed"  // 保留期结束，未承接流量的新颜色 Deployment 已删除，或原 Deployment 已缩容到 0
+	BlueGreenPhaseAborted    = "Aborted"    // 切换前中止，新颜色 Deployment 已删除
+	BlueGreenPhaseFailed     = "Failed"     // 推进过程中出错，需要人工处理
+)
+
+const (
+	BlueGreenBlue  = "blue"
+	BlueGreenGreen = "green"
+
+	defaultBlueGreenLabelKey        = "color"
+	defaultBlueGreenRetention       = 30 * time.Minute
+	defaultBlueGreenProgressTimeout = 10 * time.Minute
+	blueGreenReconcileInterval      = 10 * time.Second
+)
+
+// BlueGreenRequest 蓝绿发布请求
+type BlueGreenRequest struct {
+	Images                  map[string]string `json:"images"`                            // 容器名 -> 新版本镜像
+	ColorLabelKey           string            `json:"colorLabelKey,omitempty"`           // 默认为 color
+	RetentionSeconds        int               `json:"retentionSeconds,omitempty"`        // 切换后旧颜色保留多久，默认 1800 秒
+	ProgressDeadlineSeconds int               `json:"progressDeadlineSeconds,omitempty"` // 等待新颜色就绪的期限，默认 600 秒
+	AutoSwitch              bool              `json:"autoSwitch,omitempty"`              // 新颜色就绪后自动切换
+}
+
+// BlueGreenServiceChange 切换时受影响的 Service
+type BlueGreenServiceChange struct {
+	Name        string            `json:"name"`
+	Selector    map[string]string `json:"selector"`
+	NewSelector map[string]string `json:"newSelector"`
+	// Pinned 当前选择器已包含颜色标签；未固定的 Service 会在创建新颜色之前先固定到当前颜色
+	Pinned bool `json:"pinned"`
+}
+
+// BlueGreenIngressPath 经由受影响 Service 的 Ingress 路径
+type BlueGreenIngressPath struct {
+	Ingress string `json:"ingress"`
+	Host    string `json:"host,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Service string `json:"service"`
+	Port    string `json:"port,omitempty"`
+}
+
+// BlueGreenPreview 切换影响范围
+type BlueGreenPreview struct {
+	ActiveDeployment  string                   `json:"activeDeployment"`
+	ActiveColor       string                   `json:"activeColor"`
+	PreviewDeployment string                   `json:"previewDeployment"`
+	PreviewColor      string                   `json:"previewColor"`
+	Services          []BlueGreenServiceChange `json:"services"`
+	IngressPaths      []BlueGreenIngressPath   `json:"ingressPaths"`
+}
+
+// BlueGreenDeployment 一次蓝绿发布及其进度，Deployment 为发起发布时承接流量的 Deployment
+type BlueGreenDeployment struct {
+	Cluster           string           `json:"cluster"`
+	Namespace         string           `json:"namespace"`
+	Deployment        string           `json:"deployment"`
+	Spec              BlueGreenRequest `json:"spec"`
+	ActiveColor       string           `json:"activeColor"`
+	PreviewDeployment string           `json:"previewDeployment"`
+	PreviewColor      string           `json:"previewColor"`
+	ServingColor      string           `json:"servingColor"`
+	Services          []string         `json:"services"`
+	Phase             string           `json:"phase"`
+	Message           string           `json:"message,omitempty"`
+	StartedBy         string           `json:"startedBy,omitempty"`
+	StartedAt         time.Time        `json:"startedAt"`
+	PhaseStartedAt    time.Time        `json:"phaseStartedAt"`
+	UpdatedAt         time.Time        `json:"updatedAt"`
+	SwitchedAt        *time.Time       `json:"switchedAt,omitempty"`
+	RetainUntil       *time.Time       `json:"retainUntil,omitempty"`
+	FinishedAt        *time.Time       `json:"finishedAt,omitempty"`
+}
+
+func (b *BlueGreenDeployment) key() string {
+	return canaryKey(b.Cluster, b.Namespace, b.Deployment)
+}
+
+// Active 发布是否仍由控制器管理
+func (b *BlueGreenDeployment) Active() bool {
+	switch b.Phase {
+	case BlueGreenPhaseCompleted, BlueGreenPhaseAborted, BlueGreenPhaseFailed:
+		return false
+	}
+	return true
+}
+
+func (b *BlueGreenDeployment) setPhase(phase, message string) {
+	now := time.Now()
+	b.Phase = phase
+	b.Message = message
+	b.PhaseStartedAt = now
+	if !b.Active() {
+		b.FinishedAt = &now
+	}
+}
+
+// servingDeployment 当前承接流量的 Deployment
+func (b *BlueGreenDeployment) servingDeployment() string {
+	if b.ServingColor == b.PreviewColor {
+		return b.PreviewDeployment
+	}
+	return b.Deployment
+}
+
+// idleDeployment 当前未承接流量的 Deployment
+func (b *BlueGreenDeployment) idleDeployment() string {
+	if b.ServingColor == b.PreviewColor {
+		return b.Deployment
+	}
+	return b.PreviewDeployment
+}
+
+// BlueGreenStore 蓝绿发布进度的持久化接口
+type BlueGreenStore interface {
+	List() ([]BlueGreenDeployment, error)
+	Save(deployment BlueGreenDeployment) error
+}
+
+// FileBlueGreenStore 基于本地 JSON 文件的 BlueGreenStore 默认实现
+type FileBlueGreenStore struct {
+	file *recordFile[BlueGreenDeployment]
+}
+
+// NewFileBlueGreenStore 创建文件蓝绿发布存储，文件不存在时在首次写入时创建
+func NewFileBlueGreenStore(path string) (*FileBlueGreenStore, error) {
+	file, err := openRecordFile(path, "deployments", func(b BlueGreenDeployment) string { return b.key() })
+	if err != nil {
+		return nil, err
+	}
+	return &FileBlueGreenStore{file: file}, nil
+}
+
+// List 返回所有已保存的蓝绿发布
+func (s *FileBlueGreenStore) List() ([]BlueGreenDeployment, error) {
+	return s.file.list(), nil
+}
+
+// Save 新增或覆盖蓝绿发布
+func (s *FileBlueGreenStore) Save(deployment BlueGreenDeployment) error {
+	return s.file.save(deployment)
+}
+
+// oppositeColor 返回另一种颜色，非 blue/green 的取值视为 blue
+func oppositeColor(color string) string {
+	if color == BlueGreenGreen {
+		return BlueGreenBlue
+	}
+	return BlueGreenGreen
+}
+
+// previewDeploymentName 新颜色 Deployment 的名称：去掉当前颜色后缀后加上新颜色
+func previewDeploymentName(active, activeColor, previewColor string) string {
+	return strings.TrimSuffix(active, "-"+activeColor) + "-" + previewColor
+}
+
+// selectingServices 返回选择器能选中 Pod 模板标签的 Service
+func selectingServices(services []corev1.Service, podLabels map[string]string) []corev1.Service {
+	var matched []corev1.Service
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(podLabels)) {
+			matched = append(matched, svc)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// ingressPathsFor 返回后端为指定 Service 的 Ingress 路径
+func ingressPathsFor(ingresses []networkingv1.Ingress, services map[string]bool) []BlueGreenIngressPath {
+	paths := make([]BlueGreenIngressPath, 0)
+	for _, ing := range ingresses {
+		if b := ing.Spec.DefaultBackend; b != nil && b.Service != nil && services[b.Service.Name] {
+			paths = append(paths, BlueGreenIngressPath{
+				Ingress: ing.Name,
+				Service: b.Service.Name,
+				Port:    servicePortString(b.Service.Port),
+			})
+		}
+		for _, rule := range convertIngressRulesFromK8s(ing.Spec.Rules) {
+			for _, p := range rule.Paths {
+				if !services[p.Backend.ServiceName] {
+					continue
+				}
+				paths = append(paths, BlueGreenIngressPath{
+					Ingress: ing.Name,
+					Host:    rule.Host,
+					Path:    p.Path,
+					Service: p.Backend.ServiceName,
+					Port:    p.Backend.ServicePort,
+				})
+			}
+		}
+	}
+	return paths
+}
+
+func servicePortString(port networkingv1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	if port.Number != 0 {
+		return fmt.Sprintf("%d", port.Number)
+	}
+	return ""
+}
+
+// BlueGreenController 蓝绿发布控制器：创建新颜色的 Deployment，就绪后一次性切换 Service 选择器，
+// 旧颜色在保留期内可随时切回，保留期结束后删除新颜色或把原 Deployment 缩容到 0
+type BlueGreenController struct {
+	clientManager *ClientManager
+	deployments   *DeploymentService
+	services      *ServiceManager
+	ingresses     *IngressManager
+	store         BlueGreenStore
+	records       map[string]*BlueGreenDeployment
+	mutex         sync.RWMutex
+	// opMutex 串行化控制循环与手动操作
+	opMutex sync.Mutex
+}
+
+// NewBlueGreenController 创建蓝绿发布控制器，store 为空时进度只保存在内存中
+func NewBlueGreenController(clientManager *ClientManager, deployments *DeploymentService, services *ServiceManager, ingresses *IngressManager, store BlueGreenStore) *BlueGreenController {
+	c := &BlueGreenController{
+		clientManager: clientManager,
+		deployments:   deployments,
+		services:      services,
+		ingresses:     ingresses,
+		store:         store,
+		records:       make(map[string]*BlueGreenDeployment),
+	}
+	if store != nil {
+		records, err := store.List()
+		if err != nil {
+			logger.Error("加载蓝绿发布记录失败", "error", err.Error())
+		}
+		for i := range records {
+			c.records[records[i].key()] = &records[i]
+		}
+	}
+	return c
+}
+
+// Start 启动控制循环
+func (c *BlueGreenController) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(blueGreenReconcileInterval)
+		defer ticker.Stop()
+
+		logger.Info("启动蓝绿发布控制器", "interval", blueGreenReconcileInterval.String())
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("停止蓝绿发布控制器")
+				return
+			case <-ticker.C:
+				c.reconcileAll(ctx)
+			}
+		}
+	}()
+}
+
+// Preview 返回切换将影响的 Service 与 Ingress 路径。进行中的发布返回其记录的 Service；
+// 否则按 Deployment 当前的 Pod 标签计算
+func (c *BlueGreenController) Preview(ctx context.Context, clusterName, namespace, name, colorKey string) (*BlueGreenPreview, error) {
+	if record, err := c.Get(clusterName, namespace, name); err == nil && record.Active() {
+		idleColor := record.PreviewColor
+		if record.ServingColor == record.PreviewColor {
+			idleColor = record.ActiveColor
+		}
+		preview := &BlueGreenPreview{
+			ActiveDeployment:  record.servingDeployment(),
+			ActiveColor:       record.ServingColor,
+			PreviewDeployment: record.idleDeployment(),
+			PreviewColor:      idleColor,
+		}
+		return c.fillPreview(ctx, clusterName, namespace, record.Spec.ColorLabelKey, preview, record.Services, nil)
+	}
+
+	if colorKey == "" {
+		colorKey = defaultBlueGreenLabelKey
+	}
+	client, err := c.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Deployment 失败: %w", err)
+	}
+	activeColor := deployment.Spec.Template.Labels[colorKey]
+	if activeColor == "" {
+		activeColor = BlueGreenBlue
+	}
+	previewColor := oppositeColor(activeColor)
+	preview := &BlueGreenPreview{
+		ActiveDeployment:  name,
+		ActiveColor:       activeColor,
+		PreviewDeployment: previewDeploymentName(name, activeColor, previewColor),
+		PreviewColor:      previewColor,
+	}
+	return c.fillPreview(ctx, clusterName, namespace, colorKey, preview, nil, deployment.Spec.Template.Labels)
+}
+
+// fillPreview 填充 Service 与 Ingress 影响范围；names 为空时按 podLabels 匹配 Service
+func (c *BlueGreenController) fillPreview(ctx context.Context, clusterName, namespace, colorKey string, preview *BlueGreenPreview, names []string, podLabels map[string]string) (*BlueGreenPreview, error) {
+	all, err := c.services.GetServicesByNamespace(ctx, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var affected []corev1.Service
+	if names == nil {
+		affected = selectingServices(all, podLabels)
+	} else {
+		wanted := make(map[string]bool, len(names))
+		for _, n := range names {
+			wanted[n] = true
+		}
+		for _, svc := range all {
+			if wanted[svc.Name] {
+				affected = append(affected, svc)
+			}
+		}
+	}
+
+	preview.Services = make([]BlueGreenServiceChange, 0, len(affected))
+	serviceNames := make(map[string]bool, len(affected))
+	for _, svc := range affected {
+		newSelector := copyStringMap(svc.Spec.Selector)
+		newSelector[colorKey] = preview.PreviewColor
+		_, pinned := svc.Spec.Selector[colorKey]
+		preview.Services = append(preview.Services, BlueGreenServiceChange{
+			Name:        svc.Name,
+			Selector:    svc.Spec.Selector,
+			NewSelector: newSelector,
+			Pinned:      pinned,
+		})
+		serviceNames[svc.Name] = true
+	}
+
+	ingresses, err := c.ingresses.GetIngressesByNamespace(ctx, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	preview.IngressPaths = ingressPathsFor(ingresses, serviceNames)
+	return preview, nil
+}
+
+// Prepare 开始蓝绿发布：必要时先把 Service 固定到当前颜色，再创建新颜色的 Deployment。
+// 发起时的检查与修改使用登录用户身份（用户模拟），之后由控制器以集群凭据推进。
+func (c *BlueGreenController) Prepare(ctx context.Context, clusterName, namespace, name string, req BlueGreenRequest) (*BlueGreenDeployment, error) {
+	if len(req.Images) == 0 {
+		return nil, fmt.Errorf("至少需要指定一个容器的新版本镜像")
+	}
+	if req.ColorLabelKey == "" {
+		req.ColorLabelKey = defaultBlueGreenLabelKey
+	}
+	if req.RetentionSeconds <= 0 {
+		req.RetentionSeconds = int(defaultBlueGreenRetention.Seconds())
+	}
+	if req.ProgressDeadlineSeconds <= 0 {
+		req.ProgressDeadlineSeconds = int(defaultBlueGreenProgressTimeout.Seconds())
+	}
+
+	c.opMutex.Lock()
+	defer c.opMutex.Unlock()
+
+	if existing, err := c.Get(clusterName, namespace, name); err == nil && existing.Active() {
+		return nil, fmt.Errorf("Deployment %s 已有进行中的蓝绿发布（%s）", name, existing.Phase)
+	}
+
+	client, err := c.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	active, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Deployment 失败: %w", err)
+	}
+	preview, err := c.Preview(ctx, clusterName, namespace, name, req.ColorLabelKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(preview.Services) == 0 {
+		return nil, fmt.Errorf("没有 Service 选中 Deployment %s 的 Pod，无法进行蓝绿切换", name)
+	}
+	if _, err := client.AppsV1().Deployments(namespace).Get(ctx, preview.PreviewDeployment, metav1.GetOptions{}); err == nil {
+		return nil, fmt.Errorf("Deployment %s 已存在", preview.PreviewDeployment)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("获取 Deployment 失败: %w", err)
+	}
+
+	now := time.Now()
+	record := &BlueGreenDeployment{
+		Cluster:           clusterName,
+		Namespace:         namespace,
+		Deployment:        name,
+		Spec:              req,
+		ActiveColor:       preview.ActiveColor,
+		PreviewDeployment: preview.PreviewDeployment,
+		PreviewColor:      preview.PreviewColor,
+		ServingColor:      preview.ActiveColor,
+		StartedAt:         now,
+	}
+	for _, svc := range preview.Services {
+		record.Services = append(record.Services, svc.Name)
+	}
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		record.StartedBy = identity.Username
+	}
+
+	if _, labeled := active.Spec.Template.Labels[req.ColorLabelKey]; !labeled {
+		// 当前版本的 Pod 还没有颜色标签：先补上标签（触发一次相同配置的滚动更新），就绪后再固定 Service
+		patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"labels":{%q:%q}}}}}`, req.ColorLabelKey, record.ActiveColor)
+		if _, err := client.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+			return nil, fmt.Errorf("为 Deployment 添加颜色标签失败: %w", err)
+		}
+		record.setPhase(BlueGreenPhasePinning, fmt.Sprintf("为 %s 的 Pod 添加标签 %s=%s", name, req.ColorLabelKey, record.ActiveColor))
+	} else {
+		if err := c.pinServices(ctx, record); err != nil {
+			return nil, err
+		}
+		if err := c.createPreview(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+	c.save(record)
+
+	logger.Info("开始蓝绿发布", "cluster", clusterName, "namespace", namespace, "deployment", name, "preview", record.PreviewDeployment)
+	out := *record
+	return &out, nil
+}
+
+// Get 获取 Deployment 最近一次蓝绿发布
+func (c *BlueGreenController) Get(clusterName, namespace, name string) (*BlueGreenDeployment, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	record, ok := c.records[canaryKey(clusterName, namespace, name)]
+	if !ok {
+		return nil, fmt.Errorf("Deployment %s 没有蓝绿发布记录", name)
+	}
+	out := *record
+	return &out, nil
+}
+
+// List 列出集群中的蓝绿发布，namespace 为空时返回全部命名空间
+func (c *BlueGreenController) List(clusterName, namespace string) []BlueGreenDeployment {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	records := make([]BlueGreenDeployment, 0)
+	for _, r := range c.records {
+		if r.Cluster != clusterName || (namespace != "" && r.Namespace != namespace) {
+			continue
+		}
+		records = append(records, *r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].key() < records[j].key() })
+	return records
+}
+
+// Switch 把 Service 切换到新颜色（Ready 或已切回旧颜色时可用）
+func (c *BlueGreenController) Switch(ctx context.Context, clusterName, namespace, name string) (*BlueGreenDeployment, error) {
+	return c.manual(clusterName, namespace, name, func(r *BlueGreenDeployment) error {
+		if r.Phase != BlueGreenPhaseReady && r.Phase != BlueGreenPhaseRolledBack {
+			return fmt.Errorf("当前阶段 %s 不能切换", r.Phase)
+		}
+		return c.flip(ctx, r, r.PreviewColor, BlueGreenPhaseSwitched)
+	})
+}
+
+// Rollback 在保留期内把 Service 切回旧颜色
+func (c *BlueGreenController) Rollback(ctx context.Context, clusterName, namespace, name string) (*BlueGreenDeployment, error) {
+	return c.manual(clusterName, namespace, name, func(r *BlueGreenDeployment) error {
+		if r.Phase != BlueGreenPhaseSwitched {
+			return fmt.Errorf("当前阶段 %s 不能切回", r.Phase)
+		}
+		return c.flip(ctx, r, r.ActiveColor, BlueGreenPhaseRolledBack)
+	})
+}
+
+// Abort 在切换前中止发布，删除新颜色的 Deployment
+func (c *BlueGreenController) Abort(ctx context.Context, clusterName, namespace, name string) (*BlueGreenDeployment, error) {
+	return c.manual(clusterName, namespace, name, func(r *BlueGreenDeployment) error {
+		switch r.Phase {
+		case BlueGreenPhasePinning, BlueGreenPhaseDeploying, BlueGreenPhaseReady:
+		default:
+			return fmt.Errorf("当前阶段 %s 不能中止，请使用切回", r.Phase)
+		}
+		if err := c.deleteDeployment(ctx, r.Cluster, r.Namespace, r.PreviewDeployment); err != nil {
+			return err
+		}
+		r.setPhase(BlueGreenPhaseAborted, "手动中止发布")
+		return nil
+	})
+}
+
+func (c *BlueGreenController) manual(clusterName, namespace, name string, action func(*BlueGreenDeployment) error) (*BlueGreenDeployment, error) {
+	c.opMutex.Lock()
+	defer c.opMutex.Unlock()
+
+	record, err := c.Get(clusterName, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !record.Active() {
+		return nil, fmt.Errorf("蓝绿发布已结束（%s）", record.Phase)
+	}
+	if err := action(record); err != nil {
+		return nil, err
+	}
+	c.save(record)
+	return record, nil
+}
+
+// flip 把所有受影响 Service 的颜色标签改为 color；任一 Service 更新失败时恢复已修改的 Service
+func (c *BlueGreenController) flip(ctx context.Context, r *BlueGreenDeployment, color, phase string) error {
+	previous := r.ServingColor
+	var switched []string
+	for _, name := range r.Services {
+		if err := c.setServiceColor(ctx, r, name, color); err != nil {
+			for _, done := range switched {
+				if rerr := c.setServiceColor(ctx, r, done, previous); rerr != nil {
+					logger.Error("恢复 Service 选择器失败", "service", done, "error", rerr.Error())
+				}
+			}
+			return fmt.Errorf("切换 Service %s 失败: %w", name, err)
+		}
+		switched = append(switched, name)
+	}
+
+	now := time.Now()
+	retainUntil := now.Add(time.Duration(r.Spec.RetentionSeconds) * time.Second)
+	r.ServingColor = color
+	r.SwitchedAt = &now
+	r.RetainUntil = &retainUntil
+	r.setPhase(phase, fmt.Sprintf("Service 已切换到 %s=%s，%s 保留到 %s",
+		r.Spec.ColorLabelKey, color, r.idleDeployment(), retainUntil.Format(time.RFC3339)))
+	logger.Info("蓝绿发布已切换", "deployment", r.key(), "color", color)
+	return nil
+}
+
+func (c *BlueGreenController) setServiceColor(ctx context.Context, r *BlueGreenDeployment, name, color string) error {
+	svc, err := c.services.GetServiceDetails(ctx, r.Cluster, r.Namespace, name)
+	if err != nil {
+		return err
+	}
+	if svc.Spec.Selector[r.Spec.ColorLabelKey] == color {
+		return nil
+	}
+	svc.Spec.Selector = copyStringMap(svc.Spec.Selector)
+	svc.Spec.Selector[r.Spec.ColorLabelKey] = color
+	return c.services.UpdateService(ctx, r.Cluster, svc)
+}
+
+// pinServices 把尚未包含颜色标签的 Service 固定到当前颜色，避免新颜色的 Pod 在切换前接收流量
+func (c *BlueGreenController) pinServices(ctx context.Context, r *BlueGreenDeployment) error {
+	for _, name := range r.Services {
+		svc, err := c.services.GetServiceDetails(ctx, r.Cluster, r.Namespace, name)
+		if err != nil {
+			return err
+		}
+		if _, pinned := svc.Spec.Selector[r.Spec.ColorLabelKey]; pinned {
+			continue
+		}
+		if err := c.setServiceColor(ctx, r, name, r.ActiveColor); err != nil {
+			return fmt.Errorf("固定 Service %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// createPreview 基于当前版本创建新颜色的 Deployment，副本数与当前版本一致
+func (c *BlueGreenController) createPreview(ctx context.Context, r *BlueGreenDeployment) error {
+	client, err := c.clientManager.GetClientFor(ctx, r.Cluster)
+	if err != nil {
+		return err
+	}
+	active, err := client.AppsV1().Deployments(r.Namespace).Get(ctx, r.Deployment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("获取 Deployment 失败: %w", err)
+	}
+	replicas := desiredReplicas(active)
+	preview, err := buildColorDeployment(active, r, replicas)
+	if err != nil {
+		return err
+	}
+	if _, err := client.AppsV1().Deployments(r.Namespace).Create(ctx, preview, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("创建 Deployment %s 失败: %w", r.PreviewDeployment, err)
+	}
+	r.setPhase(BlueGreenPhaseDeploying, fmt.Sprintf("已创建 %s，等待就绪", r.PreviewDeployment))
+	return nil
+}
+
+// buildColorDeployment 复制当前版本，选择器与 Pod 标签中的颜色替换为新颜色
+func buildColorDeployment(active *appsv1.Deployment, r *BlueGreenDeployment, replicas int32) (*appsv1.Deployment, error) {
+	base := active.DeepCopy()
+	// 当前版本的选择器可能不含颜色标签，新 Deployment 的选择器统一加上颜色
+	if base.Spec.Selector != nil {
+		delete(base.Spec.Selector.MatchLabels, r.Spec.ColorLabelKey)
+	}
+	return buildCanaryDeployment(base, CreateCanaryDeploymentRequest{
+		Name:           r.PreviewDeployment,
+		Replicas:       &replicas,
+		CanaryLabelKey: r.Spec.ColorLabelKey,
+		CanaryLabelVal: r.PreviewColor,
+		Images:         r.Spec.Images,
+	})
+}
+
+func (c *BlueGreenController) deleteDeployment(ctx context.Context, clusterName, namespace, name string) error {
+	client, err := c.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	err = client.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("删除 Deployment %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// retireIdle 保留期结束后处理未承接流量的 Deployment：新颜色的 Deployment 直接删除；
+// 发起发布时的原 Deployment 可能由 GitOps/Helm 管理或被 HPA、后续发布引用，只缩容到 0 并保留
+func (c *BlueGreenController) retireIdle(ctx context.Context, r *BlueGreenDeployment) (string, error) {
+	idle := r.idleDeployment()
+	if idle != r.Deployment {
+		if err := c.deleteDeployment(ctx, r.Cluster, r.Namespace, idle); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("保留期结束，已删除 %s", idle), nil
+	}
+	if err := c.deployments.ScaleDeployment(ctx, r.Cluster, r.Namespace, idle, 0); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("保留期结束，已将 %s 缩容到 0，流量由 %s 承接", idle, r.servingDeployment()), nil
+}
+
+func (c *BlueGreenController) reconcileAll(ctx context.Context) {
+	c.mutex.RLock()
+	keys := make([]string, 0, len(c.records))
+	for key, r := range c.records {
+		if r.Active() && r.Phase != BlueGreenPhaseReady {
+			keys = append(keys, key)
+		}
+	}
+	c.mutex.RUnlock()
+
+	for _, key := range keys {
+		c.reconcile(ctx, key)
+	}
+}
+
+// reconcile 推进单个发布，使用集群凭据（ctx 不携带登录用户）
+func (c *BlueGreenController) reconcile(ctx context.Context, key string) {
+	c.opMutex.Lock()
+	defer c.opMutex.Unlock()
+
+	c.mutex.RLock()
+	current, ok := c.records[key]
+	var r BlueGreenDeployment
+	if ok {
+		r = *current
+	}
+	c.mutex.RUnlock()
+	if !ok || !r.Active() {
+		return
+	}
+
+	deadline := time.Duration(r.Spec.ProgressDeadlineSeconds) * time.Second
+	var err error
+	switch r.Phase {
+	case BlueGreenPhasePinning:
+		var status *RolloutStatus
+		status, err = c.deployments.GetRolloutStatus(ctx, r.Cluster, r.Namespace, r.Deployment)
+		if err == nil && status.Complete {
+			if err = c.pinServices(ctx, &r); err == nil {
+				err = c.createPreview(ctx, &r)
+			}
+		} else if err == nil && time.Since(r.PhaseStartedAt) > deadline {
+			err = fmt.Errorf("%s 添加颜色标签后未在 %s 内完成滚动更新", r.Deployment, deadline)
+		}
+	case BlueGreenPhaseDeploying:
+		var status *RolloutStatus
+		status, err = c.deployments.GetRolloutStatus(ctx, r.Cluster, r.Namespace, r.PreviewDeployment)
+		if err == nil && status.Complete {
+			r.setPhase(BlueGreenPhaseReady, fmt.Sprintf("%s 已就绪，可以切换", r.PreviewDeployment))
+			if r.Spec.AutoSwitch {
+				err = c.flip(ctx, &r, r.PreviewColor, BlueGreenPhaseSwitched)
+			}
+		} else if err == nil && time.Since(r.PhaseStartedAt) > deadline {
+			// 保留未就绪的 Deployment 以便排查，可通过中止删除
+			err = fmt.Errorf("%s 未在 %s 内就绪", r.PreviewDeployment, deadline)
+		}
+	case BlueGreenPhaseSwitched, BlueGreenPhaseRolledBack:
+		if r.RetainUntil != nil && time.Now().After(*r.RetainUntil) {
+			var message string
+			if message, err = c.retireIdle(ctx, &r); err == nil {
+				r.setPhase(BlueGreenPhaseCompleted, message)
+			}
+		}
+	}
+	if err != nil {
+		logger.Error("推进蓝绿发布失败", "deployment", key, "error", err.Error())
+		r.setPhase(BlueGreenPhaseFailed, err.Error())
+	}
+	c.save(&r)
+}
+
+func (c *BlueGreenController) save(r *BlueGreenDeployment) {
+	r.UpdatedAt = time.Now()
+	stored := *r
+	stored.Services = append([]string(nil), r.Services...)
+	c.mutex.Lock()
+	c.records[r.key()] = &stored
+	c.mutex.Unlock()
+	if c.store != nil {
+		if err := c.store.Save(stored); err != nil {
+			logger.Error("保存蓝绿发布进度失败", "deployment", r.key(), "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestPreviewDeploymentName(t *testing.T) {
+	cases := []struct{ active, color, want string }{
+		{"web", BlueGreenBlue, "web-green"},
+		{"web-green", BlueGreenGreen, "web-blue"},
+		{"web-blue", BlueGreenBlue, "web-green"},
+	}
+	for _, tc := range cases {
+		if got := previewDeploymentName(tc.active, tc.color, oppositeColor(tc.color)); got != tc.want {
+			t.Errorf("previewDeploymentName(%s) = %s, want %s", tc.active, got, tc.want)
+		}
+	}
+}
+
+func TestBlueGreenAffectedServicesAndIngressPaths(t *testing.T) {
+	services := []corev1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web"}, Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api"}, Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "api"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "external"}},
+	}
+	matched := selectingServices(services, map[string]string{"app": "web", "color": "blue"})
+	if len(matched) != 1 || matched[0].Name != "web" {
+		t.Fatalf("unexpected services: %+v", matched)
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	ingresses := []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "public"},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+			Host: "example.com",
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+				{Path: "/", PathType: &pathType, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}}}},
+				{Path: "/api", PathType: &pathType, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api", Port: networkingv1.ServiceBackendPort{Number: 8080}}}},
+			}}},
+		}}},
+	}}
+	paths := ingressPathsFor(ingresses, map[string]bool{"web": true})
+	if len(paths) != 1 || paths[0].Host != "example.com" || paths[0].Path != "/" || paths[0].Port != "80" {
+		t.Fatalf("unexpected ingress paths: %+v", paths)
+	}
+}
+
+func TestBuildColorDeployment(t *testing.T) {
+	active := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-green", Namespace: "prod"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web", "color": "green"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", "color": "green"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx:1.26"}}},
+			},
+		},
+	}
+	record := &BlueGreenDeployment{
+		Spec:              BlueGreenRequest{ColorLabelKey: "color", Images: map[string]string{"web": "nginx:1.27"}},
+		PreviewDeployment: "web-blue",
+		PreviewColor:      BlueGreenBlue,
+	}
+	preview, err := buildColorDeployment(active, record, 3)
+	if err != nil {
+		t.Fatalf("buildColorDeployment: %v", err)
+	}
+	if preview.Name != "web-blue" || *preview.Spec.Replicas != 3 ||
+		preview.Spec.Selector.MatchLabels["color"] != "blue" || preview.Spec.Template.Labels["color"] != "blue" ||
+		preview.Spec.Template.Spec.Containers[0].Image != "nginx:1.27" {
+		t.Fatalf("unexpected preview deployment: %+v", preview)
+	}
+	if active.Spec.Selector.MatchLabels["color"] != "green" {
+		t.Fatal("active deployment must not be modified")
+	}
+}
+
+// fakeServiceAPI 保存 Service 的选择器，记录 PUT 请求
+type fakeServiceAPI struct {
+	mutex     sync.Mutex
+	selectors map[string]map[string]string
+	failPut   string
+}
+
+func (f *fakeServiceAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	name := filepath.Base(r.URL.Path)
+	selector, ok := f.selectors[name]
+	if filepath.Dir(r.URL.Path) != "/api/v1/namespaces/prod/services" || !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(corev1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		})
+	case http.MethodPut:
+		if name == f.failPut {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Conflict","code":409}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var svc corev1.Service
+		json.Unmarshal(body, &svc)
+		f.selectors[name] = svc.Spec.Selector
+		w.Write(body)
+	}
+}
+
+func newBlueGreenTestController(t *testing.T, api *fakeServiceAPI) *BlueGreenController {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	cm := NewClientManager()
+	// 以 JSON 发送请求体，便于 fake API 解析
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = client
+	return NewBlueGreenController(cm, NewDeploymentService(cm), NewServiceManager(cm), NewIngressManager(cm), nil)
+}
+
+func TestBlueGreenSwitchFlipsSelectors(t *testing.T) {
+	api := &fakeServiceAPI{selectors: map[string]map[string]string{
+		"web":     {"app": "web", "color": "blue"},
+		"web-alt": {"app": "web", "color": "blue"},
+	}}
+	controller := newBlueGreenTestController(t, api)
+	controller.records["dev/prod/web"] = &BlueGreenDeployment{
+		Cluster: "dev", Namespace: "prod", Deployment: "web",
+		Spec:              BlueGreenRequest{ColorLabelKey: "color", RetentionSeconds: 60},
+		ActiveColor:       BlueGreenBlue,
+		PreviewDeployment: "web-green",
+		PreviewColor:      BlueGreenGreen,
+		ServingColor:      BlueGreenBlue,
+		Services:          []string{"web", "web-alt"},
+		Phase:             BlueGreenPhaseReady,
+	}
+
+	if _, err := controller.Rollback(context.Background(), "dev", "prod", "web"); err == nil {
+		t.Fatal("rollback should require a switched deployment")
+	}
+	record, err := controller.Switch(context.Background(), "dev", "prod", "web")
+	if err != nil {
+		t.Fatalf("Switch: %v", err)
+	}
+	if record.Phase != BlueGreenPhaseSwitched || record.ServingColor != BlueGreenGreen || record.RetainUntil == nil ||
+		record.RetainUntil.Before(time.Now().Add(50*time.Second)) {
+		t.Fatalf("unexpected record after switch: %+v", record)
+	}
+	for name, selector := range api.selectors {
+		if selector["color"] != BlueGreenGreen || selector["app"] != "web" {
+			t.Fatalf("service %s not switched: %v", name, selector)
+		}
+	}
+
+	// 第二个 Service 更新失败时，已切换的 Service 恢复原颜色
+	api.failPut = "web-alt"
+	if _, err := controller.Rollback(context.Background(), "dev", "prod", "web"); err == nil {
+		t.Fatal("expected rollback to fail")
+	}
+	if api.selectors["web"]["color"] != BlueGreenGreen {
+		t.Fatalf("partially switched service should be restored, got %v", api.selectors["web"])
+	}
+	if record, _ := controller.Get("dev", "prod", "web"); record.Phase != BlueGreenPhaseSwitched {
+		t.Fatalf("failed rollback must keep the phase, got %s", record.Phase)
+	}
+}
+
+// fakeDeploymentAPI 保存 Deployment 的副本数，记录写操作
+type fakeDeploymentAPI struct {
+	mutex    sync.Mutex
+	replicas map[string]int32
+	requests []string
+}
+
+func (f *fakeDeploymentAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	name := filepath.Base(r.URL.Path)
+	replicas, ok := f.replicas[name]
+	if filepath.Dir(r.URL.Path) != "/apis/apps/v1/namespaces/prod/deployments" || !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		})
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var deployment appsv1.Deployment
+		json.Unmarshal(body, &deployment)
+		f.replicas[name] = *deployment.Spec.Replicas
+		f.requests = append(f.requests, "PUT "+name)
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.replicas, name)
+		f.requests = append(f.requests, "DELETE "+name)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+	}
+}
+
+func TestBlueGreenRetentionKeepsOriginalDeployment(t *testing.T) {
+	api := &fakeDeploymentAPI{replicas: map[string]int32{"web": 3, "web-green": 3}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	cm := NewClientManager()
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = client
+	controller := NewBlueGreenController(cm, NewDeploymentService(cm), NewServiceManager(cm), NewIngressManager(cm), nil)
+
+	expired := time.Now().Add(-time.Minute)
+	record := func(serving string) *BlueGreenDeployment {
+		return &BlueGreenDeployment{
+			Cluster: "dev", Namespace: "prod", Deployment: "web",
+			Spec:              BlueGreenRequest{ColorLabelKey: "color"},
+			ActiveColor:       BlueGreenBlue,
+			PreviewDeployment: "web-green",
+			PreviewColor:      BlueGreenGreen,
+			ServingColor:      serving,
+			Phase:             BlueGreenPhaseSwitched,
+			RetainUntil:       &expired,
+		}
+	}
+
+	// 切换后原 Deployment 不再承接流量：缩容到 0，不删除
+	controller.records["dev/prod/web"] = record(BlueGreenGreen)
+	controller.reconcile(context.Background(), "dev/prod/web")
+	if got, _ := controller.Get("dev", "prod", "web"); got.Phase != BlueGreenPhaseCompleted {
+		t.Fatalf("expected completed, got %s: %s", got.Phase, got.Message)
+	}
+	if replicas, ok := api.replicas["web"]; !ok || replicas != 0 || api.replicas["web-green"] != 3 {
+		t.Fatalf("original deployment should be scaled to 0 and kept: %v (%v)", api.replicas, api.requests)
+	}
+
+	// 切回后新颜色不再承接流量：删除新颜色
+	api.requests = nil
+	controller.records["dev/prod/web"] = record(BlueGreenBlue)
+	controller.reconcile(context.Background(), "dev/prod/web")
+	if len(api.requests) != 1 || api.requests[0] != "DELETE web-green" {
+		t.Fatalf("preview deployment should be deleted, got %v", api.requests)
+	}
+}
@@ -0,0 +1,701 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/logger"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// 渐进式发布阶段
+const (
+	CanaryPhaseProgressing = "Progressing" // 按步骤调整金丝雀/稳定版本副本比例并执行分析
+	CanaryPhasePromoting   = "Promoting"   // 已将金丝雀版本写入基础 Deployment，等待滚动完成
+	CanaryPhasePromoted    = "Promoted"    // 发布完成，金丝雀 Deployment 已删除
+	CanaryPhaseRolledBack  = "RolledBack"  // 分析失败或手动中止，已恢复稳定版本
+	CanaryPhaseFailed      = "Failed"      // 推进过程中出错，需要人工处理
+)
+
+const (
+	defaultCanaryPause           = 60 * time.Second
+	defaultCanaryProgressTimeout = 10 * time.Minute
+	canaryReconcileInterval      = 10 * time.Second
+	canaryQueryTimeout           = 10 * time.Second
+	maxCanaryHistory             = 100
+)
+
+// defaultCanarySteps 未指定步骤时的流量比例
+var defaultCanarySteps = []CanaryStep{{Weight: 10}, {Weight: 25}, {Weight: 50}}
+
+// CanaryStep 发布步骤，Weight 为金丝雀版本占总副本数的百分比
+type CanaryStep struct {
+	Weight       int `json:"weight"`
+	PauseSeconds int `json:"pauseSeconds,omitempty"` // 副本就绪后观察多久再执行分析，默认 60 秒
+}
+
+// CanaryAnalysis 每个步骤结束时执行的 PromQL 检查，查询结果取第一个样本值与 Min/Max 比较。
+// 查询中的 {{namespace}}、{{canary}}、{{stable}} 会替换为命名空间、金丝雀与稳定版本 Deployment 名称。
+type CanaryAnalysis struct {
+	Name  string   `json:"name"`
+	Query string   `json:"query"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+// CanaryRolloutSpec 渐进式发布配置
+type CanaryRolloutSpec struct {
+	CanaryName              string            `json:"canaryName,omitempty"` // 默认为 <deployment>-canary
+	Images                  map[string]string `json:"images"`               // 容器名 -> 新版本镜像
+	Steps                   []CanaryStep      `json:"steps,omitempty"`
+	Analysis                []CanaryAnalysis  `json:"analysis,omitempty"`
+	FailureLimit            int               `json:"failureLimit,omitempty"`            // 允许连续失败的分析次数，超过后自动回滚
+	ProgressDeadlineSeconds int               `json:"progressDeadlineSeconds,omitempty"` // 每个步骤等待副本就绪的期限，默认 600 秒
+}
+
+// CanaryAnalysisResult 单条分析的结果
+type CanaryAnalysisResult struct {
+	Name   string   `json:"name"`
+	Query  string   `json:"query"`
+	Value  *float64 `json:"value,omitempty"`
+	Passed bool     `json:"passed"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// CanaryEvent 发布过程记录
+type CanaryEvent struct {
+	Time    time.Time              `json:"time"`
+	Step    int                    `json:"step"`
+	Weight  int                    `json:"weight"`
+	Message string                 `json:"message"`
+	Results []CanaryAnalysisResult `json:"results,omitempty"`
+}
+
+// CanaryRollout 一次渐进式发布及其进度
+type CanaryRollout struct {
+	Cluster       string            `json:"cluster"`
+	Namespace     string            `json:"namespace"`
+	Deployment    string            `json:"deployment"`
+	Spec          CanaryRolloutSpec `json:"spec"`
+	Phase         string            `json:"phase"`
+	Message       string            `json:"message,omitempty"`
+	TotalReplicas int32             `json:"totalReplicas"`
+	CurrentStep   int               `json:"currentStep"`
+	StepStartedAt time.Time         `json:"stepStartedAt"`
+	StepReadyAt   *time.Time        `json:"stepReadyAt,omitempty"`
+	Failures      int               `json:"failures"`
+	StartedBy     string            `json:"startedBy,omitempty"`
+	StartedAt     time.Time         `json:"startedAt"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+	FinishedAt    *time.Time        `json:"finishedAt,omitempty"`
+	History       []CanaryEvent     `json:"history,omitempty"`
+}
+
+func canaryKey(cluster, namespace, deployment string) string {
+	return cluster + "/" + namespace + "/" + deployment
+}
+
+func (r *CanaryRollout) key() string {
+	return canaryKey(r.Cluster, r.Namespace, r.Deployment)
+}
+
+// Active 发布是否仍由控制器推进
+func (r *CanaryRollout) Active() bool {
+	return r.Phase == CanaryPhaseProgressing || r.Phase == CanaryPhasePromoting
+}
+
+// currentWeight 当前步骤的金丝雀比例，进入 Promoting 后为 100
+func (r *CanaryRollout) currentWeight() int {
+	if r.CurrentStep >= len(r.Spec.Steps) {
+		return 100
+	}
+	return r.Spec.Steps[r.CurrentStep].Weight
+}
+
+func (r *CanaryRollout) record(message string, results []CanaryAnalysisResult) {
+	r.Message = message
+	r.History = append(r.History, CanaryEvent{
+		Time:    time.Now(),
+		Step:    r.CurrentStep,
+		Weight:  r.currentWeight(),
+		Message: message,
+		Results: results,
+	})
+	if len(r.History) > maxCanaryHistory {
+		r.History = r.History[len(r.History)-maxCanaryHistory:]
+	}
+}
+
+func (r *CanaryRollout) finish(phase, message string) {
+	now := time.Now()
+	r.Phase = phase
+	r.FinishedAt = &now
+	r.record(message, nil)
+}
+
+// copy 返回可以安全交给调用方的副本
+func (r *CanaryRollout) copy() CanaryRollout {
+	out := *r
+	out.History = append([]CanaryEvent(nil), r.History...)
+	return out
+}
+
+// normalizeCanarySpec 校验发布配置并填充默认值
+func normalizeCanarySpec(deployment string, spec CanaryRolloutSpec) (CanaryRolloutSpec, error) {
+	if len(spec.Images) == 0 {
+		return spec, fmt.Errorf("至少需要指定一个容器的新版本镜像")
+	}
+	if spec.CanaryName == "" {
+		spec.CanaryName = deployment + "-canary"
+	}
+	if spec.CanaryName == deployment {
+		return spec, fmt.Errorf("金丝雀 Deployment 名称不能与基础 Deployment 相同")
+	}
+	if len(spec.Steps) == 0 {
+		spec.Steps = append([]CanaryStep(nil), defaultCanarySteps...)
+	}
+	prev := 0
+	for i, step := range spec.Steps {
+		if step.Weight <= prev || step.Weight > 100 {
+			return spec, fmt.Errorf("第 %d 步的比例 %d 无效，比例需在 1-100 之间且逐步递增", i+1, step.Weight)
+		}
+		if step.PauseSeconds < 0 {
+			return spec, fmt.Errorf("第 %d 步的观察时间不能为负数", i+1)
+		}
+		if step.PauseSeconds == 0 {
+			spec.Steps[i].PauseSeconds = int(defaultCanaryPause.Seconds())
+		}
+		prev = step.Weight
+	}
+	for i, a := range spec.Analysis {
+		if strings.TrimSpace(a.Query) == "" {
+			return spec, fmt.Errorf("第 %d 条分析缺少 PromQL 查询", i+1)
+		}
+		if a.Min == nil && a.Max == nil {
+			return spec, fmt.Errorf("分析 %s 至少需要设置 min 或 max", a.Name)
+		}
+		if spec.Analysis[i].Name == "" {
+			spec.Analysis[i].Name = fmt.Sprintf("analysis-%d", i+1)
+		}
+	}
+	if spec.FailureLimit < 0 {
+		spec.FailureLimit = 0
+	}
+	if spec.ProgressDeadlineSeconds <= 0 {
+		spec.ProgressDeadlineSeconds = int(defaultCanaryProgressTimeout.Seconds())
+	}
+	return spec, nil
+}
+
+// canaryReplicas 按比例拆分副本数，金丝雀版本向上取整以保证至少一个副本
+func canaryReplicas(total int32, weight int) (canary, stable int32) {
+	canary = int32(math.Ceil(float64(total) * float64(weight) / 100))
+	if canary > total {
+		canary = total
+	}
+	return canary, total - canary
+}
+
+// checkCanaryThreshold 判断分析结果是否在阈值范围内
+func checkCanaryThreshold(value float64, a CanaryAnalysis) error {
+	if math.IsNaN(value) {
+		return fmt.Errorf("查询结果为 NaN")
+	}
+	if a.Min != nil && value < *a.Min {
+		return fmt.Errorf("%g 低于下限 %g", value, *a.Min)
+	}
+	if a.Max != nil && value > *a.Max {
+		return fmt.Errorf("%g 高于上限 %g", value, *a.Max)
+	}
+	return nil
+}
+
+// parseCanaryQueryResult 取即时查询结果中的第一个样本值
+func parseCanaryQueryResult(raw json.RawMessage) (float64, error) {
+	var resp promInstantResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return 0, fmt.Errorf("解析查询结果失败: %w", err)
+	}
+	if resp.Status != "success" {
+		return 0, fmt.Errorf("查询失败: %s", resp.Status)
+	}
+	if len(resp.Data.Result) == 0 {
+		return 0, fmt.Errorf("查询没有返回数据")
+	}
+	return instantValue(resp.Data.Result[0].Value), nil
+}
+
+// CanaryController 渐进式金丝雀发布控制器：按步骤调整副本比例，
+// 每步通过 Prometheus 指标分析后继续推进，全部通过后自动晋升，分析失败时自动回滚
+type CanaryController struct {
+	clientManager *ClientManager
+	prometheus    *PrometheusService
+	store         CanaryStore
+	rollouts      map[string]*CanaryRollout
+	mutex         sync.RWMutex
+	// opMutex 串行化控制循环与手动操作，避免并发修改同一次发布
+	opMutex sync.Mutex
+}
+
+// NewCanaryController 创建渐进式发布控制器，store 为空时进度只保存在内存中
+func NewCanaryController(clientManager *ClientManager, prometheus *PrometheusService, store CanaryStore) *CanaryController {
+	c := &CanaryController{
+		clientManager: clientManager,
+		prometheus:    prometheus,
+		store:         store,
+		rollouts:      make(map[string]*CanaryRollout),
+	}
+	if store != nil {
+		rollouts, err := store.List()
+		if err != nil {
+			logger.Error("加载金丝雀发布记录失败", "error", err.Error())
+		}
+		for i := range rollouts {
+			c.rollouts[rollouts[i].key()] = &rollouts[i]
+		}
+	}
+	return c
+}
+
+// Start 启动控制循环，重启前未完成的发布会继续推进
+func (c *CanaryController) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(canaryReconcileInterval)
+		defer ticker.Stop()
+
+		logger.Info("启动金丝雀发布控制器", "interval", canaryReconcileInterval.String())
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("停止金丝雀发布控制器")
+				return
+			case <-ticker.C:
+				c.reconcileAll(ctx)
+			}
+		}
+	}()
+}
+
+// StartRollout 开始渐进式发布：创建金丝雀 Deployment 并按第一步比例拆分副本。
+// 创建与首次调整使用登录用户身份（用户模拟），之后由控制器以集群凭据推进。
+func (c *CanaryController) StartRollout(ctx context.Context, clusterName, namespace, name string, spec CanaryRolloutSpec) (*CanaryRollout, error) {
+	spec, err := normalizeCanarySpec(name, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Analysis) > 0 && c.prometheus == nil {
+		return nil, fmt.Errorf("未启用 Prometheus，无法执行指标分析")
+	}
+
+	c.opMutex.Lock()
+	defer c.opMutex.Unlock()
+
+	key := canaryKey(clusterName, namespace, name)
+	c.mutex.RLock()
+	existing, ok := c.rollouts[key]
+	active := ok && existing.Active()
+	c.mutex.RUnlock()
+	if active {
+		return nil, fmt.Errorf("Deployment %s 已有进行中的金丝雀发布", name)
+	}
+
+	client, err := c.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	base, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取基础 Deployment 失败: %w", err)
+	}
+	total := desiredReplicas(base)
+	if total < 1 {
+		return nil, fmt.Errorf("基础 Deployment 副本数为 0，无法进行金丝雀发布")
+	}
+
+	canaryCount, stableCount := canaryReplicas(total, spec.Steps[0].Weight)
+	canary, err := buildCanaryDeployment(base, CreateCanaryDeploymentRequest{
+		Name:     spec.CanaryName,
+		Replicas: &canaryCount,
+		Images:   spec.Images,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.AppsV1().Deployments(namespace).Create(ctx, canary, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("创建金丝雀 Deployment 失败: %w", err)
+	}
+	if err := scaleDeployment(ctx, client, namespace, name, stableCount); err != nil {
+		// 基础版本未调整时直接撤销金丝雀，保持发布前状态
+		client.AppsV1().Deployments(namespace).Delete(ctx, spec.CanaryName, metav1.DeleteOptions{})
+		return nil, fmt.Errorf("调整基础 Deployment 副本数失败: %w", err)
+	}
+
+	now := time.Now()
+	rollout := &CanaryRollout{
+		Cluster:       clusterName,
+		Namespace:     namespace,
+		Deployment:    name,
+		Spec:          spec,
+		Phase:         CanaryPhaseProgressing,
+		TotalReplicas: total,
+		StepStartedAt: now,
+		StartedAt:     now,
+		UpdatedAt:     now,
+	}
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		rollout.StartedBy = identity.Username
+	}
+	rollout.record(fmt.Sprintf("开始发布，金丝雀 %d / 稳定 %d", canaryCount, stableCount), nil)
+	c.save(rollout)
+
+	logger.Info("开始金丝雀发布", "cluster", clusterName, "namespace", namespace, "deployment", name, "canary", spec.CanaryName)
+	out := rollout.copy()
+	return &out, nil
+}
+
+// GetRollout 获取 Deployment 最近一次金丝雀发布
+func (c *CanaryController) GetRollout(clusterName, namespace, name string) (*CanaryRollout, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	rollout, ok := c.rollouts[canaryKey(clusterName, namespace, name)]
+	if !ok {
+		return nil, fmt.Errorf("Deployment %s 没有金丝雀发布记录", name)
+	}
+	out := rollout.copy()
+	return &out, nil
+}
+
+// ListRollouts 列出集群中的金丝雀发布，namespace 为空时返回全部命名空间
+func (c *CanaryController) ListRollouts(clusterName, namespace string) []CanaryRollout {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	rollouts := make([]CanaryRollout, 0)
+	for _, r := range c.rollouts {
+		if r.Cluster != clusterName || (namespace != "" && r.Namespace != namespace) {
+			continue
+		}
+		rollouts = append(rollouts, r.copy())
+	}
+	sort.Slice(rollouts, func(i, j int) bool { return rollouts[i].key() < rollouts[j].key() })
+	return rollouts
+}
+
+// AbortRollout 手动中止发布：恢复基础 Deployment 副本数并删除金丝雀
+func (c *CanaryController) AbortRollout(ctx context.Context, clusterName, namespace, name string) (*CanaryRollout, error) {
+	return c.manual(ctx, clusterName, namespace, name, func(client kubernetes.Interface, r *CanaryRollout) error {
+		if r.Phase == CanaryPhasePromoting {
+			return fmt.Errorf("金丝雀版本已写入基础 Deployment，无法中止，请使用回滚")
+		}
+		return c.rollback(ctx, client, r, "手动中止发布")
+	})
+}
+
+// PromoteRollout 跳过剩余步骤与分析，直接晋升金丝雀版本
+func (c *CanaryController) PromoteRollout(ctx context.Context, clusterName, namespace, name string) (*CanaryRollout, error) {
+	return c.manual(ctx, clusterName, namespace, name, func(client kubernetes.Interface, r *CanaryRollout) error {
+		if r.Phase == CanaryPhasePromoting {
+			return nil
+		}
+		r.CurrentStep = len(r.Spec.Steps)
+		return c.promote(ctx, client, r, "手动晋升")
+	})
+}
+
+// manual 以登录用户身份执行手动操作
+func (c *CanaryController) manual(ctx context.Context, clusterName, namespace, name string, action func(kubernetes.Interface, *CanaryRollout) error) (*CanaryRollout, error) {
+	c.opMutex.Lock()
+	defer c.opMutex.Unlock()
+
+	c.mutex.RLock()
+	current, ok := c.rollouts[canaryKey(clusterName, namespace, name)]
+	var rollout CanaryRollout
+	if ok {
+		rollout = current.copy()
+	}
+	c.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("Deployment %s 没有金丝雀发布记录", name)
+	}
+	if !rollout.Active() {
+		return nil, fmt.Errorf("金丝雀发布已结束（%s）", rollout.Phase)
+	}
+
+	client, err := c.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		logger.Info("手动操作金丝雀发布", "cluster", clusterName, "namespace", namespace, "deployment", name, "user", identity.Username)
+	}
+	if err := action(client, &rollout); err != nil {
+		return nil, err
+	}
+	c.save(&rollout)
+	return &rollout, nil
+}
+
+// reconcileAll 推进所有进行中的发布
+func (c *CanaryController) reconcileAll(ctx context.Context) {
+	c.mutex.RLock()
+	keys := make([]string, 0, len(c.rollouts))
+	for key, r := range c.rollouts {
+		if r.Active() {
+			keys = append(keys, key)
+		}
+	}
+	c.mutex.RUnlock()
+
+	for _, key := range keys {
+		c.reconcile(ctx, key)
+	}
+}
+
+func (c *CanaryController) reconcile(ctx context.Context, key string) {
+	c.opMutex.Lock()
+	defer c.opMutex.Unlock()
+
+	c.mutex.RLock()
+	current, ok := c.rollouts[key]
+	var rollout CanaryRollout
+	if ok {
+		rollout = current.copy()
+	}
+	c.mutex.RUnlock()
+	if !ok || !rollout.Active() {
+		return
+	}
+
+	client, err := c.clientManager.GetClient(rollout.Cluster)
+	if err != nil {
+		// 集群暂时不可用时保留进度，等待下次推进
+		logger.Warn("金丝雀发布所在集群不可用", "cluster", rollout.Cluster, "error", err.Error())
+		return
+	}
+
+	switch rollout.Phase {
+	case CanaryPhaseProgressing:
+		err = c.progress(ctx, client, &rollout)
+	case CanaryPhasePromoting:
+		err = c.checkPromotion(ctx, client, &rollout)
+	}
+	if err != nil {
+		logger.Error("推进金丝雀发布失败", "rollout", key, "error", err.Error())
+		rollout.finish(CanaryPhaseFailed, err.Error())
+	}
+	c.save(&rollout)
+}
+
+// progress 确保当前步骤的副本比例，就绪并观察足够时间后执行分析，决定推进或回滚
+func (c *CanaryController) progress(ctx context.Context, client kubernetes.Interface, r *CanaryRollout) error {
+	deployments := client.AppsV1().Deployments(r.Namespace)
+	step := r.Spec.Steps[r.CurrentStep]
+	canaryCount, stableCount := canaryReplicas(r.TotalReplicas, step.Weight)
+
+	canary, err := deployments.Get(ctx, r.Spec.CanaryName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return c.rollback(ctx, client, r, "金丝雀 Deployment 已被删除")
+	}
+	if err != nil {
+		return fmt.Errorf("获取金丝雀 Deployment 失败: %w", err)
+	}
+	base, err := deployments.Get(ctx, r.Deployment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("获取基础 Deployment 失败: %w", err)
+	}
+	scaled := false
+	if canary.Spec.Replicas == nil || *canary.Spec.Replicas != canaryCount {
+		if err := scaleDeployment(ctx, client, r.Namespace, r.Spec.CanaryName, canaryCount); err != nil {
+			return fmt.Errorf("调整金丝雀副本数失败: %w", err)
+		}
+		scaled = true
+	}
+	if base.Spec.Replicas == nil || *base.Spec.Replicas != stableCount {
+		if err := scaleDeployment(ctx, client, r.Namespace, r.Deployment, stableCount); err != nil {
+			return fmt.Errorf("调整基础 Deployment 副本数失败: %w", err)
+		}
+	}
+	if scaled {
+		// 下次推进时再检查新副本是否就绪
+		return nil
+	}
+
+	now := time.Now()
+	if !deploymentSettled(canary, canaryCount) {
+		deadline := time.Duration(r.Spec.ProgressDeadlineSeconds) * time.Second
+		if now.Sub(r.StepStartedAt) > deadline {
+			return c.rollback(ctx, client, r, fmt.Sprintf("金丝雀版本未在 %s 内就绪", deadline))
+		}
+		return nil
+	}
+	if r.StepReadyAt == nil {
+		r.StepReadyAt = &now
+		r.record(fmt.Sprintf("第 %d 步副本已就绪，金丝雀 %d / 稳定 %d", r.CurrentStep+1, canaryCount, stableCount), nil)
+	}
+	if now.Sub(*r.StepReadyAt) < time.Duration(step.PauseSeconds)*time.Second {
+		return nil
+	}
+
+	results, passed := c.analyze(ctx, r)
+	if !passed {
+		r.Failures++
+		if r.Failures > r.Spec.FailureLimit {
+			r.record(fmt.Sprintf("第 %d 步分析未通过", r.CurrentStep+1), results)
+			return c.rollback(ctx, client, r, "指标分析未通过，自动回滚")
+		}
+		// 未超过失败次数上限时重新观察一轮
+		r.StepReadyAt = &now
+		r.record(fmt.Sprintf("第 %d 步分析未通过（%d/%d），继续观察", r.CurrentStep+1, r.Failures, r.Spec.FailureLimit), results)
+		return nil
+	}
+
+	r.Failures = 0
+	r.record(fmt.Sprintf("第 %d 步分析通过", r.CurrentStep+1), results)
+	r.CurrentStep++
+	r.StepStartedAt = now
+	r.StepReadyAt = nil
+	if r.CurrentStep >= len(r.Spec.Steps) {
+		return c.promote(ctx, client, r, "全部步骤通过，开始晋升")
+	}
+	return nil
+}
+
+// analyze 执行所有分析，全部通过时返回 true
+func (c *CanaryController) analyze(ctx context.Context, r *CanaryRollout) ([]CanaryAnalysisResult, bool) {
+	replacer := strings.NewReplacer("{{namespace}}", r.Namespace, "{{canary}}", r.Spec.CanaryName, "{{stable}}", r.Deployment)
+	results := make([]CanaryAnalysisResult, 0, len(r.Spec.Analysis))
+	passed := true
+	for _, a := range r.Spec.Analysis {
+		result := CanaryAnalysisResult{Name: a.Name, Query: replacer.Replace(a.Query)}
+		value, err := c.query(ctx, r.Cluster, result.Query)
+		if err == nil {
+			result.Value = &value
+			err = checkCanaryThreshold(value, a)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			passed = false
+		} else {
+			result.Passed = true
+		}
+		results = append(results, result)
+	}
+	return results, passed
+}
+
+func (c *CanaryController) query(ctx context.Context, clusterName, query string) (float64, error) {
+	if c.prometheus == nil {
+		return 0, fmt.Errorf("未启用 Prometheus")
+	}
+	raw, err := c.prometheus.QueryInstant(ctx, clusterName, query, canaryQueryTimeout)
+	if err != nil {
+		return 0, err
+	}
+	return parseCanaryQueryResult(raw)
+}
+
+// promote 将金丝雀 Pod 模板写入基础 Deployment 并恢复总副本数，滚动完成后删除金丝雀
+func (c *CanaryController) promote(ctx context.Context, client kubernetes.Interface, r *CanaryRollout, message string) error {
+	deployments := client.AppsV1().Deployments(r.Namespace)
+	canary, err := deployments.Get(ctx, r.Spec.CanaryName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("获取金丝雀 Deployment 失败: %w", err)
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		base, err := deployments.Get(ctx, r.Deployment, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		base.Spec.Template.Spec = *canary.Spec.Template.Spec.DeepCopy()
+		base.Spec.Template.Annotations = copyStringMap(canary.Spec.Template.Annotations)
+		base.Spec.Replicas = &r.TotalReplicas
+		_, err = deployments.Update(ctx, base, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("更新基础 Deployment 失败: %w", err)
+	}
+	r.Phase = CanaryPhasePromoting
+	r.StepStartedAt = time.Now()
+	r.StepReadyAt = nil
+	r.record(message, nil)
+	return nil
+}
+
+// checkPromotion 基础 Deployment 滚动完成后删除金丝雀，完成发布
+func (c *CanaryController) checkPromotion(ctx context.Context, client kubernetes.Interface, r *CanaryRollout) error {
+	deployments := client.AppsV1().Deployments(r.Namespace)
+	base, err := deployments.Get(ctx, r.Deployment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("获取基础 Deployment 失败: %w", err)
+	}
+	if !deploymentSettled(base, r.TotalReplicas) {
+		deadline := time.Duration(r.Spec.ProgressDeadlineSeconds) * time.Second
+		if time.Since(r.StepStartedAt) > deadline {
+			// 新版本已写入基础 Deployment，保留金丝雀以便排查，交由人工处理
+			return fmt.Errorf("基础 Deployment 未在 %s 内完成滚动更新", deadline)
+		}
+		return nil
+	}
+	if err := deployments.Delete(ctx, r.Spec.CanaryName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("删除金丝雀 Deployment 失败: %w", err)
+	}
+	r.finish(CanaryPhasePromoted, "发布完成")
+	logger.Info("金丝雀发布完成", "rollout", r.key())
+	return nil
+}
+
+// rollback 恢复基础 Deployment 副本数并删除金丝雀
+func (c *CanaryController) rollback(ctx context.Context, client kubernetes.Interface, r *CanaryRollout, reason string) error {
+	if err := scaleDeployment(ctx, client, r.Namespace, r.Deployment, r.TotalReplicas); err != nil {
+		return fmt.Errorf("恢复基础 Deployment 副本数失败: %w", err)
+	}
+	err := client.AppsV1().Deployments(r.Namespace).Delete(ctx, r.Spec.CanaryName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("删除金丝雀 Deployment 失败: %w", err)
+	}
+	r.finish(CanaryPhaseRolledBack, reason)
+	logger.Warn("金丝雀发布已回滚", "rollout", r.key(), "reason", reason)
+	return nil
+}
+
+// save 更新内存中的进度并持久化
+func (c *CanaryController) save(r *CanaryRollout) {
+	r.UpdatedAt = time.Now()
+	stored := r.copy()
+	c.mutex.Lock()
+	c.rollouts[r.key()] = &stored
+	c.mutex.Unlock()
+	if c.store != nil {
+		if err := c.store.Save(stored); err != nil {
+			logger.Error("保存金丝雀发布进度失败", "rollout", r.key(), "error", err.Error())
+		}
+	}
+}
+
+// scaleDeployment 只修改 spec.replicas，不影响其他字段
+func scaleDeployment(ctx context.Context, client kubernetes.Interface, namespace, name string, replicas int32) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	_, err := client.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// deploymentSettled Deployment 已观察到最新配置，且全部副本为新版本并可用
+func deploymentSettled(d *appsv1.Deployment, replicas int32) bool {
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas >= replicas &&
+		d.Status.AvailableReplicas >= replicas &&
+		d.Status.Replicas == d.Status.UpdatedReplicas
+}
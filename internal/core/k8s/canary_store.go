@@ -0,0 +1,33 @@
+package k8s
+
+// CanaryStore 渐进式金丝雀发布进度的持久化接口，控制器重启后据此继续推进
+type CanaryStore interface {
+	// List 返回所有已保存的发布记录
+	List() ([]CanaryRollout, error)
+	// Save 新增或覆盖发布记录（按集群/命名空间/Deployment 唯一）
+	Save(rollout CanaryRollout) error
+}
+
+// FileCanaryStore 基于本地 JSON 文件的 CanaryStore 默认实现
+type FileCanaryStore struct {
+	file *recordFile[CanaryRollout]
+}
+
+// NewFileCanaryStore 创建文件发布记录存储，文件不存在时在首次写入时创建
+func NewFileCanaryStore(path string) (*FileCanaryStore, error) {
+	file, err := openRecordFile(path, "rollouts", func(r CanaryRollout) string { return r.key() })
+	if err != nil {
+		return nil, err
+	}
+	return &FileCanaryStore{file: file}, nil
+}
+
+// List 返回所有已保存的发布记录
+func (s *FileCanaryStore) List() ([]CanaryRollout, error) {
+	return s.file.list(), nil
+}
+
+// Save 新增或覆盖发布记录
+func (s *FileCanaryStore) Save(rollout CanaryRollout) error {
+	return s.file.save(rollout)
+}
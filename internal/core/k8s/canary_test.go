@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCanaryReplicas(t *testing.T) {
+	cases := []struct {
+		total          int32
+		weight         int
+		canary, stable int32
+	}{
+		{10, 10, 1, 9},
+		{4, 10, 1, 3}, // 向上取整，至少一个金丝雀副本
+		{4, 50, 2, 2},
+		{3, 100, 3, 0},
+	}
+	for _, tc := range cases {
+		canary, stable := canaryReplicas(tc.total, tc.weight)
+		if canary != tc.canary || stable != tc.stable {
+			t.Errorf("canaryReplicas(%d, %d) = %d/%d, want %d/%d", tc.total, tc.weight, canary, stable, tc.canary, tc.stable)
+		}
+	}
+}
+
+func TestNormalizeCanarySpec(t *testing.T) {
+	spec, err := normalizeCanarySpec("web", CanaryRolloutSpec{Images: map[string]string{"web": "nginx:1.27"}})
+	if err != nil {
+		t.Fatalf("normalizeCanarySpec: %v", err)
+	}
+	if spec.CanaryName != "web-canary" || len(spec.Steps) != len(defaultCanarySteps) ||
+		spec.Steps[0].PauseSeconds != 60 || spec.ProgressDeadlineSeconds != 600 {
+		t.Fatalf("unexpected defaults: %+v", spec)
+	}
+
+	invalid := []CanaryRolloutSpec{
+		{},
+		{Images: map[string]string{"web": "v2"}, Steps: []CanaryStep{{Weight: 50}, {Weight: 20}}},
+		{Images: map[string]string{"web": "v2"}, Steps: []CanaryStep{{Weight: 120}}},
+		{Images: map[string]string{"web": "v2"}, Analysis: []CanaryAnalysis{{Query: "up"}}},
+		{Images: map[string]string{"web": "v2"}, CanaryName: "web"},
+	}
+	for i, s := range invalid {
+		if _, err := normalizeCanarySpec("web", s); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
+
+func TestCanaryAnalysisThreshold(t *testing.T) {
+	maxErrors := 0.05
+	a := CanaryAnalysis{Name: "error-rate", Query: "x", Max: &maxErrors}
+
+	raw := json.RawMessage(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.02"]}]}}`)
+	value, err := parseCanaryQueryResult(raw)
+	if err != nil || value != 0.02 {
+		t.Fatalf("parseCanaryQueryResult = %v, %v", value, err)
+	}
+	if err := checkCanaryThreshold(value, a); err != nil {
+		t.Fatalf("0.02 should pass: %v", err)
+	}
+	if err := checkCanaryThreshold(0.1, a); err == nil {
+		t.Fatal("0.1 should exceed the max")
+	}
+
+	empty := json.RawMessage(`{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	if _, err := parseCanaryQueryResult(empty); err == nil {
+		t.Fatal("empty result should fail the analysis")
+	}
+	nan := json.RawMessage(`{"status":"success","data":{"result":[{"value":[1700000000,"NaN"]}]}}`)
+	value, err = parseCanaryQueryResult(nan)
+	if err != nil {
+		t.Fatalf("parseCanaryQueryResult: %v", err)
+	}
+	if err := checkCanaryThreshold(value, a); err == nil {
+		t.Fatal("NaN should fail the analysis")
+	}
+}
+
+func TestBuildCanaryDeploymentOverridesImages(t *testing.T) {
+	base := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "web", Image: "nginx:1.26"},
+					{Name: "sidecar", Image: "envoy:1.30"},
+				}},
+			},
+		},
+	}
+	canary, err := buildCanaryDeployment(base, CreateCanaryDeploymentRequest{Name: "web-canary", Images: map[string]string{"web": "nginx:1.27"}})
+	if err != nil {
+		t.Fatalf("buildCanaryDeployment: %v", err)
+	}
+	containers := canary.Spec.Template.Spec.Containers
+	if containers[0].Image != "nginx:1.27" || containers[1].Image != "envoy:1.30" {
+		t.Fatalf("unexpected images: %+v", containers)
+	}
+	if base.Spec.Template.Spec.Containers[0].Image != "nginx:1.26" {
+		t.Fatal("base deployment must not be modified")
+	}
+	if canary.Namespace != "prod" || canary.Spec.Selector.MatchLabels["track"] != "canary" {
+		t.Fatalf("unexpected canary metadata: %+v", canary.ObjectMeta)
+	}
+	if _, err := buildCanaryDeployment(base, CreateCanaryDeploymentRequest{Name: "web-canary", Images: map[string]string{"db": "x"}}); err == nil {
+		t.Fatal("unknown container should be rejected")
+	}
+}
+
+func TestFileCanaryStoreRestoresRollouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "canaries.json")
+	store, err := NewFileCanaryStore(path)
+	if err != nil {
+		t.Fatalf("NewFileCanaryStore: %v", err)
+	}
+	rollout := CanaryRollout{
+		Cluster:       "dev",
+		Namespace:     "prod",
+		Deployment:    "web",
+		Spec:          CanaryRolloutSpec{CanaryName: "web-canary", Images: map[string]string{"web": "nginx:1.27"}, Steps: defaultCanarySteps},
+		Phase:         CanaryPhaseProgressing,
+		TotalReplicas: 4,
+		CurrentStep:   1,
+		StepStartedAt: time.Now(),
+	}
+	if err := store.Save(rollout); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// 重启后控制器从存储中恢复进度
+	reopened, err := NewFileCanaryStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	controller := NewCanaryController(NewClientManager(), nil, reopened)
+	restored, err := controller.GetRollout("dev", "prod", "web")
+	if err != nil {
+		t.Fatalf("GetRollout: %v", err)
+	}
+	if !restored.Active() || restored.CurrentStep != 1 || restored.TotalReplicas != 4 || restored.Spec.CanaryName != "web-canary" {
+		t.Fatalf("unexpected restored rollout: %+v", restored)
+	}
+	if rollouts := controller.ListRollouts("dev", "staging"); len(rollouts) != 0 {
+		t.Fatalf("namespace filter should exclude rollout, got %d", len(rollouts))
+	}
+}
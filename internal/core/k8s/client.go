@@ -20,6 +20,11 @@ type ClientManager struct {
 	configs         map[string]*rest.Config
 	addTypes        map[string]string // 存储集群添加方式："path"或"content"
 	prometheusURLs  map[string]string
+	impersonate     map[string]bool                 // 按集群开启的用户模拟
+	impersonated    map[string]*impersonatedClient // 模拟用户的客户端缓存，key 为集群+用户
+	informers       map[string]*clusterInformers   // 按集群懒启动的共享 informer
+	discovery       map[string]*clusterDiscovery   // 按集群缓存的 API 发现信息与 RESTMapper
+	store           ClusterStore // 可选的持久化存储，为 nil 时仅保存在内存中
 	mutex           sync.RWMutex
 }
 
@@ -70,114 +75,266 @@ type Cluster struct {
 	KubeconfigPath    string `json:"kubeconfigPath"`
 	KubeconfigContent string `json:"kubeconfigContent,omitempty"`
 	PrometheusURL     string `json:"prometheusUrl,omitempty"`
+	// 开启后以登录用户身份（Impersonate）访问集群，由 K8s RBAC 决定权限
+	Impersonate bool `json:"impersonate,omitempty"`
 	// 添加一个类型字段，标识用户通过哪种方式添加的集群
 	AddType string `json:"addType,omitempty"` // "path" 或 "content"
 }
 
 // NewClientManager Create client manager
-func NewClientManager() *ClientManager {
-	return &ClientManager{
+func NewClientManager(opts ...func(*ClientManager)) *ClientManager {
+	cm := &ClientManager{
 		clients:        make(map[string]*kubernetes.Clientset),
 		configs:        make(map[string]*rest.Config),
 		addTypes:       make(map[string]string),
 		prometheusURLs: make(map[string]string),
+		impersonate:    make(map[string]bool),
+		impersonated:   make(map[string]*impersonatedClient),
+		informers:      make(map[string]*clusterInformers),
+		discovery:      make(map[string]*clusterDiscovery),
 	}
+	for _, opt := range opts {
+		opt(cm)
+	}
+	return cm
+}
+
+// WithClusterStore 设置集群持久化存储
+func WithClusterStore(store ClusterStore) func(*ClientManager) {
+	return func(cm *ClientManager) {
+		cm.store = store
+	}
+}
+
+// LoadClusters 从持久化存储恢复所有集群。
+// 恢复时不做连通性测试，暂时不可达的集群也会被注册，避免重启后丢失。
+func (cm *ClientManager) LoadClusters() error {
+	if cm.store == nil {
+		return nil
+	}
+	clusters, err := cm.store.List()
+	if err != nil {
+		return fmt.Errorf("failed to load clusters from store: %w", err)
+	}
+	for _, cluster := range clusters {
+		if err := cm.restoreCluster(cluster); err != nil {
+			logger.Warn("failed to restore cluster", "cluster", cluster.Name, "error", err.Error())
+			continue
+		}
+		logger.Info("cluster restored", "cluster", cluster.Name, "addType", cluster.AddType)
+	}
+	return nil
+}
+
+func (cm *ClientManager) restoreCluster(cluster Cluster) error {
+	var config *rest.Config
+	var err error
+	if cluster.AddType == "content" {
+		config, err = clientcmd.RESTConfigFromKubeConfig([]byte(cluster.KubeconfigContent))
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", cluster.KubeconfigPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build kubeconfig: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	cm.stopInformersLocked(cluster.Name)
+	cm.clients[cluster.Name] = clientset
+	cm.configs[cluster.Name] = config
+	cm.addTypes[cluster.Name] = cluster.AddType
+	cm.setImpersonationLocked(cluster.Name, cluster.Impersonate)
+	if err := cm.storePrometheusURL(cluster.Name, cluster.PrometheusURL); err != nil {
+		logger.Warn("ignoring invalid stored Prometheus URL", "cluster", cluster.Name, "error", err.Error())
+	}
+	return nil
+}
+
+// clusterRegistration 集群在内存中的注册信息
+type clusterRegistration struct {
+	client        *kubernetes.Clientset
+	config        *rest.Config
+	addType       string
+	prometheusURL string
+	impersonate   bool
+}
+
+// registrationLocked 返回集群当前的注册信息，未注册时返回 nil
+func (cm *ClientManager) registrationLocked(clusterName string) *clusterRegistration {
+	client, ok := cm.clients[clusterName]
+	if !ok {
+		return nil
+	}
+	return &clusterRegistration{
+		client:        client,
+		config:        cm.configs[clusterName],
+		addType:       cm.addTypes[clusterName],
+		prometheusURL: cm.prometheusURLs[clusterName],
+		impersonate:   cm.impersonate[clusterName],
+	}
+}
+
+// registerLocked 以 reg 整体替换集群的注册信息：停止旧客户端的共享缓存、清空 API 发现缓存，
+// Prometheus URL 与用户模拟开关均以本次注册为准（为空时清除），不沿用之前的值
+func (cm *ClientManager) registerLocked(clusterName string, reg clusterRegistration) {
+	cm.stopInformersLocked(clusterName)
+	delete(cm.discovery, clusterName)
+	cm.clients[clusterName] = reg.client
+	cm.configs[clusterName] = reg.config
+	cm.addTypes[clusterName] = reg.addType
+	if reg.prometheusURL != "" {
+		cm.prometheusURLs[clusterName] = reg.prometheusURL
+	} else {
+		delete(cm.prometheusURLs, clusterName)
+	}
+	cm.setImpersonationLocked(clusterName, reg.impersonate)
+}
+
+// persistCluster 将集群写入持久化存储，失败时回滚内存中的注册信息：
+// 重新注册的集群恢复为 previous，新集群则移除
+func (cm *ClientManager) persistCluster(cluster Cluster, previous *clusterRegistration) error {
+	if cm.store == nil {
+		return nil
+	}
+	if err := cm.store.Save(cluster); err != nil {
+		cm.mutex.Lock()
+		if previous != nil {
+			cm.registerLocked(cluster.Name, *previous)
+		} else {
+			cm.forgetClusterLocked(cluster.Name)
+		}
+		cm.mutex.Unlock()
+		return fmt.Errorf("failed to persist cluster: %w", err)
+	}
+	return nil
+}
+
+func (cm *ClientManager) forgetClusterLocked(clusterName string) {
+	delete(cm.clients, clusterName)
+	delete(cm.configs, clusterName)
+	delete(cm.addTypes, clusterName)
+	delete(cm.prometheusURLs, clusterName)
+	cm.setImpersonationLocked(clusterName, false)
+	cm.stopInformersLocked(clusterName)
+	delete(cm.discovery, clusterName)
 }
 
 // AddCluster Add cluster
 func (cm *ClientManager) AddCluster(clusterName, kubeconfigPath string) error {
-	return cm.addCluster(clusterName, kubeconfigPath, "path", "")
+	return cm.AddClusterWithOptions(Cluster{Name: clusterName, KubeconfigPath: kubeconfigPath, AddType: "path"})
 }
 
-func (cm *ClientManager) addCluster(clusterName, kubeconfigPath, addType, prometheusURL string) error {
+// addCluster 注册（或重新注册）集群，返回之前的注册信息（新集群为 nil）
+func (cm *ClientManager) addCluster(cluster Cluster) (*clusterRegistration, error) {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
+	if err := ValidatePrometheusURL(cluster.PrometheusURL); err != nil {
+		return nil, err
+	}
+
 	// Load kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	config, err := clientcmd.BuildConfigFromFlags("", cluster.KubeconfigPath)
 	if err != nil {
-		return fmt.Errorf("failed to build kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
 	}
 
 	// Create client
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
 	// Test connection
 	_, err = clientset.ServerVersion()
 	if err != nil {
-		return fmt.Errorf("failed to connect to cluster: %w", err)
+		return nil, fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
 	// Store client
-	cm.clients[clusterName] = clientset
-	cm.configs[clusterName] = config
-	cm.addTypes[clusterName] = addType
-	if err := cm.storePrometheusURL(clusterName, prometheusURL); err != nil {
-		return err
-	}
+	previous := cm.registrationLocked(cluster.Name)
+	cm.registerLocked(cluster.Name, clusterRegistration{
+		client:        clientset,
+		config:        config,
+		addType:       cluster.AddType,
+		prometheusURL: cluster.PrometheusURL,
+		impersonate:   cluster.Impersonate,
+	})
 
 	if report := PrepareClusterAccess(context.Background(), clientset); report != nil && !report.AllGranted {
-		logger.Warn("cluster permissions incomplete", "cluster", clusterName, "message", report.Message)
+		logger.Warn("cluster permissions incomplete", "cluster", cluster.Name, "message", report.Message)
 	}
 
-	return nil
+	return previous, nil
 }
 
 // AddClusterWithContent 通过kubeconfig内容添加集群
 func (cm *ClientManager) AddClusterWithContent(clusterName, content string) error {
-	return cm.addClusterWithContent(clusterName, content, "")
+	return cm.AddClusterWithOptions(Cluster{Name: clusterName, KubeconfigContent: content, AddType: "content"})
 }
 
-func (cm *ClientManager) addClusterWithContent(clusterName, content, prometheusURL string) error {
+// addClusterWithContent 以 kubeconfig 内容注册（或重新注册）集群，返回之前的注册信息（新集群为 nil）
+func (cm *ClientManager) addClusterWithContent(cluster Cluster) (*clusterRegistration, error) {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
+	if err := ValidatePrometheusURL(cluster.PrometheusURL); err != nil {
+		return nil, err
+	}
+	clusterName := cluster.Name
+
 	// 创建临时文件
 	tmpDir := os.TempDir()
 	kubeconfigPath := filepath.Join(tmpDir, fmt.Sprintf("kubeconfig-%s.yaml", clusterName))
 
 	// 写入内容到临时文件
-	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
-		return fmt.Errorf("failed to write kubeconfig content to file: %w", err)
+	if err := os.WriteFile(kubeconfigPath, []byte(cluster.KubeconfigContent), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write kubeconfig content to file: %w", err)
 	}
 
 	// 加载kubeconfig
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
 	if err != nil {
 		os.Remove(kubeconfigPath) // 清理临时文件
-		return fmt.Errorf("failed to build kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to build kubeconfig: %w", err)
 	}
 
 	// 创建客户端
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		os.Remove(kubeconfigPath) // 清理临时文件
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
 	// 测试连接
 	_, err = clientset.ServerVersion()
 	if err != nil {
 		os.Remove(kubeconfigPath) // 清理临时文件
-		return fmt.Errorf("failed to connect to cluster: %w", err)
+		return nil, fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
 	// 存储客户端
-	cm.clients[clusterName] = clientset
-	cm.configs[clusterName] = config
-	cm.addTypes[clusterName] = "content"
-	if err := cm.storePrometheusURL(clusterName, prometheusURL); err != nil {
-		return err
-	}
+	previous := cm.registrationLocked(clusterName)
+	cm.registerLocked(clusterName, clusterRegistration{
+		client:        clientset,
+		config:        config,
+		addType:       "content",
+		prometheusURL: cluster.PrometheusURL,
+		impersonate:   cluster.Impersonate,
+	})
 
 	if report := PrepareClusterAccess(context.Background(), clientset); report != nil && !report.AllGranted {
 		logger.Warn("cluster permissions incomplete", "cluster", clusterName, "message", report.Message)
 	}
 
-	return nil
+	return previous, nil
 }
 
 // RemoveCluster Remove cluster
@@ -189,10 +346,13 @@ func (cm *ClientManager) RemoveCluster(clusterName string) error {
 		return fmt.Errorf("cluster %s not found", clusterName)
 	}
 
-	delete(cm.clients, clusterName)
-	delete(cm.configs, clusterName)
-	delete(cm.addTypes, clusterName)
-	delete(cm.prometheusURLs, clusterName)
+	if cm.store != nil {
+		if err := cm.store.Delete(clusterName); err != nil {
+			return fmt.Errorf("failed to remove cluster from store: %w", err)
+		}
+	}
+
+	cm.forgetClusterLocked(clusterName)
 	return nil
 }
 
@@ -259,18 +419,40 @@ func (cm *ClientManager) GetAddType(clusterName string) string {
 }
 
 // AddClusterWithOptions 添加集群并可选配置 Prometheus URL
+// 配置了持久化存储时，连接成功后会写入存储以便重启后恢复
 func (cm *ClientManager) AddClusterWithOptions(cluster Cluster) error {
+	var previous *clusterRegistration
+	var err error
 	if cluster.AddType == "content" {
-		return cm.addClusterWithContent(cluster.Name, cluster.KubeconfigContent, cluster.PrometheusURL)
+		previous, err = cm.addClusterWithContent(cluster)
+	} else {
+		cluster.AddType = "path"
+		previous, err = cm.addCluster(cluster)
+	}
+	if err != nil {
+		return err
 	}
-	return cm.addCluster(cluster.Name, cluster.KubeconfigPath, "path", cluster.PrometheusURL)
+	return cm.persistCluster(cluster, previous)
 }
 
 // SetPrometheusURL 设置集群 Prometheus URL
 func (cm *ClientManager) SetPrometheusURL(clusterName, url string) {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
-	_ = cm.storePrometheusURLLocked(clusterName, url)
+	if err := cm.storePrometheusURLLocked(clusterName, url); err != nil {
+		return
+	}
+	if cm.store == nil {
+		return
+	}
+	cluster, err := cm.store.Get(clusterName)
+	if err != nil {
+		return
+	}
+	cluster.PrometheusURL = url
+	if err := cm.store.Save(cluster); err != nil {
+		logger.Warn("failed to persist Prometheus URL", "cluster", clusterName, "error", err.Error())
+	}
 }
 
 func (cm *ClientManager) storePrometheusURL(clusterName, prometheusURL string) error {
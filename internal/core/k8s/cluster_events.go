@@ -33,7 +33,7 @@ func NewClusterEventService(clientManager *ClientManager) *ClusterEventService {
 
 // ListClusterEvents 获取过滤后的集群事件
 func (s *ClusterEventService) ListClusterEvents(ctx context.Context, clusterName string, filter EventFilterOptions) ([]corev1.Event, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,237 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"kube-tide/internal/utils/logger"
+)
+
+// 检查项状态，按严重程度升序
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusWarning  = "warning"
+	HealthStatusCritical = "critical"
+	HealthStatusUnknown  = "unknown" // API Server 不可达等原因未能执行检查
+)
+
+// 检查项
+const (
+	HealthCheckAPIServer     = "apiserver"
+	HealthCheckNodes         = "nodes"
+	HealthCheckSystemPods    = "systemPods"
+	HealthCheckMetricsServer = "metricsServer"
+	HealthCheckStorage       = "storage"
+	HealthCheckCertificates  = "certificates"
+)
+
+// HealthChecks 全部检查项，按执行顺序
+var HealthChecks = []string{
+	HealthCheckAPIServer,
+	HealthCheckNodes,
+	HealthCheckSystemPods,
+	HealthCheckMetricsServer,
+	HealthCheckStorage,
+	HealthCheckCertificates,
+}
+
+const (
+	// DefaultClusterHealthInterval 后台检查间隔
+	DefaultClusterHealthInterval = time.Minute
+
+	clusterHealthTimeout = 30 * time.Second
+)
+
+// HealthCheck 单个检查项的结果
+type HealthCheck struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	Details   []string  `json:"details,omitempty"`
+	Since     time.Time `json:"since"` // 当前状态开始的时间
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// ClusterHealth 集群健康状况，Status 为各检查项中最严重的状态
+type ClusterHealth struct {
+	Cluster   string        `json:"cluster"`
+	Status    string        `json:"status"`
+	CheckedAt time.Time     `json:"checkedAt"`
+	Checks    []HealthCheck `json:"checks"`
+}
+
+// ClusterHealthService 定期检查所有已注册集群，保存最近一次结果，并在检查项状态变化时交给告警引擎
+type ClusterHealthService struct {
+	clientManager *ClientManager
+	alerts        *AlertEngine
+	interval      time.Duration
+	results       map[string]*ClusterHealth
+	mutex         sync.RWMutex
+	// 同一集群同时只执行一次检查
+	running map[string]*sync.Mutex
+}
+
+// NewClusterHealthService 创建集群健康检查服务，alerts 为 nil 时不产生告警
+func NewClusterHealthService(clientManager *ClientManager, alerts *AlertEngine, interval time.Duration) *ClusterHealthService {
+	if interval <= 0 {
+		interval = DefaultClusterHealthInterval
+	}
+	return &ClusterHealthService{
+		clientManager: clientManager,
+		alerts:        alerts,
+		interval:      interval,
+		results:       make(map[string]*ClusterHealth),
+		running:       make(map[string]*sync.Mutex),
+	}
+}
+
+// Start 启动后台检查，每个周期并行检查当前注册的全部集群
+func (s *ClusterHealthService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		logger.Info("启动集群健康检查", "interval", s.interval.String())
+		s.checkAll(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("停止集群健康检查")
+				return
+			case <-ticker.C:
+				s.checkAll(ctx)
+			}
+		}
+	}()
+}
+
+func (s *ClusterHealthService) checkAll(ctx context.Context) {
+	clusters := s.clientManager.ListClusters()
+	active := make(map[string]bool, len(clusters))
+	var wg sync.WaitGroup
+	for _, clusterName := range clusters {
+		active[clusterName] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Check(ctx, clusterName)
+		}()
+	}
+	wg.Wait()
+
+	// 清理已移除集群的结果与告警
+	s.mutex.Lock()
+	for clusterName := range s.results {
+		if !active[clusterName] {
+			delete(s.results, clusterName)
+			delete(s.running, clusterName)
+		}
+	}
+	s.mutex.Unlock()
+	s.alerts.forgetClusters(active)
+}
+
+// GetHealth 返回集群最近一次的检查结果，尚未检查过时立即检查
+func (s *ClusterHealthService) GetHealth(ctx context.Context, clusterName string) *ClusterHealth {
+	s.mutex.RLock()
+	health, ok := s.results[clusterName]
+	s.mutex.RUnlock()
+	if ok {
+		return health
+	}
+	return s.Check(ctx, clusterName)
+}
+
+// Summary 按状态统计各集群的最近一次结果，尚未检查的集群计为 unknown
+func (s *ClusterHealthService) Summary() map[string]int {
+	summary := map[string]int{
+		HealthStatusOK:       0,
+		HealthStatusWarning:  0,
+		HealthStatusCritical: 0,
+		HealthStatusUnknown:  0,
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, clusterName := range s.clientManager.ListClusters() {
+		if health, ok := s.results[clusterName]; ok {
+			summary[health.Status]++
+		} else {
+			summary[HealthStatusUnknown]++
+		}
+	}
+	return summary
+}
+
+// Check 立即检查集群并保存结果，检查项状态变化时触发告警规则
+func (s *ClusterHealthService) Check(ctx context.Context, clusterName string) *ClusterHealth {
+	s.mutex.Lock()
+	lock, ok := s.running[clusterName]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.running[clusterName] = lock
+	}
+	s.mutex.Unlock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, clusterHealthTimeout)
+	defer cancel()
+	now := time.Now()
+	checks := runHealthChecks(ctx, s.clientManager, clusterName, now)
+
+	s.mutex.Lock()
+	previous := s.results[clusterName]
+	health := &ClusterHealth{Cluster: clusterName, CheckedAt: now, Checks: mergeHealthChecks(previous, checks)}
+	health.Status = worstHealthStatus(health.Checks)
+	s.results[clusterName] = health
+	s.mutex.Unlock()
+
+	s.alerts.evaluate(health, now)
+	return health
+}
+
+// mergeHealthChecks 沿用状态未变化的检查项的 Since
+func mergeHealthChecks(previous *ClusterHealth, checks []HealthCheck) []HealthCheck {
+	if previous == nil {
+		return checks
+	}
+	for i := range checks {
+		for _, old := range previous.Checks {
+			if old.Name == checks[i].Name && old.Status == checks[i].Status {
+				checks[i].Since = old.Since
+			}
+		}
+	}
+	return checks
+}
+
+// healthStatusRank 状态的严重程度，unknown 不参与比较
+func healthStatusRank(status string) int {
+	switch status {
+	case HealthStatusWarning:
+		return 1
+	case HealthStatusCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// worstHealthStatus 各检查项中最严重的状态；API Server 不可达时其余检查为 unknown，整体为 critical
+func worstHealthStatus(checks []HealthCheck) string {
+	status := HealthStatusOK
+	for _, check := range checks {
+		if healthStatusRank(check.Status) > healthStatusRank(status) {
+			status = check.Status
+		}
+	}
+	if status == HealthStatusOK {
+		for _, check := range checks {
+			if check.Status == HealthStatusUnknown {
+				return HealthStatusUnknown
+			}
+		}
+	}
+	return status
+}
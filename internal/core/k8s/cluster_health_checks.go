@@ -0,0 +1,324 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+const (
+	// apiServerSlowThreshold /readyz 响应超过该时长视为 warning
+	apiServerSlowThreshold = time.Second
+	// pvcPendingThreshold PVC 处于 Pending 超过该时长视为绑定失败
+	pvcPendingThreshold = 5 * time.Minute
+	// metricsStaleThreshold 节点指标的采集时间早于该时长视为 metrics-server 未在更新
+	metricsStaleThreshold = 5 * time.Minute
+	// systemPodRestartThreshold 系统 Pod 容器重启次数超过该值时提示
+	systemPodRestartThreshold = 10
+	// maxHealthDetails 每个检查项最多列出的明细条数
+	maxHealthDetails = 20
+
+	systemNamespace = "kube-system"
+)
+
+// runHealthChecks 使用平台自身凭据依次执行全部检查项。API Server 不可达时其余检查项为 unknown
+func runHealthChecks(ctx context.Context, clientManager *ClientManager, clusterName string, now time.Time) []HealthCheck {
+	checks := make([]HealthCheck, 0, len(HealthChecks))
+	add := func(name string, check HealthCheck) {
+		check.Name = name
+		check.CheckedAt = now
+		check.Since = now
+		checks = append(checks, check)
+	}
+
+	unknown := func(message string) []HealthCheck {
+		for _, name := range HealthChecks[len(checks):] {
+			add(name, HealthCheck{Status: HealthStatusUnknown, Message: message})
+		}
+		return checks
+	}
+
+	client, err := clientManager.GetClient(clusterName)
+	if err != nil {
+		return unknown(err.Error())
+	}
+	config, err := clientManager.GetConfig(clusterName)
+	if err != nil {
+		return unknown(err.Error())
+	}
+	add(HealthCheckAPIServer, checkAPIServer(ctx, client))
+	if checks[0].Status == HealthStatusCritical {
+		return unknown("API Server 不可达，未执行检查")
+	}
+	add(HealthCheckNodes, checkNodes(ctx, client))
+	add(HealthCheckSystemPods, checkSystemPods(ctx, client))
+	add(HealthCheckMetricsServer, checkMetricsServer(ctx, config, now))
+	add(HealthCheckStorage, checkStorage(ctx, client, now))
+	add(HealthCheckCertificates, checkCertificates(ctx, client, config, now))
+	return checks
+}
+
+// healthResult 根据问题明细生成检查结果，没有问题时为 ok
+func healthResult(status, message string, details []string, okMessage string) HealthCheck {
+	if len(details) == 0 && status == HealthStatusOK {
+		return HealthCheck{Status: HealthStatusOK, Message: okMessage}
+	}
+	if len(details) > maxHealthDetails {
+		more := len(details) - maxHealthDetails
+		details = append(details[:maxHealthDetails:maxHealthDetails], fmt.Sprintf("... 另有 %d 项", more))
+	}
+	return HealthCheck{Status: status, Message: message, Details: details}
+}
+
+// checkAPIServer 请求 /readyz 并记录耗时
+func checkAPIServer(ctx context.Context, client kubernetes.Interface) HealthCheck {
+	start := time.Now()
+	_, err := client.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	return evaluateAPIServer(time.Since(start), err)
+}
+
+func evaluateAPIServer(latency time.Duration, err error) HealthCheck {
+	latencyText := latency.Round(time.Millisecond).String()
+	switch {
+	case err != nil:
+		return HealthCheck{Status: HealthStatusCritical, Message: fmt.Sprintf("API Server 不可达: %v", err), Details: []string{"latency=" + latencyText}}
+	case latency > apiServerSlowThreshold:
+		return HealthCheck{Status: HealthStatusWarning, Message: fmt.Sprintf("API Server 响应缓慢（%s）", latencyText), Details: []string{"latency=" + latencyText}}
+	default:
+		return HealthCheck{Status: HealthStatusOK, Message: fmt.Sprintf("API Server 就绪（%s）", latencyText), Details: []string{"latency=" + latencyText}}
+	}
+}
+
+func checkNodes(ctx context.Context, client kubernetes.Interface) HealthCheck {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return HealthCheck{Status: HealthStatusUnknown, Message: fmt.Sprintf("获取节点列表失败: %v", err)}
+	}
+	return evaluateNodes(nodes.Items)
+}
+
+// evaluateNodes 节点 NotReady 为 critical，存在内存/磁盘/PID 压力或网络不可用为 warning
+func evaluateNodes(nodes []corev1.Node) HealthCheck {
+	status := HealthStatusOK
+	var details []string
+	notReady := 0
+	for _, node := range nodes {
+		ready := false
+		for _, cond := range node.Status.Conditions {
+			switch cond.Type {
+			case corev1.NodeReady:
+				ready = cond.Status == corev1.ConditionTrue
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+				if cond.Status == corev1.ConditionTrue {
+					details = append(details, fmt.Sprintf("%s: %s", node.Name, cond.Type))
+					if status == HealthStatusOK {
+						status = HealthStatusWarning
+					}
+				}
+			}
+		}
+		if !ready {
+			notReady++
+			details = append(details, fmt.Sprintf("%s: NotReady", node.Name))
+			status = HealthStatusCritical
+		}
+	}
+	sort.Strings(details)
+	message := fmt.Sprintf("%d/%d 个节点未就绪", notReady, len(nodes))
+	if notReady == 0 {
+		message = "部分节点存在资源压力"
+	}
+	return healthResult(status, message, details, fmt.Sprintf("%d 个节点全部就绪", len(nodes)))
+}
+
+func checkSystemPods(ctx context.Context, client kubernetes.Interface) HealthCheck {
+	pods, err := client.CoreV1().Pods(systemNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return HealthCheck{Status: HealthStatusUnknown, Message: fmt.Sprintf("获取 %s Pod 列表失败: %v", systemNamespace, err)}
+	}
+	return evaluateSystemPods(pods.Items)
+}
+
+// evaluateSystemPods kube-system 中容器处于 CrashLoopBackOff 为 critical，
+// 镜像拉取失败、Pod Failed 或重启次数过多为 warning
+func evaluateSystemPods(pods []corev1.Pod) HealthCheck {
+	status := HealthStatusOK
+	var details []string
+	crashing := 0
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodFailed {
+			details = append(details, fmt.Sprintf("%s: Failed %s", pod.Name, pod.Status.Reason))
+			if status == HealthStatusOK {
+				status = HealthStatusWarning
+			}
+			continue
+		}
+		statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			switch {
+			case cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff":
+				crashing++
+				details = append(details, fmt.Sprintf("%s/%s: CrashLoopBackOff (restarts=%d)", pod.Name, cs.Name, cs.RestartCount))
+				status = HealthStatusCritical
+			case cs.State.Waiting != nil && (cs.State.Waiting.Reason == "ImagePullBackOff" || cs.State.Waiting.Reason == "ErrImagePull"):
+				details = append(details, fmt.Sprintf("%s/%s: %s", pod.Name, cs.Name, cs.State.Waiting.Reason))
+				if status == HealthStatusOK {
+					status = HealthStatusWarning
+				}
+			case cs.RestartCount > systemPodRestartThreshold:
+				details = append(details, fmt.Sprintf("%s/%s: restarts=%d", pod.Name, cs.Name, cs.RestartCount))
+				if status == HealthStatusOK {
+					status = HealthStatusWarning
+				}
+			}
+		}
+	}
+	sort.Strings(details)
+	message := fmt.Sprintf("%d 个系统容器处于 CrashLoopBackOff", crashing)
+	if crashing == 0 {
+		message = "部分系统 Pod 状态异常"
+	}
+	return healthResult(status, message, details, fmt.Sprintf("%d 个系统 Pod 运行正常", len(pods)))
+}
+
+// checkMetricsServer 通过 metrics.k8s.io 获取节点指标，不可用或指标长时间未更新为 warning
+func checkMetricsServer(ctx context.Context, config *rest.Config, now time.Time) HealthCheck {
+	metricsClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		return HealthCheck{Status: HealthStatusUnknown, Message: fmt.Sprintf("创建 metrics 客户端失败: %v", err)}
+	}
+	nodeMetrics, err := metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return HealthCheck{Status: HealthStatusWarning, Message: fmt.Sprintf("metrics-server 不可用: %v", err)}
+	}
+	if len(nodeMetrics.Items) == 0 {
+		return HealthCheck{Status: HealthStatusWarning, Message: "metrics-server 未返回任何节点指标"}
+	}
+	var stale []string
+	for _, m := range nodeMetrics.Items {
+		if now.Sub(m.Timestamp.Time) > metricsStaleThreshold {
+			stale = append(stale, fmt.Sprintf("%s: %s", m.Name, m.Timestamp.Format(time.RFC3339)))
+		}
+	}
+	sort.Strings(stale)
+	return healthResult(HealthStatusWarning, fmt.Sprintf("%d 个节点的指标超过 %s 未更新", len(stale), metricsStaleThreshold), stale,
+		fmt.Sprintf("metrics-server 正常（%d 个节点）", len(nodeMetrics.Items)))
+}
+
+func checkStorage(ctx context.Context, client kubernetes.Interface, now time.Time) HealthCheck {
+	pvcs, err := client.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return HealthCheck{Status: HealthStatusUnknown, Message: fmt.Sprintf("获取 PVC 列表失败: %v", err)}
+	}
+	pvs, err := client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return HealthCheck{Status: HealthStatusUnknown, Message: fmt.Sprintf("获取 PV 列表失败: %v", err)}
+	}
+	return evaluateStorage(pvcs.Items, pvs.Items, now)
+}
+
+// evaluateStorage PVC Lost 与 PV Failed 为 critical，PVC Pending 超过 5 分钟为 warning
+func evaluateStorage(pvcs []corev1.PersistentVolumeClaim, pvs []corev1.PersistentVolume, now time.Time) HealthCheck {
+	status := HealthStatusOK
+	var details []string
+	for _, pvc := range pvcs {
+		switch {
+		case pvc.Status.Phase == corev1.ClaimLost:
+			details = append(details, fmt.Sprintf("PVC %s/%s: Lost", pvc.Namespace, pvc.Name))
+			status = HealthStatusCritical
+		case pvc.Status.Phase == corev1.ClaimPending && now.Sub(pvc.CreationTimestamp.Time) > pvcPendingThreshold:
+			details = append(details, fmt.Sprintf("PVC %s/%s: Pending %s", pvc.Namespace, pvc.Name, now.Sub(pvc.CreationTimestamp.Time).Round(time.Minute)))
+			if status == HealthStatusOK {
+				status = HealthStatusWarning
+			}
+		}
+	}
+	for _, pv := range pvs {
+		if pv.Status.Phase == corev1.VolumeFailed {
+			details = append(details, fmt.Sprintf("PV %s: Failed %s", pv.Name, pv.Status.Message))
+			status = HealthStatusCritical
+		}
+	}
+	sort.Strings(details)
+	return healthResult(status, fmt.Sprintf("%d 个存储卷绑定异常", len(details)), details,
+		fmt.Sprintf("%d 个 PVC、%d 个 PV 状态正常", len(pvcs), len(pvs)))
+}
+
+func checkCertificates(ctx context.Context, client kubernetes.Interface, config *rest.Config, now time.Time) HealthCheck {
+	secrets, err := client.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return HealthCheck{Status: HealthStatusUnknown, Message: fmt.Sprintf("获取 TLS Secret 列表失败: %v", err)}
+	}
+	items := buildCertificateReport(secrets.Items, nil, certificateExpiringDays, now)
+	return evaluateCertificates(items, kubeconfigCertificates(config, now))
+}
+
+// kubeconfigCertificates 解析 kubeconfig 中的集群 CA 与客户端证书，未配置的跳过
+func kubeconfigCertificates(config *rest.Config, now time.Time) map[string]*CertificateInfo {
+	certs := make(map[string]*CertificateInfo)
+	read := func(name string, data []byte, file string) {
+		if len(data) == 0 && file != "" {
+			data, _ = os.ReadFile(file)
+		}
+		if len(data) == 0 {
+			return
+		}
+		if info, err := inspectCertificate(data, now); err == nil {
+			certs[name] = info
+		}
+	}
+	read("kubeconfig CA", config.CAData, config.CAFile)
+	read("kubeconfig client certificate", config.CertData, config.CertFile)
+	return certs
+}
+
+// evaluateCertificates TLS Secret 与 kubeconfig 证书已过期为 critical，30 天内过期或无法解析为 warning
+func evaluateCertificates(items []CertificateReportItem, kubeconfig map[string]*CertificateInfo) HealthCheck {
+	status := HealthStatusOK
+	var details []string
+	mark := func(name, certStatus string, days int) {
+		switch certStatus {
+		case CertificateExpired:
+			details = append(details, fmt.Sprintf("%s: expired", name))
+			status = HealthStatusCritical
+		case CertificateExpiring:
+			details = append(details, fmt.Sprintf("%s: %d days remaining", name, days))
+			if status == HealthStatusOK {
+				status = HealthStatusWarning
+			}
+		case CertificateInvalid:
+			details = append(details, fmt.Sprintf("%s: invalid", name))
+			if status == HealthStatusOK {
+				status = HealthStatusWarning
+			}
+		}
+	}
+	for name, info := range kubeconfig {
+		mark(name, info.Status, info.DaysRemaining)
+	}
+	for _, item := range items {
+		days := 0
+		if item.Certificate != nil {
+			days = item.Certificate.DaysRemaining
+		}
+		mark("Secret "+item.Namespace+"/"+item.Name, item.Status, days)
+	}
+	sort.Strings(details)
+	return healthResult(status, fmt.Sprintf("%d 个证书已过期、即将过期或无法解析", len(details)), details, "证书均在有效期内")
+}
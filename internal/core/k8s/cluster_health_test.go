@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvaluateNodes(t *testing.T) {
+	node := func(name string, conditions ...corev1.NodeCondition) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.NodeStatus{Conditions: conditions}}
+	}
+	ready := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}
+	notReady := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown}
+	diskPressure := corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue}
+
+	if got := evaluateNodes([]corev1.Node{node("a", ready), node("b", ready)}); got.Status != HealthStatusOK || len(got.Details) != 0 {
+		t.Fatalf("ready nodes should be ok: %+v", got)
+	}
+	got := evaluateNodes([]corev1.Node{node("a", ready, diskPressure), node("b", ready)})
+	if got.Status != HealthStatusWarning || len(got.Details) != 1 || got.Details[0] != "a: DiskPressure" {
+		t.Fatalf("pressure should be a warning: %+v", got)
+	}
+	got = evaluateNodes([]corev1.Node{node("a", ready, diskPressure), node("b", notReady)})
+	if got.Status != HealthStatusCritical || got.Message != "1/2 个节点未就绪" || len(got.Details) != 2 {
+		t.Fatalf("not ready node should be critical: %+v", got)
+	}
+}
+
+func TestEvaluateSystemPods(t *testing.T) {
+	pod := func(name string, statuses ...corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: statuses},
+		}
+	}
+	waiting := func(name, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, RestartCount: 12, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}}
+	}
+	healthy := corev1.ContainerStatus{Name: "dns", RestartCount: 1}
+
+	if got := evaluateSystemPods([]corev1.Pod{pod("coredns", healthy)}); got.Status != HealthStatusOK {
+		t.Fatalf("healthy pods should be ok: %+v", got)
+	}
+	got := evaluateSystemPods([]corev1.Pod{pod("coredns", healthy), pod("kube-proxy", waiting("proxy", "ImagePullBackOff"))})
+	if got.Status != HealthStatusWarning {
+		t.Fatalf("image pull failure should be a warning: %+v", got)
+	}
+	got = evaluateSystemPods([]corev1.Pod{pod("kube-proxy", waiting("proxy", "ImagePullBackOff")), pod("coredns", waiting("dns", "CrashLoopBackOff"))})
+	if got.Status != HealthStatusCritical || got.Details[0] != "coredns/dns: CrashLoopBackOff (restarts=12)" {
+		t.Fatalf("crash loop should be critical: %+v", got)
+	}
+}
+
+func TestEvaluateStorage(t *testing.T) {
+	now := time.Now()
+	pvc := func(name string, phase corev1.PersistentVolumeClaimPhase, age time.Duration) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+	bound := pvc("data", corev1.ClaimBound, time.Hour)
+	fresh := pvc("new", corev1.ClaimPending, time.Minute)
+	if got := evaluateStorage([]corev1.PersistentVolumeClaim{bound, fresh}, nil, now); got.Status != HealthStatusOK {
+		t.Fatalf("recently created pending claim should be ok: %+v", got)
+	}
+	stuck := pvc("stuck", corev1.ClaimPending, 10*time.Minute)
+	if got := evaluateStorage([]corev1.PersistentVolumeClaim{bound, stuck}, nil, now); got.Status != HealthStatusWarning {
+		t.Fatalf("long pending claim should be a warning: %+v", got)
+	}
+	failed := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}, Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeFailed}}
+	got := evaluateStorage([]corev1.PersistentVolumeClaim{stuck}, []corev1.PersistentVolume{failed}, now)
+	if got.Status != HealthStatusCritical || len(got.Details) != 2 {
+		t.Fatalf("failed volume should be critical: %+v", got)
+	}
+}
+
+func TestEvaluateCertificates(t *testing.T) {
+	items := []CertificateReportItem{
+		{Namespace: "web", Name: "soon", Status: CertificateExpiring, Certificate: &CertificateInfo{DaysRemaining: 5}},
+	}
+	got := evaluateCertificates(items, map[string]*CertificateInfo{"kubeconfig CA": {Status: CertificateValid}})
+	if got.Status != HealthStatusWarning || got.Details[0] != "Secret web/soon: 5 days remaining" {
+		t.Fatalf("expiring certificate should be a warning: %+v", got)
+	}
+	got = evaluateCertificates(items, map[string]*CertificateInfo{"kubeconfig client certificate": {Status: CertificateExpired}})
+	if got.Status != HealthStatusCritical || len(got.Details) != 2 {
+		t.Fatalf("expired client certificate should be critical: %+v", got)
+	}
+	if got := evaluateCertificates(nil, nil); got.Status != HealthStatusOK {
+		t.Fatalf("no certificates should be ok: %+v", got)
+	}
+}
+
+func TestHealthResultAndStatus(t *testing.T) {
+	details := make([]string, maxHealthDetails+5)
+	got := healthResult(HealthStatusWarning, "problems", details, "ok")
+	if len(got.Details) != maxHealthDetails+1 || !strings.Contains(got.Details[maxHealthDetails], "5") {
+		t.Fatalf("details should be truncated: %d %q", len(got.Details), got.Details[len(got.Details)-1])
+	}
+
+	if got := evaluateAPIServer(50*time.Millisecond, nil); got.Status != HealthStatusOK {
+		t.Fatalf("fast apiserver should be ok: %+v", got)
+	}
+	if got := evaluateAPIServer(2*time.Second, nil); got.Status != HealthStatusWarning {
+		t.Fatalf("slow apiserver should be a warning: %+v", got)
+	}
+	if got := evaluateAPIServer(time.Second, errors.New("connection refused")); got.Status != HealthStatusCritical {
+		t.Fatalf("unreachable apiserver should be critical: %+v", got)
+	}
+
+	earlier := time.Now().Add(-time.Hour)
+	previous := &ClusterHealth{Checks: []HealthCheck{
+		{Name: HealthCheckNodes, Status: HealthStatusOK, Since: earlier},
+		{Name: HealthCheckStorage, Status: HealthStatusOK, Since: earlier},
+	}}
+	now := time.Now()
+	checks := mergeHealthChecks(previous, []HealthCheck{
+		{Name: HealthCheckNodes, Status: HealthStatusOK, Since: now},
+		{Name: HealthCheckStorage, Status: HealthStatusWarning, Since: now},
+		{Name: HealthCheckCertificates, Status: HealthStatusUnknown, Since: now},
+	})
+	if !checks[0].Since.Equal(earlier) || !checks[1].Since.Equal(now) {
+		t.Fatalf("since should only be kept for unchanged statuses: %+v", checks)
+	}
+	if status := worstHealthStatus(checks); status != HealthStatusWarning {
+		t.Fatalf("worst status = %s", status)
+	}
+	if status := worstHealthStatus([]HealthCheck{checks[0], checks[2]}); status != HealthStatusUnknown {
+		t.Fatalf("unknown check without problems should be unknown, got %s", status)
+	}
+}
@@ -0,0 +1,270 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"kube-tide/internal/utils/logger"
+)
+
+// 集群历史数据来源
+const (
+	HistorySourcePrometheus = "prometheus" // 集群配置的 Prometheus（query_range）
+	HistorySourceLocal      = "local"      // kube-tide 后台定期采样
+)
+
+const (
+	// DefaultClusterHistoryWindow 历史数据时间窗口
+	DefaultClusterHistoryWindow = 24 * time.Hour
+	// DefaultClusterHistoryInterval 本地采样间隔，同时作为 Prometheus 查询步长
+	DefaultClusterHistoryInterval = 5 * time.Minute
+
+	clusterHistoryQueryTimeout = 15 * time.Second
+)
+
+// 集群级 PromQL：CPU/内存使用率依赖 node-exporter，Pod 数依赖 kube-state-metrics
+const (
+	clusterCPUUsageQuery    = `100 * (1 - avg(rate(node_cpu_seconds_total{mode="idle"}[5m])))`
+	clusterMemoryUsageQuery = `100 * (1 - sum(node_memory_MemAvailable_bytes) / sum(node_memory_MemTotal_bytes))`
+	clusterPodCountQuery    = `sum(kube_pod_info)`
+)
+
+// ClusterHistory 集群历史指标
+type ClusterHistory struct {
+	CPUUsage    []MetricDataPoint `json:"cpuUsage"`
+	MemoryUsage []MetricDataPoint `json:"memoryUsage"`
+	PodCount    []MetricDataPoint `json:"podCount"`
+}
+
+// clusterSample 一次本地采样
+type clusterSample struct {
+	time     time.Time
+	cpu      float64
+	memory   float64
+	measured bool // 使用率来自 metrics-server，未安装时只记录 Pod 数
+	pods     int
+}
+
+// ClusterHistoryService 集群历史指标服务：配置了 Prometheus 的集群从 Prometheus 查询，
+// 其余集群及 Prometheus 查询失败时使用后台采集器在内存中保存的采样
+type ClusterHistoryService struct {
+	clientManager *ClientManager
+	prometheus    *PrometheusService
+	window        time.Duration
+	interval      time.Duration
+	samples       map[string][]clusterSample
+	mutex         sync.RWMutex
+}
+
+// NewClusterHistoryService 创建集群历史指标服务
+func NewClusterHistoryService(clientManager *ClientManager, prometheus *PrometheusService) *ClusterHistoryService {
+	return &ClusterHistoryService{
+		clientManager: clientManager,
+		prometheus:    prometheus,
+		window:        DefaultClusterHistoryWindow,
+		interval:      DefaultClusterHistoryInterval,
+		samples:       make(map[string][]clusterSample),
+	}
+}
+
+// GetHistory 返回集群过去一个窗口的历史数据及其来源。
+// Prometheus 查询失败时回退到本地采样。
+func (s *ClusterHistoryService) GetHistory(ctx context.Context, clusterName string) (ClusterHistory, string) {
+	if s.clientManager.GetPrometheusURL(clusterName) != "" {
+		history, err := s.queryPrometheus(ctx, clusterName)
+		if err == nil {
+			return history, HistorySourcePrometheus
+		}
+		logger.Warn("查询Prometheus历史指标失败，使用本地采样", "cluster", clusterName, "error", err.Error())
+	}
+	return s.localHistory(clusterName), HistorySourceLocal
+}
+
+// Start 启动后台采集，每个采样周期遍历当前注册的全部集群
+func (s *ClusterHistoryService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		logger.Info("启动集群历史指标采集", "interval", s.interval.String())
+		s.collect(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("停止集群历史指标采集")
+				return
+			case <-ticker.C:
+				s.collect(ctx)
+			}
+		}
+	}()
+}
+
+func (s *ClusterHistoryService) collect(ctx context.Context) {
+	clusters := s.clientManager.ListClusters()
+	active := make(map[string]bool, len(clusters))
+	for _, clusterName := range clusters {
+		active[clusterName] = true
+		// 已接入 Prometheus 的集群同样采样，Prometheus 不可用时才有数据可回退
+		if err := s.sample(ctx, clusterName); err != nil {
+			logger.Warn("采集集群历史指标失败", "cluster", clusterName, "error", err.Error())
+		}
+	}
+
+	// 清理已移除集群的采样
+	s.mutex.Lock()
+	for clusterName := range s.samples {
+		if !active[clusterName] {
+			delete(s.samples, clusterName)
+		}
+	}
+	s.mutex.Unlock()
+}
+
+func (s *ClusterHistoryService) sample(ctx context.Context, clusterName string) error {
+	// 后台采集使用平台自身凭据，不经过用户模拟
+	client, err := s.clientManager.GetClient(clusterName)
+	if err != nil {
+		return err
+	}
+	config, err := s.clientManager.GetConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+	metrics, err := GetClusterMetrics(ctx, client, config)
+	if err != nil {
+		return err
+	}
+	s.record(clusterName, clusterSample{
+		time:     time.Now(),
+		cpu:      metrics.CPUUsage,
+		memory:   metrics.MemoryUsage,
+		measured: metrics.usageMeasured,
+		pods:     metrics.PodCount,
+	})
+	return nil
+}
+
+func (s *ClusterHistoryService) record(clusterName string, sample clusterSample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cutoff := sample.time.Add(-s.window)
+	samples := s.samples[clusterName]
+	start := 0
+	for start < len(samples) && samples[start].time.Before(cutoff) {
+		start++
+	}
+	s.samples[clusterName] = append(samples[start:], sample)
+}
+
+func (s *ClusterHistoryService) localHistory(clusterName string) ClusterHistory {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	history := ClusterHistory{
+		CPUUsage:    []MetricDataPoint{},
+		MemoryUsage: []MetricDataPoint{},
+		PodCount:    []MetricDataPoint{},
+	}
+	cutoff := time.Now().Add(-s.window)
+	for _, sample := range s.samples[clusterName] {
+		if sample.time.Before(cutoff) {
+			continue
+		}
+		timestamp := sample.time.Format(time.RFC3339)
+		if sample.measured {
+			history.CPUUsage = append(history.CPUUsage, MetricDataPoint{Timestamp: timestamp, Value: roundMetric(sample.cpu)})
+			history.MemoryUsage = append(history.MemoryUsage, MetricDataPoint{Timestamp: timestamp, Value: roundMetric(sample.memory)})
+		}
+		history.PodCount = append(history.PodCount, MetricDataPoint{Timestamp: timestamp, Value: float64(sample.pods)})
+	}
+	return history
+}
+
+func (s *ClusterHistoryService) queryPrometheus(ctx context.Context, clusterName string) (ClusterHistory, error) {
+	end := time.Now()
+	params := QueryRangeParams{
+		Start: strconv.FormatInt(end.Add(-s.window).Unix(), 10),
+		End:   strconv.FormatInt(end.Unix(), 10),
+		Step:  strconv.Itoa(int(s.interval.Seconds())),
+	}
+
+	var history ClusterHistory
+	for _, q := range []struct {
+		query  string
+		target *[]MetricDataPoint
+	}{
+		{clusterCPUUsageQuery, &history.CPUUsage},
+		{clusterMemoryUsageQuery, &history.MemoryUsage},
+		{clusterPodCountQuery, &history.PodCount},
+	} {
+		params.Query = q.query
+		raw, err := s.prometheus.QueryRange(ctx, clusterName, params, clusterHistoryQueryTimeout)
+		if err != nil {
+			return ClusterHistory{}, err
+		}
+		points, err := parseMatrixSeries(raw)
+		if err != nil {
+			return ClusterHistory{}, err
+		}
+		*q.target = points
+	}
+	return history, nil
+}
+
+type promMatrixResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]any           `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// parseMatrixSeries 解析 query_range 响应中的第一条序列（集群级查询经 sum/avg 聚合后只有一条）
+func parseMatrixSeries(raw json.RawMessage) ([]MetricDataPoint, error) {
+	var resp promMatrixResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("解析 Prometheus 响应失败: %w", err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("Prometheus 查询失败: %s", resp.Error)
+	}
+	points := make([]MetricDataPoint, 0)
+	if len(resp.Data.Result) == 0 {
+		return points, nil
+	}
+	for _, pair := range resp.Data.Result[0].Values {
+		if len(pair) < 2 {
+			continue
+		}
+		ts, ok := pair[0].(float64)
+		if !ok {
+			continue
+		}
+		value := instantValue(pair)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		points = append(points, MetricDataPoint{
+			Timestamp: time.Unix(int64(ts), 0).Format(time.RFC3339),
+			Value:     roundMetric(value),
+		})
+	}
+	return points, nil
+}
+
+func roundMetric(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseMatrixSeries(t *testing.T) {
+	raw := []byte(`{"status":"success","data":{"resultType":"matrix","result":[
+		{"metric":{},"values":[[1700000000,"42.345"],[1700000300,"NaN"],[1700000600,"50"]]}]}}`)
+	points, err := parseMatrixSeries(raw)
+	if err != nil {
+		t.Fatalf("parseMatrixSeries: %v", err)
+	}
+	if len(points) != 2 || points[0].Value != 42.3 || points[1].Value != 50 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+	if points[0].Timestamp != time.Unix(1700000000, 0).Format(time.RFC3339) {
+		t.Fatalf("unexpected timestamp %s", points[0].Timestamp)
+	}
+
+	if _, err := parseMatrixSeries([]byte(`{"status":"error","error":"bad query"}`)); err == nil {
+		t.Fatal("expected error for failed query")
+	}
+}
+
+func TestLocalClusterHistory(t *testing.T) {
+	s := NewClusterHistoryService(NewClientManager(), nil)
+	now := time.Now()
+
+	// 超出窗口的采样在写入时被丢弃
+	s.record("dev", clusterSample{time: now.Add(-25 * time.Hour), cpu: 10, measured: true, pods: 1})
+	s.record("dev", clusterSample{time: now.Add(-10 * time.Minute), cpu: 20, memory: 30, measured: true, pods: 5})
+	// 没有 metrics-server 时只记录 Pod 数
+	s.record("dev", clusterSample{time: now.Add(-5 * time.Minute), pods: 6})
+
+	history, source := s.GetHistory(context.Background(), "dev")
+	if source != HistorySourceLocal {
+		t.Fatalf("expected local source, got %s", source)
+	}
+	if len(history.CPUUsage) != 1 || history.CPUUsage[0].Value != 20 || len(history.MemoryUsage) != 1 {
+		t.Fatalf("unexpected usage history: %+v", history)
+	}
+	if len(history.PodCount) != 2 || history.PodCount[1].Value != 6 {
+		t.Fatalf("unexpected pod count history: %+v", history.PodCount)
+	}
+
+	if history, _ := s.GetHistory(context.Background(), "other"); len(history.PodCount) != 0 || history.CPUUsage == nil {
+		t.Fatalf("expected empty, non-nil history for unknown cluster: %+v", history)
+	}
+}
@@ -0,0 +1,215 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"kube-tide/internal/utils/secretbox"
+)
+
+// ClusterStore 集群注册信息的持久化接口，ClientManager 通过它在重启后恢复集群
+type ClusterStore interface {
+	// List 返回所有已保存的集群
+	List() ([]Cluster, error)
+	// Get 返回指定集群，不存在时返回错误
+	Get(name string) (Cluster, error)
+	// Save 新增或覆盖集群
+	Save(cluster Cluster) error
+	// Delete 删除集群，不存在时不报错
+	Delete(name string) error
+}
+
+// storedCluster 集群在文件中的表示，kubeconfig 内容以密文保存
+type storedCluster struct {
+	Name                string    `json:"name"`
+	AddType             string    `json:"addType"`
+	KubeconfigPath      string    `json:"kubeconfigPath,omitempty"`
+	EncryptedKubeconfig string    `json:"encryptedKubeconfig,omitempty"`
+	PrometheusURL       string    `json:"prometheusUrl,omitempty"`
+	Impersonate         bool      `json:"impersonate,omitempty"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
+
+type clusterStoreFile struct {
+	Clusters []storedCluster `json:"clusters"`
+}
+
+// FileClusterStore 基于本地 JSON 文件的 ClusterStore 默认实现
+type FileClusterStore struct {
+	path     string
+	box      *secretbox.Box
+	clusters map[string]storedCluster
+	mutex    sync.Mutex
+}
+
+// NewFileClusterStore 创建文件集群存储，文件不存在时在首次写入时创建
+func NewFileClusterStore(path string, box *secretbox.Box) (*FileClusterStore, error) {
+	if box == nil {
+		return nil, fmt.Errorf("cluster store requires an encryption box")
+	}
+	s := &FileClusterStore{
+		path:     path,
+		box:      box,
+		clusters: make(map[string]storedCluster),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileClusterStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cluster store: %w", err)
+	}
+	var file clusterStoreFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse cluster store %s: %w", s.path, err)
+	}
+	for _, c := range file.Clusters {
+		s.clusters[c.Name] = c
+	}
+	return nil
+}
+
+// flush 以临时文件+重命名的方式原子写入
+func (s *FileClusterStore) flush() error {
+	file := clusterStoreFile{Clusters: make([]storedCluster, 0, len(s.clusters))}
+	for _, c := range s.clusters {
+		file.Clusters = append(file.Clusters, c)
+	}
+	sort.Slice(file.Clusters, func(i, j int) bool { return file.Clusters[i].Name < file.Clusters[j].Name })
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cluster store: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create cluster store directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cluster store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace cluster store: %w", err)
+	}
+	return nil
+}
+
+func (s *FileClusterStore) decode(c storedCluster) (Cluster, error) {
+	cluster := Cluster{
+		Name:           c.Name,
+		AddType:        c.AddType,
+		KubeconfigPath: c.KubeconfigPath,
+		PrometheusURL:  c.PrometheusURL,
+		Impersonate:    c.Impersonate,
+	}
+	if c.EncryptedKubeconfig != "" {
+		content, err := s.box.Open(c.EncryptedKubeconfig)
+		if err != nil {
+			return Cluster{}, fmt.Errorf("failed to decrypt kubeconfig of cluster %s: %w", c.Name, err)
+		}
+		cluster.KubeconfigContent = string(content)
+	}
+	return cluster, nil
+}
+
+// List 返回所有已保存的集群（kubeconfig 内容已解密）
+func (s *FileClusterStore) List() ([]Cluster, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names := make([]string, 0, len(s.clusters))
+	for name := range s.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]Cluster, 0, len(names))
+	for _, name := range names {
+		cluster, err := s.decode(s.clusters[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cluster)
+	}
+	return result, nil
+}
+
+// Get 返回指定集群
+func (s *FileClusterStore) Get(name string) (Cluster, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c, exists := s.clusters[name]
+	if !exists {
+		return Cluster{}, fmt.Errorf("cluster %s not found in store", name)
+	}
+	return s.decode(c)
+}
+
+// Save 新增或覆盖集群
+func (s *FileClusterStore) Save(cluster Cluster) error {
+	record := storedCluster{
+		Name:           cluster.Name,
+		AddType:        cluster.AddType,
+		KubeconfigPath: cluster.KubeconfigPath,
+		PrometheusURL:  cluster.PrometheusURL,
+		Impersonate:    cluster.Impersonate,
+		UpdatedAt:      time.Now(),
+	}
+	if record.AddType == "" {
+		record.AddType = "path"
+	}
+	if record.AddType == "content" {
+		record.KubeconfigPath = ""
+		sealed, err := s.box.Seal([]byte(cluster.KubeconfigContent))
+		if err != nil {
+			return err
+		}
+		record.EncryptedKubeconfig = sealed
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, existed := s.clusters[cluster.Name]
+	s.clusters[cluster.Name] = record
+	if err := s.flush(); err != nil {
+		if existed {
+			s.clusters[cluster.Name] = previous
+		} else {
+			delete(s.clusters, cluster.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete 删除集群
+func (s *FileClusterStore) Delete(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, exists := s.clusters[name]
+	if !exists {
+		return nil
+	}
+	delete(s.clusters, name)
+	if err := s.flush(); err != nil {
+		s.clusters[name] = previous
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kube-tide/internal/utils/secretbox"
+)
+
+func TestFileClusterStore(t *testing.T) {
+	box, err := secretbox.NewFromPassphrase("test-passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "clusters.json")
+	store, err := NewFileClusterStore(path, box)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "apiVersion: v1\nkind: Config\nusers:\n- name: admin\n  user:\n    token: super-secret-token\n"
+	if err := store.Save(Cluster{Name: "prod", AddType: "content", KubeconfigContent: content, PrometheusURL: "http://prometheus.monitoring.svc:9090"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(Cluster{Name: "dev", KubeconfigPath: "/etc/kube/dev.yaml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "super-secret-token") {
+		t.Fatal("kubeconfig content stored in plaintext")
+	}
+
+	// Reopen to simulate a restart
+	reopened, err := NewFileClusterStore(path, box)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusters, err := reopened.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 2 || clusters[0].Name != "dev" || clusters[1].Name != "prod" {
+		t.Fatalf("unexpected clusters: %+v", clusters)
+	}
+	if clusters[0].AddType != "path" || clusters[0].KubeconfigPath != "/etc/kube/dev.yaml" {
+		t.Errorf("path cluster not restored: %+v", clusters[0])
+	}
+	if clusters[1].KubeconfigContent != content || clusters[1].PrometheusURL != "http://prometheus.monitoring.svc:9090" {
+		t.Errorf("content cluster not restored: %+v", clusters[1])
+	}
+
+	if err := reopened.Delete("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Get("prod"); err == nil {
+		t.Error("expected deleted cluster to be gone")
+	}
+
+	other, _ := secretbox.NewFromPassphrase("other-passphrase")
+	wrongKey, err := NewFileClusterStore(path, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wrongKey.List(); err != nil {
+		t.Fatalf("path-only clusters should not need decryption: %v", err)
+	}
+}
+
+// flakyClusterStore 内存中的集群存储，fail 为 true 时保存失败
+type flakyClusterStore struct {
+	clusters map[string]Cluster
+	fail     bool
+}
+
+func (s *flakyClusterStore) List() ([]Cluster, error) { return nil, nil }
+
+func (s *flakyClusterStore) Get(name string) (Cluster, error) {
+	cluster, ok := s.clusters[name]
+	if !ok {
+		return Cluster{}, errors.New("not found")
+	}
+	return cluster, nil
+}
+
+func (s *flakyClusterStore) Save(cluster Cluster) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	s.clusters[cluster.Name] = cluster
+	return nil
+}
+
+func (s *flakyClusterStore) Delete(name string) error {
+	delete(s.clusters, name)
+	return nil
+}
+
+func TestReAddClusterRegistration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/version" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"major":"1","minor":"36","gitVersion":"v1.36.0"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	content := "apiVersion: v1\nkind: Config\nclusters:\n- name: dev\n  cluster:\n    server: " + server.URL +
+		"\ncontexts:\n- name: dev\n  context:\n    cluster: dev\n    user: dev\ncurrent-context: dev\nusers:\n- name: dev\n  user:\n    token: test\n"
+
+	store := &flakyClusterStore{clusters: map[string]Cluster{}}
+	cm := NewClientManager(WithClusterStore(store))
+	if err := cm.AddClusterWithOptions(Cluster{Name: "dev", AddType: "content", KubeconfigContent: content, PrometheusURL: "http://prometheus:9090", Impersonate: true}); err != nil {
+		t.Fatal(err)
+	}
+	working := cm.clients["dev"]
+	cm.discovery["dev"] = &clusterDiscovery{}
+
+	// 保存失败时恢复之前可用的注册信息，而不是移除集群
+	store.fail = true
+	if err := cm.AddClusterWithOptions(Cluster{Name: "dev", AddType: "content", KubeconfigContent: content}); err == nil {
+		t.Fatal("expected the store failure to be returned")
+	}
+	if cm.clients["dev"] != working || cm.addTypes["dev"] != "content" || cm.GetPrometheusURL("dev") != "http://prometheus:9090" || !cm.IsImpersonationEnabled("dev") {
+		t.Fatalf("previous registration should be restored: url=%q impersonate=%v", cm.GetPrometheusURL("dev"), cm.IsImpersonationEnabled("dev"))
+	}
+	if err := cm.AddClusterWithOptions(Cluster{Name: "new", AddType: "content", KubeconfigContent: content}); err == nil {
+		t.Fatal("expected the store failure to be returned")
+	}
+	if _, err := cm.GetClient("new"); err == nil {
+		t.Fatal("a new cluster should not stay registered when it cannot be saved")
+	}
+
+	// 重新注册以本次参数为准：清空发现缓存，Prometheus URL 为空时不沿用旧值
+	store.fail = false
+	cm.discovery["dev"] = &clusterDiscovery{}
+	if err := cm.AddClusterWithOptions(Cluster{Name: "dev", AddType: "content", KubeconfigContent: content}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.discovery["dev"]; ok {
+		t.Error("discovery cache should be reset on re-registration")
+	}
+	if url := cm.GetPrometheusURL("dev"); url != "" || store.clusters["dev"].PrometheusURL != "" {
+		t.Errorf("Prometheus URL should be cleared, got %q", url)
+	}
+	if cm.IsImpersonationEnabled("dev") {
+		t.Error("impersonation should follow the new registration")
+	}
+}
@@ -0,0 +1,239 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// 被引用的配置类型
+const (
+	ConfigKindConfigMap = "ConfigMap"
+	ConfigKindSecret    = "Secret"
+)
+
+// 引用方式
+const (
+	ConfigUsageEnvFrom         = "envFrom"
+	ConfigUsageEnv             = "env"
+	ConfigUsageVolume          = "volume"
+	ConfigUsageProjected       = "projected"
+	ConfigUsageImagePullSecret = "imagePullSecret"
+)
+
+// 重启结果
+const (
+	ConsumerRestarted = "restarted"
+	ConsumerSkipped   = "skipped"
+	ConsumerFailed    = "failed"
+)
+
+// ConfigUsage 工作负载中一处对 ConfigMap/Secret 的引用
+type ConfigUsage struct {
+	Type      string `json:"type"`
+	Container string `json:"container,omitempty"`
+	Key       string `json:"key,omitempty"`    // env.valueFrom 引用的键
+	Env       string `json:"env,omitempty"`    // env.valueFrom 设置的环境变量
+	Volume    string `json:"volume,omitempty"` // volume / projected 所在的卷
+	Optional  bool   `json:"optional,omitempty"`
+}
+
+// ConfigConsumer 引用某个 ConfigMap/Secret 的工作负载
+type ConfigConsumer struct {
+	Kind        string        `json:"kind"`
+	Name        string        `json:"name"`
+	Namespace   string        `json:"namespace"`
+	Restartable bool          `json:"restartable"` // Job / CronJob 不支持重启，修改在下次创建 Pod 时生效
+	Usages      []ConfigUsage `json:"usages"`
+}
+
+// ConsumerRestartResult 重启引用方的结果
+type ConsumerRestartResult struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ConfigConsumerService 查找引用 ConfigMap/Secret 的工作负载，并在配置更新后重启它们
+type ConfigConsumerService struct {
+	clientManager *ClientManager
+	deployments   *DeploymentService
+	statefulSets  *StatefulSetService
+	daemonSets    *DaemonSetService
+}
+
+// NewConfigConsumerService 创建配置引用查询服务，重启复用各工作负载服务的重启逻辑
+func NewConfigConsumerService(clientManager *ClientManager, deployments *DeploymentService, statefulSets *StatefulSetService, daemonSets *DaemonSetService) *ConfigConsumerService {
+	return &ConfigConsumerService{
+		clientManager: clientManager,
+		deployments:   deployments,
+		statefulSets:  statefulSets,
+		daemonSets:    daemonSets,
+	}
+}
+
+// findConfigUsages 在 Pod 模板中查找对指定 ConfigMap/Secret 的引用：
+// envFrom、env.valueFrom、configMap/secret 卷、projected 卷，以及 Secret 的 imagePullSecrets
+func findConfigUsages(spec *corev1.PodSpec, kind, name string) []ConfigUsage {
+	var usages []ConfigUsage
+	optional := func(b *bool) bool { return b != nil && *b }
+
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, c := range containers {
+		for _, from := range c.EnvFrom {
+			switch {
+			case kind == ConfigKindConfigMap && from.ConfigMapRef != nil && from.ConfigMapRef.Name == name:
+				usages = append(usages, ConfigUsage{Type: ConfigUsageEnvFrom, Container: c.Name, Optional: optional(from.ConfigMapRef.Optional)})
+			case kind == ConfigKindSecret && from.SecretRef != nil && from.SecretRef.Name == name:
+				usages = append(usages, ConfigUsage{Type: ConfigUsageEnvFrom, Container: c.Name, Optional: optional(from.SecretRef.Optional)})
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			switch ref := env.ValueFrom; {
+			case kind == ConfigKindConfigMap && ref.ConfigMapKeyRef != nil && ref.ConfigMapKeyRef.Name == name:
+				usages = append(usages, ConfigUsage{Type: ConfigUsageEnv, Container: c.Name, Env: env.Name, Key: ref.ConfigMapKeyRef.Key, Optional: optional(ref.ConfigMapKeyRef.Optional)})
+			case kind == ConfigKindSecret && ref.SecretKeyRef != nil && ref.SecretKeyRef.Name == name:
+				usages = append(usages, ConfigUsage{Type: ConfigUsageEnv, Container: c.Name, Env: env.Name, Key: ref.SecretKeyRef.Key, Optional: optional(ref.SecretKeyRef.Optional)})
+			}
+		}
+	}
+
+	for _, vol := range spec.Volumes {
+		switch {
+		case kind == ConfigKindConfigMap && vol.ConfigMap != nil && vol.ConfigMap.Name == name:
+			usages = append(usages, ConfigUsage{Type: ConfigUsageVolume, Volume: vol.Name, Optional: optional(vol.ConfigMap.Optional)})
+		case kind == ConfigKindSecret && vol.Secret != nil && vol.Secret.SecretName == name:
+			usages = append(usages, ConfigUsage{Type: ConfigUsageVolume, Volume: vol.Name, Optional: optional(vol.Secret.Optional)})
+		case vol.Projected != nil:
+			for _, source := range vol.Projected.Sources {
+				switch {
+				case kind == ConfigKindConfigMap && source.ConfigMap != nil && source.ConfigMap.Name == name:
+					usages = append(usages, ConfigUsage{Type: ConfigUsageProjected, Volume: vol.Name, Optional: optional(source.ConfigMap.Optional)})
+				case kind == ConfigKindSecret && source.Secret != nil && source.Secret.Name == name:
+					usages = append(usages, ConfigUsage{Type: ConfigUsageProjected, Volume: vol.Name, Optional: optional(source.Secret.Optional)})
+				}
+			}
+		}
+	}
+
+	if kind == ConfigKindSecret {
+		for _, ref := range spec.ImagePullSecrets {
+			if ref.Name == name {
+				usages = append(usages, ConfigUsage{Type: ConfigUsageImagePullSecret})
+			}
+		}
+	}
+	return usages
+}
+
+// FindConsumers 扫描命名空间内 Deployment、StatefulSet、DaemonSet、Job、CronJob 的 Pod 模板，返回引用指定 ConfigMap/Secret 的工作负载。
+// 由 CronJob 创建的 Job 与其 CronJob 使用同一模板，不单独列出。
+func (s *ConfigConsumerService) FindConsumers(ctx context.Context, clusterName, namespace, kind, name string) ([]ConfigConsumer, error) {
+	if kind != ConfigKindConfigMap && kind != ConfigKindSecret {
+		return nil, fmt.Errorf("不支持的配置类型: %s", kind)
+	}
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	consumers := []ConfigConsumer{}
+	add := func(workloadKind, workloadName string, restartable bool, spec *corev1.PodSpec) {
+		if usages := findConfigUsages(spec, kind, name); len(usages) > 0 {
+			consumers = append(consumers, ConfigConsumer{
+				Kind:        workloadKind,
+				Name:        workloadName,
+				Namespace:   namespace,
+				Restartable: restartable,
+				Usages:      usages,
+			})
+		}
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Deployment 列表失败: %w", err)
+	}
+	for i := range deployments.Items {
+		add("Deployment", deployments.Items[i].Name, true, &deployments.Items[i].Spec.Template.Spec)
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 StatefulSet 列表失败: %w", err)
+	}
+	for i := range statefulSets.Items {
+		add("StatefulSet", statefulSets.Items[i].Name, true, &statefulSets.Items[i].Spec.Template.Spec)
+	}
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 DaemonSet 列表失败: %w", err)
+	}
+	for i := range daemonSets.Items {
+		add("DaemonSet", daemonSets.Items[i].Name, true, &daemonSets.Items[i].Spec.Template.Spec)
+	}
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 CronJob 列表失败: %w", err)
+	}
+	for i := range cronJobs.Items {
+		add("CronJob", cronJobs.Items[i].Name, false, &cronJobs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+	jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Job 列表失败: %w", err)
+	}
+	for i := range jobs.Items {
+		if owner := metav1.GetControllerOf(&jobs.Items[i]); owner != nil && owner.Kind == "CronJob" {
+			continue
+		}
+		add("Job", jobs.Items[i].Name, false, &jobs.Items[i].Spec.Template.Spec)
+	}
+
+	sort.SliceStable(consumers, func(i, j int) bool {
+		if consumers[i].Kind != consumers[j].Kind {
+			return consumers[i].Kind < consumers[j].Kind
+		}
+		return consumers[i].Name < consumers[j].Name
+	})
+	return consumers, nil
+}
+
+// RestartConsumers 滚动重启引用指定 ConfigMap/Secret 的 Deployment、StatefulSet 与 DaemonSet，
+// 使以环境变量或 subPath 方式引用的配置生效；Job / CronJob 跳过。单个工作负载失败不影响其余工作负载。
+func (s *ConfigConsumerService) RestartConsumers(ctx context.Context, clusterName, namespace, kind, name string) ([]ConsumerRestartResult, error) {
+	consumers, err := s.FindConsumers(ctx, clusterName, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ConsumerRestartResult, 0, len(consumers))
+	for _, consumer := range consumers {
+		result := ConsumerRestartResult{Kind: consumer.Kind, Name: consumer.Name, Status: ConsumerRestarted}
+		var err error
+		switch consumer.Kind {
+		case "Deployment":
+			err = s.deployments.RestartDeployment(ctx, clusterName, namespace, consumer.Name)
+		case "StatefulSet":
+			_, err = s.statefulSets.RestartStatefulSet(ctx, clusterName, namespace, consumer.Name)
+		case "DaemonSet":
+			err = s.daemonSets.RestartDaemonSet(ctx, clusterName, namespace, consumer.Name)
+		default:
+			result.Status = ConsumerSkipped
+			result.Message = "Job / CronJob 不支持重启，修改在下次创建 Pod 时生效"
+		}
+		if err != nil {
+			result.Status = ConsumerFailed
+			result.Message = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFindConfigUsages(t *testing.T) {
+	optional := true
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name:    "migrate",
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "web",
+			Env: []corev1.EnvVar{
+				{Name: "LOG_LEVEL", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}, Key: "level", Optional: &optional,
+				}}},
+				{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password",
+				}}},
+				{Name: "PLAIN", Value: "app-config"},
+			},
+		}},
+		Volumes: []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}}},
+			{Name: "bundle", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}},
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}}},
+			}}}},
+			{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "app-config"}}},
+		},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "db"}},
+	}
+
+	usages := findConfigUsages(spec, ConfigKindConfigMap, "app-config")
+	want := []ConfigUsage{
+		{Type: ConfigUsageEnvFrom, Container: "migrate"},
+		{Type: ConfigUsageEnv, Container: "web", Env: "LOG_LEVEL", Key: "level", Optional: true},
+		{Type: ConfigUsageVolume, Volume: "config"},
+		{Type: ConfigUsageProjected, Volume: "bundle"},
+	}
+	if len(usages) != len(want) {
+		t.Fatalf("unexpected configmap usages: %+v", usages)
+	}
+	for i := range want {
+		if usages[i] != want[i] {
+			t.Errorf("usage %d = %+v, want %+v", i, usages[i], want[i])
+		}
+	}
+
+	secretUsages := findConfigUsages(spec, ConfigKindSecret, "db")
+	types := make([]string, 0, len(secretUsages))
+	for _, u := range secretUsages {
+		types = append(types, u.Type)
+	}
+	if len(types) != 3 || types[0] != ConfigUsageEnv || types[1] != ConfigUsageProjected || types[2] != ConfigUsageImagePullSecret {
+		t.Fatalf("unexpected secret usages: %+v", secretUsages)
+	}
+
+	if got := findConfigUsages(spec, ConfigKindSecret, "missing"); len(got) != 0 {
+		t.Fatalf("unreferenced secret should have no usages: %+v", got)
+	}
+}
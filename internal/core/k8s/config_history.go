@@ -0,0 +1,337 @@
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"kube-tide/internal/core/auth"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrConfigVersionNotFound 指定的历史版本不存在
+var ErrConfigVersionNotFound = errors.New("历史版本不存在")
+
+// ErrInvalidConfigVersion 版本参数不是正整数或 current
+var ErrInvalidConfigVersion = errors.New("无效的版本号")
+
+// MaxConfigVersions 每个 ConfigMap / Secret 保留的历史版本数量，超出时删除最旧的版本
+const MaxConfigVersions = 20
+
+// ConfigVersionCurrent 对比时表示集群中的当前版本
+const ConfigVersionCurrent = "current"
+
+// 覆盖历史版本的操作
+const (
+	ConfigActionUpdate  = "update"
+	ConfigActionRestore = "restore"
+)
+
+// 键的变化类型
+const (
+	ConfigKeyAdded    = "added"
+	ConfigKeyRemoved  = "removed"
+	ConfigKeyModified = "modified"
+)
+
+// ConfigVersion 历史版本摘要，记录的是被修改前的内容
+type ConfigVersion struct {
+	Cluster         string    `json:"cluster"`
+	Kind            string    `json:"kind"`
+	Namespace       string    `json:"namespace"`
+	Name            string    `json:"name"`
+	Version         int       `json:"version"`
+	ResourceVersion string    `json:"resourceVersion"`
+	Keys            []string  `json:"keys"`
+	Action          string    `json:"action"` // 覆盖该版本的操作：update / restore
+	User            string    `json:"user,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// ConfigSnapshot 历史版本的完整内容。
+// ConfigMap 保存 data；Secret 的数据只在内存中以 SecretData 明文存在，落盘时加密为 SealedData。
+type ConfigSnapshot struct {
+	ConfigVersion
+	Labels     map[string]string `json:"labels,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	SecretData map[string][]byte `json:"-"`
+	SealedData string            `json:"sealedData,omitempty"`
+}
+
+// ConfigKeyDiff 单个键的差异。ConfigMap 给出统一格式差异，Secret 只给出大小与 SHA-256，不包含明文
+type ConfigKeyDiff struct {
+	Key       string `json:"key"`
+	Change    string `json:"change"`
+	OldSize   int    `json:"oldSize"`
+	NewSize   int    `json:"newSize"`
+	OldSHA256 string `json:"oldSha256,omitempty"`
+	NewSHA256 string `json:"newSha256,omitempty"`
+	Diff      string `json:"diff,omitempty"`
+}
+
+// ConfigVersionDiff 两个版本之间按键的差异，只包含有变化的键
+type ConfigVersionDiff struct {
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	Keys          []ConfigKeyDiff   `json:"keys"`
+	LabelsChanged bool              `json:"labelsChanged"`
+	FromLabels    map[string]string `json:"fromLabels,omitempty"`
+	ToLabels      map[string]string `json:"toLabels,omitempty"`
+}
+
+// ConfigHistory 在更新 ConfigMap / Secret 前保存修改前的内容，供查看、对比与恢复
+type ConfigHistory struct {
+	store ConfigHistoryStore
+	// 同一对象的记录串行执行，避免并发更新分配到相同的版本号
+	locks map[string]*objectLock
+	mutex sync.Mutex
+}
+
+// objectLock 按对象加锁，没有等待者时从 locks 中移除
+type objectLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// NewConfigHistory 创建配置历史，store 为 nil 时不记录历史
+func NewConfigHistory(store ConfigHistoryStore) *ConfigHistory {
+	return &ConfigHistory{store: store, locks: make(map[string]*objectLock)}
+}
+
+// lockObject 锁定对象的历史记录，返回解锁函数
+func (h *ConfigHistory) lockObject(cluster, kind, namespace, name string) func() {
+	key := cluster + "/" + kind + "/" + namespace + "/" + name
+	h.mutex.Lock()
+	lock, ok := h.locks[key]
+	if !ok {
+		lock = &objectLock{}
+		h.locks[key] = lock
+	}
+	lock.waiters++
+	h.mutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		h.mutex.Lock()
+		if lock.waiters--; lock.waiters == 0 {
+			delete(h.locks, key)
+		}
+		h.mutex.Unlock()
+	}
+}
+
+func (h *ConfigHistory) enabled() bool {
+	return h != nil && h.store != nil
+}
+
+// record 保存修改前的内容。与最近一个版本的 resourceVersion 相同时视为已保存，不重复记录
+func (h *ConfigHistory) record(ctx context.Context, snapshot ConfigSnapshot) error {
+	if !h.enabled() {
+		return nil
+	}
+	unlock := h.lockObject(snapshot.Cluster, snapshot.Kind, snapshot.Namespace, snapshot.Name)
+	defer unlock()
+	existing, err := h.store.List(snapshot.Cluster, snapshot.Kind, snapshot.Namespace, snapshot.Name)
+	if err != nil {
+		return err
+	}
+	snapshot.Version = 1
+	if n := len(existing); n > 0 {
+		if existing[n-1].ResourceVersion == snapshot.ResourceVersion {
+			return nil
+		}
+		snapshot.Version = existing[n-1].Version + 1
+	}
+	snapshot.CreatedAt = time.Now()
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		snapshot.User = identity.Username
+	}
+	if err := h.store.Save(snapshot); err != nil {
+		return err
+	}
+	if overflow := len(existing) + 1 - MaxConfigVersions; overflow > 0 {
+		versions := make([]int, 0, overflow)
+		for _, old := range existing[:overflow] {
+			versions = append(versions, old.Version)
+		}
+		return h.store.Delete(snapshot.Cluster, snapshot.Kind, snapshot.Namespace, snapshot.Name, versions...)
+	}
+	return nil
+}
+
+// versions 按版本号倒序返回历史版本摘要
+func (h *ConfigHistory) versions(cluster, kind, namespace, name string) ([]ConfigVersion, error) {
+	result := []ConfigVersion{}
+	if !h.enabled() {
+		return result, nil
+	}
+	snapshots, err := h.store.List(cluster, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		result = append(result, snapshots[i].ConfigVersion)
+	}
+	return result, nil
+}
+
+// snapshot 获取指定版本的完整内容
+func (h *ConfigHistory) snapshot(cluster, kind, namespace, name string, version int) (*ConfigSnapshot, error) {
+	if !h.enabled() {
+		return nil, fmt.Errorf("%w: %d", ErrConfigVersionNotFound, version)
+	}
+	snapshots, err := h.store.List(cluster, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshots {
+		if snapshots[i].Version == version {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrConfigVersionNotFound, version)
+}
+
+// parseConfigVersion 解析版本参数，空字符串或 current 表示当前版本（返回 0）
+func parseConfigVersion(ref string) (int, error) {
+	if ref == "" || ref == ConfigVersionCurrent {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(ref)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidConfigVersion, ref)
+	}
+	return version, nil
+}
+
+// resolveVersion 取历史版本或当前对象（version 为 0）的内容
+func (h *ConfigHistory) resolveVersion(current *ConfigSnapshot, ref string) (*ConfigSnapshot, string, error) {
+	version, err := parseConfigVersion(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	if version == 0 {
+		return current, ConfigVersionCurrent, nil
+	}
+	snapshot, err := h.snapshot(current.Cluster, current.Kind, current.Namespace, current.Name, version)
+	if err != nil {
+		return nil, "", err
+	}
+	return snapshot, strconv.Itoa(version), nil
+}
+
+// diff 对比两个版本，from / to 为版本号或 current
+func (h *ConfigHistory) diff(current *ConfigSnapshot, from, to string) (*ConfigVersionDiff, error) {
+	before, fromRef, err := h.resolveVersion(current, from)
+	if err != nil {
+		return nil, err
+	}
+	after, toRef, err := h.resolveVersion(current, to)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := diffConfigSnapshots(before, after)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigVersionDiff{
+		From:          fromRef,
+		To:            toRef,
+		Keys:          keys,
+		LabelsChanged: !maps.Equal(before.Labels, after.Labels),
+		FromLabels:    before.Labels,
+		ToLabels:      after.Labels,
+	}, nil
+}
+
+// snapshotValues 以字节形式返回快照中的数据，masked 表示值不能出现在差异中
+func snapshotValues(s *ConfigSnapshot) (map[string][]byte, bool) {
+	if s.Kind == ConfigKindSecret {
+		return s.SecretData, true
+	}
+	values := make(map[string][]byte, len(s.Data))
+	for k, v := range s.Data {
+		values[k] = []byte(v)
+	}
+	return values, false
+}
+
+// diffConfigSnapshots 按键对比两个版本，结果按键名排序
+func diffConfigSnapshots(before, after *ConfigSnapshot) ([]ConfigKeyDiff, error) {
+	oldValues, masked := snapshotValues(before)
+	newValues, _ := snapshotValues(after)
+	keys := make(map[string]bool, len(oldValues)+len(newValues))
+	for k := range oldValues {
+		keys[k] = true
+	}
+	for k := range newValues {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	diffs := []ConfigKeyDiff{}
+	for _, key := range sorted {
+		oldValue, inOld := oldValues[key]
+		newValue, inNew := newValues[key]
+		d := ConfigKeyDiff{Key: key, OldSize: len(oldValue), NewSize: len(newValue)}
+		switch {
+		case !inOld:
+			d.Change = ConfigKeyAdded
+		case !inNew:
+			d.Change = ConfigKeyRemoved
+		case string(oldValue) == string(newValue):
+			continue
+		default:
+			d.Change = ConfigKeyModified
+		}
+		if masked {
+			if inOld {
+				d.OldSHA256 = sha256Hex(oldValue)
+			}
+			if inNew {
+				d.NewSHA256 = sha256Hex(newValue)
+			}
+		} else {
+			text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(oldValue)),
+				B:        difflib.SplitLines(string(newValue)),
+				FromFile: "a/" + key,
+				ToFile:   "b/" + key,
+				Context:  3,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("生成差异失败: %w", err)
+			}
+			d.Diff = text
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
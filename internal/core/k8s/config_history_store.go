@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sync"
+
+	"kube-tide/internal/utils/secretbox"
+)
+
+// ConfigHistoryStore ConfigMap / Secret 历史版本的持久化接口
+type ConfigHistoryStore interface {
+	// List 按版本号升序返回对象的所有历史版本
+	List(cluster, kind, namespace, name string) ([]ConfigSnapshot, error)
+	// Save 保存一个历史版本（按对象与版本号唯一）
+	Save(snapshot ConfigSnapshot) error
+	// Delete 删除对象的指定历史版本
+	Delete(cluster, kind, namespace, name string, versions ...int) error
+}
+
+// FileConfigHistoryStore 基于本地 JSON 文件的 ConfigHistoryStore 默认实现。
+// 每个对象一个文件：<dir>/<cluster>/<kind>/<namespace>/<name>.json，Secret 的数据加密后保存。
+type FileConfigHistoryStore struct {
+	dir   string
+	box   *secretbox.Box
+	files map[string]*recordFile[ConfigSnapshot]
+	mutex sync.Mutex
+}
+
+// NewFileConfigHistoryStore 创建文件历史版本存储，目录不存在时在首次写入时创建
+func NewFileConfigHistoryStore(dir string, box *secretbox.Box) (*FileConfigHistoryStore, error) {
+	if box == nil {
+		return nil, fmt.Errorf("config history store requires an encryption box")
+	}
+	return &FileConfigHistoryStore{
+		dir:   dir,
+		box:   box,
+		files: make(map[string]*recordFile[ConfigSnapshot]),
+	}, nil
+}
+
+func configSnapshotKey(s ConfigSnapshot) string {
+	return fmt.Sprintf("%06d", s.Version)
+}
+
+// file 打开对象对应的记录文件，路径中的各段经过转义，不会跳出存储目录
+func (s *FileConfigHistoryStore) file(cluster, kind, namespace, name string) (*recordFile[ConfigSnapshot], error) {
+	parts := []string{cluster, kind, namespace, name}
+	for i, part := range parts {
+		escaped := url.PathEscape(part)
+		if escaped == "" || escaped == "." || escaped == ".." {
+			return nil, fmt.Errorf("invalid history path segment %q", part)
+		}
+		parts[i] = escaped
+	}
+	path := filepath.Join(s.dir, parts[0], parts[1], parts[2], parts[3]+".json")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if f, ok := s.files[path]; ok {
+		return f, nil
+	}
+	f, err := openRecordFile(path, "versions", configSnapshotKey)
+	if err != nil {
+		return nil, err
+	}
+	s.files[path] = f
+	return f, nil
+}
+
+// List 按版本号升序返回对象的所有历史版本，Secret 数据已解密
+func (s *FileConfigHistoryStore) List(cluster, kind, namespace, name string) ([]ConfigSnapshot, error) {
+	f, err := s.file(cluster, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := f.list()
+	for i := range snapshots {
+		if snapshots[i].SealedData == "" {
+			continue
+		}
+		plain, err := s.box.Open(snapshots[i].SealedData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s/%s version %d: %w", namespace, name, snapshots[i].Version, err)
+		}
+		if err := json.Unmarshal(plain, &snapshots[i].SecretData); err != nil {
+			return nil, fmt.Errorf("failed to decode %s/%s version %d: %w", namespace, name, snapshots[i].Version, err)
+		}
+		snapshots[i].SealedData = ""
+	}
+	return snapshots, nil
+}
+
+// Save 保存一个历史版本，Secret 数据加密后写入
+func (s *FileConfigHistoryStore) Save(snapshot ConfigSnapshot) error {
+	f, err := s.file(snapshot.Cluster, snapshot.Kind, snapshot.Namespace, snapshot.Name)
+	if err != nil {
+		return err
+	}
+	if snapshot.SecretData != nil {
+		plain, err := json.Marshal(snapshot.SecretData)
+		if err != nil {
+			return fmt.Errorf("failed to encode secret data: %w", err)
+		}
+		if snapshot.SealedData, err = s.box.Seal(plain); err != nil {
+			return err
+		}
+		snapshot.SecretData = nil
+	}
+	return f.save(snapshot)
+}
+
+// Delete 删除对象的指定历史版本
+func (s *FileConfigHistoryStore) Delete(cluster, kind, namespace, name string, versions ...int) error {
+	f, err := s.file(cluster, kind, namespace, name)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(versions))
+	for _, v := range versions {
+		keys = append(keys, configSnapshotKey(ConfigSnapshot{ConfigVersion: ConfigVersion{Version: v}}))
+	}
+	return f.remove(keys...)
+}
@@ -0,0 +1,194 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"kube-tide/internal/utils/secretbox"
+)
+
+func TestFileConfigHistoryStore(t *testing.T) {
+	box, err := secretbox.NewFromPassphrase("test-passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	store, err := NewFileConfigHistoryStore(dir, box)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := ConfigSnapshot{
+		ConfigVersion: ConfigVersion{Cluster: "prod", Kind: ConfigKindSecret, Namespace: "app", Name: "db", Version: 1},
+		SecretData:    map[string][]byte{"password": []byte("super-secret-password")},
+	}
+	if err := store.Save(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "prod", "Secret", "app", "db.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "super-secret-password") || strings.Contains(string(raw), "c3VwZXItc2VjcmV0LXBhc3N3b3Jk") {
+		t.Fatal("secret history stored in plaintext")
+	}
+
+	reopened, err := NewFileConfigHistoryStore(dir, box)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := reopened.List("prod", ConfigKindSecret, "app", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || string(snapshots[0].SecretData["password"]) != "super-secret-password" || snapshots[0].SealedData != "" {
+		t.Fatalf("unexpected snapshots after reopen: %+v", snapshots)
+	}
+
+	if _, err := reopened.List("prod", ConfigKindSecret, "..", "db"); err == nil {
+		t.Fatal("path traversal segment should be rejected")
+	}
+}
+
+func TestConfigHistoryRecord(t *testing.T) {
+	box, err := secretbox.NewFromPassphrase("test-passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewFileConfigHistoryStore(t.TempDir(), box)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := NewConfigHistory(store)
+	ctx := context.Background()
+	snapshot := func(rv int) ConfigSnapshot {
+		return ConfigSnapshot{
+			ConfigVersion: ConfigVersion{Cluster: "prod", Kind: ConfigKindConfigMap, Namespace: "app", Name: "web", ResourceVersion: strconv.Itoa(rv)},
+			Data:          map[string]string{"rv": strconv.Itoa(rv)},
+		}
+	}
+
+	for rv := 1; rv <= MaxConfigVersions+5; rv++ {
+		if err := history.record(ctx, snapshot(rv)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 同一 resourceVersion 不重复记录
+	if err := history.record(ctx, snapshot(MaxConfigVersions+5)); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := history.versions("prod", ConfigKindConfigMap, "app", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != MaxConfigVersions || versions[0].Version != MaxConfigVersions+5 || versions[len(versions)-1].Version != 6 {
+		t.Fatalf("unexpected retention: %d versions, newest %d", len(versions), versions[0].Version)
+	}
+	if _, err := history.snapshot("prod", ConfigKindConfigMap, "app", "web", 1); !errors.Is(err, ErrConfigVersionNotFound) {
+		t.Fatalf("pruned version should not be found, got %v", err)
+	}
+
+	current := snapshot(100)
+	diff, err := history.diff(&current, "10", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.From != "10" || diff.To != ConfigVersionCurrent || len(diff.Keys) != 1 || diff.Keys[0].Change != ConfigKeyModified {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+	if _, err := history.diff(&current, "latest", ""); !errors.Is(err, ErrInvalidConfigVersion) {
+		t.Fatalf("invalid version should be rejected, got %v", err)
+	}
+
+	var disabled *ConfigHistory
+	if err := disabled.record(ctx, snapshot(1)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// slowListStore 延迟 List 返回，扩大 List 与 Save 之间的并发窗口
+type slowListStore struct {
+	ConfigHistoryStore
+}
+
+func (s slowListStore) List(cluster, kind, namespace, name string) ([]ConfigSnapshot, error) {
+	snapshots, err := s.ConfigHistoryStore.List(cluster, kind, namespace, name)
+	time.Sleep(5 * time.Millisecond)
+	return snapshots, err
+}
+
+func TestConfigHistoryConcurrentRecord(t *testing.T) {
+	box, err := secretbox.NewFromPassphrase("test-passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewFileConfigHistoryStore(t.TempDir(), box)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := NewConfigHistory(slowListStore{store})
+	var wg sync.WaitGroup
+	for rv := range MaxConfigVersions {
+		wg.Go(func() {
+			snapshot := ConfigSnapshot{
+				ConfigVersion: ConfigVersion{Cluster: "prod", Kind: ConfigKindConfigMap, Namespace: "app", Name: "web", ResourceVersion: strconv.Itoa(rv)},
+				Data:          map[string]string{"rv": strconv.Itoa(rv)},
+			}
+			if err := history.record(context.Background(), snapshot); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	// 并发更新不能分配到相同的版本号而互相覆盖
+	versions, err := history.versions("prod", ConfigKindConfigMap, "app", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != MaxConfigVersions || versions[0].Version != MaxConfigVersions {
+		t.Fatalf("expected %d distinct versions, got %d (newest %d)", MaxConfigVersions, len(versions), versions[0].Version)
+	}
+	if len(history.locks) != 0 {
+		t.Fatalf("object locks should be released, got %d", len(history.locks))
+	}
+}
+
+func TestDiffConfigSnapshots(t *testing.T) {
+	before := &ConfigSnapshot{
+		ConfigVersion: ConfigVersion{Kind: ConfigKindConfigMap},
+		Data:          map[string]string{"app.conf": "port=80\nlevel=info\n", "old": "x", "same": "y"},
+	}
+	after := &ConfigSnapshot{
+		ConfigVersion: ConfigVersion{Kind: ConfigKindConfigMap},
+		Data:          map[string]string{"app.conf": "port=8080\nlevel=info\n", "new": "z", "same": "y"},
+	}
+	diffs, err := diffConfigSnapshots(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 3 || diffs[0].Key != "app.conf" || diffs[1].Change != ConfigKeyAdded || diffs[2].Change != ConfigKeyRemoved {
+		t.Fatalf("unexpected configmap diff: %+v", diffs)
+	}
+	if !strings.Contains(diffs[0].Diff, "-port=80\n") || !strings.Contains(diffs[0].Diff, "+port=8080\n") {
+		t.Fatalf("unexpected unified diff:\n%s", diffs[0].Diff)
+	}
+
+	secretBefore := &ConfigSnapshot{ConfigVersion: ConfigVersion{Kind: ConfigKindSecret}, SecretData: map[string][]byte{"password": []byte("old-password")}}
+	secretAfter := &ConfigSnapshot{ConfigVersion: ConfigVersion{Kind: ConfigKindSecret}, SecretData: map[string][]byte{"password": []byte("new-password")}}
+	diffs, err = diffConfigSnapshots(secretBefore, secretAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Diff != "" || diffs[0].OldSHA256 != sha256Hex([]byte("old-password")) || diffs[0].NewSHA256 == diffs[0].OldSHA256 {
+		t.Fatalf("secret diff must only contain fingerprints: %+v", diffs)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,11 +27,28 @@ type ConfigMapDetail struct {
 // ConfigMapService ConfigMap 管理服务
 type ConfigMapService struct {
 	clientManager *ClientManager
+	history       *ConfigHistory
 }
 
-// NewConfigMapService 创建 ConfigMap 服务
-func NewConfigMapService(clientManager *ClientManager) *ConfigMapService {
-	return &ConfigMapService{clientManager: clientManager}
+// NewConfigMapService 创建 ConfigMap 服务，history 为 nil 时更新不保存历史版本
+func NewConfigMapService(clientManager *ClientManager, history *ConfigHistory) *ConfigMapService {
+	return &ConfigMapService{clientManager: clientManager, history: history}
+}
+
+func configMapSnapshot(clusterName string, cm *corev1.ConfigMap, action string) ConfigSnapshot {
+	return ConfigSnapshot{
+		ConfigVersion: ConfigVersion{
+			Cluster:         clusterName,
+			Kind:            ConfigKindConfigMap,
+			Namespace:       cm.Namespace,
+			Name:            cm.Name,
+			ResourceVersion: cm.ResourceVersion,
+			Keys:            sortedKeys(cm.Data),
+			Action:          action,
+		},
+		Labels: cm.Labels,
+		Data:   cm.Data,
+	}
 }
 
 func toConfigMapInfo(cm corev1.ConfigMap) ConfigMapInfo {
@@ -49,7 +67,7 @@ func toConfigMapInfo(cm corev1.ConfigMap) ConfigMapInfo {
 
 // ListConfigMaps 获取集群所有 ConfigMap
 func (s *ConfigMapService) ListConfigMaps(ctx context.Context, clusterName string) ([]ConfigMapInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +84,7 @@ func (s *ConfigMapService) ListConfigMaps(ctx context.Context, clusterName strin
 
 // ListConfigMapsByNamespace 按命名空间获取 ConfigMap
 func (s *ConfigMapService) ListConfigMapsByNamespace(ctx context.Context, clusterName, namespace string) ([]ConfigMapInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +101,7 @@ func (s *ConfigMapService) ListConfigMapsByNamespace(ctx context.Context, cluste
 
 // GetConfigMap 获取 ConfigMap 详情
 func (s *ConfigMapService) GetConfigMap(ctx context.Context, clusterName, namespace, name string) (*ConfigMapDetail, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +125,7 @@ func (s *ConfigMapService) CreateConfigMap(ctx context.Context, clusterName, nam
 	if req.Name == "" {
 		return nil, fmt.Errorf("ConfigMap 名称不能为空")
 	}
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -123,9 +141,13 @@ func (s *ConfigMapService) CreateConfigMap(ctx context.Context, clusterName, nam
 	return &ConfigMapDetail{ConfigMapInfo: info, Data: created.Data}, nil
 }
 
-// UpdateConfigMap 更新 ConfigMap data
+// UpdateConfigMap 更新 ConfigMap data，更新前保存修改前的内容为历史版本
 func (s *ConfigMapService) UpdateConfigMap(ctx context.Context, clusterName, namespace, name string, data map[string]string, labels map[string]string) (*ConfigMapDetail, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	return s.updateConfigMap(ctx, clusterName, namespace, name, data, labels, ConfigActionUpdate)
+}
+
+func (s *ConfigMapService) updateConfigMap(ctx context.Context, clusterName, namespace, name string, data map[string]string, labels map[string]string, action string) (*ConfigMapDetail, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +155,9 @@ func (s *ConfigMapService) UpdateConfigMap(ctx context.Context, clusterName, nam
 	if err != nil {
 		return nil, fmt.Errorf("获取 ConfigMap 失败: %w", err)
 	}
+	if err := s.history.record(ctx, configMapSnapshot(clusterName, cm, action)); err != nil {
+		return nil, fmt.Errorf("保存历史版本失败: %w", err)
+	}
 	if data != nil {
 		cm.Data = data
 	}
@@ -149,7 +174,7 @@ func (s *ConfigMapService) UpdateConfigMap(ctx context.Context, clusterName, nam
 
 // DeleteConfigMap 删除 ConfigMap
 func (s *ConfigMapService) DeleteConfigMap(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -158,3 +183,43 @@ func (s *ConfigMapService) DeleteConfigMap(ctx context.Context, clusterName, nam
 	}
 	return nil
 }
+
+// ListConfigMapVersions 获取 ConfigMap 的历史版本，按版本号倒序
+func (s *ConfigMapService) ListConfigMapVersions(ctx context.Context, clusterName, namespace, name string) ([]ConfigVersion, error) {
+	return s.history.versions(clusterName, ConfigKindConfigMap, namespace, name)
+}
+
+// DiffConfigMapVersions 按键对比两个版本，from / to 为版本号或 current（集群中的当前内容）
+func (s *ConfigMapService) DiffConfigMapVersions(ctx context.Context, clusterName, namespace, name, from, to string) (*ConfigVersionDiff, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	current := &ConfigSnapshot{ConfigVersion: ConfigVersion{Cluster: clusterName, Kind: ConfigKindConfigMap, Namespace: namespace, Name: name}}
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		snapshot := configMapSnapshot(clusterName, cm, "")
+		current = &snapshot
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("获取 ConfigMap 失败: %w", err)
+	}
+	return s.history.diff(current, from, to)
+}
+
+// RestoreConfigMapVersion 以指定历史版本的 data 与标签更新 ConfigMap，恢复前的内容同样保存为历史版本
+func (s *ConfigMapService) RestoreConfigMapVersion(ctx context.Context, clusterName, namespace, name string, version int) (*ConfigMapDetail, error) {
+	snapshot, err := s.history.snapshot(clusterName, ConfigKindConfigMap, namespace, name, version)
+	if err != nil {
+		return nil, err
+	}
+	data := snapshot.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	labels := snapshot.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return s.updateConfigMap(ctx, clusterName, namespace, name, data, labels, ConfigActionRestore)
+}
@@ -0,0 +1,247 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // 校验 timeZone 不依赖宿主机的时区数据库
+)
+
+// ErrInvalidCronSchedule schedule 或 timeZone 无效
+var ErrInvalidCronSchedule = errors.New("无效的 CronJob 调度配置")
+
+// MaxCronScheduleRuns 预览下次执行时间的最大数量
+const MaxCronScheduleRuns = 50
+
+// cronStarBit 标记日/周字段为 * 或 ?，用于判断日与周是"与"还是"或"的关系
+const cronStarBit = 1 << 63
+
+type cronField struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronMinuteField = cronField{name: "分钟", min: 0, max: 59}
+	cronHourField   = cronField{name: "小时", min: 0, max: 23}
+	cronDomField    = cronField{name: "日", min: 1, max: 31}
+	cronMonthField  = cronField{name: "月", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDowField = cronField{name: "星期", min: 0, max: 6, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Kubernetes CronJob 支持的预定义调度
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule 解析后的 CronJob 调度，规则与 CronJob 控制器一致：
+// 5 个字段（分 时 日 月 周）、预定义调度（@daily 等）以及 @every <duration>
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	every                         time.Duration
+	location                      *time.Location
+}
+
+// ParseCronSchedule 校验并解析 schedule 与 timeZone。
+// 未设置 timeZone 时按 UTC 计算（kube-controller-manager 的默认时区）。
+func ParseCronSchedule(schedule string, timeZone *string) (*CronSchedule, error) {
+	location := time.UTC
+	if timeZone != nil {
+		if *timeZone == "" || strings.EqualFold(*timeZone, "Local") {
+			return nil, fmt.Errorf("%w: timeZone 必须是明确的 IANA 时区名称，例如 Asia/Shanghai", ErrInvalidCronSchedule)
+		}
+		loc, err := time.LoadLocation(*timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: 未知的时区 %q", ErrInvalidCronSchedule, *timeZone)
+		}
+		location = loc
+	}
+
+	spec := strings.TrimSpace(schedule)
+	if spec == "" {
+		return nil, fmt.Errorf("%w: schedule 不能为空", ErrInvalidCronSchedule)
+	}
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return nil, fmt.Errorf("%w: schedule 中不能使用 TZ 或 CRON_TZ，请使用 timeZone 字段", ErrInvalidCronSchedule)
+	}
+	if strings.HasPrefix(spec, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("%w: 无效的间隔 %q", ErrInvalidCronSchedule, spec)
+		}
+		if every < time.Second {
+			every = time.Second
+		}
+		return &CronSchedule{every: every.Truncate(time.Second), location: location}, nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := cronDescriptors[spec]
+		if !ok {
+			return nil, fmt.Errorf("%w: 不支持的预定义调度 %q", ErrInvalidCronSchedule, spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: 需要 5 个字段（分 时 日 月 周），实际为 %d 个", ErrInvalidCronSchedule, len(fields))
+	}
+	s := &CronSchedule{location: location}
+	targets := []*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, field := range []cronField{cronMinuteField, cronHourField, cronDomField, cronMonthField, cronDowField} {
+		bits, err := field.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCronSchedule, err)
+		}
+		*targets[i] = bits
+	}
+	return s, nil
+}
+
+// parse 解析单个字段，支持 *、?、列表、区间与步长，例如 "1-10/2,30"
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(value, ",") {
+		rangeAndStep := strings.Split(expr, "/")
+		lowAndHigh := strings.Split(rangeAndStep[0], "-")
+		var start, end uint
+		var extra uint64
+		var err error
+		if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+			start, end, extra = f.min, f.max, cronStarBit
+			if len(lowAndHigh) > 1 {
+				return 0, fmt.Errorf("%s字段 %q 无效", f.name, expr)
+			}
+		} else {
+			if start, err = f.value(lowAndHigh[0]); err != nil {
+				return 0, err
+			}
+			switch len(lowAndHigh) {
+			case 1:
+				end = start
+			case 2:
+				if end, err = f.value(lowAndHigh[1]); err != nil {
+					return 0, err
+				}
+			default:
+				return 0, fmt.Errorf("%s字段 %q 无效", f.name, expr)
+			}
+		}
+
+		step := uint(1)
+		switch len(rangeAndStep) {
+		case 1:
+		case 2:
+			n, err := strconv.ParseUint(rangeAndStep[1], 10, 32)
+			if err != nil || n == 0 {
+				return 0, fmt.Errorf("%s字段 %q 的步长无效", f.name, expr)
+			}
+			step = uint(n)
+			// "5/15" 表示从 5 开始到最大值
+			if len(lowAndHigh) == 1 {
+				end = f.max
+			}
+			if step > 1 {
+				extra = 0
+			}
+		default:
+			return 0, fmt.Errorf("%s字段 %q 无效", f.name, expr)
+		}
+
+		if start < f.min || end > f.max || start > end {
+			return 0, fmt.Errorf("%s字段 %q 超出范围 %d-%d", f.name, expr, f.min, f.max)
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+		bits |= extra
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (uint, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%s字段的值 %q 无效", f.name, s)
+	}
+	return uint(n), nil
+}
+
+// Location 调度使用的时区
+func (s *CronSchedule) Location() *time.Location {
+	return s.location
+}
+
+// Next 返回 t 之后的下一次执行时间，5 年内没有匹配时返回零值
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Truncate(time.Second).Add(s.every)
+	}
+	origin := t.Location()
+	t = t.In(s.location)
+	// 从下一个整分钟开始查找
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + 5
+
+	for t.Year() <= yearLimit {
+		if 1<<uint(t.Month())&s.month == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		// 小时和分钟按绝对时间推进，避免夏令时切换时回退到重复的时间段
+		if 1<<uint(t.Hour())&s.hour == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if 1<<uint(t.Minute())&s.minute == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t.In(origin)
+	}
+	return time.Time{}
+}
+
+// dayMatches 日与周都被限制时满足任一即可，否则两者都需满足
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&s.dom > 0
+	dowMatch := 1<<uint(t.Weekday())&s.dow > 0
+	if s.dom&cronStarBit > 0 || s.dow&cronStarBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// NextRuns 返回 from 之后的 count 次执行时间
+func (s *CronSchedule) NextRuns(from time.Time, count int) []time.Time {
+	runs := make([]time.Time, 0, count)
+	for len(runs) < count {
+		from = s.Next(from)
+		if from.IsZero() {
+			break
+		}
+		runs = append(runs, from)
+	}
+	return runs
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCronScheduleRejectsInvalid(t *testing.T) {
+	empty, local, unknown := "", "Local", "Mars/Olympus"
+	cases := []struct {
+		schedule string
+		timeZone *string
+	}{
+		{"", nil},
+		{"* * * *", nil},
+		{"61 * * * *", nil},
+		{"*/0 * * * *", nil},
+		{"5-1 * * * *", nil},
+		{"0 0 * * 7", nil},
+		{"@fortnightly", nil},
+		{"TZ=UTC 0 0 * * *", nil},
+		{"0 0 * * *", &empty},
+		{"0 0 * * *", &local},
+		{"0 0 * * *", &unknown},
+	}
+	for _, tc := range cases {
+		if _, err := ParseCronSchedule(tc.schedule, tc.timeZone); !errors.Is(err, ErrInvalidCronSchedule) {
+			t.Errorf("ParseCronSchedule(%q) error = %v, want ErrInvalidCronSchedule", tc.schedule, err)
+		}
+	}
+}
+
+func TestCronScheduleNextRuns(t *testing.T) {
+	from := time.Date(2026, 1, 30, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		schedule string
+		want     []string
+	}{
+		{"*/15 * * * *", []string{"2026-01-30T10:15:00Z", "2026-01-30T10:30:00Z"}},
+		{"@daily", []string{"2026-01-31T00:00:00Z", "2026-02-01T00:00:00Z"}},
+		{"0 9 * * mon-fri", []string{"2026-02-02T09:00:00Z", "2026-02-03T09:00:00Z"}},
+		{"0 0 31 * *", []string{"2026-01-31T00:00:00Z", "2026-03-31T00:00:00Z"}},
+		// 日与周都被限制时满足任一即可：1 号或周日
+		{"0 0 1 * sun", []string{"2026-02-01T00:00:00Z", "2026-02-08T00:00:00Z"}},
+		{"@every 90m", []string{"2026-01-30T11:37:30Z", "2026-01-30T13:07:30Z"}},
+	}
+	for _, tc := range cases {
+		schedule, err := ParseCronSchedule(tc.schedule, nil)
+		if err != nil {
+			t.Fatalf("ParseCronSchedule(%q): %v", tc.schedule, err)
+		}
+		runs := schedule.NextRuns(from, len(tc.want))
+		for i, want := range tc.want {
+			if got := runs[i].UTC().Format(time.RFC3339); got != want {
+				t.Errorf("%q run %d = %s, want %s", tc.schedule, i, got, want)
+			}
+		}
+	}
+}
+
+func TestCronScheduleTimeZone(t *testing.T) {
+	tz := "America/New_York"
+	schedule, err := ParseCronSchedule("30 2 * * *", &tz)
+	if err != nil {
+		t.Fatalf("ParseCronSchedule: %v", err)
+	}
+	// 2026-03-08 是夏令时开始日，02:30 不存在，应跳到下一天
+	from := time.Date(2026, 3, 7, 5, 0, 0, 0, time.UTC)
+	runs := schedule.NextRuns(from, 2)
+	want := []string{"2026-03-07T02:30:00-05:00", "2026-03-09T02:30:00-04:00"}
+	for i := range want {
+		if got := runs[i].In(schedule.Location()).Format(time.RFC3339); got != want[i] {
+			t.Errorf("run %d = %s, want %s", i, got, want[i])
+		}
+	}
+
+	never, err := ParseCronSchedule("0 0 30 2 *", nil)
+	if err != nil {
+		t.Fatalf("ParseCronSchedule: %v", err)
+	}
+	if runs := never.NextRuns(from, 3); len(runs) != 0 {
+		t.Fatalf("Feb 30 should never fire, got %v", runs)
+	}
+}
@@ -23,16 +23,18 @@ func NewCronJobService(clientManager *ClientManager) *CronJobService {
 
 // CronJobInfo CronJob 摘要
 type CronJobInfo struct {
-	Name              string            `json:"name"`
-	Namespace         string            `json:"namespace"`
-	Schedule          string            `json:"schedule"`
-	Suspend           bool              `json:"suspend"`
-	LastScheduleTime  *time.Time        `json:"lastScheduleTime,omitempty"`
-	LastSuccessfulTime *time.Time       `json:"lastSuccessfulTime,omitempty"`
-	ActiveJobs        int               `json:"activeJobs"`
-	CreationTime      time.Time         `json:"creationTime"`
-	Labels            map[string]string `json:"labels,omitempty"`
-	ConcurrencyPolicy string            `json:"concurrencyPolicy,omitempty"`
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	Schedule           string            `json:"schedule"`
+	TimeZone           string            `json:"timeZone,omitempty"`
+	Suspend            bool              `json:"suspend"`
+	LastScheduleTime   *time.Time        `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *time.Time        `json:"lastSuccessfulTime,omitempty"`
+	ActiveJobs         int               `json:"activeJobs"`
+	CreationTime       time.Time         `json:"creationTime"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	ConcurrencyPolicy  string            `json:"concurrencyPolicy,omitempty"`
+	NextRuns           []time.Time       `json:"nextRuns,omitempty"`
 }
 
 // CreateCronJobRequest 创建 CronJob 请求
@@ -40,6 +42,7 @@ type CreateCronJobRequest struct {
 	Name              string            `json:"name" binding:"required"`
 	Namespace         string            `json:"namespace"`
 	Schedule          string            `json:"schedule" binding:"required"`
+	TimeZone          *string           `json:"timeZone,omitempty"`
 	Labels            map[string]string `json:"labels,omitempty"`
 	Annotations       map[string]string `json:"annotations,omitempty"`
 	Suspend           *bool             `json:"suspend,omitempty"`
@@ -51,6 +54,7 @@ type CreateCronJobRequest struct {
 // UpdateCronJobRequest 更新 CronJob 请求
 type UpdateCronJobRequest struct {
 	Schedule          *string           `json:"schedule,omitempty"`
+	TimeZone          *string           `json:"timeZone,omitempty"` // 空字符串表示清除
 	Suspend           *bool             `json:"suspend,omitempty"`
 	Image             map[string]string `json:"image,omitempty"`
 	Labels            map[string]string `json:"labels,omitempty"`
@@ -60,7 +64,7 @@ type UpdateCronJobRequest struct {
 
 // ListCronJobs 获取 CronJob 列表
 func (s *CronJobService) ListCronJobs(ctx context.Context, clusterName, namespace string) ([]CronJobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +89,7 @@ func (s *CronJobService) ListCronJobs(ctx context.Context, clusterName, namespac
 
 // GetCronJob 获取 CronJob 详情
 func (s *CronJobService) GetCronJob(ctx context.Context, clusterName, namespace, name string) (*CronJobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -99,13 +103,19 @@ func (s *CronJobService) GetCronJob(ctx context.Context, clusterName, namespace,
 
 // CreateCronJob 创建 CronJob
 func (s *CronJobService) CreateCronJob(ctx context.Context, clusterName, namespace string, req CreateCronJobRequest) (*CronJobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
 	if req.Namespace != "" {
 		namespace = req.Namespace
 	}
+	if req.TimeZone != nil && *req.TimeZone == "" {
+		req.TimeZone = nil
+	}
+	if _, err := ParseCronSchedule(req.Schedule, req.TimeZone); err != nil {
+		return nil, err
+	}
 	labels := req.Labels
 	if labels == nil {
 		labels = map[string]string{"cronjob": req.Name}
@@ -135,6 +145,7 @@ func (s *CronJobService) CreateCronJob(ctx context.Context, clusterName, namespa
 		},
 		Spec: batchv1.CronJobSpec{
 			Schedule:          req.Schedule,
+			TimeZone:          req.TimeZone,
 			Suspend:           &suspend,
 			ConcurrencyPolicy: concurrency,
 			JobTemplate: batchv1.JobTemplateSpec{
@@ -160,7 +171,7 @@ func (s *CronJobService) CreateCronJob(ctx context.Context, clusterName, namespa
 
 // UpdateCronJob 更新 CronJob
 func (s *CronJobService) UpdateCronJob(ctx context.Context, clusterName, namespace, name string, req UpdateCronJobRequest) (*CronJobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +182,17 @@ func (s *CronJobService) UpdateCronJob(ctx context.Context, clusterName, namespa
 	if req.Schedule != nil {
 		cj.Spec.Schedule = *req.Schedule
 	}
+	if req.TimeZone != nil {
+		cj.Spec.TimeZone = req.TimeZone
+		if *req.TimeZone == "" {
+			cj.Spec.TimeZone = nil
+		}
+	}
+	if req.Schedule != nil || req.TimeZone != nil {
+		if _, err := ParseCronSchedule(cj.Spec.Schedule, cj.Spec.TimeZone); err != nil {
+			return nil, err
+		}
+	}
 	if req.Suspend != nil {
 		cj.Spec.Suspend = req.Suspend
 	}
@@ -205,7 +227,7 @@ func (s *CronJobService) SuspendCronJob(ctx context.Context, clusterName, namesp
 
 // DeleteCronJob 删除 CronJob
 func (s *CronJobService) DeleteCronJob(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -228,6 +250,14 @@ func convertCronJobInfo(cj *batchv1.CronJob) CronJobInfo {
 		Labels:            cj.Labels,
 		ConcurrencyPolicy: string(cj.Spec.ConcurrencyPolicy),
 	}
+	if cj.Spec.TimeZone != nil {
+		info.TimeZone = *cj.Spec.TimeZone
+	}
+	if !suspend {
+		if schedule, err := ParseCronSchedule(cj.Spec.Schedule, cj.Spec.TimeZone); err == nil {
+			info.NextRuns = schedule.NextRuns(time.Now(), cronJobNextRunsPreview)
+		}
+	}
 	if cj.Status.LastScheduleTime != nil {
 		t := cj.Status.LastScheduleTime.Time
 		info.LastScheduleTime = &t
@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cronJobInstantiateAnnotation 手动触发的 Job 标记，与 kubectl create job --from=cronjob 一致
+const cronJobInstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+// cronJobNextRunsPreview CronJob 详情中展示的下次执行时间数量
+const cronJobNextRunsPreview = 5
+
+// TriggerCronJobRequest 立即执行请求，Name 为空时自动生成
+type TriggerCronJobRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// CronJobRun CronJob 创建的一次执行
+type CronJobRun struct {
+	JobName         string     `json:"jobName"`
+	Status          string     `json:"status"`
+	Message         string     `json:"message,omitempty"`
+	Manual          bool       `json:"manual"`
+	ScheduledTime   *time.Time `json:"scheduledTime,omitempty"`
+	StartTime       *time.Time `json:"startTime,omitempty"`
+	FinishTime      *time.Time `json:"finishTime,omitempty"`
+	DurationSeconds int64      `json:"durationSeconds"`
+	Succeeded       int32      `json:"succeeded"`
+	Failed          int32      `json:"failed"`
+	Active          int32      `json:"active"`
+}
+
+// CronJobHistory CronJob 执行历史。
+// 历史 Job 受 successfulJobsHistoryLimit / failedJobsHistoryLimit 清理，只能看到保留下来的部分。
+type CronJobHistory struct {
+	Runs                       []CronJobRun `json:"runs"`
+	Succeeded                  int          `json:"succeeded"`
+	Failed                     int          `json:"failed"`
+	Running                    int          `json:"running"`
+	SuccessfulJobsHistoryLimit *int32       `json:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32       `json:"failedJobsHistoryLimit,omitempty"`
+}
+
+// buildJobFromCronJob 按 jobTemplate 生成 Job，并设置 CronJob 为所有者，执行记录与历史清理由 CronJob 统一管理
+func buildJobFromCronJob(cj *batchv1.CronJob, name string) *batchv1.Job {
+	if name == "" {
+		name = generateJobName(cj.Name, "manual")
+	}
+	annotations := map[string]string{cronJobInstantiateAnnotation: "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	labels := make(map[string]string, len(cj.Spec.JobTemplate.Labels))
+	for k, v := range cj.Spec.JobTemplate.Labels {
+		labels[k] = v
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cj.Namespace,
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob"))},
+		},
+		Spec: *cj.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
+
+// jobFinishTime Job 结束时间；失败的 Job 没有 completionTime，取 Failed 条件的时间
+func jobFinishTime(job *batchv1.Job) *time.Time {
+	if job.Status.CompletionTime != nil {
+		t := job.Status.CompletionTime.Time
+		return &t
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			t := cond.LastTransitionTime.Time
+			return &t
+		}
+	}
+	return nil
+}
+
+// buildCronJobHistory 汇总 CronJob 所属 Job 的执行结果与耗时，按创建时间倒序
+func buildCronJobHistory(cj *batchv1.CronJob, jobs []batchv1.Job, now time.Time) CronJobHistory {
+	history := CronJobHistory{
+		Runs:                       []CronJobRun{},
+		SuccessfulJobsHistoryLimit: cj.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     cj.Spec.FailedJobsHistoryLimit,
+	}
+	owned := make([]batchv1.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if metav1.IsControlledBy(&job, cj) {
+			owned = append(owned, job)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[j].CreationTimestamp.Before(&owned[i].CreationTimestamp)
+	})
+
+	for i := range owned {
+		job := &owned[i]
+		status, message := jobStatus(job)
+		run := CronJobRun{
+			JobName:    job.Name,
+			Status:     status,
+			Message:    message,
+			Manual:     job.Annotations[cronJobInstantiateAnnotation] == "manual",
+			FinishTime: jobFinishTime(job),
+			Succeeded:  job.Status.Succeeded,
+			Failed:     job.Status.Failed,
+			Active:     job.Status.Active,
+		}
+		if scheduled, err := time.Parse(time.RFC3339, job.Annotations[batchv1.CronJobScheduledTimestampAnnotation]); err == nil {
+			run.ScheduledTime = &scheduled
+		}
+		if job.Status.StartTime != nil {
+			start := job.Status.StartTime.Time
+			run.StartTime = &start
+			end := now
+			if run.FinishTime != nil {
+				end = *run.FinishTime
+			}
+			run.DurationSeconds = int64(end.Sub(start).Seconds())
+		}
+		switch status {
+		case JobStatusComplete:
+			history.Succeeded++
+		case JobStatusFailed:
+			history.Failed++
+		default:
+			history.Running++
+		}
+		history.Runs = append(history.Runs, run)
+	}
+	return history
+}
+
+// TriggerCronJob 立即按 CronJob 的 jobTemplate 创建一个 Job，不受 suspend 与 concurrencyPolicy 限制
+func (s *CronJobService) TriggerCronJob(ctx context.Context, clusterName, namespace, name string, req TriggerCronJobRequest) (*JobInfo, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	cj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 CronJob 失败: %w", err)
+	}
+	created, err := client.BatchV1().Jobs(namespace).Create(ctx, buildJobFromCronJob(cj, req.Name), metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("触发 CronJob 失败: %w", err)
+	}
+	info := convertJobInfo(created)
+	return &info, nil
+}
+
+// GetCronJobHistory 获取 CronJob 创建的 Job 执行历史
+func (s *CronJobService) GetCronJobHistory(ctx context.Context, clusterName, namespace, name string) (*CronJobHistory, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	cj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 CronJob 失败: %w", err)
+	}
+	jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Job 列表失败: %w", err)
+	}
+	history := buildCronJobHistory(cj, jobs.Items, time.Now())
+	return &history, nil
+}
+
+// PreviewSchedule 校验 schedule 与 timeZone 并返回接下来 count 次执行时间
+func (s *CronJobService) PreviewSchedule(schedule string, timeZone *string, count int) ([]time.Time, error) {
+	parsed, err := ParseCronSchedule(schedule, timeZone)
+	if err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		count = cronJobNextRunsPreview
+	}
+	if count > MaxCronScheduleRuns {
+		count = MaxCronScheduleRuns
+	}
+	return parsed.NextRuns(time.Now(), count), nil
+}
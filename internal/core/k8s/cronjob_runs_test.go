@@ -0,0 +1,90 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func testCronJob() *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ops", UID: types.UID("cj-uid")},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 3 * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "backup"}, Annotations: map[string]string{"team": "ops"}},
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers:    []corev1.Container{{Name: "backup", Image: "backup:1"}},
+				}}},
+			},
+		},
+	}
+}
+
+func TestBuildJobFromCronJob(t *testing.T) {
+	cj := testCronJob()
+	job := buildJobFromCronJob(cj, "")
+	if !strings.HasPrefix(job.Name, "backup-manual-") || job.Namespace != "ops" {
+		t.Fatalf("unexpected job meta: %+v", job.ObjectMeta)
+	}
+	if !metav1.IsControlledBy(job, cj) {
+		t.Fatal("job should be controlled by the CronJob")
+	}
+	if job.Annotations[cronJobInstantiateAnnotation] != "manual" || job.Annotations["team"] != "ops" || job.Labels["app"] != "backup" {
+		t.Fatalf("unexpected labels/annotations: %v %v", job.Labels, job.Annotations)
+	}
+	job.Spec.Template.Spec.Containers[0].Image = "changed"
+	if cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image != "backup:1" {
+		t.Fatal("jobTemplate must not be modified")
+	}
+}
+
+func TestBuildCronJobHistory(t *testing.T) {
+	cj := testCronJob()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	owner := []metav1.OwnerReference{*metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob"))}
+	at := func(hour int) metav1.Time { return metav1.NewTime(time.Date(2026, 5, 1, hour, 0, 0, 0, time.UTC)) }
+	start1, done1 := at(3), at(4)
+	start2, start3 := at(9), at(11)
+
+	jobs := []batchv1.Job{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-1", CreationTimestamp: at(3), OwnerReferences: owner,
+				Annotations: map[string]string{batchv1.CronJobScheduledTimestampAnnotation: "2026-05-01T03:00:00Z"}},
+			Status: batchv1.JobStatus{StartTime: &start1, CompletionTime: &done1, Succeeded: 1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-manual", CreationTimestamp: at(9), OwnerReferences: owner,
+				Annotations: map[string]string{cronJobInstantiateAnnotation: "manual"}},
+			Status: batchv1.JobStatus{StartTime: &start2, Failed: 2,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", LastTransitionTime: at(10)}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-3", CreationTimestamp: at(11), OwnerReferences: owner},
+			Status:     batchv1.JobStatus{StartTime: &start3, Active: 1},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", CreationTimestamp: at(12)}},
+	}
+
+	history := buildCronJobHistory(cj, jobs, now)
+	if len(history.Runs) != 3 || history.Succeeded != 1 || history.Failed != 1 || history.Running != 1 {
+		t.Fatalf("unexpected history summary: %+v", history)
+	}
+	running, failed, done := history.Runs[0], history.Runs[1], history.Runs[2]
+	if running.JobName != "backup-3" || running.Status != JobStatusRunning || running.DurationSeconds != 3600 {
+		t.Fatalf("unexpected running run: %+v", running)
+	}
+	if !failed.Manual || failed.FinishTime == nil || failed.DurationSeconds != 3600 || failed.Status != JobStatusFailed {
+		t.Fatalf("unexpected failed run: %+v", failed)
+	}
+	if done.Manual || done.ScheduledTime == nil || done.DurationSeconds != 3600 {
+		t.Fatalf("unexpected completed run: %+v", done)
+	}
+}
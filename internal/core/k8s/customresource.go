@@ -0,0 +1,434 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"kube-tide/internal/utils/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// ErrClusterScopedCustomResource 集群级资源只能通过不带命名空间的路由访问
+var ErrClusterScopedCustomResource = errors.New("集群级资源不能在命名空间下访问")
+
+// CustomResourceService 自定义资源浏览：CRD 列表、实例的查看、编辑与删除（通过动态客户端）
+type CustomResourceService struct {
+	clientManager *ClientManager
+}
+
+// NewCustomResourceService 创建自定义资源服务
+func NewCustomResourceService(clientManager *ClientManager) *CustomResourceService {
+	return &CustomResourceService{clientManager: clientManager}
+}
+
+// PrinterColumn CRD 的 additionalPrinterColumns
+type PrinterColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+	JSONPath    string `json:"jsonPath"`
+	Priority    int32  `json:"priority,omitempty"`
+}
+
+// CRDVersion CRD 的一个版本
+type CRDVersion struct {
+	Name           string          `json:"name"`
+	Served         bool            `json:"served"`
+	Storage        bool            `json:"storage"`
+	Deprecated     bool            `json:"deprecated,omitempty"`
+	PrinterColumns []PrinterColumn `json:"printerColumns"`
+}
+
+// CRDInfo CRD 摘要
+type CRDInfo struct {
+	Name         string       `json:"name"`
+	Group        string       `json:"group"`
+	Kind         string       `json:"kind"`
+	Plural       string       `json:"plural"`
+	Singular     string       `json:"singular,omitempty"`
+	ShortNames   []string     `json:"shortNames,omitempty"`
+	Categories   []string     `json:"categories,omitempty"`
+	Scope        string       `json:"scope"`
+	Versions     []CRDVersion `json:"versions"`
+	Established  bool         `json:"established"`
+	CreationTime time.Time    `json:"creationTime"`
+}
+
+// ResourceCondition status.conditions 中的一项
+type ResourceCondition struct {
+	Type               string     `json:"type"`
+	Status             string     `json:"status"`
+	Reason             string     `json:"reason,omitempty"`
+	Message            string     `json:"message,omitempty"`
+	LastTransitionTime *time.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// CustomResourceItem 自定义资源实例摘要，Values 与 CustomResourceList.Columns 一一对应
+type CustomResourceItem struct {
+	Name         string              `json:"name"`
+	Namespace    string              `json:"namespace,omitempty"`
+	Values       []any               `json:"values"`
+	Conditions   []ResourceCondition `json:"conditions,omitempty"`
+	CreationTime time.Time           `json:"creationTime"`
+}
+
+// CustomResourceList 自定义资源实例列表
+type CustomResourceList struct {
+	CRD     CRDInfo              `json:"crd"`
+	Version string               `json:"version"`
+	Columns []PrinterColumn      `json:"columns"`
+	Items   []CustomResourceItem `json:"items"`
+}
+
+// CustomResourceDetail 自定义资源实例详情
+type CustomResourceDetail struct {
+	CustomResourceItem
+	CRD      CRDInfo         `json:"crd"`
+	Version  string          `json:"version"`
+	Columns  []PrinterColumn `json:"columns"`
+	Manifest string          `json:"manifest"`
+	Events   []corev1.Event  `json:"events"`
+}
+
+func convertCRDInfo(obj *unstructured.Unstructured) (CRDInfo, error) {
+	var crd struct {
+		Spec struct {
+			Group string `json:"group"`
+			Names struct {
+				Kind       string   `json:"kind"`
+				Plural     string   `json:"plural"`
+				Singular   string   `json:"singular"`
+				ShortNames []string `json:"shortNames"`
+				Categories []string `json:"categories"`
+			} `json:"names"`
+			Scope    string `json:"scope"`
+			Versions []struct {
+				Name                     string          `json:"name"`
+				Served                   bool            `json:"served"`
+				Storage                  bool            `json:"storage"`
+				Deprecated               bool            `json:"deprecated"`
+				AdditionalPrinterColumns []PrinterColumn `json:"additionalPrinterColumns"`
+			} `json:"versions"`
+		} `json:"spec"`
+		Status struct {
+			Conditions []ResourceCondition `json:"conditions"`
+		} `json:"status"`
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+		return CRDInfo{}, fmt.Errorf("解析 CRD %s 失败: %w", obj.GetName(), err)
+	}
+	info := CRDInfo{
+		Name:         obj.GetName(),
+		Group:        crd.Spec.Group,
+		Kind:         crd.Spec.Names.Kind,
+		Plural:       crd.Spec.Names.Plural,
+		Singular:     crd.Spec.Names.Singular,
+		ShortNames:   crd.Spec.Names.ShortNames,
+		Categories:   crd.Spec.Names.Categories,
+		Scope:        crd.Spec.Scope,
+		Versions:     make([]CRDVersion, 0, len(crd.Spec.Versions)),
+		CreationTime: obj.GetCreationTimestamp().Time,
+	}
+	for _, v := range crd.Spec.Versions {
+		columns := v.AdditionalPrinterColumns
+		if columns == nil {
+			columns = []PrinterColumn{}
+		}
+		info.Versions = append(info.Versions, CRDVersion{
+			Name: v.Name, Served: v.Served, Storage: v.Storage, Deprecated: v.Deprecated, PrinterColumns: columns,
+		})
+	}
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == "Established" && cond.Status == string(corev1.ConditionTrue) {
+			info.Established = true
+		}
+	}
+	return info, nil
+}
+
+// printerColumnsFor 返回指定版本的打印列，版本不存在时返回 nil
+func (c CRDInfo) printerColumnsFor(version string) []PrinterColumn {
+	for _, v := range c.Versions {
+		if v.Name == version {
+			return v.PrinterColumns
+		}
+	}
+	return nil
+}
+
+// evaluatePrinterColumns 按 jsonPath 计算打印列的值，与 kubectl get 相同：缺失字段为 nil，多个结果取第一个
+func evaluatePrinterColumns(columns []PrinterColumn, obj map[string]any) []any {
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		parser := jsonpath.New(column.Name).AllowMissingKeys(true)
+		if err := parser.Parse(fmt.Sprintf("{%s}", column.JSONPath)); err != nil {
+			continue
+		}
+		results, err := parser.FindResults(obj)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			continue
+		}
+		if v := results[0][0]; v.IsValid() && v.CanInterface() {
+			values[i] = v.Interface()
+		}
+	}
+	return values
+}
+
+// resourceConditions 解析 status.conditions，格式不符合约定时忽略
+func resourceConditions(obj map[string]any) []ResourceCondition {
+	raw, found, err := unstructured.NestedSlice(obj, "status", "conditions")
+	if err != nil || !found {
+		return nil
+	}
+	conditions := make([]ResourceCondition, 0, len(raw))
+	for _, item := range raw {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		cond := ResourceCondition{}
+		cond.Type, _, _ = unstructured.NestedString(m, "type")
+		cond.Status, _, _ = unstructured.NestedString(m, "status")
+		cond.Reason, _, _ = unstructured.NestedString(m, "reason")
+		cond.Message, _, _ = unstructured.NestedString(m, "message")
+		if cond.Type == "" {
+			continue
+		}
+		if ts, _, _ := unstructured.NestedString(m, "lastTransitionTime"); ts != "" {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				cond.LastTransitionTime = &t
+			}
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions
+}
+
+func convertCustomResourceItem(obj *unstructured.Unstructured, columns []PrinterColumn) CustomResourceItem {
+	return CustomResourceItem{
+		Name:         obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+		Values:       evaluatePrinterColumns(columns, obj.Object),
+		Conditions:   resourceConditions(obj.Object),
+		CreationTime: obj.GetCreationTimestamp().Time,
+	}
+}
+
+// ListCRDs 获取集群中的 CRD 列表
+func (s *CustomResourceService) ListCRDs(ctx context.Context, clusterName string) ([]CRDInfo, error) {
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.Resource(crdGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 CRD 列表失败: %w", err)
+	}
+	result := make([]CRDInfo, 0, len(list.Items))
+	for i := range list.Items {
+		info, err := convertCRDInfo(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Group != result[j].Group {
+			return result[i].Group < result[j].Group
+		}
+		return result[i].Kind < result[j].Kind
+	})
+	return result, nil
+}
+
+// GetCRD 获取 CRD 详情
+func (s *CustomResourceService) GetCRD(ctx context.Context, clusterName, crdName string) (*CRDInfo, error) {
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Resource(crdGVR).Get(ctx, crdName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 CRD 失败: %w", err)
+	}
+	info, err := convertCRDInfo(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// resolve 通过 API 发现确定实例使用的版本（未指定时取首选版本）与资源接口。
+// requireNamespace 为 true 时，命名空间级资源必须指定命名空间（操作单个实例）；
+// 集群级资源指定了命名空间时返回 ErrClusterScopedCustomResource，避免按命名空间授权的请求操作集群级对象。
+func (s *CustomResourceService) resolve(ctx context.Context, clusterName, crdName, version, namespace string, requireNamespace bool) (*CRDInfo, *meta.RESTMapping, dynamic.ResourceInterface, error) {
+	crd, err := s.GetCRD(ctx, clusterName, crdName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	mapping, err := s.clientManager.RESTMapping(clusterName, schema.GroupVersionKind{Group: crd.Group, Version: version, Kind: crd.Kind})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		if namespace != "" {
+			return nil, nil, nil, fmt.Errorf("%s: %w", crd.Kind, ErrClusterScopedCustomResource)
+		}
+		return crd, mapping, client.Resource(mapping.Resource), nil
+	}
+	if requireNamespace && namespace == "" {
+		return nil, nil, nil, fmt.Errorf("%s 是命名空间级资源，需要指定命名空间", crd.Kind)
+	}
+	return crd, mapping, client.Resource(mapping.Resource).Namespace(namespace), nil
+}
+
+// ListCustomResources 获取自定义资源实例，namespace 为空时列出所有命名空间；version 为空时使用首选版本
+func (s *CustomResourceService) ListCustomResources(ctx context.Context, clusterName, crdName, version, namespace string) (*CustomResourceList, error) {
+	crd, mapping, resource, err := s.resolve(ctx, clusterName, crdName, version, namespace, false)
+	if err != nil {
+		return nil, err
+	}
+	list, err := resource.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 列表失败: %w", crd.Kind, err)
+	}
+	columns := crd.printerColumnsFor(mapping.GroupVersionKind.Version)
+	result := &CustomResourceList{
+		CRD:     *crd,
+		Version: mapping.GroupVersionKind.Version,
+		Columns: columns,
+		Items:   make([]CustomResourceItem, 0, len(list.Items)),
+	}
+	for i := range list.Items {
+		result.Items = append(result.Items, convertCustomResourceItem(&list.Items[i], columns))
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		if result.Items[i].Namespace != result.Items[j].Namespace {
+			return result.Items[i].Namespace < result.Items[j].Namespace
+		}
+		return result.Items[i].Name < result.Items[j].Name
+	})
+	return result, nil
+}
+
+// GetCustomResource 获取自定义资源实例详情，包括打印列、状态条件、YAML 与相关事件
+func (s *CustomResourceService) GetCustomResource(ctx context.Context, clusterName, crdName, version, namespace, name string) (*CustomResourceDetail, error) {
+	crd, mapping, resource, err := s.resolve(ctx, clusterName, crdName, version, namespace, true)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s %s 失败: %w", crd.Kind, name, err)
+	}
+	manifest, err := manifestYAML(obj)
+	if err != nil {
+		return nil, err
+	}
+	columns := crd.printerColumnsFor(mapping.GroupVersionKind.Version)
+	return &CustomResourceDetail{
+		CustomResourceItem: convertCustomResourceItem(obj, columns),
+		CRD:                *crd,
+		Version:            mapping.GroupVersionKind.Version,
+		Columns:            columns,
+		Manifest:           manifest,
+		Events:             s.objectEvents(ctx, clusterName, obj),
+	}, nil
+}
+
+// objectEvents 按 involvedObject.uid 获取对象的事件，失败时返回空列表
+func (s *CustomResourceService) objectEvents(ctx context.Context, clusterName string, obj *unstructured.Unstructured) []corev1.Event {
+	events := []corev1.Event{}
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return events
+	}
+	list, err := client.CoreV1().Events(obj.GetNamespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.uid=" + string(obj.GetUID()),
+	})
+	if err != nil {
+		logger.Debug("获取自定义资源事件失败", "kind", obj.GetKind(), "name", obj.GetName(), "error", err)
+		return events
+	}
+	events = list.Items
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastTimestamp.After(events[j].LastTimestamp.Time)
+	})
+	return events
+}
+
+// UpdateCustomResource 以编辑后的 YAML/JSON 整体替换实例。
+// 保留 metadata.resourceVersion 时可检测并发修改；未提供时覆盖最新版本。
+func (s *CustomResourceService) UpdateCustomResource(ctx context.Context, clusterName, crdName, namespace, name, manifest string) (*CustomResourceDetail, error) {
+	objects, err := decodeManifests(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) != 1 {
+		return nil, fmt.Errorf("只能提交一个对象，实际为 %d 个", len(objects))
+	}
+	obj := objects[0]
+	gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return nil, fmt.Errorf("无效的 apiVersion %q: %w", obj.GetAPIVersion(), err)
+	}
+	crd, mapping, resource, err := s.resolve(ctx, clusterName, crdName, gv.Version, namespace, true)
+	if err != nil {
+		return nil, err
+	}
+	if gv.Group != crd.Group || obj.GetKind() != crd.Kind || obj.GetName() != name {
+		return nil, fmt.Errorf("提交的对象 %s/%s %s 与 %s %s 不一致", obj.GetAPIVersion(), obj.GetKind(), obj.GetName(), crd.Kind, name)
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if ns := obj.GetNamespace(); ns != "" && ns != namespace {
+			return nil, fmt.Errorf("提交的对象命名空间 %s 与 %s 不一致", ns, namespace)
+		}
+		obj.SetNamespace(namespace)
+	} else {
+		obj.SetNamespace("")
+	}
+	obj.SetManagedFields(nil)
+	if obj.GetResourceVersion() == "" {
+		live, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("获取 %s %s 失败: %w", crd.Kind, name, err)
+		}
+		obj.SetResourceVersion(live.GetResourceVersion())
+	}
+	if _, err := resource.Update(ctx, obj, metav1.UpdateOptions{FieldManager: ManifestFieldManager}); err != nil {
+		return nil, fmt.Errorf("更新 %s %s 失败: %w", crd.Kind, name, err)
+	}
+	return s.GetCustomResource(ctx, clusterName, crdName, gv.Version, namespace, name)
+}
+
+// DeleteCustomResource 删除自定义资源实例
+func (s *CustomResourceService) DeleteCustomResource(ctx context.Context, clusterName, crdName, namespace, name string) error {
+	crd, _, resource, err := s.resolve(ctx, clusterName, crdName, "", namespace, true)
+	if err != nil {
+		return err
+	}
+	propagation := metav1.DeletePropagationBackground
+	if err := resource.Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+		return fmt.Errorf("删除 %s %s 失败: %w", crd.Kind, name, err)
+	}
+	return nil
+}
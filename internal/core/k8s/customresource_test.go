@@ -0,0 +1,74 @@
+package k8s
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestConvertCRDInfo(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "certificates.cert-manager.io"},
+		"spec": map[string]any{
+			"group": "cert-manager.io",
+			"names": map[string]any{"kind": "Certificate", "plural": "certificates", "shortNames": []any{"cert", "certs"}},
+			"scope": "Namespaced",
+			"versions": []any{
+				map[string]any{"name": "v1", "served": true, "storage": true, "additionalPrinterColumns": []any{
+					map[string]any{"name": "Ready", "type": "string", "jsonPath": `.status.conditions[?(@.type=="Ready")].status`},
+					map[string]any{"name": "Secret", "type": "string", "jsonPath": ".spec.secretName", "priority": int64(1)},
+				}},
+				map[string]any{"name": "v1alpha2", "served": false, "storage": false},
+			},
+		},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Established", "status": "True", "lastTransitionTime": "2026-01-02T03:04:05Z"},
+		}},
+	}}
+	info, err := convertCRDInfo(obj)
+	if err != nil {
+		t.Fatalf("convertCRDInfo: %v", err)
+	}
+	if info.Kind != "Certificate" || info.Group != "cert-manager.io" || info.Scope != "Namespaced" || !info.Established || len(info.ShortNames) != 2 {
+		t.Fatalf("unexpected CRD info: %+v", info)
+	}
+	if columns := info.printerColumnsFor("v1"); len(columns) != 2 || columns[1].Priority != 1 {
+		t.Fatalf("unexpected v1 printer columns: %+v", columns)
+	}
+	if columns := info.printerColumnsFor("v1alpha2"); columns == nil || len(columns) != 0 {
+		t.Fatalf("version without printer columns should have an empty list, got %v", columns)
+	}
+}
+
+func TestEvaluatePrinterColumnsAndConditions(t *testing.T) {
+	obj := map[string]any{
+		"spec": map[string]any{"secretName": "web-tls", "replicas": int64(3)},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Issuing", "status": "False"},
+			map[string]any{"type": "Ready", "status": "True", "reason": "Ready", "lastTransitionTime": "2026-01-02T03:04:05Z"},
+			map[string]any{"status": "True"},
+			"not-a-condition",
+		}},
+	}
+	columns := []PrinterColumn{
+		{Name: "Ready", JSONPath: `.status.conditions[?(@.type=="Ready")].status`},
+		{Name: "Secret", JSONPath: ".spec.secretName"},
+		{Name: "Replicas", JSONPath: ".spec.replicas"},
+		{Name: "Missing", JSONPath: ".status.notThere"},
+		{Name: "Invalid", JSONPath: ".spec[unclosed"},
+	}
+	values := evaluatePrinterColumns(columns, obj)
+	if values[0] != "True" || values[1] != "web-tls" || values[2] != int64(3) || values[3] != nil || values[4] != nil {
+		t.Fatalf("unexpected column values: %#v", values)
+	}
+
+	conditions := resourceConditions(obj)
+	if len(conditions) != 2 || conditions[1].Type != "Ready" || conditions[1].LastTransitionTime == nil {
+		t.Fatalf("unexpected conditions: %+v", conditions)
+	}
+	if resourceConditions(map[string]any{"status": map[string]any{}}) != nil {
+		t.Fatal("missing conditions should be nil")
+	}
+}
@@ -58,7 +58,7 @@ type UpdateDaemonSetRequest struct {
 
 // ListDaemonSets 获取 DaemonSet 列表
 func (s *DaemonSetService) ListDaemonSets(ctx context.Context, clusterName, namespace string) ([]DaemonSetInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (s *DaemonSetService) ListDaemonSets(ctx context.Context, clusterName, name
 
 // GetDaemonSet 获取 DaemonSet 详情
 func (s *DaemonSetService) GetDaemonSet(ctx context.Context, clusterName, namespace, name string) (*DaemonSetInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +97,7 @@ func (s *DaemonSetService) GetDaemonSet(ctx context.Context, clusterName, namesp
 
 // CreateDaemonSet 创建 DaemonSet
 func (s *DaemonSetService) CreateDaemonSet(ctx context.Context, clusterName, namespace string, req CreateDaemonSetRequest) (*DaemonSetInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +140,7 @@ func (s *DaemonSetService) CreateDaemonSet(ctx context.Context, clusterName, nam
 
 // UpdateDaemonSet 更新 DaemonSet
 func (s *DaemonSetService) UpdateDaemonSet(ctx context.Context, clusterName, namespace, name string, req UpdateDaemonSetRequest) (*DaemonSetInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -190,16 +190,36 @@ func (s *DaemonSetService) UpdateDaemonSet(ctx context.Context, clusterName, nam
 
 // DeleteDaemonSet 删除 DaemonSet
 func (s *DaemonSetService) DeleteDaemonSet(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
 	return client.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// RestartDaemonSet 滚动重启 DaemonSet（更新 Pod 模板的重启注解，与 kubectl rollout restart 一致）
+func (s *DaemonSetService) RestartDaemonSet(ctx context.Context, clusterName, namespace, name string) error {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("获取 DaemonSet 失败: %w", err)
+	}
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = make(map[string]string)
+	}
+	ds.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
+	if _, err := client.AppsV1().DaemonSets(namespace).Update(ctx, ds, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("重启 DaemonSet 失败: %w", err)
+	}
+	return nil
+}
+
 // GetDaemonSetPods 获取 DaemonSet 关联 Pod
 func (s *DaemonSetService) GetDaemonSetPods(ctx context.Context, clusterName, namespace, name string) ([]corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -100,13 +101,17 @@ type ResourceRequirements struct {
 }
 
 // ListDeployments 获取所有Deployment列表
-func (ds *DeploymentService) ListDeployments(clusterName string) ([]DeploymentInfo, error) {
-	client, err := ds.clientManager.GetClient(clusterName)
+func (ds *DeploymentService) ListDeployments(ctx context.Context, clusterName string) ([]DeploymentInfo, error) {
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
-	deployments, err := client.AppsV1().Deployments("").List(context.TODO(), metav1.ListOptions{})
+	if lister, ok := ds.clientManager.DeploymentLister(ctx, clusterName); ok {
+		return ds.listCachedDeployments(lister.List(labels.Everything()))
+	}
+
+	deployments, err := client.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取Deployments列表失败: %v", err)
 	}
@@ -115,13 +120,17 @@ func (ds *DeploymentService) ListDeployments(clusterName string) ([]DeploymentIn
 }
 
 // ListDeploymentsByNamespace 获取指定命名空间的Deployment列表
-func (ds *DeploymentService) ListDeploymentsByNamespace(clusterName, namespace string) ([]DeploymentInfo, error) {
-	client, err := ds.clientManager.GetClient(clusterName)
+func (ds *DeploymentService) ListDeploymentsByNamespace(ctx context.Context, clusterName, namespace string) ([]DeploymentInfo, error) {
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
-	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if lister, ok := ds.clientManager.DeploymentLister(ctx, clusterName); ok {
+		return ds.listCachedDeployments(lister.Deployments(namespace).List(labels.Everything()))
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取命名空间 %s 的Deployments列表失败: %v", namespace, err)
 	}
@@ -129,14 +138,27 @@ func (ds *DeploymentService) ListDeploymentsByNamespace(clusterName, namespace s
 	return ds.convertDeploymentList(deployments.Items), nil
 }
 
+// listCachedDeployments 转换共享缓存中的 Deployment，缓存对象只读
+func (ds *DeploymentService) listCachedDeployments(cached []*appsv1.Deployment, err error) ([]DeploymentInfo, error) {
+	if err != nil {
+		return nil, fmt.Errorf("获取Deployments列表失败: %v", err)
+	}
+	sortObjects(cached)
+	deployments := make([]appsv1.Deployment, 0, len(cached))
+	for _, deployment := range cached {
+		deployments = append(deployments, *deployment)
+	}
+	return ds.convertDeploymentList(deployments), nil
+}
+
 // GetDeploymentDetails 获取单个Deployment的详细信息
-func (ds *DeploymentService) GetDeploymentDetails(clusterName, namespace, name string) (*DeploymentDetails, error) {
-	client, err := ds.clientManager.GetClient(clusterName)
+func (ds *DeploymentService) GetDeploymentDetails(ctx context.Context, clusterName, namespace, name string) (*DeploymentDetails, error) {
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取Deployment详情失败: %v", err)
 	}
@@ -186,12 +208,12 @@ func (ds *DeploymentService) GetDeploymentDetails(clusterName, namespace, name s
 
 // GetDeploymentEvents 获取Deployment相关的事件
 func (s *DeploymentService) GetDeploymentEvents(ctx context.Context, clusterName, namespace, deploymentName string) ([]corev1.Event, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
 
-	deployment, err := s.GetDeploymentDetails(clusterName, namespace, deploymentName)
+	deployment, err := s.GetDeploymentDetails(ctx, clusterName, namespace, deploymentName)
 	if err != nil {
 		return nil, fmt.Errorf("获取Deployment详情失败: %w", err)
 	}
@@ -221,7 +243,7 @@ func (s *DeploymentService) GetDeploymentEvents(ctx context.Context, clusterName
 
 // GetDeploymentPodEvents 获取Deployment关联的所有Pod的事件
 func (s *DeploymentService) GetDeploymentPodEvents(ctx context.Context, clusterName, namespace, deploymentName string) ([]corev1.Event, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +326,7 @@ func (s *DeploymentService) GetAllDeploymentEvents(ctx context.Context, clusterN
 
 // GetReplicaSetEvents 获取ReplicaSet相关的事件
 func (s *DeploymentService) GetReplicaSetEvents(ctx context.Context, clusterName, namespace, deploymentName string) ([]corev1.Event, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -399,15 +421,15 @@ func (ds *DeploymentService) convertDeployment(deployment *appsv1.Deployment) De
 }
 
 // ScaleDeployment 调整Deployment的副本数
-func (ds *DeploymentService) ScaleDeployment(clusterName, namespace, name string, replicas int32) error {
+func (ds *DeploymentService) ScaleDeployment(ctx context.Context, clusterName, namespace, name string, replicas int32) error {
 	logger.Info("调整Deployment副本数:", clusterName, namespace, name, replicas)
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
 	// 获取当前Deployment
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("获取Deployment失败: %v", err)
 	}
@@ -416,7 +438,7 @@ func (ds *DeploymentService) ScaleDeployment(clusterName, namespace, name string
 	deployment.Spec.Replicas = &replicas
 
 	// 更新Deployment
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	_, err = client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("更新Deployment副本数失败: %v", err)
 	}
@@ -426,28 +448,28 @@ func (ds *DeploymentService) ScaleDeployment(clusterName, namespace, name string
 }
 
 // RestartDeployment 重启Deployment（通过添加重启注解实现）
-func (ds *DeploymentService) RestartDeployment(clusterName, namespace, name string) error {
+func (ds *DeploymentService) RestartDeployment(ctx context.Context, clusterName, namespace, name string) error {
 	logger.Info("重启Deployment:", clusterName, namespace, name)
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
 	// 获取当前Deployment
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		logger.Error("获取Deployment失败", err)
 		return fmt.Errorf("获取Deployment失败: %v", err)
 	}
 
-	// 添加或更新重启注解
-	if deployment.Annotations == nil {
-		deployment.Annotations = make(map[string]string)
+	// 在 Pod 模板上添加或更新重启注解，模板变化才会触发滚动更新
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = make(map[string]string)
 	}
-	deployment.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
+	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
 
 	// 更新Deployment
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	_, err = client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		logger.Error("更新Deployment失败", err)
 		return fmt.Errorf("重启Deployment失败: %v", err)
@@ -733,15 +755,15 @@ type Capabilities struct {
 }
 
 // UpdateDeployment 更新Deployment配置
-func (ds *DeploymentService) UpdateDeployment(clusterName, namespace, name string, update UpdateDeploymentRequest) error {
+func (ds *DeploymentService) UpdateDeployment(ctx context.Context, clusterName, namespace, name string, update UpdateDeploymentRequest) error {
 	logger.Info("更新Deployment:", clusterName, namespace, name)
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
 	// 获取当前Deployment
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		logger.Error("获取Deployment失败", err)
 		return fmt.Errorf("获取Deployment失败: %v", err)
@@ -1005,7 +1027,7 @@ func (ds *DeploymentService) UpdateDeployment(clusterName, namespace, name strin
 	}
 
 	// 更新Deployment
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	_, err = client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("更新Deployment失败: %v", err)
 	}
@@ -1015,8 +1037,8 @@ func (ds *DeploymentService) UpdateDeployment(clusterName, namespace, name strin
 }
 
 // CreateDeployment 创建新的Deployment
-func (ds *DeploymentService) CreateDeployment(clusterName, namespace string, create CreateDeploymentRequest) (*DeploymentInfo, error) {
-	client, err := ds.clientManager.GetClient(clusterName)
+func (ds *DeploymentService) CreateDeployment(ctx context.Context, clusterName, namespace string, create CreateDeploymentRequest) (*DeploymentInfo, error) {
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群客户端失败: %v", err)
 	}
@@ -1395,7 +1417,7 @@ func (ds *DeploymentService) CreateDeployment(clusterName, namespace string, cre
 	}
 
 	// 创建Deployment
-	result, err := client.AppsV1().Deployments(namespace).Create(context.TODO(), deployment, metav1.CreateOptions{})
+	result, err := client.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("创建Deployment失败: %v", err)
 	}
@@ -1472,9 +1494,9 @@ func convertKeyToPath(items []KeyToPath) []corev1.KeyToPath {
 }
 
 // DeleteDeployment 删除指定的Deployment
-func (ds *DeploymentService) DeleteDeployment(clusterName, namespace, name string) error {
+func (ds *DeploymentService) DeleteDeployment(ctx context.Context, clusterName, namespace, name string) error {
 	logger.Info("删除Deployment:", clusterName, namespace, name)
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群客户端失败: %v", err)
 	}
@@ -1485,7 +1507,7 @@ func (ds *DeploymentService) DeleteDeployment(clusterName, namespace, name strin
 		PropagationPolicy: &deletePolicy,
 	}
 
-	err = client.AppsV1().Deployments(namespace).Delete(context.TODO(), name, deleteOptions)
+	err = client.AppsV1().Deployments(namespace).Delete(ctx, name, deleteOptions)
 	if err != nil {
 		logger.Error("删除Deployment失败", err)
 		return fmt.Errorf("删除Deployment失败: %v", err)
@@ -1511,22 +1533,22 @@ type RevisionInfo struct {
 }
 
 // GetDeploymentRolloutHistory 获取Deployment的版本历史
-func (ds *DeploymentService) GetDeploymentRolloutHistory(clusterName, namespace, name string) ([]RevisionInfo, error) {
+func (ds *DeploymentService) GetDeploymentRolloutHistory(ctx context.Context, clusterName, namespace, name string) ([]RevisionInfo, error) {
 	logger.Info("获取Deployment版本历史:", clusterName, namespace, name)
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
 	// 获取Deployment
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取Deployment失败: %v", err)
 	}
 
 	// 获取与Deployment关联的所有ReplicaSets
 	labelSelector := metav1.FormatLabelSelector(deployment.Spec.Selector)
-	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
 	if err != nil {
@@ -1580,10 +1602,10 @@ func (ds *DeploymentService) GetDeploymentRolloutHistory(clusterName, namespace,
 }
 
 // GetDeploymentRevisionDetails 获取指定版本的详细信息
-func (ds *DeploymentService) GetDeploymentRevisionDetails(clusterName, namespace, name string, revision int64) (*RevisionInfo, error) {
+func (ds *DeploymentService) GetDeploymentRevisionDetails(ctx context.Context, clusterName, namespace, name string, revision int64) (*RevisionInfo, error) {
 	logger.Info("获取Deployment版本详情:", clusterName, namespace, name, "版本:", revision)
 
-	revisions, err := ds.GetDeploymentRolloutHistory(clusterName, namespace, name)
+	revisions, err := ds.GetDeploymentRolloutHistory(ctx, clusterName, namespace, name)
 	if err != nil {
 		return nil, err
 	}
@@ -1598,21 +1620,21 @@ func (ds *DeploymentService) GetDeploymentRevisionDetails(clusterName, namespace
 }
 
 // RollbackDeployment 回滚Deployment到指定版本
-func (ds *DeploymentService) RollbackDeployment(clusterName, namespace, name string, revision int64) error {
+func (ds *DeploymentService) RollbackDeployment(ctx context.Context, clusterName, namespace, name string, revision int64) error {
 	logger.Info("回滚Deployment:", clusterName, namespace, name, "到版本:", revision)
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群客户端失败: %v", err)
 	}
 
 	// 获取当前Deployment
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("获取Deployment失败: %v", err)
 	}
 
 	// 获取目标版本的详细信息
-	targetRevision, err := ds.GetDeploymentRevisionDetails(clusterName, namespace, name, revision)
+	targetRevision, err := ds.GetDeploymentRevisionDetails(ctx, clusterName, namespace, name, revision)
 	if err != nil {
 		return fmt.Errorf("获取目标版本详情失败: %v", err)
 	}
@@ -1628,7 +1650,7 @@ func (ds *DeploymentService) RollbackDeployment(clusterName, namespace, name str
 	deployment.Annotations["kubernetes.io/change-cause"] = fmt.Sprintf("Rollback to revision %d", revision)
 
 	// 更新Deployment
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	_, err = client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("回滚Deployment失败: %v", err)
 	}
@@ -1638,10 +1660,10 @@ func (ds *DeploymentService) RollbackDeployment(clusterName, namespace, name str
 }
 
 // RollbackToPreviousRevision 回滚到上一个版本
-func (ds *DeploymentService) RollbackToPreviousRevision(clusterName, namespace, name string) error {
+func (ds *DeploymentService) RollbackToPreviousRevision(ctx context.Context, clusterName, namespace, name string) error {
 	logger.Info("回滚Deployment到上一个版本:", clusterName, namespace, name)
 
-	revisions, err := ds.GetDeploymentRolloutHistory(clusterName, namespace, name)
+	revisions, err := ds.GetDeploymentRolloutHistory(ctx, clusterName, namespace, name)
 	if err != nil {
 		return err
 	}
@@ -1653,5 +1675,5 @@ func (ds *DeploymentService) RollbackToPreviousRevision(clusterName, namespace,
 	// 获取上一个版本（第二个，因为第一个是当前版本）
 	previousRevision := revisions[1].Revision
 
-	return ds.RollbackDeployment(clusterName, namespace, name, previousRevision)
+	return ds.RollbackDeployment(ctx, clusterName, namespace, name, previousRevision)
 }
@@ -18,6 +18,7 @@ type RolloutStatus struct {
 	Replicas            int32                 `json:"replicas"`
 	ObservedGeneration  int64                 `json:"observedGeneration"`
 	Paused              bool                  `json:"paused"`
+	Complete            bool                  `json:"complete"` // 最新配置已生效，全部副本为新版本且可用
 	Conditions          []DeploymentCondition `json:"conditions,omitempty"`
 }
 
@@ -28,11 +29,12 @@ type CreateCanaryDeploymentRequest struct {
 	Labels         map[string]string `json:"labels,omitempty"`
 	CanaryLabelKey string            `json:"canaryLabelKey,omitempty"`
 	CanaryLabelVal string            `json:"canaryLabelValue,omitempty"`
+	Images         map[string]string `json:"images,omitempty"` // 容器名 -> 金丝雀版本镜像，未指定的容器沿用基础版本
 }
 
 // PauseRollout 暂停 Deployment 滚动更新
 func (ds *DeploymentService) PauseRollout(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -47,7 +49,7 @@ func (ds *DeploymentService) PauseRollout(ctx context.Context, clusterName, name
 
 // ResumeRollout 恢复 Deployment 滚动更新
 func (ds *DeploymentService) ResumeRollout(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -62,7 +64,7 @@ func (ds *DeploymentService) ResumeRollout(ctx context.Context, clusterName, nam
 
 // GetRolloutStatus 获取 Deployment 滚动更新状态
 func (ds *DeploymentService) GetRolloutStatus(ctx context.Context, clusterName, namespace, name string) (*RolloutStatus, error) {
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +80,7 @@ func (ds *DeploymentService) GetRolloutStatus(ctx context.Context, clusterName,
 		Replicas:            deployment.Status.Replicas,
 		ObservedGeneration:  deployment.Status.ObservedGeneration,
 		Paused:              deployment.Spec.Paused,
+		Complete:            deploymentSettled(deployment, desiredReplicas(deployment)),
 	}
 	for _, c := range deployment.Status.Conditions {
 		status.Conditions = append(status.Conditions, DeploymentCondition{
@@ -94,7 +97,7 @@ func (ds *DeploymentService) GetRolloutStatus(ctx context.Context, clusterName,
 
 // CreateCanaryDeployment 基于现有 Deployment 创建金丝雀版本
 func (ds *DeploymentService) CreateCanaryDeployment(ctx context.Context, clusterName, namespace, baseName string, req CreateCanaryDeploymentRequest) (*DeploymentInfo, error) {
-	client, err := ds.clientManager.GetClient(clusterName)
+	client, err := ds.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -102,11 +105,25 @@ func (ds *DeploymentService) CreateCanaryDeployment(ctx context.Context, cluster
 	if err != nil {
 		return nil, fmt.Errorf("获取基础 Deployment 失败: %w", err)
 	}
+	canary, err := buildCanaryDeployment(base, req)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.AppsV1().Deployments(namespace).Create(ctx, canary, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("创建金丝雀 Deployment 失败: %w", err)
+	}
+	info := ds.convertDeployment(created)
+	return &info, nil
+}
+
+// buildCanaryDeployment 复制基础 Deployment 的 Pod 模板，在标签与选择器中加上金丝雀标签
+func buildCanaryDeployment(base *appsv1.Deployment, req CreateCanaryDeploymentRequest) (*appsv1.Deployment, error) {
 	if base.Spec.Selector == nil || len(base.Spec.Selector.MatchLabels) == 0 {
 		return nil, fmt.Errorf("基础 Deployment 缺少 selector.matchLabels，无法创建金丝雀版本")
 	}
 
-	canaryName := req.Name
 	labelKey := req.CanaryLabelKey
 	if labelKey == "" {
 		labelKey = "track"
@@ -132,8 +149,8 @@ func (ds *DeploymentService) CreateCanaryDeployment(ctx context.Context, cluster
 
 	canary := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        canaryName,
-			Namespace:   namespace,
+			Name:        req.Name,
+			Namespace:   base.Namespace,
 			Labels:      mergeStringMaps(base.Labels, req.Labels),
 			Annotations: copyStringMap(base.Annotations),
 		},
@@ -154,12 +171,28 @@ func (ds *DeploymentService) CreateCanaryDeployment(ctx context.Context, cluster
 		},
 	}
 
-	created, err := client.AppsV1().Deployments(namespace).Create(ctx, canary, metav1.CreateOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("创建金丝雀 Deployment 失败: %w", err)
+	containers := canary.Spec.Template.Spec.Containers
+	for name, image := range req.Images {
+		found := false
+		for i := range containers {
+			if containers[i].Name == name {
+				containers[i].Image = image
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("基础 Deployment 中不存在容器 %s", name)
+		}
+	}
+	return canary, nil
+}
+
+// desiredReplicas 返回 Deployment 期望副本数，未设置时为 1
+func desiredReplicas(d *appsv1.Deployment) int32 {
+	if d.Spec.Replicas == nil {
+		return 1
 	}
-	info := ds.convertDeployment(created)
-	return &info, nil
+	return *d.Spec.Replicas
 }
 
 func mergeStringMaps(base, extra map[string]string) map[string]string {
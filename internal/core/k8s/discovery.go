@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// clusterDiscovery 集群的 API 发现缓存，RESTMapper 基于同一份缓存
+type clusterDiscovery struct {
+	client discovery.CachedDiscoveryInterface
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+}
+
+// discoveryFor 返回集群的发现缓存，首次使用时创建。
+// 发现信息只描述集群提供了哪些资源类型，与请求用户无关，因此使用 kubeconfig 身份并按集群共享。
+func (cm *ClientManager) discoveryFor(clusterName string) (*clusterDiscovery, error) {
+	cm.mutex.RLock()
+	cd, ok := cm.discovery[clusterName]
+	cm.mutex.RUnlock()
+	if ok {
+		return cd, nil
+	}
+
+	config, err := cm.GetConfig(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("创建发现客户端失败: %w", err)
+	}
+	cached := memory.NewMemCacheClient(client)
+	cd = &clusterDiscovery{client: cached, mapper: restmapper.NewDeferredDiscoveryRESTMapper(cached)}
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	if existing, ok := cm.discovery[clusterName]; ok {
+		return existing, nil
+	}
+	cm.discovery[clusterName] = cd
+	return cd, nil
+}
+
+// RESTMapping 将 GroupVersionKind 映射为资源，找不到时刷新发现缓存后重试一次，以识别新安装的 CRD
+func (cm *ClientManager) RESTMapping(clusterName string, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	cd, err := cm.discoveryFor(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := cd.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		cd.mapper.Reset()
+		mapping, err = cd.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("集群不支持资源类型 %s: %w", gvk.String(), err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,379 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"kube-tide/internal/utils/logger"
+)
+
+// ErrInvalidAlertRule 告警规则不合法
+var ErrInvalidAlertRule = errors.New("无效的告警规则")
+
+// ErrAlertRuleNotFound 告警规则不存在
+var ErrAlertRuleNotFound = errors.New("告警规则不存在")
+
+// 告警状态
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+const (
+	// maxAlertHistory 内存中保留的已恢复告警数量
+	maxAlertHistory = 200
+
+	alertNotifyTimeout = 10 * time.Second
+)
+
+var alertRuleNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,62}$`)
+
+// AlertRule 告警规则：匹配的集群与检查项达到 Severity（含更严重的状态）并持续 ForSeconds 后触发，恢复到更低状态时解除
+type AlertRule struct {
+	Name       string   `json:"name"`
+	Enabled    bool     `json:"enabled"`
+	Clusters   []string `json:"clusters,omitempty"`   // 为空表示全部集群
+	Checks     []string `json:"checks,omitempty"`     // 为空表示全部检查项
+	Severity   string   `json:"severity"`             // warning / critical
+	ForSeconds int      `json:"forSeconds,omitempty"` // 状态持续多久后触发，0 表示立即触发
+}
+
+// Alert 一条告警，同一规则、集群与检查项同时只有一条 firing 的告警
+type Alert struct {
+	ID       string    `json:"id"` // <规则>/<集群>/<检查项>
+	Rule     string    `json:"rule"`
+	Cluster  string    `json:"cluster"`
+	Check    string    `json:"check"`
+	Status   string    `json:"status"` // 检查项当前（或恢复前）的状态
+	Message  string    `json:"message"`
+	Details  []string  `json:"details,omitempty"`
+	State    string    `json:"state"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt,omitzero"`
+}
+
+// AlertList 告警列表：正在触发的告警与最近恢复的告警
+type AlertList struct {
+	Firing   []Alert `json:"firing"`
+	Resolved []Alert `json:"resolved"`
+}
+
+// AlertNotifier 告警触发、升级与恢复时的通知方式
+type AlertNotifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// DefaultAlertRules 首次启动且没有任何规则时写入的默认规则
+func DefaultAlertRules() []AlertRule {
+	return []AlertRule{
+		{Name: "critical", Enabled: true, Severity: HealthStatusCritical},
+		{Name: "warning", Enabled: true, Severity: HealthStatusWarning, ForSeconds: 600},
+	}
+}
+
+// validateAlertRule 校验规则名称、状态、检查项与持续时间
+func validateAlertRule(rule AlertRule) error {
+	if !alertRuleNamePattern.MatchString(rule.Name) {
+		return fmt.Errorf("%w: 名称只能包含字母、数字、'-'、'_'、'.'，且不超过 63 个字符", ErrInvalidAlertRule)
+	}
+	if rule.Severity != HealthStatusWarning && rule.Severity != HealthStatusCritical {
+		return fmt.Errorf("%w: severity 只能为 %s 或 %s", ErrInvalidAlertRule, HealthStatusWarning, HealthStatusCritical)
+	}
+	for _, check := range rule.Checks {
+		if !slices.Contains(HealthChecks, check) {
+			return fmt.Errorf("%w: 未知的检查项 %q", ErrInvalidAlertRule, check)
+		}
+	}
+	if rule.ForSeconds < 0 {
+		return fmt.Errorf("%w: forSeconds 不能为负数", ErrInvalidAlertRule)
+	}
+	return nil
+}
+
+func (r AlertRule) matches(cluster, check string) bool {
+	return r.Enabled &&
+		(len(r.Clusters) == 0 || slices.Contains(r.Clusters, cluster)) &&
+		(len(r.Checks) == 0 || slices.Contains(r.Checks, check))
+}
+
+// pendingAlert 已达到规则状态但未满 ForSeconds 的告警
+type pendingAlert struct {
+	cluster string
+	since   time.Time
+}
+
+// AlertEngine 根据集群健康检查结果评估告警规则，在告警触发、升级与恢复时通知
+type AlertEngine struct {
+	store     AlertRuleStore
+	notifiers []AlertNotifier
+	rules     []AlertRule
+	firing    map[string]*Alert
+	pending   map[string]pendingAlert
+	resolved  []Alert // 最近恢复的告警，新的在前
+	mutex     sync.Mutex
+}
+
+// NewAlertEngine 创建告警引擎并加载规则，没有任何规则时写入默认规则
+func NewAlertEngine(store AlertRuleStore, notifiers ...AlertNotifier) (*AlertEngine, error) {
+	rules, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		rules = DefaultAlertRules()
+		for _, rule := range rules {
+			if err := store.Save(rule); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &AlertEngine{
+		store:     store,
+		notifiers: notifiers,
+		rules:     rules,
+		firing:    make(map[string]*Alert),
+		pending:   make(map[string]pendingAlert),
+	}, nil
+}
+
+// Rules 按名称返回告警规则
+func (e *AlertEngine) Rules() []AlertRule {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return slices.Clone(e.rules)
+}
+
+// SaveRule 新增或覆盖告警规则，下一次检查时生效
+func (e *AlertEngine) SaveRule(rule AlertRule) error {
+	if err := validateAlertRule(rule); err != nil {
+		return err
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if err := e.store.Save(rule); err != nil {
+		return err
+	}
+	i := slices.IndexFunc(e.rules, func(r AlertRule) bool { return r.Name == rule.Name })
+	if i >= 0 {
+		e.rules[i] = rule
+	} else {
+		e.rules = append(e.rules, rule)
+		sort.Slice(e.rules, func(i, j int) bool { return e.rules[i].Name < e.rules[j].Name })
+	}
+	return nil
+}
+
+// DeleteRule 删除告警规则，由该规则触发的告警在下一次检查时恢复
+func (e *AlertEngine) DeleteRule(name string) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	i := slices.IndexFunc(e.rules, func(r AlertRule) bool { return r.Name == name })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrAlertRuleNotFound, name)
+	}
+	if err := e.store.Delete(name); err != nil {
+		return err
+	}
+	e.rules = slices.Delete(e.rules, i, i+1)
+	return nil
+}
+
+// Alerts 返回指定集群（为空时为全部集群）正在触发与最近恢复的告警
+func (e *AlertEngine) Alerts(cluster string) AlertList {
+	list := AlertList{Firing: []Alert{}, Resolved: []Alert{}}
+	if e == nil {
+		return list
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, alert := range e.firing {
+		if cluster == "" || alert.Cluster == cluster {
+			list.Firing = append(list.Firing, *alert)
+		}
+	}
+	sort.Slice(list.Firing, func(i, j int) bool { return list.Firing[i].StartsAt.After(list.Firing[j].StartsAt) })
+	for _, alert := range e.resolved {
+		if cluster == "" || alert.Cluster == cluster {
+			list.Resolved = append(list.Resolved, alert)
+		}
+	}
+	return list
+}
+
+// evaluate 按集群最新的检查结果更新告警状态。unknown 的检查项保持原有告警状态不变
+func (e *AlertEngine) evaluate(health *ClusterHealth, now time.Time) {
+	if e == nil {
+		return
+	}
+	var notify []Alert
+	e.mutex.Lock()
+	seen := make(map[string]bool)
+	for _, rule := range e.rules {
+		for _, check := range health.Checks {
+			if !rule.matches(health.Cluster, check.Name) {
+				continue
+			}
+			id := rule.Name + "/" + health.Cluster + "/" + check.Name
+			seen[id] = true
+			if check.Status == HealthStatusUnknown {
+				continue
+			}
+			alert := e.firing[id]
+			if healthStatusRank(check.Status) < healthStatusRank(rule.Severity) {
+				delete(e.pending, id)
+				if alert != nil {
+					alert.Status = check.Status
+					alert.Message = check.Message
+					alert.Details = check.Details
+					notify = append(notify, e.resolveLocked(id, now))
+				}
+				continue
+			}
+			if alert != nil {
+				// 已触发的告警状态升级或降级（仍满足规则）时再次通知
+				changed := alert.Status != check.Status
+				alert.Status = check.Status
+				alert.Message = check.Message
+				alert.Details = check.Details
+				if changed {
+					notify = append(notify, *alert)
+				}
+				continue
+			}
+			pending, ok := e.pending[id]
+			if !ok {
+				pending = pendingAlert{cluster: health.Cluster, since: now}
+				e.pending[id] = pending
+			}
+			if now.Sub(pending.since) < time.Duration(rule.ForSeconds)*time.Second {
+				continue
+			}
+			delete(e.pending, id)
+			alert = &Alert{
+				ID:       id,
+				Rule:     rule.Name,
+				Cluster:  health.Cluster,
+				Check:    check.Name,
+				Status:   check.Status,
+				Message:  check.Message,
+				Details:  check.Details,
+				State:    AlertFiring,
+				StartsAt: pending.since,
+			}
+			e.firing[id] = alert
+			notify = append(notify, *alert)
+		}
+	}
+	// 规则被删除、停用或不再匹配该集群时恢复其告警
+	for id, alert := range e.firing {
+		if alert.Cluster == health.Cluster && !seen[id] {
+			notify = append(notify, e.resolveLocked(id, now))
+		}
+	}
+	for id, pending := range e.pending {
+		if pending.cluster == health.Cluster && !seen[id] {
+			delete(e.pending, id)
+		}
+	}
+	e.mutex.Unlock()
+
+	for _, alert := range notify {
+		e.dispatch(alert)
+	}
+}
+
+// forgetClusters 丢弃已移除集群的告警状态，不发送通知
+func (e *AlertEngine) forgetClusters(active map[string]bool) {
+	if e == nil {
+		return
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for id, alert := range e.firing {
+		if !active[alert.Cluster] {
+			e.resolveLocked(id, time.Now())
+		}
+	}
+	for id, pending := range e.pending {
+		if !active[pending.cluster] {
+			delete(e.pending, id)
+		}
+	}
+}
+
+func (e *AlertEngine) resolveLocked(id string, now time.Time) Alert {
+	alert := *e.firing[id]
+	delete(e.firing, id)
+	alert.State = AlertResolved
+	alert.EndsAt = now
+	e.resolved = append([]Alert{alert}, e.resolved...)
+	if len(e.resolved) > maxAlertHistory {
+		e.resolved = e.resolved[:maxAlertHistory]
+	}
+	return alert
+}
+
+// dispatch 记录日志并异步通知，通知失败不影响健康检查
+func (e *AlertEngine) dispatch(alert Alert) {
+	if alert.State == AlertFiring {
+		logger.Warn("集群告警触发", "id", alert.ID, "status", alert.Status, "message", alert.Message)
+	} else {
+		logger.Info("集群告警恢复", "id", alert.ID, "message", alert.Message)
+	}
+	for _, notifier := range e.notifiers {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), alertNotifyTimeout)
+			defer cancel()
+			if err := notifier.Notify(ctx, alert); err != nil {
+				logger.Warn("发送告警通知失败", "id", alert.ID, "error", err.Error())
+			}
+		}()
+	}
+}
+
+// WebhookNotifier 以 JSON POST 告警到配置的地址
+type WebhookNotifier struct {
+	urls       []string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier 创建 Webhook 通知
+func NewWebhookNotifier(urls []string) *WebhookNotifier {
+	return &WebhookNotifier{urls: urls, httpClient: &http.Client{Timeout: alertNotifyTimeout}}
+}
+
+// Notify 依次 POST 到每个地址，返回所有失败
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, url := range n.urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := n.httpClient.Do(req)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			errs = append(errs, fmt.Errorf("webhook %s 返回 %d", url, resp.StatusCode))
+		}
+	}
+	return errors.Join(errs...)
+}
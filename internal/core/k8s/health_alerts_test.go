@@ -0,0 +1,179 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// recordingNotifier 收集通知，dispatch 为异步调用
+type recordingNotifier chan Alert
+
+func (n recordingNotifier) Notify(ctx context.Context, alert Alert) error {
+	n <- alert
+	return nil
+}
+
+func (n recordingNotifier) next(t *testing.T) Alert {
+	t.Helper()
+	select {
+	case alert := <-n:
+		return alert
+	case <-time.After(time.Second):
+		t.Fatal("expected a notification")
+		return Alert{}
+	}
+}
+
+func (n recordingNotifier) none(t *testing.T) {
+	t.Helper()
+	select {
+	case alert := <-n:
+		t.Fatalf("unexpected notification: %+v", alert)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func clusterHealth(cluster string, statuses map[string]string) *ClusterHealth {
+	health := &ClusterHealth{Cluster: cluster}
+	for _, name := range HealthChecks {
+		status := statuses[name]
+		if status == "" {
+			status = HealthStatusOK
+		}
+		health.Checks = append(health.Checks, HealthCheck{Name: name, Status: status, Message: name + " " + status})
+	}
+	return health
+}
+
+func TestAlertEngine(t *testing.T) {
+	store, err := NewFileAlertRuleStore(filepath.Join(t.TempDir(), "alert-rules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notifier := make(recordingNotifier, 10)
+	engine, err := NewAlertEngine(store, notifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules := engine.Rules(); len(rules) != 2 || rules[0].Name != "critical" {
+		t.Fatalf("default rules should be seeded: %+v", rules)
+	}
+	if err := engine.DeleteRule("warning"); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.SaveRule(AlertRule{Name: "nodes-warning", Enabled: true, Checks: []string{HealthCheckNodes}, Severity: HealthStatusWarning, ForSeconds: 120}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	engine.evaluate(clusterHealth("prod", map[string]string{HealthCheckNodes: HealthStatusWarning}), start)
+	notifier.none(t)
+	engine.evaluate(clusterHealth("prod", map[string]string{HealthCheckNodes: HealthStatusWarning}), start.Add(2*time.Minute))
+	fired := notifier.next(t)
+	if fired.ID != "nodes-warning/prod/nodes" || fired.State != AlertFiring || !fired.StartsAt.Equal(start) {
+		t.Fatalf("unexpected alert: %+v", fired)
+	}
+
+	// 升级为 critical：已有告警再次通知，critical 规则立即触发
+	engine.evaluate(clusterHealth("prod", map[string]string{HealthCheckNodes: HealthStatusCritical}), start.Add(3*time.Minute))
+	got := map[string]string{}
+	for range 2 {
+		alert := notifier.next(t)
+		got[alert.ID] = alert.Status
+	}
+	if got["nodes-warning/prod/nodes"] != HealthStatusCritical || got["critical/prod/nodes"] != HealthStatusCritical {
+		t.Fatalf("unexpected escalation notifications: %v", got)
+	}
+
+	// unknown 不改变告警状态
+	engine.evaluate(clusterHealth("prod", map[string]string{HealthCheckNodes: HealthStatusUnknown}), start.Add(4*time.Minute))
+	notifier.none(t)
+	if alerts := engine.Alerts("prod"); len(alerts.Firing) != 2 {
+		t.Fatalf("unknown status should keep alerts firing: %+v", alerts)
+	}
+
+	// 降为 warning：critical 规则恢复，warning 规则的告警仍在触发
+	engine.evaluate(clusterHealth("prod", map[string]string{HealthCheckNodes: HealthStatusWarning}), start.Add(5*time.Minute))
+	got = map[string]string{}
+	for range 2 {
+		alert := notifier.next(t)
+		got[alert.ID] = alert.State
+	}
+	if got["critical/prod/nodes"] != AlertResolved || got["nodes-warning/prod/nodes"] != AlertFiring {
+		t.Fatalf("unexpected de-escalation notifications: %v", got)
+	}
+
+	// 删除规则后其告警在下一次检查时恢复
+	if err := engine.DeleteRule("nodes-warning"); err != nil {
+		t.Fatal(err)
+	}
+	engine.evaluate(clusterHealth("prod", map[string]string{HealthCheckNodes: HealthStatusWarning}), start.Add(6*time.Minute))
+	if resolved := notifier.next(t); resolved.ID != "nodes-warning/prod/nodes" || resolved.State != AlertResolved {
+		t.Fatalf("deleted rule should resolve its alert: %+v", resolved)
+	}
+	alerts := engine.Alerts("prod")
+	if len(alerts.Firing) != 0 || len(alerts.Resolved) != 2 || alerts.Resolved[0].Rule != "nodes-warning" {
+		t.Fatalf("unexpected alert list: %+v", alerts)
+	}
+	if other := engine.Alerts("dev"); len(other.Resolved) != 0 {
+		t.Fatalf("alerts should be filtered by cluster: %+v", other)
+	}
+
+	// 规则持久化，重新加载后不再写入默认规则
+	reloaded, err := NewAlertEngine(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules := reloaded.Rules(); len(rules) != 1 || rules[0].Name != "critical" {
+		t.Fatalf("unexpected reloaded rules: %+v", rules)
+	}
+}
+
+func TestValidateAlertRule(t *testing.T) {
+	invalid := []AlertRule{
+		{Name: "", Severity: HealthStatusWarning},
+		{Name: "a/b", Severity: HealthStatusWarning},
+		{Name: "ok", Severity: HealthStatusOK},
+		{Name: "ok", Severity: HealthStatusWarning, Checks: []string{"disk"}},
+		{Name: "ok", Severity: HealthStatusWarning, ForSeconds: -1},
+	}
+	for i, rule := range invalid {
+		if err := validateAlertRule(rule); !errors.Is(err, ErrInvalidAlertRule) {
+			t.Errorf("rule %d should be rejected, got %v", i, err)
+		}
+	}
+	if err := validateAlertRule(AlertRule{Name: "prod.certs", Severity: HealthStatusCritical, Checks: []string{HealthCheckCertificates}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Error(err)
+		}
+		received <- alert
+	}))
+	defer server.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	notifier := NewWebhookNotifier([]string{server.URL, failing.URL})
+	err := notifier.Notify(context.Background(), Alert{ID: "critical/prod/apiserver", State: AlertFiring})
+	if err == nil {
+		t.Fatal("failing webhook should be reported")
+	}
+	if alert := <-received; alert.ID != "critical/prod/apiserver" {
+		t.Fatalf("unexpected webhook payload: %+v", alert)
+	}
+}
@@ -95,7 +95,7 @@ func (s *HPAService) listOptions(namespace string) (string, error) {
 
 // ListHPAs 获取 HPA 列表
 func (s *HPAService) ListHPAs(ctx context.Context, clusterName, namespace string) ([]HPAInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +123,7 @@ func (s *HPAService) ListHPAs(ctx context.Context, clusterName, namespace string
 
 // GetHPA 获取 HPA 详情
 func (s *HPAService) GetHPA(ctx context.Context, clusterName, namespace, name string) (*HPADetails, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +141,7 @@ func (s *HPAService) GetHPA(ctx context.Context, clusterName, namespace, name st
 
 // CreateHPA 创建 HPA
 func (s *HPAService) CreateHPA(ctx context.Context, clusterName, namespace string, req CreateHPARequest) (*HPAInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +176,7 @@ func (s *HPAService) CreateHPA(ctx context.Context, clusterName, namespace strin
 
 // UpdateHPA 更新 HPA
 func (s *HPAService) UpdateHPA(ctx context.Context, clusterName, namespace, name string, req UpdateHPARequest) (*HPAInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +209,7 @@ func (s *HPAService) UpdateHPA(ctx context.Context, clusterName, namespace, name
 
 // DeleteHPA 删除 HPA
 func (s *HPAService) DeleteHPA(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
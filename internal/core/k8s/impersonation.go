@@ -0,0 +1,175 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/logger"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// maxImpersonatedClients 模拟用户客户端缓存上限，超出时淘汰最久未使用的条目
+const maxImpersonatedClients = 256
+
+// impersonatedClient 以某个平台用户身份访问集群的客户端
+type impersonatedClient struct {
+	cluster  string
+	client   *kubernetes.Clientset
+	config   *rest.Config
+	lastUsed time.Time
+}
+
+// impersonationKey 缓存键：集群 + 用户名 + 排序后的用户组
+func impersonationKey(cluster string, identity *auth.Identity) string {
+	groups := slices.Clone(identity.Groups)
+	slices.Sort(groups)
+	return cluster + "\x00" + identity.Username + "\x00" + strings.Join(groups, ",")
+}
+
+// impersonatedIdentity 返回需要模拟的用户；未登录或认证关闭（匿名）时不模拟
+func impersonatedIdentity(ctx context.Context) *auth.Identity {
+	if ctx == nil {
+		return nil
+	}
+	identity := auth.IdentityFromContext(ctx)
+	if identity == nil || identity.Method == auth.MethodAnonymous || identity.Username == "" {
+		return nil
+	}
+	return identity
+}
+
+// SetImpersonation 开启或关闭集群的用户模拟，并写入持久化存储
+func (cm *ClientManager) SetImpersonation(clusterName string, enabled bool) error {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if _, exists := cm.clients[clusterName]; !exists {
+		return fmt.Errorf("cluster %s not found", clusterName)
+	}
+	if cm.store != nil {
+		cluster, err := cm.store.Get(clusterName)
+		if err != nil {
+			return fmt.Errorf("failed to load cluster from store: %w", err)
+		}
+		cluster.Impersonate = enabled
+		if err := cm.store.Save(cluster); err != nil {
+			return fmt.Errorf("failed to persist cluster: %w", err)
+		}
+	}
+	cm.setImpersonationLocked(clusterName, enabled)
+	return nil
+}
+
+// IsImpersonationEnabled 集群是否开启用户模拟
+func (cm *ClientManager) IsImpersonationEnabled(clusterName string) bool {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	return cm.impersonate[clusterName]
+}
+
+// setImpersonationLocked 更新开关并清理该集群已缓存的模拟客户端
+func (cm *ClientManager) setImpersonationLocked(clusterName string, enabled bool) {
+	if enabled {
+		cm.impersonate[clusterName] = true
+	} else {
+		delete(cm.impersonate, clusterName)
+	}
+	for key, entry := range cm.impersonated {
+		if entry.cluster == clusterName {
+			delete(cm.impersonated, key)
+		}
+	}
+}
+
+// GetClientFor 返回处理当前请求使用的客户端。
+// 集群开启用户模拟且 ctx 携带已认证用户时，返回以该用户及其用户组 Impersonate 的客户端，
+// 否则返回 kubeconfig 身份的共享客户端（后台任务等无用户上下文的调用也走这里）。
+func (cm *ClientManager) GetClientFor(ctx context.Context, clusterName string) (*kubernetes.Clientset, error) {
+	identity := impersonatedIdentity(ctx)
+	if identity == nil || !cm.IsImpersonationEnabled(clusterName) {
+		return cm.GetClient(clusterName)
+	}
+	entry, err := cm.getImpersonated(clusterName, identity)
+	if err != nil {
+		return nil, err
+	}
+	return entry.client, nil
+}
+
+// GetConfigFor 返回处理当前请求使用的 rest.Config，规则同 GetClientFor
+func (cm *ClientManager) GetConfigFor(ctx context.Context, clusterName string) (*rest.Config, error) {
+	identity := impersonatedIdentity(ctx)
+	if identity == nil || !cm.IsImpersonationEnabled(clusterName) {
+		return cm.GetConfig(clusterName)
+	}
+	entry, err := cm.getImpersonated(clusterName, identity)
+	if err != nil {
+		return nil, err
+	}
+	return entry.config, nil
+}
+
+// GetDynamicClientFor 返回处理当前请求使用的动态客户端（用于 CRD 等无类型资源），规则同 GetClientFor
+func (cm *ClientManager) GetDynamicClientFor(ctx context.Context, clusterName string) (dynamic.Interface, error) {
+	config, err := cm.GetConfigFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("创建动态客户端失败: %w", err)
+	}
+	return client, nil
+}
+
+func (cm *ClientManager) getImpersonated(clusterName string, identity *auth.Identity) (*impersonatedClient, error) {
+	key := impersonationKey(clusterName, identity)
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if entry, ok := cm.impersonated[key]; ok {
+		entry.lastUsed = time.Now()
+		return entry, nil
+	}
+
+	base, exists := cm.configs[clusterName]
+	if !exists {
+		return nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+	config := rest.CopyConfig(base)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: identity.Username,
+		Groups:   slices.Clone(identity.Groups),
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create impersonating client: %w", err)
+	}
+
+	if len(cm.impersonated) >= maxImpersonatedClients {
+		cm.evictOldestImpersonatedLocked()
+	}
+	entry := &impersonatedClient{cluster: clusterName, client: client, config: config, lastUsed: time.Now()}
+	cm.impersonated[key] = entry
+	logger.Info("impersonating client created", "cluster", clusterName, "user", identity.Username)
+	return entry, nil
+}
+
+func (cm *ClientManager) evictOldestImpersonatedLocked() {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range cm.impersonated {
+		if oldestKey == "" || entry.lastUsed.Before(oldest) {
+			oldestKey, oldest = key, entry.lastUsed
+		}
+	}
+	delete(cm.impersonated, oldestKey)
+}
@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"kube-tide/internal/core/auth"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestGetClientForImpersonation(t *testing.T) {
+	cm := NewClientManager()
+	config := &rest.Config{Host: "https://127.0.0.1:6443"}
+	base, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = base
+	cm.configs["dev"] = config
+
+	alice := &auth.Identity{Username: "alice", Groups: []string{"team-a"}, Method: auth.MethodLocal}
+	ctx := auth.WithIdentity(context.Background(), alice)
+
+	// 未开启时始终使用 kubeconfig 身份
+	if client, _ := cm.GetClientFor(ctx, "dev"); client != base {
+		t.Fatal("expected shared client while impersonation is disabled")
+	}
+
+	if err := cm.SetImpersonation("dev", true); err != nil {
+		t.Fatalf("SetImpersonation: %v", err)
+	}
+	impersonated, err := cm.GetConfigFor(ctx, "dev")
+	if err != nil {
+		t.Fatalf("GetConfigFor: %v", err)
+	}
+	if impersonated.Impersonate.UserName != "alice" || len(impersonated.Impersonate.Groups) != 1 {
+		t.Fatalf("unexpected impersonation config: %+v", impersonated.Impersonate)
+	}
+	if config.Impersonate.UserName != "" {
+		t.Fatal("base config must not be modified")
+	}
+	first, _ := cm.GetClientFor(ctx, "dev")
+	second, _ := cm.GetClientFor(ctx, "dev")
+	if first == base || first != second {
+		t.Fatal("expected a cached impersonating client")
+	}
+
+	// 无用户上下文（后台任务）与匿名身份不模拟
+	if client, _ := cm.GetClientFor(context.Background(), "dev"); client != base {
+		t.Fatal("expected shared client without identity")
+	}
+	anonymous := auth.WithIdentity(context.Background(), &auth.Identity{Username: "anonymous", Method: auth.MethodAnonymous})
+	if client, _ := cm.GetClientFor(anonymous, "dev"); client != base {
+		t.Fatal("expected shared client for anonymous identity")
+	}
+
+	if err := cm.SetImpersonation("dev", false); err != nil {
+		t.Fatalf("SetImpersonation: %v", err)
+	}
+	if len(cm.impersonated) != 0 {
+		t.Fatal("disabling impersonation must drop cached clients")
+	}
+}
@@ -0,0 +1,244 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"kube-tide/internal/utils/logger"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// informerResyncPeriod 共享 informer 的全量 resync 周期
+const informerResyncPeriod = 10 * time.Minute
+
+// 共享缓存中的资源
+const (
+	InformerPods        = "pods"
+	InformerNamespaces  = "namespaces"
+	InformerServices    = "services"
+	InformerDeployments = "deployments"
+	InformerReplicaSets = "replicasets"
+	InformerIngresses   = "ingresses"
+)
+
+// clusterInformers 单个集群的共享 informer，资源在首次使用时注册并启动
+type clusterInformers struct {
+	factory   informers.SharedInformerFactory
+	stopCh    chan struct{}
+	startedAt time.Time
+	resources map[string]cache.SharedIndexInformer
+	mutex     sync.Mutex
+}
+
+// InformerResourceStatus 单个资源的缓存状态
+type InformerResourceStatus struct {
+	Resource        string `json:"resource"`
+	Synced          bool   `json:"synced"`
+	Items           int    `json:"items"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// InformerSyncStatus 集群共享缓存的同步状态
+type InformerSyncStatus struct {
+	Cluster   string                   `json:"cluster"`
+	Started   bool                     `json:"started"`
+	StartedAt *time.Time               `json:"startedAt,omitempty"`
+	Synced    bool                     `json:"synced"` // 已启动的资源全部完成首次同步
+	Resources []InformerResourceStatus `json:"resources"`
+}
+
+// stripManagedFields 缓存中不保留 managedFields，大集群下可显著降低内存占用
+func stripManagedFields(obj any) (any, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+// sharedInformer 返回集群共享缓存中的 informer，首次使用时注册并启动。
+// 返回 false 时调用方应直接访问 API Server：请求需以登录用户身份执行（用户模拟），
+// 或 informer 尚未完成首次同步。
+func (cm *ClientManager) sharedInformer(ctx context.Context, clusterName, resource string, newInformer func(informers.SharedInformerFactory) cache.SharedIndexInformer) (cache.SharedIndexInformer, bool) {
+	// 共享缓存使用 kubeconfig 身份，模拟用户的请求必须交给 API Server 鉴权
+	if impersonatedIdentity(ctx) != nil && cm.IsImpersonationEnabled(clusterName) {
+		return nil, false
+	}
+
+	cm.mutex.Lock()
+	ci, ok := cm.informers[clusterName]
+	if !ok {
+		client, exists := cm.clients[clusterName]
+		if !exists {
+			cm.mutex.Unlock()
+			return nil, false
+		}
+		ci = &clusterInformers{
+			factory: informers.NewSharedInformerFactoryWithOptions(client, informerResyncPeriod,
+				informers.WithTransform(stripManagedFields)),
+			stopCh:    make(chan struct{}),
+			startedAt: time.Now(),
+			resources: make(map[string]cache.SharedIndexInformer),
+		}
+		cm.informers[clusterName] = ci
+		logger.Info("启动集群共享缓存", "cluster", clusterName)
+	}
+	cm.mutex.Unlock()
+
+	ci.mutex.Lock()
+	informer, registered := ci.resources[resource]
+	if !registered {
+		informer = newInformer(ci.factory)
+		ci.resources[resource] = informer
+		// Start 只会启动尚未运行的 informer
+		ci.factory.Start(ci.stopCh)
+		logger.Info("集群共享缓存开始同步", "cluster", clusterName, "resource", resource)
+	}
+	ci.mutex.Unlock()
+
+	return informer, informer.HasSynced()
+}
+
+// PodLister 返回集群 Pod 的 lister，规则同 sharedInformer
+func (cm *ClientManager) PodLister(ctx context.Context, clusterName string) (corelisters.PodLister, bool) {
+	informer, ok := cm.sharedInformer(ctx, clusterName, InformerPods, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Pods().Informer()
+	})
+	if !ok {
+		return nil, false
+	}
+	return corelisters.NewPodLister(informer.GetIndexer()), true
+}
+
+// NamespaceLister 返回集群 Namespace 的 lister，规则同 sharedInformer
+func (cm *ClientManager) NamespaceLister(ctx context.Context, clusterName string) (corelisters.NamespaceLister, bool) {
+	informer, ok := cm.sharedInformer(ctx, clusterName, InformerNamespaces, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Namespaces().Informer()
+	})
+	if !ok {
+		return nil, false
+	}
+	return corelisters.NewNamespaceLister(informer.GetIndexer()), true
+}
+
+// ServiceLister 返回集群 Service 的 lister，规则同 sharedInformer
+func (cm *ClientManager) ServiceLister(ctx context.Context, clusterName string) (corelisters.ServiceLister, bool) {
+	informer, ok := cm.sharedInformer(ctx, clusterName, InformerServices, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Services().Informer()
+	})
+	if !ok {
+		return nil, false
+	}
+	return corelisters.NewServiceLister(informer.GetIndexer()), true
+}
+
+// DeploymentLister 返回集群 Deployment 的 lister，规则同 sharedInformer
+func (cm *ClientManager) DeploymentLister(ctx context.Context, clusterName string) (appslisters.DeploymentLister, bool) {
+	informer, ok := cm.sharedInformer(ctx, clusterName, InformerDeployments, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().Deployments().Informer()
+	})
+	if !ok {
+		return nil, false
+	}
+	return appslisters.NewDeploymentLister(informer.GetIndexer()), true
+}
+
+// ReplicaSetLister 返回集群 ReplicaSet 的 lister，规则同 sharedInformer
+func (cm *ClientManager) ReplicaSetLister(ctx context.Context, clusterName string) (appslisters.ReplicaSetLister, bool) {
+	informer, ok := cm.sharedInformer(ctx, clusterName, InformerReplicaSets, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().ReplicaSets().Informer()
+	})
+	if !ok {
+		return nil, false
+	}
+	return appslisters.NewReplicaSetLister(informer.GetIndexer()), true
+}
+
+// IngressLister 返回集群 Ingress 的 lister，规则同 sharedInformer
+func (cm *ClientManager) IngressLister(ctx context.Context, clusterName string) (networkinglisters.IngressLister, bool) {
+	informer, ok := cm.sharedInformer(ctx, clusterName, InformerIngresses, func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Networking().V1().Ingresses().Informer()
+	})
+	if !ok {
+		return nil, false
+	}
+	return networkinglisters.NewIngressLister(informer.GetIndexer()), true
+}
+
+// GetInformerStatus 返回集群共享缓存的同步状态，未使用过缓存的集群 Started 为 false
+func (cm *ClientManager) GetInformerStatus(clusterName string) (*InformerSyncStatus, error) {
+	cm.mutex.RLock()
+	_, exists := cm.clients[clusterName]
+	ci := cm.informers[clusterName]
+	cm.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+
+	status := &InformerSyncStatus{Cluster: clusterName, Resources: []InformerResourceStatus{}}
+	if ci == nil {
+		return status, nil
+	}
+	startedAt := ci.startedAt
+	status.Started = true
+	status.StartedAt = &startedAt
+	status.Synced = true
+
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+	for resource, informer := range ci.resources {
+		synced := informer.HasSynced()
+		status.Synced = status.Synced && synced
+		status.Resources = append(status.Resources, InformerResourceStatus{
+			Resource:        resource,
+			Synced:          synced,
+			Items:           len(informer.GetStore().ListKeys()),
+			ResourceVersion: informer.LastSyncResourceVersion(),
+		})
+	}
+	sort.Slice(status.Resources, func(i, j int) bool {
+		return status.Resources[i].Resource < status.Resources[j].Resource
+	})
+	return status, nil
+}
+
+// StopInformers 停止全部集群的共享缓存，进程退出时调用
+func (cm *ClientManager) StopInformers() {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	for clusterName := range cm.informers {
+		cm.stopInformersLocked(clusterName)
+	}
+}
+
+// stopInformersLocked 停止并丢弃集群的共享缓存，下次使用时重新建立
+func (cm *ClientManager) stopInformersLocked(clusterName string) {
+	ci, ok := cm.informers[clusterName]
+	if !ok {
+		return
+	}
+	delete(cm.informers, clusterName)
+	close(ci.stopCh)
+	// Shutdown 等待 informer 协程退出，不在持锁期间等待
+	go ci.factory.Shutdown()
+	logger.Info("停止集群共享缓存", "cluster", clusterName)
+}
+
+// sortObjects 按命名空间、名称排序，与 API Server List 的返回顺序一致
+func sortObjects[T metav1.Object](items []T) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"kube-tide/internal/core/auth"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakePodAPI 只提供 Pod 的 list/watch：list 返回两个 Pod，watch 保持连接直到请求结束
+func fakePodAPI(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pods" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// 不支持 watch-list（sendInitialEvents），reflector 会回退到普通 List
+		if r.URL.Query().Get("sendInitialEvents") == "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"BadRequest","code":400}`))
+			return
+		}
+		if r.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","metadata":{"resourceVersion":"42"},"items":[
+			{"metadata":{"name":"web-1","namespace":"prod","resourceVersion":"40","managedFields":[{"manager":"kubectl"}]}},
+			{"metadata":{"name":"api-0","namespace":"dev","resourceVersion":"41"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSharedInformerLifecycle(t *testing.T) {
+	server := fakePodAPI(t)
+	cm := NewClientManager()
+	config := &rest.Config{Host: server.URL}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = client
+	cm.configs["dev"] = config
+
+	// 首次使用前不启动
+	if status, err := cm.GetInformerStatus("dev"); err != nil || status.Started {
+		t.Fatalf("expected idle cache, got %+v (%v)", status, err)
+	}
+
+	// 开启用户模拟后，登录用户的请求不走共享缓存
+	if err := cm.SetImpersonation("dev", true); err != nil {
+		t.Fatalf("SetImpersonation: %v", err)
+	}
+	alice := auth.WithIdentity(context.Background(), &auth.Identity{Username: "alice", Method: auth.MethodLocal})
+	if _, ok := cm.PodLister(alice, "dev"); ok {
+		t.Fatal("impersonated requests must bypass the shared cache")
+	}
+	if len(cm.informers) != 0 {
+		t.Fatal("impersonated requests must not start informers")
+	}
+
+	// 后台任务（无用户上下文）懒启动 informer，同步完成后可读取
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if podLister, ok := cm.PodLister(context.Background(), "dev"); ok {
+			pods, err := copyCachedPods(podLister.List(labels.Everything()))
+			if err != nil {
+				t.Fatalf("list cached pods: %v", err)
+			}
+			if len(pods) != 2 || pods[0].Namespace != "dev" || pods[1].Name != "web-1" {
+				t.Fatalf("unexpected cached pods: %+v", pods)
+			}
+			if len(pods[1].ManagedFields) != 0 {
+				t.Fatal("managedFields should be stripped from the cache")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("pod informer did not sync")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	status, err := cm.GetInformerStatus("dev")
+	if err != nil || !status.Started || !status.Synced || len(status.Resources) != 1 {
+		t.Fatalf("unexpected status: %+v (%v)", status, err)
+	}
+	if res := status.Resources[0]; res.Resource != InformerPods || res.Items != 2 || res.ResourceVersion != "42" {
+		t.Fatalf("unexpected resource status: %+v", res)
+	}
+
+	// 移除集群时停止 informer
+	if err := cm.RemoveCluster("dev"); err != nil {
+		t.Fatalf("RemoveCluster: %v", err)
+	}
+	if len(cm.informers) != 0 {
+		t.Fatal("informers should be stopped on RemoveCluster")
+	}
+	if _, err := cm.GetInformerStatus("dev"); err == nil {
+		t.Fatal("expected error for removed cluster")
+	}
+}
@@ -66,7 +66,7 @@ type UpdateIngressRequest struct {
 
 // GetIngressesByNamespace 获取指定命名空间中的 Ingress 列表。
 func (m *IngressManager) GetIngressesByNamespace(ctx context.Context, clusterName, namespace string) ([]networkingv1.Ingress, error) {
-	client, err := m.clientManager.GetClient(clusterName)
+	client, err := m.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +81,7 @@ func (m *IngressManager) GetIngressesByNamespace(ctx context.Context, clusterNam
 
 // GetIngress 获取单个 Ingress
 func (m *IngressManager) GetIngress(ctx context.Context, clusterName, namespace, name string) (*networkingv1.Ingress, error) {
-	client, err := m.clientManager.GetClient(clusterName)
+	client, err := m.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +94,7 @@ func (m *IngressManager) GetIngress(ctx context.Context, clusterName, namespace,
 
 // CreateIngress 创建 Ingress
 func (m *IngressManager) CreateIngress(ctx context.Context, clusterName, namespace string, req CreateIngressRequest) (*networkingv1.Ingress, error) {
-	client, err := m.clientManager.GetClient(clusterName)
+	client, err := m.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +115,7 @@ func (m *IngressManager) CreateIngress(ctx context.Context, clusterName, namespa
 
 // UpdateIngress 更新 Ingress
 func (m *IngressManager) UpdateIngress(ctx context.Context, clusterName, namespace, name string, req UpdateIngressRequest) (*networkingv1.Ingress, error) {
-	client, err := m.clientManager.GetClient(clusterName)
+	client, err := m.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +152,7 @@ func (m *IngressManager) UpdateIngress(ctx context.Context, clusterName, namespa
 
 // DeleteIngress 删除 Ingress
 func (m *IngressManager) DeleteIngress(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := m.clientManager.GetClient(clusterName)
+	client, err := m.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
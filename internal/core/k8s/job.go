@@ -14,11 +14,12 @@ import (
 // JobService Job 管理服务
 type JobService struct {
 	clientManager *ClientManager
+	podService    *PodService
 }
 
-// NewJobService 创建 Job 服务
-func NewJobService(clientManager *ClientManager) *JobService {
-	return &JobService{clientManager: clientManager}
+// NewJobService 创建 Job 服务，podService 用于汇总 Job 下 Pod 的日志
+func NewJobService(clientManager *ClientManager, podService *PodService) *JobService {
+	return &JobService{clientManager: clientManager, podService: podService}
 }
 
 // JobInfo Job 摘要
@@ -35,6 +36,16 @@ type JobInfo struct {
 	CreationTime   time.Time         `json:"creationTime"`
 	Labels         map[string]string `json:"labels,omitempty"`
 	Images         []string          `json:"images,omitempty"`
+	// Status Running / Suspended / Complete / Failed
+	Status                  string                    `json:"status"`
+	Message                 string                    `json:"message,omitempty"`
+	Suspended               bool                      `json:"suspended"`
+	CompletionMode          string                    `json:"completionMode,omitempty"`
+	BackoffLimit            *int32                    `json:"backoffLimit,omitempty"`
+	TTLSecondsAfterFinished *int32                    `json:"ttlSecondsAfterFinished,omitempty"`
+	ActiveDeadlineSeconds   *int64                    `json:"activeDeadlineSeconds,omitempty"`
+	PodFailurePolicy        *batchv1.PodFailurePolicy `json:"podFailurePolicy,omitempty"`
+	RetryOf                 string                    `json:"retryOf,omitempty"`
 }
 
 // CreateJobRequest 创建 Job 请求
@@ -52,7 +63,7 @@ type CreateJobRequest struct {
 
 // ListJobs 获取 Job 列表
 func (s *JobService) ListJobs(ctx context.Context, clusterName, namespace string) ([]JobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +88,7 @@ func (s *JobService) ListJobs(ctx context.Context, clusterName, namespace string
 
 // GetJob 获取 Job 详情
 func (s *JobService) GetJob(ctx context.Context, clusterName, namespace, name string) (*JobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +102,7 @@ func (s *JobService) GetJob(ctx context.Context, clusterName, namespace, name st
 
 // CreateJob 创建 Job
 func (s *JobService) CreateJob(ctx context.Context, clusterName, namespace string, req CreateJobRequest) (*JobInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +151,7 @@ func (s *JobService) CreateJob(ctx context.Context, clusterName, namespace strin
 
 // DeleteJob 删除 Job
 func (s *JobService) DeleteJob(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -173,5 +184,15 @@ func convertJobInfo(job *batchv1.Job) JobInfo {
 		t := job.Status.CompletionTime.Time
 		info.CompletionTime = &t
 	}
+	info.Suspended = job.Spec.Suspend != nil && *job.Spec.Suspend
+	if job.Spec.CompletionMode != nil {
+		info.CompletionMode = string(*job.Spec.CompletionMode)
+	}
+	info.BackoffLimit = job.Spec.BackoffLimit
+	info.TTLSecondsAfterFinished = job.Spec.TTLSecondsAfterFinished
+	info.ActiveDeadlineSeconds = job.Spec.ActiveDeadlineSeconds
+	info.PodFailurePolicy = job.Spec.PodFailurePolicy
+	info.RetryOf = job.Annotations[jobRetryOfAnnotation]
+	info.Status, info.Message = jobStatus(job)
 	return info
 }
@@ -0,0 +1,313 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// jobRetryOfAnnotation 重新运行生成的 Job 记录原 Job 名称
+const jobRetryOfAnnotation = "kube-tide.io/retry-of"
+
+// Job 控制器自动添加的标签，复制 Job 时需要去掉，由 API Server 按新 Job 重新生成
+var jobControllerLabels = []string{
+	"controller-uid",
+	"batch.kubernetes.io/controller-uid",
+	"job-name",
+	batchv1.JobNameLabel,
+}
+
+// Job 状态
+const (
+	JobStatusRunning   = "Running"
+	JobStatusSuspended = "Suspended"
+	JobStatusComplete  = "Complete"
+	JobStatusFailed    = "Failed"
+)
+
+// Indexed Job 单个索引的状态
+const (
+	JobIndexSucceeded = "Succeeded"
+	JobIndexFailed    = "Failed"
+	JobIndexActive    = "Active"
+	JobIndexPending   = "Pending"
+)
+
+// JobSettings Job 的可调整参数，未设置的字段保持不变
+type JobSettings struct {
+	TTLSecondsAfterFinished *int32                    `json:"ttlSecondsAfterFinished,omitempty"`
+	ActiveDeadlineSeconds   *int64                    `json:"activeDeadlineSeconds,omitempty"`
+	BackoffLimit            *int32                    `json:"backoffLimit,omitempty"`
+	PodFailurePolicy        *batchv1.PodFailurePolicy `json:"podFailurePolicy,omitempty"`
+}
+
+// RetryJobRequest 重新运行请求，Name 为空时自动生成；Settings 覆盖新 Job 的参数
+type RetryJobRequest struct {
+	Name     string      `json:"name,omitempty"`
+	Settings JobSettings `json:"settings"`
+}
+
+// JobIndexPod 索引下的 Pod
+type JobIndexPod struct {
+	Name  string `json:"name"`
+	Phase string `json:"phase"`
+}
+
+// JobIndexStatus Indexed Job 单个索引的状态
+type JobIndexStatus struct {
+	Index  int           `json:"index"`
+	Status string        `json:"status"`
+	Pods   []JobIndexPod `json:"pods"`
+}
+
+// jobStatus 根据 Job 条件得出状态与说明
+func jobStatus(job *batchv1.Job) (string, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return JobStatusComplete, cond.Message
+		case batchv1.JobFailed:
+			return JobStatusFailed, strings.TrimSpace(cond.Reason + " " + cond.Message)
+		}
+	}
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		return JobStatusSuspended, ""
+	}
+	return JobStatusRunning, ""
+}
+
+// parseJobIndexes 解析 completedIndexes / failedIndexes 的区间格式，例如 "1,3-5,7"
+func parseJobIndexes(value string) (map[int]bool, error) {
+	result := make(map[int]bool)
+	if value == "" {
+		return result, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("无效的索引区间 %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil || end < start {
+				return nil, fmt.Errorf("无效的索引区间 %q", part)
+			}
+		}
+		for i := start; i <= end; i++ {
+			result[i] = true
+		}
+	}
+	return result, nil
+}
+
+// buildJobIndexStatus 汇总 Indexed Job 每个索引的完成情况与对应 Pod
+func buildJobIndexStatus(job *batchv1.Job, pods []corev1.Pod) ([]JobIndexStatus, error) {
+	if job.Spec.CompletionMode == nil || *job.Spec.CompletionMode != batchv1.IndexedCompletion {
+		return nil, fmt.Errorf("Job %s 不是 Indexed 模式", job.Name)
+	}
+	completions := 1
+	if job.Spec.Completions != nil {
+		completions = int(*job.Spec.Completions)
+	}
+	completed, err := parseJobIndexes(job.Status.CompletedIndexes)
+	if err != nil {
+		return nil, err
+	}
+	failed := map[int]bool{}
+	if job.Status.FailedIndexes != nil {
+		if failed, err = parseJobIndexes(*job.Status.FailedIndexes); err != nil {
+			return nil, err
+		}
+	}
+
+	indexes := make([]JobIndexStatus, completions)
+	for i := range indexes {
+		indexes[i] = JobIndexStatus{Index: i, Status: JobIndexPending, Pods: []JobIndexPod{}}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp) })
+	for _, pod := range pods {
+		index, err := strconv.Atoi(pod.Annotations[batchv1.JobCompletionIndexAnnotation])
+		if err != nil || index < 0 || index >= completions {
+			continue
+		}
+		indexes[index].Pods = append(indexes[index].Pods, JobIndexPod{Name: pod.Name, Phase: string(pod.Status.Phase)})
+		if pod.DeletionTimestamp == nil && (pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning) {
+			indexes[index].Status = JobIndexActive
+		}
+	}
+	for i := range indexes {
+		switch {
+		case completed[i]:
+			indexes[i].Status = JobIndexSucceeded
+		case failed[i]:
+			indexes[i].Status = JobIndexFailed
+		}
+	}
+	return indexes, nil
+}
+
+// generateJobName 生成 <base>-<kind>-<时间戳> 形式的 Job 名称，长度不超过 63（标签值上限）
+func generateJobName(base, kind string) string {
+	suffix := "-" + kind + "-" + strconv.FormatInt(time.Now().Unix(), 36)
+	if len(base)+len(suffix) > 63 {
+		base = strings.TrimRight(base[:63-len(suffix)], "-.")
+	}
+	return base + suffix
+}
+
+// buildRetryJob 以原 Job 的 spec 生成新 Job：去掉控制器生成的选择器与标签、所有者引用及状态
+func buildRetryJob(job *batchv1.Job, name string, settings JobSettings) *batchv1.Job {
+	if name == "" {
+		name = generateJobName(job.Name, "retry")
+	}
+
+	spec := job.Spec.DeepCopy()
+	spec.Selector = nil
+	spec.ManualSelector = nil
+	spec.Suspend = nil
+	for _, key := range jobControllerLabels {
+		delete(spec.Template.Labels, key)
+	}
+	applyJobSettings(spec, settings)
+
+	labels := make(map[string]string, len(job.Labels))
+	for k, v := range job.Labels {
+		labels[k] = v
+	}
+	for _, key := range jobControllerLabels {
+		delete(labels, key)
+	}
+	annotations := make(map[string]string, len(job.Annotations)+1)
+	for k, v := range job.Annotations {
+		if k != corev1.LastAppliedConfigAnnotation && !strings.HasPrefix(k, "batch.kubernetes.io/") {
+			annotations[k] = v
+		}
+	}
+	annotations[jobRetryOfAnnotation] = job.Name
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   job.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *spec,
+	}
+}
+
+func applyJobSettings(spec *batchv1.JobSpec, settings JobSettings) {
+	if settings.TTLSecondsAfterFinished != nil {
+		spec.TTLSecondsAfterFinished = settings.TTLSecondsAfterFinished
+	}
+	if settings.ActiveDeadlineSeconds != nil {
+		spec.ActiveDeadlineSeconds = settings.ActiveDeadlineSeconds
+	}
+	if settings.BackoffLimit != nil {
+		spec.BackoffLimit = settings.BackoffLimit
+	}
+	if settings.PodFailurePolicy != nil {
+		spec.PodFailurePolicy = settings.PodFailurePolicy
+	}
+}
+
+// GetJobLogs 汇总 Job 下所有 Pod 的日志（按 job-name 标签选择）
+func (s *JobService) GetJobLogs(ctx context.Context, clusterName, namespace, name, containerName string, tailLines int64) ([]PodLogEntry, error) {
+	selector := metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"job-name": name}})
+	return s.podService.GetLogsByLabelSelector(ctx, clusterName, namespace, selector, containerName, tailLines, 0)
+}
+
+// RetryJob 以原 Job 的配置创建一个新的 Job 重新运行，原 Job 需已结束
+func (s *JobService) RetryJob(ctx context.Context, clusterName, namespace, name string, req RetryJobRequest) (*JobInfo, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Job 失败: %w", err)
+	}
+	if status, _ := jobStatus(job); status != JobStatusFailed && status != JobStatusComplete {
+		return nil, fmt.Errorf("Job %s 尚未结束，无法重新运行", name)
+	}
+	created, err := client.BatchV1().Jobs(namespace).Create(ctx, buildRetryJob(job, req.Name, req.Settings), metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("重新运行 Job 失败: %w", err)
+	}
+	info := convertJobInfo(created)
+	return &info, nil
+}
+
+// SuspendJob 暂停或恢复 Job（spec.suspend），暂停时会终止正在运行的 Pod
+func (s *JobService) SuspendJob(ctx context.Context, clusterName, namespace, name string, suspend bool) (*JobInfo, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+	updated, err := client.BatchV1().Jobs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("更新 Job 暂停状态失败: %w", err)
+	}
+	info := convertJobInfo(updated)
+	return &info, nil
+}
+
+// UpdateJob 修改 ttlSecondsAfterFinished、activeDeadlineSeconds、backoffLimit。
+// podFailurePolicy 在 Job 创建后不可修改，只能在重新运行时设置。
+func (s *JobService) UpdateJob(ctx context.Context, clusterName, namespace, name string, settings JobSettings) (*JobInfo, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Job 失败: %w", err)
+	}
+	if settings.PodFailurePolicy != nil {
+		current, _ := json.Marshal(job.Spec.PodFailurePolicy)
+		requested, _ := json.Marshal(settings.PodFailurePolicy)
+		if string(current) != string(requested) {
+			return nil, fmt.Errorf("podFailurePolicy 在 Job 创建后不可修改，请通过重新运行设置")
+		}
+	}
+	applyJobSettings(&job.Spec, settings)
+	updated, err := client.BatchV1().Jobs(namespace).Update(ctx, job, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("更新 Job 失败: %w", err)
+	}
+	info := convertJobInfo(updated)
+	return &info, nil
+}
+
+// GetJobIndexes 获取 Indexed Job 每个索引的状态
+func (s *JobService) GetJobIndexes(ctx context.Context, clusterName, namespace, name string) ([]JobIndexStatus, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Job 失败: %w", err)
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(job.Spec.Selector),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Pod 列表失败: %w", err)
+	}
+	return buildJobIndexStatus(job, pods.Items)
+}
@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseJobIndexes(t *testing.T) {
+	indexes, err := parseJobIndexes("1,3-5,7")
+	if err != nil {
+		t.Fatalf("parseJobIndexes: %v", err)
+	}
+	for _, i := range []int{1, 3, 4, 5, 7} {
+		if !indexes[i] {
+			t.Errorf("index %d should be set", i)
+		}
+	}
+	if len(indexes) != 5 {
+		t.Fatalf("unexpected indexes: %v", indexes)
+	}
+	if _, err := parseJobIndexes("5-3"); err == nil {
+		t.Fatal("descending range should be rejected")
+	}
+}
+
+func TestBuildJobIndexStatus(t *testing.T) {
+	completions := int32(4)
+	mode := batchv1.IndexedCompletion
+	failedIndexes := "2"
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "train"},
+		Spec:       batchv1.JobSpec{Completions: &completions, CompletionMode: &mode},
+		Status:     batchv1.JobStatus{CompletedIndexes: "0", FailedIndexes: &failedIndexes},
+	}
+	pod := func(name, index string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{batchv1.JobCompletionIndexAnnotation: index}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	pods := []corev1.Pod{
+		pod("train-0-a", "0", corev1.PodSucceeded),
+		pod("train-1-a", "1", corev1.PodRunning),
+		pod("train-2-a", "2", corev1.PodFailed),
+	}
+
+	indexes, err := buildJobIndexStatus(job, pods)
+	if err != nil {
+		t.Fatalf("buildJobIndexStatus: %v", err)
+	}
+	want := []string{JobIndexSucceeded, JobIndexActive, JobIndexFailed, JobIndexPending}
+	for i, status := range want {
+		if indexes[i].Status != status {
+			t.Errorf("index %d status = %s, want %s", i, indexes[i].Status, status)
+		}
+	}
+	if len(indexes[1].Pods) != 1 || indexes[1].Pods[0].Name != "train-1-a" {
+		t.Fatalf("unexpected pods for index 1: %+v", indexes[1].Pods)
+	}
+
+	job.Spec.CompletionMode = nil
+	if _, err := buildJobIndexStatus(job, pods); err == nil {
+		t.Fatal("non-indexed job should be rejected")
+	}
+}
+
+func TestBuildRetryJob(t *testing.T) {
+	manual := true
+	deadline := int64(600)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "migrate",
+			Namespace:       "prod",
+			Labels:          map[string]string{"app": "migrate", "controller-uid": "abc"},
+			Annotations:     map[string]string{"team": "batch", corev1.LastAppliedConfigAnnotation: "{}"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "nightly"}},
+		},
+		Spec: batchv1.JobSpec{
+			ManualSelector: &manual,
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "abc"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "migrate", "controller-uid": "abc", "job-name": "migrate"}},
+			},
+		},
+		Status: batchv1.JobStatus{Failed: 3},
+	}
+
+	retry := buildRetryJob(job, "", JobSettings{ActiveDeadlineSeconds: &deadline})
+	if !strings.HasPrefix(retry.Name, "migrate-retry-") || retry.Namespace != "prod" {
+		t.Fatalf("unexpected retry job meta: %+v", retry.ObjectMeta)
+	}
+	if retry.Spec.Selector != nil || retry.Spec.ManualSelector != nil || len(retry.OwnerReferences) != 0 {
+		t.Fatal("selector and owner references must be dropped")
+	}
+	if _, ok := retry.Spec.Template.Labels["controller-uid"]; ok || retry.Spec.Template.Labels["app"] != "migrate" {
+		t.Fatalf("unexpected template labels: %v", retry.Spec.Template.Labels)
+	}
+	if retry.Annotations[jobRetryOfAnnotation] != "migrate" || retry.Annotations["team"] != "batch" {
+		t.Fatalf("unexpected annotations: %v", retry.Annotations)
+	}
+	if _, ok := retry.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		t.Fatal("last-applied-configuration must not be copied")
+	}
+	if retry.Spec.ActiveDeadlineSeconds == nil || *retry.Spec.ActiveDeadlineSeconds != 600 {
+		t.Fatal("settings should override the copied spec")
+	}
+	if _, ok := job.Spec.Template.Labels["controller-uid"]; !ok {
+		t.Fatal("original job must not be modified")
+	}
+
+	long := buildRetryJob(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 63)}}, "", JobSettings{})
+	if len(long.Name) > 63 {
+		t.Fatalf("retry name too long: %d", len(long.Name))
+	}
+}
@@ -54,7 +54,7 @@ type UpdateLimitRangeRequest struct {
 
 // ListLimitRanges 获取 LimitRange 列表
 func (s *LimitRangeService) ListLimitRanges(ctx context.Context, clusterName, namespace string) ([]LimitRangeInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +79,7 @@ func (s *LimitRangeService) ListLimitRanges(ctx context.Context, clusterName, na
 
 // GetLimitRange 获取 LimitRange 详情
 func (s *LimitRangeService) GetLimitRange(ctx context.Context, clusterName, namespace, name string) (*corev1.LimitRange, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (s *LimitRangeService) GetLimitRange(ctx context.Context, clusterName, name
 
 // CreateLimitRange 创建 LimitRange
 func (s *LimitRangeService) CreateLimitRange(ctx context.Context, clusterName, namespace string, req CreateLimitRangeRequest) (*LimitRangeInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +115,7 @@ func (s *LimitRangeService) CreateLimitRange(ctx context.Context, clusterName, n
 
 // UpdateLimitRange 更新 LimitRange
 func (s *LimitRangeService) UpdateLimitRange(ctx context.Context, clusterName, namespace, name string, req UpdateLimitRangeRequest) (*LimitRangeInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +139,7 @@ func (s *LimitRangeService) UpdateLimitRange(ctx context.Context, clusterName, n
 
 // DeleteLimitRange 删除 LimitRange
 func (s *LimitRangeService) DeleteLimitRange(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,353 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestFieldManager 服务端应用（server-side apply）使用的字段管理者
+const ManifestFieldManager = "kube-tide"
+
+// 清单应用结果
+const (
+	ManifestCreated    = "created"
+	ManifestConfigured = "configured"
+	ManifestUnchanged  = "unchanged"
+	ManifestFailed     = "failed"
+)
+
+// ManifestService 通用 YAML/JSON 清单的查看、对比与应用
+type ManifestService struct {
+	clientManager *ClientManager
+}
+
+// NewManifestService 创建清单服务
+func NewManifestService(clientManager *ClientManager) *ManifestService {
+	return &ManifestService{clientManager: clientManager}
+}
+
+// ApplyManifestRequest 应用清单请求。Manifest 可包含多个以 --- 分隔的文档或 List；
+// Namespace 为未指定命名空间的命名空间级资源的默认值；Force 在字段冲突时强制接管
+type ApplyManifestRequest struct {
+	Manifest  string `json:"manifest" binding:"required"`
+	Namespace string `json:"namespace,omitempty"`
+	Force     bool   `json:"force,omitempty"`
+}
+
+// ErrManifestSecret Secret 不通过通用清单查看，明文只能经 RevealSecretKey 逐键获取
+var ErrManifestSecret = errors.New("Secret 不支持通过清单查看")
+
+// ManifestResult 单个对象的对比或应用结果，Diff 为现有对象与应用后对象的统一格式差异
+type ManifestResult struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Action     string `json:"action"`
+	Diff       string `json:"diff,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// decodeManifests 解析 YAML/JSON 清单，支持多文档与 List，跳过空文档
+func decodeManifests(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	var objects []*unstructured.Unstructured
+	for index := 1; ; index++ {
+		var raw map[string]any
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("解析第 %d 个文档失败: %w", index, err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("解析第 %d 个文档失败: %w", index, err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	for i, obj := range objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("第 %d 个对象缺少 apiVersion、kind 或 metadata.name", i+1)
+		}
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("清单中没有任何对象")
+	}
+	return objects, nil
+}
+
+// manifestYAML 去掉 managedFields 后转为 YAML，用于编辑与对比
+func manifestYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	clean := obj.DeepCopy()
+	clean.SetManagedFields(nil)
+	data, err := yaml.Marshal(clean.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func isSecretKind(gvk schema.GroupVersionKind) bool {
+	return gvk.Group == "" && gvk.Kind == "Secret"
+}
+
+// redactSecretValues 将 Secret 的 data / stringData 值替换为 SHA-256，仍能看出哪些键发生了变化；
+// kubectl apply 写入的 last-applied-configuration 注解含有明文，一并隐藏
+func redactSecretValues(obj *unstructured.Unstructured) {
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := obj.Object[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		for key, value := range values {
+			values[key] = "<redacted sha256:" + sha256Hex([]byte(fmt.Sprint(value))) + ">"
+		}
+	}
+	if annotations := obj.GetAnnotations(); annotations[corev1.LastAppliedConfigAnnotation] != "" {
+		annotations[corev1.LastAppliedConfigAnnotation] = "<redacted>"
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// manifestDiff 对比现有对象与应用后的对象，忽略每次写入都会变化的 resourceVersion 与 generation；
+// Secret 的值不会出现在差异中
+func manifestDiff(live, applied *unstructured.Unstructured) (string, error) {
+	normalize := func(obj *unstructured.Unstructured) (string, error) {
+		if obj == nil {
+			return "", nil
+		}
+		clean := obj.DeepCopy()
+		clean.SetResourceVersion("")
+		clean.SetGeneration(0)
+		if isSecretKind(clean.GroupVersionKind()) {
+			redactSecretValues(clean)
+		}
+		return manifestYAML(clean)
+	}
+	before, err := normalize(live)
+	if err != nil {
+		return "", err
+	}
+	after, err := normalize(applied)
+	if err != nil {
+		return "", err
+	}
+	if before == after {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "live",
+		ToFile:   "applied",
+		Context:  3,
+	})
+}
+
+// ManifestTarget 清单对象解析后的写入目标，集群级资源与无法识别的类型 Namespace 为空
+type ManifestTarget struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// resolveNamespace 按对象类型确定作用域并设置对象的命名空间：集群级资源清空，
+// 命名空间级资源缺省使用 defaultNamespace（为空时为 default）
+func (s *ManifestService) resolveNamespace(clusterName string, obj *unstructured.Unstructured, defaultNamespace string) (*meta.RESTMapping, error) {
+	mapping, err := s.clientManager.RESTMapping(clusterName, obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return mapping, nil
+	}
+	if obj.GetNamespace() == "" {
+		if defaultNamespace == "" {
+			defaultNamespace = metav1.NamespaceDefault
+		}
+		obj.SetNamespace(defaultNamespace)
+	}
+	return mapping, nil
+}
+
+// resourceFor 按对象类型获取动态客户端的资源接口
+func (s *ManifestService) resourceFor(clusterName string, client dynamic.Interface, obj *unstructured.Unstructured, defaultNamespace string) (dynamic.ResourceInterface, error) {
+	mapping, err := s.resolveNamespace(clusterName, obj, defaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return client.Resource(mapping.Resource), nil
+	}
+	return client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// targetFor 返回对象的写入目标；类型无法识别时按集群级处理，由调用方要求全部命名空间的权限
+func (s *ManifestService) targetFor(clusterName string, obj *unstructured.Unstructured, defaultNamespace string) ManifestTarget {
+	target := ManifestTarget{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName()}
+	if _, err := s.resolveNamespace(clusterName, obj, defaultNamespace); err == nil {
+		target.Namespace = obj.GetNamespace()
+	}
+	return target
+}
+
+// ManifestTargets 解析清单中每个对象的写入目标（与 ApplyManifest 使用相同的命名空间规则），
+// 供调用方在对比或应用前逐个授权
+func (s *ManifestService) ManifestTargets(clusterName string, req ApplyManifestRequest) ([]ManifestTarget, error) {
+	objects, err := decodeManifests(req.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]ManifestTarget, 0, len(objects))
+	for _, obj := range objects {
+		targets = append(targets, s.targetFor(clusterName, obj, req.Namespace))
+	}
+	return targets, nil
+}
+
+// ObjectTarget 解析单个对象（GetManifest 的参数）的目标命名空间
+func (s *ManifestService) ObjectTarget(clusterName, apiVersion, kind, namespace, name string) (ManifestTarget, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return ManifestTarget{}, fmt.Errorf("无效的 apiVersion %q: %w", apiVersion, err)
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gv.WithKind(kind))
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return s.targetFor(clusterName, obj, namespace), nil
+}
+
+// applyObject 以服务端应用写入单个对象，dryRun 时只由 API Server 校验并返回应用后的结果
+func (s *ManifestService) applyObject(ctx context.Context, clusterName string, client dynamic.Interface, obj *unstructured.Unstructured, req ApplyManifestRequest, dryRun bool) ManifestResult {
+	result := ManifestResult{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName()}
+	fail := func(err error) ManifestResult {
+		result.Action = ManifestFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	resource, err := s.resourceFor(clusterName, client, obj, req.Namespace)
+	if err != nil {
+		return fail(err)
+	}
+	result.Namespace = obj.GetNamespace()
+
+	live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return fail(fmt.Errorf("获取现有对象失败: %w", err))
+	}
+
+	// 服务端应用不接受 managedFields
+	obj.SetManagedFields(nil)
+	opts := metav1.ApplyOptions{FieldManager: ManifestFieldManager, Force: req.Force}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	applied, err := resource.Apply(ctx, obj.GetName(), obj, opts)
+	if err != nil {
+		if apierrors.IsConflict(err) && !req.Force {
+			return fail(fmt.Errorf("字段被其他管理者占用，确认后可使用 force 强制接管: %w", err))
+		}
+		return fail(err)
+	}
+
+	if result.Diff, err = manifestDiff(live, applied); err != nil {
+		return fail(fmt.Errorf("生成差异失败: %w", err))
+	}
+	switch {
+	case live == nil:
+		result.Action = ManifestCreated
+	case result.Diff == "":
+		result.Action = ManifestUnchanged
+	default:
+		result.Action = ManifestConfigured
+	}
+	return result
+}
+
+func (s *ManifestService) apply(ctx context.Context, clusterName string, req ApplyManifestRequest, dryRun bool) ([]ManifestResult, error) {
+	objects, err := decodeManifests(req.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ManifestResult, 0, len(objects))
+	for _, obj := range objects {
+		results = append(results, s.applyObject(ctx, clusterName, client, obj, req, dryRun))
+	}
+	return results, nil
+}
+
+// DiffManifest 以 dry-run=server 预览清单中每个对象的变更，不会写入集群
+func (s *ManifestService) DiffManifest(ctx context.Context, clusterName string, req ApplyManifestRequest) ([]ManifestResult, error) {
+	return s.apply(ctx, clusterName, req, true)
+}
+
+// ApplyManifest 以服务端应用逐个写入清单中的对象，单个对象失败不影响其余对象
+func (s *ManifestService) ApplyManifest(ctx context.Context, clusterName string, req ApplyManifestRequest) ([]ManifestResult, error) {
+	return s.apply(ctx, clusterName, req, false)
+}
+
+// GetManifest 获取任意对象的当前 YAML（去掉 managedFields），可编辑后通过 ApplyManifest 提交。
+// 保留的 resourceVersion 会作为并发修改的前置条件。Secret 返回 ErrManifestSecret。
+func (s *ManifestService) GetManifest(ctx context.Context, clusterName, apiVersion, kind, namespace, name string) (string, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return "", fmt.Errorf("无效的 apiVersion %q: %w", apiVersion, err)
+	}
+	if isSecretKind(gv.WithKind(kind)) {
+		return "", ErrManifestSecret
+	}
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return "", err
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gv.WithKind(kind))
+	obj.SetNamespace(namespace)
+	resource, err := s.resourceFor(clusterName, client, obj, namespace)
+	if err != nil {
+		return "", err
+	}
+	live, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("获取 %s %s 失败: %w", kind, name, err)
+	}
+	return manifestYAML(live)
+}
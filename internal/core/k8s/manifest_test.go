@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDecodeManifests(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  key: value
+---
+# 空文档应被跳过
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: web
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: web
+    namespace: prod
+`
+	objects, err := decodeManifests(manifest)
+	if err != nil {
+		t.Fatalf("decodeManifests: %v", err)
+	}
+	if len(objects) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(objects))
+	}
+	if objects[0].GetKind() != "ConfigMap" || objects[1].GetKind() != "Service" || objects[2].GetNamespace() != "prod" {
+		t.Fatalf("unexpected objects: %v %v %v", objects[0].GetKind(), objects[1].GetKind(), objects[2].GetNamespace())
+	}
+
+	json := `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "team-a"}}`
+	if objects, err := decodeManifests(json); err != nil || len(objects) != 1 || objects[0].GetName() != "team-a" {
+		t.Fatalf("decodeManifests(json) = %v, %v", objects, err)
+	}
+
+	for _, invalid := range []string{"", "---\n", "apiVersion: v1\nkind: ConfigMap\n", "kind: [unclosed"} {
+		if _, err := decodeManifests(invalid); err == nil {
+			t.Errorf("decodeManifests(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestManifestDiff(t *testing.T) {
+	object := func(replicas int64, resourceVersion string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":            "web",
+				"resourceVersion": resourceVersion,
+				"managedFields":   []any{map[string]any{"manager": "kubectl"}},
+			},
+			"spec": map[string]any{"replicas": replicas},
+		}}
+		return obj
+	}
+
+	diff, err := manifestDiff(object(2, "1"), object(2, "2"))
+	if err != nil || diff != "" {
+		t.Fatalf("resourceVersion-only change should produce no diff, got %q, %v", diff, err)
+	}
+
+	diff, err = manifestDiff(object(2, "1"), object(3, "2"))
+	if err != nil {
+		t.Fatalf("manifestDiff: %v", err)
+	}
+	if !strings.Contains(diff, "-  replicas: 2") || !strings.Contains(diff, "+  replicas: 3") || strings.Contains(diff, "managedFields") {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+
+	created, err := manifestDiff(nil, object(1, "1"))
+	if err != nil || !strings.Contains(created, "+kind: Deployment") {
+		t.Fatalf("new object should be shown as all additions, got %q, %v", created, err)
+	}
+}
+
+func TestManifestDiffRedactsSecrets(t *testing.T) {
+	secret := func(password string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]any{
+				"name": "db",
+				"annotations": map[string]any{
+					corev1.LastAppliedConfigAnnotation: `{"data":{"password":"` + password + `"}}`,
+				},
+			},
+			"data": map[string]any{"password": password, "user": "YWRtaW4="},
+		}}
+	}
+	diff, err := manifestDiff(secret("b2xk"), secret("bmV3"))
+	if err != nil {
+		t.Fatalf("manifestDiff: %v", err)
+	}
+	if strings.Contains(diff, "b2xk") || strings.Contains(diff, "bmV3") || strings.Contains(diff, "YWRtaW4=") {
+		t.Fatalf("secret values leaked into diff:\n%s", diff)
+	}
+	if !strings.Contains(diff, "-  password: <redacted sha256:") || !strings.Contains(diff, "+  password: <redacted sha256:") {
+		t.Fatalf("changed key should still be visible:\n%s", diff)
+	}
+
+	if _, err := (&ManifestService{}).GetManifest(context.Background(), "dev", "v1", "Secret", "default", "db"); !errors.Is(err, ErrManifestSecret) {
+		t.Fatalf("GetManifest(Secret) = %v, want ErrManifestSecret", err)
+	}
+}
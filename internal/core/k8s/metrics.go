@@ -3,7 +3,6 @@ package k8s
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,12 +31,12 @@ type ClusterMetrics struct {
 		Available int `json:"available"`
 		Total     int `json:"total"`
 	} `json:"deploymentReadiness"`
-	// 模拟过去24小时的历史数据（实际项目中应从时序数据库获取）
-	HistoricalData struct {
-		CPUUsage    []MetricDataPoint `json:"cpuUsage"`
-		MemoryUsage []MetricDataPoint `json:"memoryUsage"`
-		PodCount    []MetricDataPoint `json:"podCount"`
-	} `json:"historicalData"`
+	// 过去24小时的历史数据，来自 Prometheus 或本地采样，见 HistorySource
+	HistoricalData ClusterHistory `json:"historicalData"`
+	HistorySource  string         `json:"historySource"`
+
+	// usageMeasured CPU/内存使用率是否来自 metrics-server（否则为按请求量估算）
+	usageMeasured bool
 }
 
 // MetricDataPoint 指标数据点
@@ -47,8 +46,7 @@ type MetricDataPoint struct {
 }
 
 // GetClusterMetrics 获取集群监控指标
-func GetClusterMetrics(client *kubernetes.Clientset, config *rest.Config) (*ClusterMetrics, error) {
-	ctx := context.Background()
+func GetClusterMetrics(ctx context.Context, client *kubernetes.Clientset, config *rest.Config) (*ClusterMetrics, error) {
 	metrics := &ClusterMetrics{
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
@@ -99,16 +97,13 @@ func GetClusterMetrics(client *kubernetes.Clientset, config *rest.Config) (*Clus
 	}
 
 	// 计算资源使用率和分配率
-	metrics = calculateResourceUsage(client, config, nodes, metrics)
-
-	// 生成历史数据（模拟数据）
-	// metrics.HistoricalData = generateHistoricalData()
+	metrics = calculateResourceUsage(ctx, client, config, nodes, metrics)
 
 	return metrics, nil
 }
 
 // calculateResourceUsage 计算资源使用率和分配率
-func calculateResourceUsage(client *kubernetes.Clientset, config *rest.Config, nodes *corev1.NodeList, metrics *ClusterMetrics) *ClusterMetrics {
+func calculateResourceUsage(ctx context.Context, client *kubernetes.Clientset, config *rest.Config, nodes *corev1.NodeList, metrics *ClusterMetrics) *ClusterMetrics {
 	// 在实际项目中，应该使用metrics-server获取真实的CPU和内存使用情况
 	// 这里为了演示，我们模拟一些合理的数据
 
@@ -126,7 +121,7 @@ func calculateResourceUsage(client *kubernetes.Clientset, config *rest.Config, n
 	}
 
 	// 获取所有Pod的资源请求和限制
-	pods, _ := client.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	pods, _ := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
 	for _, pod := range pods.Items {
 		for _, container := range pod.Spec.Containers {
 			// CPU请求和限制
@@ -163,7 +158,7 @@ func calculateResourceUsage(client *kubernetes.Clientset, config *rest.Config, n
 	metricsClient, err := getMetricsClient(config)
 	if err == nil {
 		// 如果有metrics-server，获取真实的使用率数据
-		metrics = getRealMetricsData(metricsClient, metrics, totalCPUCapacity, totalMemoryCapacity)
+		metrics = getRealMetricsData(ctx, metricsClient, metrics, totalCPUCapacity, totalMemoryCapacity)
 	} else {
 		// 没有metrics-server，使用模拟数据
 		metrics.CPUUsage = metrics.CPURequestsPercentage * 0.8
@@ -188,9 +183,7 @@ func getMetricsClient(config *rest.Config) (v1beta1.MetricsV1beta1Interface, err
 }
 
 // getRealMetricsData 获取真实的指标数据
-func getRealMetricsData(metricsClient v1beta1.MetricsV1beta1Interface, metrics *ClusterMetrics, totalCPUCapacity, totalMemoryCapacity int64) *ClusterMetrics {
-	ctx := context.Background()
-
+func getRealMetricsData(ctx context.Context, metricsClient v1beta1.MetricsV1beta1Interface, metrics *ClusterMetrics, totalCPUCapacity, totalMemoryCapacity int64) *ClusterMetrics {
 	nodeMetrics, err := metricsClient.NodeMetricses().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		// 如果获取失败，使用模拟数据
@@ -214,51 +207,7 @@ func getRealMetricsData(metricsClient v1beta1.MetricsV1beta1Interface, metrics *
 	if totalMemoryCapacity > 0 {
 		metrics.MemoryUsage = float64(totalMemoryUsage) / float64(totalMemoryCapacity) * 100
 	}
+	metrics.usageMeasured = true
 
 	return metrics
 }
-
-// generateHistoricalData 生成历史数据（模拟数据）
-func generateHistoricalData() struct {
-	CPUUsage    []MetricDataPoint
-	MemoryUsage []MetricDataPoint
-	PodCount    []MetricDataPoint
-} {
-	now := time.Now()
-	data := struct {
-		CPUUsage    []MetricDataPoint
-		MemoryUsage []MetricDataPoint
-		PodCount    []MetricDataPoint
-	}{
-		CPUUsage:    make([]MetricDataPoint, 24),
-		MemoryUsage: make([]MetricDataPoint, 24),
-		PodCount:    make([]MetricDataPoint, 24),
-	}
-
-	// 使用正弦波模拟一天内的资源使用变化
-	for i := 0; i < 24; i++ {
-		timestamp := now.Add(time.Duration(-23+i) * time.Hour).Format(time.RFC3339)
-
-		// CPU使用率在30%-70%之间波动
-		cpuValue := 50 + 20*math.Sin(float64(i)/3.0)
-		// 内存使用率在40%-80%之间波动
-		memValue := 60 + 20*math.Sin(float64(i)/4.0)
-		// Pod数量在100-150之间波动
-		podValue := 125 + 25*math.Sin(float64(i)/6.0)
-
-		data.CPUUsage[i] = MetricDataPoint{
-			Timestamp: timestamp,
-			Value:     math.Round(cpuValue*10) / 10,
-		}
-		data.MemoryUsage[i] = MetricDataPoint{
-			Timestamp: timestamp,
-			Value:     math.Round(memValue*10) / 10,
-		}
-		data.PodCount[i] = MetricDataPoint{
-			Timestamp: timestamp,
-			Value:     math.Round(podValue),
-		}
-	}
-
-	return data
-}
@@ -53,14 +53,14 @@ type PatchNamespaceLabelsRequest struct {
 }
 
 // ListNamespaces 获取指定集群的所有命名空间
-func (s *NamespaceService) ListNamespaces(clusterName string) (*NamespaceListResult, error) {
+func (s *NamespaceService) ListNamespaces(ctx context.Context, clusterName string) (*NamespaceListResult, error) {
 	logger.Info("获取命名空间列表", "clusterName", clusterName)
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群 %s 的客户端失败: %w", clusterName, err)
 	}
 
-	namespaceList, err := client.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	namespaceList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取命名空间列表失败: %w", err)
 	}
@@ -84,7 +84,7 @@ func (s *NamespaceService) ListNamespaces(clusterName string) (*NamespaceListRes
 
 // GetNamespace 获取单个命名空间
 func (s *NamespaceService) GetNamespace(ctx context.Context, clusterName, name string) (*NamespaceInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +98,7 @@ func (s *NamespaceService) GetNamespace(ctx context.Context, clusterName, name s
 
 // CreateNamespace 创建命名空间
 func (s *NamespaceService) CreateNamespace(ctx context.Context, clusterName string, req CreateNamespaceRequest) (*NamespaceInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func (s *NamespaceService) CreateNamespace(ctx context.Context, clusterName stri
 
 // DeleteNamespace 删除命名空间
 func (s *NamespaceService) DeleteNamespace(ctx context.Context, clusterName, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -128,7 +128,7 @@ func (s *NamespaceService) DeleteNamespace(ctx context.Context, clusterName, nam
 
 // PatchNamespaceLabels 更新命名空间标签
 func (s *NamespaceService) PatchNamespaceLabels(ctx context.Context, clusterName, name string, req PatchNamespaceLabelsRequest) (*NamespaceInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
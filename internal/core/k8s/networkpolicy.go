@@ -91,7 +91,7 @@ type UpdateNetworkPolicyRequest struct {
 
 // ListNetworkPolicies 获取 NetworkPolicy 列表
 func (s *NetworkPolicyService) ListNetworkPolicies(ctx context.Context, clusterName, namespace string) ([]NetworkPolicyInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +116,7 @@ func (s *NetworkPolicyService) ListNetworkPolicies(ctx context.Context, clusterN
 
 // GetNetworkPolicy 获取 NetworkPolicy 详情
 func (s *NetworkPolicyService) GetNetworkPolicy(ctx context.Context, clusterName, namespace, name string) (*networkingv1.NetworkPolicy, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +125,7 @@ func (s *NetworkPolicyService) GetNetworkPolicy(ctx context.Context, clusterName
 
 // CreateNetworkPolicy 创建 NetworkPolicy
 func (s *NetworkPolicyService) CreateNetworkPolicy(ctx context.Context, clusterName, namespace string, req CreateNetworkPolicyRequest) (*NetworkPolicyInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +151,7 @@ func (s *NetworkPolicyService) CreateNetworkPolicy(ctx context.Context, clusterN
 
 // UpdateNetworkPolicy 更新 NetworkPolicy
 func (s *NetworkPolicyService) UpdateNetworkPolicy(ctx context.Context, clusterName, namespace, name string, req UpdateNetworkPolicyRequest) (*NetworkPolicyInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +178,7 @@ func (s *NetworkPolicyService) UpdateNetworkPolicy(ctx context.Context, clusterN
 
 // DeleteNetworkPolicy 删除 NetworkPolicy
 func (s *NetworkPolicyService) DeleteNetworkPolicy(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
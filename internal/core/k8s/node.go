@@ -36,7 +36,7 @@ func NewNodeService(clientManager *ClientManager, nodePoolService *NodePoolServi
 
 // GetNodes 获取节点列表
 func (s *NodeService) GetNodes(ctx context.Context, clusterName string, limit int, page int) ([]corev1.Node, int, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -72,7 +72,7 @@ func (s *NodeService) GetNodes(ctx context.Context, clusterName string, limit in
 
 // GetNodeDetails 获取节点详情
 func (s *NodeService) GetNodeDetails(ctx context.Context, clusterName, nodeName string) (*corev1.Node, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +107,7 @@ func (s *NodeService) GetNodeMetrics(ctx context.Context, clusterName, nodeName
 	}
 
 	// 获取节点上所有的Pod
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +152,7 @@ func (s *NodeService) GetNodeMetrics(ctx context.Context, clusterName, nodeName
 	}
 
 	// 获取资源使用量（通过metrics-server）
-	config, err := s.clientManager.GetConfig(clusterName)
+	config, err := s.clientManager.GetConfigFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +206,7 @@ func (s *NodeService) GetNodeMetrics(ctx context.Context, clusterName, nodeName
 
 // DrainNode 对节点进行排水操作
 func (s *NodeService) DrainNode(ctx context.Context, clusterName, nodeName string, gracePeriodSeconds int, deleteLocalData bool, ignoreDaemonSets bool) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -238,7 +238,7 @@ func (s *NodeService) DrainNode(ctx context.Context, clusterName, nodeName strin
 
 // CordonNode 将节点设置为不可调度
 func (s *NodeService) CordonNode(ctx context.Context, clusterName, nodeName string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -248,7 +248,7 @@ func (s *NodeService) CordonNode(ctx context.Context, clusterName, nodeName stri
 
 // UncordonNode 将节点设置为可调度
 func (s *NodeService) UncordonNode(ctx context.Context, clusterName, nodeName string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -298,7 +298,7 @@ func (s *NodeService) GetNodeTaints(ctx context.Context, clusterName, nodeName s
 
 // AddNodeTaint 添加节点污点
 func (s *NodeService) AddNodeTaint(ctx context.Context, clusterName, nodeName string, taint corev1.Taint) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -330,7 +330,7 @@ func (s *NodeService) AddNodeTaint(ctx context.Context, clusterName, nodeName st
 
 // RemoveNodeTaint 删除节点污点
 func (s *NodeService) RemoveNodeTaint(ctx context.Context, clusterName, nodeName string, taintKey string, effect corev1.TaintEffect) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -384,7 +384,7 @@ func (s *NodeService) AddNodeLabel(ctx context.Context, clusterName, nodeName, k
 
 	logger.Info("开始添加节点标签")
 
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		logger.Error("获取客户端失败", err)
 		return err
@@ -431,7 +431,7 @@ func (s *NodeService) RemoveNodeLabel(ctx context.Context, clusterName, nodeName
 
 	logger.Info("开始删除节点标签")
 
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		logger.Error("获取客户端失败", err)
 		return err
@@ -509,13 +509,13 @@ func (s *NodeService) AddNode(ctx context.Context, clusterName string, nodeConfi
 		nodeConfig.Taints = append(nodeConfig.Taints, nodePool.Taints...)
 	}
 
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取客户端失败: %w", err)
 	}
 
 	// 获取集群配置和token
-	config, err := s.clientManager.GetConfig(clusterName)
+	config, err := s.clientManager.GetConfigFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群配置失败: %w", err)
 	}
@@ -616,7 +616,7 @@ func (s *NodeService) AddNode(ctx context.Context, clusterName string, nodeConfi
 
 // RemoveNode 移除节点
 func (s *NodeService) RemoveNode(ctx context.Context, clusterName, nodeName string, force bool) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取客户端失败: %w", err)
 	}
@@ -664,7 +664,7 @@ func (s *NodeService) RemoveNode(ctx context.Context, clusterName, nodeName stri
 
 // GetNodePods 获取节点上运行的Pod列表
 func (s *NodeService) GetNodePods(ctx context.Context, clusterName, nodeName string) ([]corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
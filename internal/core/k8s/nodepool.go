@@ -65,7 +65,7 @@ func NewNodePoolService(clientManager *ClientManager) *NodePoolService {
 
 // ListNodePools 获取集群的所有节点池
 func (s *NodePoolService) ListNodePools(ctx context.Context, clusterName string) ([]NodePool, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ func (s *NodePoolService) ListNodePools(ctx context.Context, clusterName string)
 
 // CreateNodePool 创建新的节点池
 func (s *NodePoolService) CreateNodePool(ctx context.Context, clusterName string, pool NodePool) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -194,7 +194,7 @@ func (s *NodePoolService) CreateNodePool(ctx context.Context, clusterName string
 
 // UpdateNodePool 更新节点池配置（不存在则创建，用于接管云节点池的本地配置）
 func (s *NodePoolService) UpdateNodePool(ctx context.Context, clusterName string, pool NodePool) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -259,7 +259,7 @@ func (s *NodePoolService) UpdateNodePool(ctx context.Context, clusterName string
 
 // DeleteNodePool 删除节点池
 func (s *NodePoolService) DeleteNodePool(ctx context.Context, clusterName, poolName string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -55,7 +55,7 @@ type UpdatePDBRequest struct {
 
 // ListPDBs 获取 PDB 列表
 func (s *PDBService) ListPDBs(ctx context.Context, clusterName, namespace string) ([]PDBInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +80,7 @@ func (s *PDBService) ListPDBs(ctx context.Context, clusterName, namespace string
 
 // GetPDB 获取 PDB 详情
 func (s *PDBService) GetPDB(ctx context.Context, clusterName, namespace, name string) (*PDBInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +94,7 @@ func (s *PDBService) GetPDB(ctx context.Context, clusterName, namespace, name st
 
 // CreatePDB 创建 PDB
 func (s *PDBService) CreatePDB(ctx context.Context, clusterName, namespace string, req CreatePDBRequest) (*PDBInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +130,7 @@ func (s *PDBService) CreatePDB(ctx context.Context, clusterName, namespace strin
 
 // UpdatePDB 更新 PDB
 func (s *PDBService) UpdatePDB(ctx context.Context, clusterName, namespace, name string, req UpdatePDBRequest) (*PDBInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +164,7 @@ func (s *PDBService) UpdatePDB(ctx context.Context, clusterName, namespace, name
 
 // DeletePDB 删除 PDB
 func (s *PDBService) DeletePDB(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 )
@@ -50,11 +51,15 @@ func NewPodService(clientManager *ClientManager) *PodService {
 
 // GetPods 获取所有命名空间的Pod列表
 func (s *PodService) GetPods(ctx context.Context, clusterName string) ([]corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
 
+	if lister, ok := s.clientManager.PodLister(ctx, clusterName); ok {
+		return copyCachedPods(lister.List(labels.Everything()))
+	}
+
 	podList, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取Pod列表失败: %w", err)
@@ -65,11 +70,15 @@ func (s *PodService) GetPods(ctx context.Context, clusterName string) ([]corev1.
 
 // GetPodsByNamespace 获取指定命名空间的Pod列表
 func (s *PodService) GetPodsByNamespace(ctx context.Context, clusterName, namespace string) ([]corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
 
+	if lister, ok := s.clientManager.PodLister(ctx, clusterName); ok {
+		return copyCachedPods(lister.Pods(namespace).List(labels.Everything()))
+	}
+
 	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("获取命名空间 %s 的Pod列表失败: %w", namespace, err)
@@ -78,9 +87,22 @@ func (s *PodService) GetPodsByNamespace(ctx context.Context, clusterName, namesp
 	return podList.Items, nil
 }
 
+// copyCachedPods 复制共享缓存中的 Pod，避免调用方修改缓存对象
+func copyCachedPods(cached []*corev1.Pod, err error) ([]corev1.Pod, error) {
+	if err != nil {
+		return nil, fmt.Errorf("获取Pod列表失败: %w", err)
+	}
+	sortObjects(cached)
+	pods := make([]corev1.Pod, 0, len(cached))
+	for _, pod := range cached {
+		pods = append(pods, *pod.DeepCopy())
+	}
+	return pods, nil
+}
+
 // GetPodsBySelector 根据标签选择器获取Pod列表
 func (s *PodService) GetPodsBySelector(ctx context.Context, clusterName, namespace string, selector map[string]string) ([]corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +128,7 @@ func (s *PodService) GetPodsBySelector(ctx context.Context, clusterName, namespa
 
 // GetPodDetails 获取Pod详情
 func (s *PodService) GetPodDetails(ctx context.Context, clusterName, namespace, podName string) (*corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +143,7 @@ func (s *PodService) GetPodDetails(ctx context.Context, clusterName, namespace,
 
 // GetPodLogs 获取Pod日志
 func (s *PodService) GetPodLogs(ctx context.Context, clusterName, namespace, podName, containerName string, tailLines int64) (string, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return "", err
 	}
@@ -162,7 +184,7 @@ type PodLogEntry struct {
 
 // GetLogsByLabelSelector 按标签选择器批量获取 Pod 日志（带并发限制）
 func (s *PodService) GetLogsByLabelSelector(ctx context.Context, clusterName, namespace, labelSelector, containerName string, tailLines int64, concurrencyLimit int) ([]PodLogEntry, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +224,7 @@ func (s *PodService) GetLogsByLabelSelector(ctx context.Context, clusterName, na
 
 // StreamPodLogs 获取Pod日志流，适用于实时日志
 func (s *PodService) StreamPodLogs(ctx context.Context, clusterName, namespace, podName, containerName string, tailLines int64, follow bool) (io.ReadCloser, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +268,7 @@ func (s *PodService) GetPodStatus(pod *corev1.Pod) string {
 
 // DeletePod 删除Pod
 func (s *PodService) DeletePod(ctx context.Context, clusterName, namespace, podName string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -273,7 +295,7 @@ func (s *PodService) GetPodExecExecutor(
 	stderr bool,
 	tty bool,
 ) (remotecommand.Executor, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取客户端失败: %w", err)
 	}
@@ -293,7 +315,7 @@ func (s *PodService) GetPodExecExecutor(
 		TTY:       tty,
 	}, scheme.ParameterCodec)
 
-	config, err := s.clientManager.GetConfig(clusterName)
+	config, err := s.clientManager.GetConfigFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取配置失败: %w", err)
 	}
@@ -307,9 +329,7 @@ func (s *PodService) GetPodExecExecutor(
 }
 
 // ExecToPod 在Pod中执行命令
-func (s *PodService) ExecToPod(clusterName, namespace, podName, containerName string, terminal remotecommand.TerminalSizeQueue) error {
-	ctx := context.Background()
-
+func (s *PodService) ExecToPod(ctx context.Context, clusterName, namespace, podName, containerName string, terminal remotecommand.TerminalSizeQueue) error {
 	// 默认终端命令
 	command := []string{"/bin/sh", "-c", "if [ -x /bin/bash ]; then /bin/bash; elif [ -x /bin/sh ]; then /bin/sh; else echo 'No shell available'; exit 1; fi"}
 
@@ -363,7 +383,7 @@ func (s *PodService) ExecToPod(clusterName, namespace, podName, containerName st
 
 // CheckPodExists 检查Pod是否存在
 func (s *PodService) CheckPodExists(ctx context.Context, clusterName, namespace, podName string) (*corev1.Pod, bool, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, false, err
 	}
@@ -381,7 +401,7 @@ func (s *PodService) CheckPodExists(ctx context.Context, clusterName, namespace,
 
 // GetPodEvents 获取Pod相关的事件
 func (s *PodService) GetPodEvents(ctx context.Context, clusterName, namespace, podName string) ([]corev1.Event, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -423,7 +443,7 @@ type RestartPolicyConfig struct {
 // UpdatePodRestartPolicy 更新Pod的重启策略
 // 实际实现：由于Kubernetes不允许直接修改Pod的重启策略，此方法提供Pod重新创建的功能
 func (s *PodService) UpdatePodRestartPolicy(ctx context.Context, clusterName, namespace, podName, restartPolicy string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -487,7 +507,7 @@ func (s *PodService) UpdatePodRestartPolicy(ctx context.Context, clusterName, na
 
 // RecreatePodWithRestartPolicy 重新创建Pod并设置新的重启策略
 func (s *PodService) RecreatePodWithRestartPolicy(ctx context.Context, clusterName, namespace, podName, restartPolicy string, deleteOriginal bool) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return fmt.Errorf("获取集群客户端失败: %w", err)
 	}
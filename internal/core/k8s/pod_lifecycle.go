@@ -176,7 +176,7 @@ func (s *PodLifecycleService) ManagePodLifecycle(ctx context.Context, clusterNam
 		)
 	}
 
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, NewPodLifecycleError(
 			ErrorTypeNetwork,
@@ -758,7 +758,7 @@ func (s *PodLifecycleService) resumeReplicaSet(ctx context.Context, client *kube
 
 // GetPodLifecycleHistory 获取Pod生命周期历史
 func (s *PodLifecycleService) GetPodLifecycleHistory(ctx context.Context, clusterName, namespace, podName string) ([]PodLifecycleEvent, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("获取集群客户端失败: %w", err)
 	}
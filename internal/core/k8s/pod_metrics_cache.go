@@ -10,13 +10,13 @@ import (
 type MetricsCache struct {
 	// 使用互斥锁保护缓存数据的并发访问
 	mu sync.RWMutex
-	// 存储Pod指标数据的映射：key为"namespace/podName"，value为PodMetrics
+	// 存储Pod指标数据的映射：key为"cluster/namespace/podName"，value为PodMetrics
 	metricsCache map[string]*PodMetrics
-	// 存储资源使用数据的映射：key为"namespace/podName"，value为PodResourceUsage
+	// 存储资源使用数据的映射：key为"cluster/namespace/podName"，value为PodResourceUsage
 	resourceCache map[string]*PodResourceUsage
 	// 缓存有效期
 	ttl time.Duration
-	// 最后更新时间的映射：key为"namespace/podName"，value为更新时间
+	// 最后更新时间的映射：key为"cluster/namespace/podName"，value为更新时间
 	lastUpdated map[string]time.Time
 }
 
@@ -31,11 +31,11 @@ func NewMetricsCache(ttl time.Duration) *MetricsCache {
 }
 
 // GetPodMetrics 从缓存中获取Pod指标数据
-func (c *MetricsCache) GetPodMetrics(namespace, podName string) (*PodMetrics, bool) {
+func (c *MetricsCache) GetPodMetrics(clusterName, namespace, podName string) (*PodMetrics, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 	metrics, exists := c.metricsCache[key]
 	if !exists {
 		return nil, false
@@ -50,21 +50,21 @@ func (c *MetricsCache) GetPodMetrics(namespace, podName string) (*PodMetrics, bo
 }
 
 // SetPodMetrics 将Pod指标数据存入缓存
-func (c *MetricsCache) SetPodMetrics(namespace, podName string, metrics *PodMetrics) {
+func (c *MetricsCache) SetPodMetrics(clusterName, namespace, podName string, metrics *PodMetrics) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 	c.metricsCache[key] = metrics
 	c.lastUpdated[key] = time.Now()
 }
 
 // GetPodResourceUsage 从缓存中获取Pod资源使用情况
-func (c *MetricsCache) GetPodResourceUsage(namespace, podName string) (*PodResourceUsage, bool) {
+func (c *MetricsCache) GetPodResourceUsage(clusterName, namespace, podName string) (*PodResourceUsage, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 	usage, exists := c.resourceCache[key]
 	if !exists {
 		return nil, false
@@ -79,11 +79,11 @@ func (c *MetricsCache) GetPodResourceUsage(namespace, podName string) (*PodResou
 }
 
 // SetPodResourceUsage 将Pod资源使用情况存入缓存
-func (c *MetricsCache) SetPodResourceUsage(namespace, podName string, usage *PodResourceUsage) {
+func (c *MetricsCache) SetPodResourceUsage(clusterName, namespace, podName string, usage *PodResourceUsage) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 	c.resourceCache[key] = usage
 	c.lastUpdated[key] = time.Now()
 }
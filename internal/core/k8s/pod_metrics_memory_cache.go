@@ -3,44 +3,61 @@ package k8s
 
 import (
 	"container/list"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"kube-tide/internal/core/tsdb"
+	"kube-tide/internal/utils/logger"
 )
 
-// MaxCacheSize 默认的最大缓存条目数
+// MaxCacheSize 默认的每个集群最大缓存条目数
 const DefaultMaxCacheSize = 1000
 
 // DataAggregationInterval 数据聚合的时间间隔
 const DefaultAggregationInterval = 1 * time.Hour
 
+// DefaultStorageRetention 落盘历史数据的默认保留时长
+const DefaultStorageRetention = 7 * 24 * time.Hour
+
+// 落盘时的指标名称
+const (
+	storageMetricCPU    = "cpu"
+	storageMetricMemory = "memory"
+	storageMetricDisk   = "disk"
+)
+
 // MemoryMetricsCache 是一个基于内存的Pod指标缓存实现
-// 支持LRU淘汰策略、内存大小限制和数据聚合
+// 支持LRU淘汰策略、内存大小限制和数据聚合。条目按集群分别计数与淘汰，
+// 一个集群的Pod数量再多也不会挤掉其他集群的数据
 type MemoryMetricsCache struct {
 	// 使用互斥锁保护缓存数据的并发访问
 	mu sync.RWMutex
 
-	// 存储Pod指标数据的映射：key为"namespace/podName"，value为PodMetrics
+	// 存储Pod指标数据的映射：key为"cluster/namespace/podName"，value为PodMetrics
 	metricsCache map[string]*PodMetrics
 
-	// 存储资源使用数据的映射：key为"namespace/podName"，value为PodResourceUsage
+	// 存储资源使用数据的映射：key为"cluster/namespace/podName"，value为PodResourceUsage
 	resourceCache map[string]*PodResourceUsage
 
 	// 缓存有效期
 	ttl time.Duration
 
-	// 最后更新时间的映射：key为"namespace/podName"，value为更新时间
+	// 最后更新时间的映射：key为"cluster/namespace/podName"，value为更新时间
 	lastUpdated map[string]time.Time
 
-	// 最后访问时间的映射：key为"namespace/podName"，value为访问时间
+	// 最后访问时间的映射：key为"cluster/namespace/podName"，value为访问时间
 	lastAccessed map[string]time.Time
 
-	// LRU列表，用于实现最近最少使用淘汰策略
-	lruList *list.List
+	// 每个集群一个LRU列表，用于实现按集群的最近最少使用淘汰策略
+	lruLists map[string]*list.List
 
 	// LRU映射，用于快速查找列表中的元素
 	lruMap map[string]*list.Element
 
-	// 最大缓存条目数
+	// 每个集群的最大缓存条目数
 	maxCacheSize int
 
 	// 数据聚合时间间隔
@@ -48,11 +65,21 @@ type MemoryMetricsCache struct {
 
 	// 最后一次聚合的时间
 	lastAggregation time.Time
+
+	// 落盘存储，SaveToStorage/LoadFromStorage 首次调用时打开
+	store *tsdb.DB
+
+	// 落盘数据保留时长
+	storageRetention time.Duration
+
+	// 每条序列已落盘的最新时间：key为"<缓存键>|<指标>"，只追加更新的数据点
+	persisted map[string]time.Time
 }
 
 // LRUCacheItem LRU缓存项目
 type LRUCacheItem struct {
-	Key string
+	Key     string
+	Cluster string
 }
 
 // NewMemoryMetricsCache 创建一个新的基于内存的指标缓存
@@ -70,21 +97,23 @@ func NewMemoryMetricsCache(ttl time.Duration, maxCacheSize int, aggregationInter
 		resourceCache:       make(map[string]*PodResourceUsage),
 		lastUpdated:         make(map[string]time.Time),
 		lastAccessed:        make(map[string]time.Time),
-		lruList:             list.New(),
+		lruLists:            make(map[string]*list.List),
 		lruMap:              make(map[string]*list.Element),
 		ttl:                 ttl,
 		maxCacheSize:        maxCacheSize,
 		aggregationInterval: aggregationInterval,
 		lastAggregation:     time.Now(),
+		storageRetention:    DefaultStorageRetention,
+		persisted:           make(map[string]time.Time),
 	}
 }
 
 // GetPodMetrics 从缓存中获取Pod指标数据
-func (c *MemoryMetricsCache) GetPodMetrics(namespace, podName string) (*PodMetrics, bool) {
+func (c *MemoryMetricsCache) GetPodMetrics(clusterName, namespace, podName string) (*PodMetrics, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 	metrics, exists := c.metricsCache[key]
 	if !exists {
 		return nil, false
@@ -102,19 +131,17 @@ func (c *MemoryMetricsCache) GetPodMetrics(namespace, podName string) (*PodMetri
 }
 
 // SetPodMetrics 将Pod指标数据存入缓存
-func (c *MemoryMetricsCache) SetPodMetrics(namespace, podName string, metrics *PodMetrics) {
+func (c *MemoryMetricsCache) SetPodMetrics(clusterName, namespace, podName string, metrics *PodMetrics) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 
 	// 检查是否需要进行数据聚合
 	c.aggregateDataIfNeeded()
 
-	// 如果达到最大缓存大小且该键不存在于缓存中，则淘汰最久未使用的条目
-	if len(c.metricsCache) >= c.maxCacheSize && c.metricsCache[key] == nil {
-		c.evictLRU()
-	}
+	// 如果该集群达到最大缓存大小且该键不存在于缓存中，则淘汰该集群最久未使用的条目
+	c.ensureCapacity(clusterName, key)
 
 	// 将当前数据点添加到历史数据中
 	now := time.Now().Format(time.RFC3339)
@@ -150,15 +177,15 @@ func (c *MemoryMetricsCache) SetPodMetrics(namespace, podName string, metrics *P
 	c.lastUpdated[key] = time.Now()
 
 	// 更新LRU信息
-	c.updateLRU(key)
+	c.updateLRU(clusterName, key)
 }
 
 // GetPodResourceUsage 从缓存中获取Pod资源使用情况
-func (c *MemoryMetricsCache) GetPodResourceUsage(namespace, podName string) (*PodResourceUsage, bool) {
+func (c *MemoryMetricsCache) GetPodResourceUsage(clusterName, namespace, podName string) (*PodResourceUsage, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 	usage, exists := c.resourceCache[key]
 	if !exists {
 		return nil, false
@@ -176,26 +203,24 @@ func (c *MemoryMetricsCache) GetPodResourceUsage(namespace, podName string) (*Po
 }
 
 // SetPodResourceUsage 将Pod资源使用情况存入缓存
-func (c *MemoryMetricsCache) SetPodResourceUsage(namespace, podName string, usage *PodResourceUsage) {
+func (c *MemoryMetricsCache) SetPodResourceUsage(clusterName, namespace, podName string, usage *PodResourceUsage) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := namespace + "/" + podName
+	key := podCacheKey(clusterName, namespace, podName)
 
 	// 检查是否需要进行数据聚合
 	c.aggregateDataIfNeeded()
 
-	// 如果达到最大缓存大小且该键不存在于缓存中，则淘汰最久未使用的条目
-	if len(c.resourceCache) >= c.maxCacheSize && c.resourceCache[key] == nil {
-		c.evictLRU()
-	}
+	// 如果该集群达到最大缓存大小且该键不存在于缓存中，则淘汰该集群最久未使用的条目
+	c.ensureCapacity(clusterName, key)
 
 	// 更新缓存
 	c.resourceCache[key] = usage
 	c.lastUpdated[key] = time.Now()
 
 	// 更新LRU信息
-	c.updateLRU(key)
+	c.updateLRU(clusterName, key)
 }
 
 // Clear 清除所有缓存数据
@@ -207,8 +232,45 @@ func (c *MemoryMetricsCache) Clear() {
 	c.resourceCache = make(map[string]*PodResourceUsage)
 	c.lastUpdated = make(map[string]time.Time)
 	c.lastAccessed = make(map[string]time.Time)
-	c.lruList = list.New()
+	c.lruLists = make(map[string]*list.List)
 	c.lruMap = make(map[string]*list.Element)
+	c.persisted = make(map[string]time.Time)
+}
+
+// RemoveCluster 删除某个集群的全部缓存数据，已开启落盘时同时删除其段文件
+func (c *MemoryMetricsCache) RemoveCluster(clusterName string) {
+	c.mu.Lock()
+	if lruList, exists := c.lruLists[clusterName]; exists {
+		for element := lruList.Front(); element != nil; element = element.Next() {
+			c.deleteKey(element.Value.(*LRUCacheItem).Key)
+		}
+		delete(c.lruLists, clusterName)
+	}
+	store := c.store
+	c.mu.Unlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.Delete(clusterName); err != nil {
+		logger.Warn("删除Pod指标历史失败", "cluster", clusterName, "error", err.Error())
+	}
+}
+
+// StoredClusters 返回落盘存储中有历史数据的集群，未开启落盘时返回空
+func (c *MemoryMetricsCache) StoredClusters() []string {
+	c.mu.RLock()
+	store := c.store
+	c.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+	clusters, err := store.Clusters()
+	if err != nil {
+		logger.Warn("读取Pod指标历史失败", "error", err.Error())
+		return nil
+	}
+	return clusters
 }
 
 // CleanExpired 清除过期的缓存数据
@@ -219,36 +281,47 @@ func (c *MemoryMetricsCache) CleanExpired() {
 	now := time.Now()
 	for key, updatedAt := range c.lastUpdated {
 		if now.Sub(updatedAt) > c.ttl {
-			// 删除过期的缓存条目
-			delete(c.metricsCache, key)
-			delete(c.resourceCache, key)
-			delete(c.lastUpdated, key)
-			delete(c.lastAccessed, key)
-
-			// 从LRU列表中删除
+			// 删除过期的缓存条目，并从所属集群的LRU列表中删除
 			if element, exists := c.lruMap[key]; exists {
-				c.lruList.Remove(element)
-				delete(c.lruMap, key)
+				c.removeElement(element)
+			} else {
+				c.deleteKey(key)
 			}
 		}
 	}
 }
 
-// updateLRU 更新LRU列表
-func (c *MemoryMetricsCache) updateLRU(key string) {
+// updateLRU 更新集群的LRU列表
+func (c *MemoryMetricsCache) updateLRU(clusterName, key string) {
+	lruList, exists := c.lruLists[clusterName]
+	if !exists {
+		lruList = list.New()
+		c.lruLists[clusterName] = lruList
+	}
+
 	// 已经存在，则移到列表前端
 	if element, exists := c.lruMap[key]; exists {
-		c.lruList.MoveToFront(element)
+		lruList.MoveToFront(element)
 		c.lastAccessed[key] = time.Now()
 		return
 	}
 
 	// 不存在，则添加到列表前端
-	element := c.lruList.PushFront(&LRUCacheItem{Key: key})
+	element := lruList.PushFront(&LRUCacheItem{Key: key, Cluster: clusterName})
 	c.lruMap[key] = element
 	c.lastAccessed[key] = time.Now()
 }
 
+// ensureCapacity 新键写入前，若集群已达到最大缓存条目数则淘汰该集群最久未使用的条目
+func (c *MemoryMetricsCache) ensureCapacity(clusterName, key string) {
+	if _, exists := c.lruMap[key]; exists {
+		return
+	}
+	if lruList, exists := c.lruLists[clusterName]; exists && lruList.Len() >= c.maxCacheSize {
+		c.evictLRU(clusterName)
+	}
+}
+
 // updateAccess 更新访问时间（在读锁保护下调用，只是记录逻辑调用时间）
 func (c *MemoryMetricsCache) updateAccess(key string) {
 	// 这里不需要更新LRU位置，因为GetXXX方法已经在读锁中
@@ -256,30 +329,39 @@ func (c *MemoryMetricsCache) updateAccess(key string) {
 	c.lastAccessed[key] = time.Now()
 }
 
-// evictLRU 淘汰最近最少使用的缓存条目
-func (c *MemoryMetricsCache) evictLRU() {
-	if c.lruList.Len() == 0 {
+// evictLRU 淘汰集群中最近最少使用的缓存条目
+func (c *MemoryMetricsCache) evictLRU(clusterName string) {
+	lruList, exists := c.lruLists[clusterName]
+	if !exists || lruList.Len() == 0 {
 		return
 	}
 
-	// 获取最后一个元素（最久未使用的）
-	element := c.lruList.Back()
-	if element == nil {
-		return
-	}
-
-	// 从LRU列表中删除
-	c.lruList.Remove(element)
+	// 获取最后一个元素（最久未使用的）并从缓存中删除
+	c.removeElement(lruList.Back())
+}
 
-	// 获取键并从缓存中删除
+// removeElement 从所属集群的LRU列表和缓存中删除一个条目
+func (c *MemoryMetricsCache) removeElement(element *list.Element) {
 	item := element.Value.(*LRUCacheItem)
-	key := item.Key
+	if lruList, exists := c.lruLists[item.Cluster]; exists {
+		lruList.Remove(element)
+		if lruList.Len() == 0 {
+			delete(c.lruLists, item.Cluster)
+		}
+	}
+	c.deleteKey(item.Key)
+}
 
+// deleteKey 从各个映射中删除缓存键，包括各序列的落盘水位
+func (c *MemoryMetricsCache) deleteKey(key string) {
 	delete(c.metricsCache, key)
 	delete(c.resourceCache, key)
 	delete(c.lastUpdated, key)
 	delete(c.lastAccessed, key)
 	delete(c.lruMap, key)
+	for _, metric := range []string{storageMetricCPU, storageMetricMemory, storageMetricDisk} {
+		delete(c.persisted, key+"|"+metric)
+	}
 }
 
 // aggregateDataIfNeeded 检查是否需要进行数据聚合，如果需要则执行聚合
@@ -437,6 +519,13 @@ func (c *MemoryMetricsCache) aggregateTimeSeriesData(dataPoints []MetricDataPoin
 	result := append(dailyAggregatedPoints, hourlyAggregatedPoints...)
 	result = append(result, recentPoints...)
 
+	// 分桶遍历顺序不确定，按时间重新排序
+	sort.SliceStable(result, func(i, j int) bool {
+		ti, erri := time.Parse(time.RFC3339, result[i].Timestamp)
+		tj, errj := time.Parse(time.RFC3339, result[j].Timestamp)
+		return erri == nil && errj == nil && ti.Before(tj)
+	})
+
 	return result
 }
 
@@ -496,19 +585,31 @@ func (c *MemoryMetricsCache) aggregateResourceDataPoints(dataPoints []ResourceDa
 	return result
 }
 
-// GetCacheSize 获取当前缓存大小
+// GetCacheSize 获取当前缓存大小（所有集群合计）
 func (c *MemoryMetricsCache) GetCacheSize() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return len(c.metricsCache)
+	return len(c.lruMap)
+}
+
+// GetClusterCacheSizes 获取每个集群的缓存条目数
+func (c *MemoryMetricsCache) GetClusterCacheSizes() map[string]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	sizes := make(map[string]int, len(c.lruLists))
+	for clusterName, lruList := range c.lruLists {
+		sizes[clusterName] = lruList.Len()
+	}
+	return sizes
 }
 
-// GetMaxCacheSize 获取最大缓存大小
+// GetMaxCacheSize 获取每个集群的最大缓存大小
 func (c *MemoryMetricsCache) GetMaxCacheSize() int {
 	return c.maxCacheSize
 }
 
-// SetMaxCacheSize 设置最大缓存大小
+// SetMaxCacheSize 设置每个集群的最大缓存大小
 func (c *MemoryMetricsCache) SetMaxCacheSize(size int) {
 	if size <= 0 {
 		size = DefaultMaxCacheSize
@@ -517,15 +618,12 @@ func (c *MemoryMetricsCache) SetMaxCacheSize(size int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	oldSize := c.maxCacheSize
 	c.maxCacheSize = size
 
-	// 如果新的大小小于旧的大小，需要淘汰多余的条目
-	if size < oldSize && len(c.metricsCache) > size {
-		// 需要淘汰的条目数
-		toEvict := len(c.metricsCache) - size
-		for i := 0; i < toEvict; i++ {
-			c.evictLRU()
+	// 淘汰各集群超出新上限的条目
+	for clusterName, lruList := range c.lruLists {
+		for lruList.Len() > size {
+			c.evictLRU(clusterName)
 		}
 	}
 }
@@ -547,16 +645,250 @@ func (c *MemoryMetricsCache) GetAggregationInterval() time.Duration {
 	return c.aggregationInterval
 }
 
-// SaveToStorage 将缓存数据保存到持久存储（接口预留，暂不实现）
+// SetStorageRetention 设置落盘数据保留时长，需在首次 SaveToStorage/LoadFromStorage 之前调用
+func (c *MemoryMetricsCache) SetStorageRetention(retention time.Duration) {
+	if retention <= 0 {
+		retention = DefaultStorageRetention
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.storageRetention = retention
+}
+
+// SaveToStorage 将上次保存之后的新数据点追加到 storagePath 下各集群的段文件，
+// 段文件增长到阈值后按 aggregateTimeSeriesData 的策略降采样压缩
 func (c *MemoryMetricsCache) SaveToStorage(storagePath string) error {
-	// 这是一个预留的接口，用于将内存数据持久化到磁盘
-	// 暂时返回nil，表示不执行任何操作
+	store, err := c.openStore(storagePath)
+	if err != nil {
+		return err
+	}
+
+	// 在锁内收集新数据点，落盘在锁外进行
+	batches := make(map[string]map[tsdb.Key][]tsdb.Point)
+	watermarks := make(map[string]time.Time)
+	c.mu.RLock()
+	for key, metrics := range c.metricsCache {
+		if metrics == nil {
+			continue
+		}
+		partition, series := splitCacheKey(key)
+		for metric, dataPoints := range map[string][]MetricDataPoint{
+			storageMetricCPU:    metrics.HistoricalData.CPUUsage,
+			storageMetricMemory: metrics.HistoricalData.MemoryUsage,
+			storageMetricDisk:   metrics.HistoricalData.DiskUsage,
+		} {
+			seriesKey := key + "|" + metric
+			points, latest := pointsAfter(dataPoints, c.persisted[seriesKey])
+			if len(points) == 0 {
+				continue
+			}
+			if batches[partition] == nil {
+				batches[partition] = make(map[tsdb.Key][]tsdb.Point)
+			}
+			batches[partition][tsdb.Key{Series: series, Metric: metric}] = points
+			watermarks[seriesKey] = latest
+		}
+	}
+	c.mu.RUnlock()
+
+	for partition, batch := range batches {
+		if err := store.Append(partition, batch); err != nil {
+			return fmt.Errorf("保存Pod指标历史失败: %w", err)
+		}
+		if store.NeedsCompaction(partition) {
+			if err := store.Compact(partition, c.downsample); err != nil {
+				logger.Warn("压缩Pod指标历史失败", "partition", partition, "error", err.Error())
+			}
+		}
+	}
+
+	c.mu.Lock()
+	for seriesKey, latest := range watermarks {
+		// 落盘期间已被淘汰的条目不再记录水位
+		if _, exists := c.metricsCache[seriesKey[:strings.LastIndex(seriesKey, "|")]]; !exists {
+			continue
+		}
+		if latest.After(c.persisted[seriesKey]) {
+			c.persisted[seriesKey] = latest
+		}
+	}
+	c.mu.Unlock()
 	return nil
 }
 
-// LoadFromStorage 从持久存储加载缓存数据（接口预留，暂不实现）
+// LoadFromStorage 从 storagePath 加载落盘的历史数据，已在缓存中的条目不会被覆盖。
+// 最后一个数据点早于缓存有效期的 Pod 不加载。
 func (c *MemoryMetricsCache) LoadFromStorage(storagePath string) error {
-	// 这是一个预留的接口，用于从磁盘加载持久化的数据
-	// 暂时返回nil，表示不执行任何操作
+	store, err := c.openStore(storagePath)
+	if err != nil {
+		return err
+	}
+	partitions, err := store.Clusters()
+	if err != nil {
+		return err
+	}
+
+	type loadedEntry struct {
+		key     string
+		metrics *PodMetrics
+		latest  time.Time
+	}
+	entries := make(map[string]*loadedEntry)
+	watermarks := make(map[string]time.Time)
+	for _, partition := range partitions {
+		// 启动时先压缩一次，去掉过期与重复的数据
+		if err := store.Compact(partition, c.downsample); err != nil {
+			logger.Warn("压缩Pod指标历史失败", "partition", partition, "error", err.Error())
+		}
+		data, err := store.Load(partition)
+		if err != nil {
+			return fmt.Errorf("加载Pod指标历史失败: %w", err)
+		}
+		for seriesKey, points := range data {
+			key := joinCacheKey(partition, seriesKey.Series)
+			entry, ok := entries[key]
+			if !ok {
+				entry = &loadedEntry{key: key, metrics: &PodMetrics{}}
+				entries[key] = entry
+			}
+			dataPoints := toMetricDataPoints(points)
+			switch seriesKey.Metric {
+			case storageMetricCPU:
+				entry.metrics.HistoricalData.CPUUsage = dataPoints
+			case storageMetricMemory:
+				entry.metrics.HistoricalData.MemoryUsage = dataPoints
+			case storageMetricDisk:
+				entry.metrics.HistoricalData.DiskUsage = dataPoints
+			default:
+				continue
+			}
+			latest := points[len(points)-1].Time
+			watermarks[key+"|"+seriesKey.Metric] = latest
+			if latest.After(entry.latest) {
+				entry.latest = latest
+			}
+		}
+	}
+
+	// 按最后更新时间从旧到新放入缓存，使最近活跃的 Pod 位于 LRU 前端
+	sorted := make([]*loadedEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].latest.Before(sorted[j].latest)
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	loaded := 0
+	for _, entry := range sorted {
+		if time.Since(entry.latest) > c.ttl || c.metricsCache[entry.key] != nil {
+			continue
+		}
+		clusterName, _ := splitCacheKey(entry.key)
+		c.ensureCapacity(clusterName, entry.key)
+		c.metricsCache[entry.key] = entry.metrics
+		c.lastUpdated[entry.key] = entry.latest
+		c.updateLRU(clusterName, entry.key)
+		loaded++
+	}
+	for seriesKey, latest := range watermarks {
+		if latest.After(c.persisted[seriesKey]) {
+			c.persisted[seriesKey] = latest
+		}
+	}
+	logger.Info("已加载Pod指标历史", "path", storagePath, "pods", loaded)
 	return nil
 }
+
+// CloseStorage 关闭落盘存储
+func (c *MemoryMetricsCache) CloseStorage() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.store == nil {
+		return nil
+	}
+	err := c.store.Close()
+	c.store = nil
+	return err
+}
+
+// openStore 打开（或复用）storagePath 对应的落盘存储
+func (c *MemoryMetricsCache) openStore(storagePath string) (*tsdb.DB, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.store != nil && c.store.Dir() == storagePath {
+		return c.store, nil
+	}
+	if c.store != nil {
+		c.store.Close()
+		c.persisted = make(map[string]time.Time)
+	}
+	store, err := tsdb.Open(storagePath, tsdb.Options{Retention: c.storageRetention})
+	if err != nil {
+		return nil, err
+	}
+	c.store = store
+	return store, nil
+}
+
+// downsample 压缩段文件时复用内存聚合策略
+func (c *MemoryMetricsCache) downsample(_ tsdb.Key, points []tsdb.Point) []tsdb.Point {
+	aggregated := c.aggregateTimeSeriesData(toMetricDataPoints(points))
+	result, _ := pointsAfter(aggregated, time.Time{})
+	return result
+}
+
+// podCacheKey 生成缓存键 "cluster/namespace/podName"
+func podCacheKey(clusterName, namespace, podName string) string {
+	return clusterName + "/" + namespace + "/" + podName
+}
+
+// splitCacheKey 将缓存键拆分为集群（段文件分区）与 "namespace/podName" 序列名。
+// 命名空间和Pod名称不含 "/"，因此从右侧拆分，集群名称中可以包含 "/"
+func splitCacheKey(key string) (string, string) {
+	podSep := strings.LastIndex(key, "/")
+	if podSep <= 0 {
+		return "", key
+	}
+	nsSep := strings.LastIndex(key[:podSep], "/")
+	if nsSep < 0 {
+		return "", key
+	}
+	return key[:nsSep], key[nsSep+1:]
+}
+
+// joinCacheKey 由集群与序列名还原缓存键
+func joinCacheKey(clusterName, series string) string {
+	return clusterName + "/" + series
+}
+
+// pointsAfter 返回时间晚于 after 的数据点及其中最新的时间
+func pointsAfter(dataPoints []MetricDataPoint, after time.Time) ([]tsdb.Point, time.Time) {
+	points := make([]tsdb.Point, 0)
+	latest := after
+	for _, dp := range dataPoints {
+		timestamp, err := time.Parse(time.RFC3339, dp.Timestamp)
+		if err != nil || !timestamp.After(after) {
+			continue
+		}
+		points = append(points, tsdb.Point{Time: timestamp, Value: dp.Value})
+		if timestamp.After(latest) {
+			latest = timestamp
+		}
+	}
+	return points, latest
+}
+
+func toMetricDataPoints(points []tsdb.Point) []MetricDataPoint {
+	dataPoints := make([]MetricDataPoint, len(points))
+	for i, p := range points {
+		dataPoints[i] = MetricDataPoint{Timestamp: p.Time.Format(time.RFC3339), Value: p.Value}
+	}
+	return dataPoints
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryMetricsCacheStorageRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewMemoryMetricsCache(24*time.Hour, DefaultMaxCacheSize, DefaultAggregationInterval)
+	cache.SetPodMetrics("prod/eu", "default", "web-0", &PodMetrics{CPUUsage: 12.5, MemoryUsage: 40, DiskUsedBytes: 1024})
+	if err := cache.SaveToStorage(dir); err != nil {
+		t.Fatalf("SaveToStorage: %v", err)
+	}
+	// 第二次保存没有新数据点，不应重复追加
+	if err := cache.SaveToStorage(dir); err != nil {
+		t.Fatalf("SaveToStorage: %v", err)
+	}
+	cache.CloseStorage()
+
+	restored := NewMemoryMetricsCache(24*time.Hour, DefaultMaxCacheSize, DefaultAggregationInterval)
+	if err := restored.LoadFromStorage(dir); err != nil {
+		t.Fatalf("LoadFromStorage: %v", err)
+	}
+	defer restored.CloseStorage()
+
+	metrics, ok := restored.GetPodMetrics("prod/eu", "default", "web-0")
+	if !ok {
+		t.Fatal("expected restored pod metrics")
+	}
+	if len(metrics.HistoricalData.CPUUsage) != 1 || metrics.HistoricalData.CPUUsage[0].Value != 12.5 {
+		t.Fatalf("unexpected cpu history: %+v", metrics.HistoricalData.CPUUsage)
+	}
+	if len(metrics.HistoricalData.DiskUsage) != 1 || metrics.HistoricalData.DiskUsage[0].Value != 1024 {
+		t.Fatalf("unexpected disk history: %+v", metrics.HistoricalData.DiskUsage)
+	}
+}
+
+func TestMemoryMetricsCachePerClusterEviction(t *testing.T) {
+	cache := NewMemoryMetricsCache(24*time.Hour, 2, DefaultAggregationInterval)
+
+	// 同名Pod在不同集群中互不覆盖
+	cache.SetPodMetrics("a", "default", "web-0", &PodMetrics{CPUUsage: 1})
+	cache.SetPodMetrics("b", "default", "web-0", &PodMetrics{CPUUsage: 2})
+	if m, _ := cache.GetPodMetrics("a", "default", "web-0"); m == nil || m.CPUUsage != 1 {
+		t.Fatalf("cluster a entry overwritten: %+v", m)
+	}
+
+	// 集群 b 写满后只淘汰自己的条目
+	cache.SetPodMetrics("b", "default", "web-1", &PodMetrics{})
+	cache.SetPodMetrics("b", "default", "web-2", &PodMetrics{})
+	if _, ok := cache.GetPodMetrics("a", "default", "web-0"); !ok {
+		t.Fatal("cluster a entry evicted by cluster b")
+	}
+	if _, ok := cache.GetPodMetrics("b", "default", "web-0"); ok {
+		t.Fatal("expected least recently used entry of cluster b to be evicted")
+	}
+	sizes := cache.GetClusterCacheSizes()
+	if sizes["a"] != 1 || sizes["b"] != 2 {
+		t.Fatalf("unexpected cluster sizes: %v", sizes)
+	}
+
+	cache.RemoveCluster("b")
+	if cache.GetCacheSize() != 1 {
+		t.Fatalf("expected only cluster a to remain, size %d", cache.GetCacheSize())
+	}
+}
+
+func TestMemoryMetricsCacheRemoveClusterStorage(t *testing.T) {
+	cache := NewMemoryMetricsCache(24*time.Hour, 1, DefaultAggregationInterval)
+	defer cache.CloseStorage()
+	cache.SetPodMetrics("a", "default", "web-0", &PodMetrics{CPUUsage: 1})
+	cache.SetPodMetrics("b", "default", "web-0", &PodMetrics{CPUUsage: 2})
+	if err := cache.SaveToStorage(t.TempDir()); err != nil {
+		t.Fatalf("SaveToStorage: %v", err)
+	}
+	if len(cache.persisted) != 6 {
+		t.Fatalf("expected watermarks for 2 pods x 3 metrics, got %d", len(cache.persisted))
+	}
+
+	// 淘汰条目时同时清理其落盘水位
+	cache.SetPodMetrics("a", "default", "web-1", &PodMetrics{CPUUsage: 3})
+	if _, ok := cache.persisted[podCacheKey("a", "default", "web-0")+"|"+storageMetricCPU]; ok {
+		t.Fatal("watermark of an evicted entry should be pruned")
+	}
+
+	// 移除集群时删除其段文件
+	cache.RemoveCluster("b")
+	if clusters := cache.StoredClusters(); len(clusters) != 1 || clusters[0] != "a" {
+		t.Fatalf("unexpected stored clusters: %v", clusters)
+	}
+	if len(cache.persisted) != 0 {
+		t.Fatalf("watermarks should be pruned, got %v", cache.persisted)
+	}
+}
+
+func TestSplitCacheKey(t *testing.T) {
+	clusterName, series := splitCacheKey(podCacheKey("prod/eu", "default", "web-0"))
+	if clusterName != "prod/eu" || series != "default/web-0" {
+		t.Fatalf("unexpected split: %q %q", clusterName, series)
+	}
+}
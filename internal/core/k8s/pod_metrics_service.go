@@ -2,22 +2,26 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"kube-tide/internal/utils/logger"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
 )
 
 // PodMetricsService pod指标服务
 type PodMetricsService struct {
 	clientManager *ClientManager
 	metricsCache  *MemoryMetricsCache
+	storageDir    string // 指标历史落盘目录，为空表示不落盘
 }
 
 // NewPodMetricsService 创建一个新的Pod指标服务
 func NewPodMetricsService(clientManager *ClientManager) *PodMetricsService {
-	// 创建MemoryMetricsCache实例，设置TTL为24小时，最大缓存大小为1000，聚合间隔为1小时
+	// 创建MemoryMetricsCache实例，设置TTL为24小时，每个集群最多缓存1000个Pod，聚合间隔为1小时
 	cache := NewMemoryMetricsCache(24*time.Hour, DefaultMaxCacheSize, DefaultAggregationInterval)
 	return &PodMetricsService{
 		clientManager: clientManager,
@@ -27,17 +31,17 @@ func NewPodMetricsService(clientManager *ClientManager) *PodMetricsService {
 
 // GetPodMetrics 获取Pod的CPU和内存监控指标
 func (s *PodMetricsService) GetPodMetrics(ctx context.Context, clusterName, namespace, podName string) (*PodMetrics, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
-	config, err := s.clientManager.GetConfig(clusterName)
+	config, err := s.clientManager.GetConfigFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
 
 	// 首先尝试从缓存获取指标数据
-	if metrics, found := s.metricsCache.GetPodMetrics(namespace, podName); found {
+	if metrics, found := s.metricsCache.GetPodMetrics(clusterName, namespace, podName); found {
 		currentMetrics, err := GetPodMetrics(client, config, namespace, podName)
 		if err == nil {
 			now := time.Now().Format(time.RFC3339)
@@ -61,7 +65,7 @@ func (s *PodMetricsService) GetPodMetrics(ctx context.Context, clusterName, name
 			metrics.DiskUsed = currentMetrics.DiskUsed
 			metrics.Containers = currentMetrics.Containers
 
-			s.metricsCache.SetPodMetrics(namespace, podName, metrics)
+			s.metricsCache.SetPodMetrics(clusterName, namespace, podName, metrics)
 
 			logger.Debug("更新Pod指标并添加历史数据点",
 				"namespace", namespace,
@@ -81,7 +85,7 @@ func (s *PodMetricsService) GetPodMetrics(ctx context.Context, clusterName, name
 		return nil, err
 	}
 
-	s.metricsCache.SetPodMetrics(namespace, podName, metrics)
+	s.metricsCache.SetPodMetrics(clusterName, namespace, podName, metrics)
 
 	logger.Debug("首次获取Pod指标数据",
 		"namespace", namespace,
@@ -115,12 +119,12 @@ func (s *PodMetricsService) StartPeriodicMetricsCollection(ctx context.Context,
 
 // collectAllPodsMetrics 收集所有Pod的指标数据
 func (s *PodMetricsService) collectAllPodsMetrics(ctx context.Context, clusterName string) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		logger.Error("获取K8s客户端失败", "cluster", clusterName, "error", err)
 		return
 	}
-	config, err := s.clientManager.GetConfig(clusterName)
+	config, err := s.clientManager.GetConfigFor(ctx, clusterName)
 	if err != nil {
 		logger.Error("获取集群配置失败", "cluster", clusterName, "error", err)
 		return
@@ -130,62 +134,51 @@ func (s *PodMetricsService) collectAllPodsMetrics(ctx context.Context, clusterNa
 	startTime := time.Now()
 	logger.Debug("开始收集Pod指标数据", "cluster", clusterName, "time", startTime.Format(time.RFC3339))
 
-	// 获取所有命名空间
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	pods, err := s.listPodsForCollection(ctx, client, clusterName)
 	if err != nil {
-		logger.Error("获取命名空间列表失败", "cluster", clusterName, "error", err)
+		logger.Error("获取Pod列表失败", "cluster", clusterName, "error", err)
 		return
 	}
 
 	// 统计信息
-	totalPods := 0
+	totalPods := len(pods)
 	successPods := 0
 
-	// 遍历所有命名空间和Pod，收集指标
-	for _, ns := range namespaces.Items {
-		pods, err := client.CoreV1().Pods(ns.Name).List(ctx, metav1.ListOptions{})
+	// 遍历所有Pod，收集指标
+	for _, pod := range pods {
+		metrics, err := GetPodMetrics(client, config, pod.namespace, pod.name)
 		if err != nil {
-			logger.Warn("获取Pod列表失败", "namespace", ns.Name, "error", err)
+			logger.Debug("获取Pod指标失败", "namespace", pod.namespace, "pod", pod.name, "error", err)
 			continue
 		}
 
-		totalPods += len(pods.Items)
+		// 获取现有的缓存数据
+		existingMetrics, found := s.metricsCache.GetPodMetrics(clusterName, pod.namespace, pod.name)
+		if found {
+			// 保留历史数据
+			metrics.HistoricalData = existingMetrics.HistoricalData
 
-		for _, pod := range pods.Items {
-			metrics, err := GetPodMetrics(client, config, ns.Name, pod.Name)
-			if err != nil {
-				logger.Debug("获取Pod指标失败", "namespace", ns.Name, "pod", pod.Name, "error", err)
-				continue
-			}
+			// 添加当前数据点到历史数据中
+			now := time.Now().Format(time.RFC3339)
+			metrics.HistoricalData.CPUUsage = append(metrics.HistoricalData.CPUUsage, MetricDataPoint{
+				Timestamp: now,
+				Value:     metrics.CPUUsage,
+			})
 
-			// 获取现有的缓存数据
-			existingMetrics, found := s.metricsCache.GetPodMetrics(ns.Name, pod.Name)
-			if found {
-				// 保留历史数据
-				metrics.HistoricalData = existingMetrics.HistoricalData
-
-				// 添加当前数据点到历史数据中
-				now := time.Now().Format(time.RFC3339)
-				metrics.HistoricalData.CPUUsage = append(metrics.HistoricalData.CPUUsage, MetricDataPoint{
-					Timestamp: now,
-					Value:     metrics.CPUUsage,
-				})
-
-				metrics.HistoricalData.MemoryUsage = append(metrics.HistoricalData.MemoryUsage, MetricDataPoint{
-					Timestamp: now,
-					Value:     metrics.MemoryUsage,
-				})
-
-				metrics.HistoricalData.DiskUsage = append(metrics.HistoricalData.DiskUsage, MetricDataPoint{
-					Timestamp: now,
-					Value:     float64(metrics.DiskUsedBytes),
-				})
-			}
+			metrics.HistoricalData.MemoryUsage = append(metrics.HistoricalData.MemoryUsage, MetricDataPoint{
+				Timestamp: now,
+				Value:     metrics.MemoryUsage,
+			})
 
-			// 更新缓存
-			s.metricsCache.SetPodMetrics(ns.Name, pod.Name, metrics)
-			successPods++
+			metrics.HistoricalData.DiskUsage = append(metrics.HistoricalData.DiskUsage, MetricDataPoint{
+				Timestamp: now,
+				Value:     float64(metrics.DiskUsedBytes),
+			})
 		}
+
+		// 更新缓存
+		s.metricsCache.SetPodMetrics(clusterName, pod.namespace, pod.name, metrics)
+		successPods++
 	}
 
 	// 记录完成收集指标的时间和统计数据
@@ -196,14 +189,120 @@ func (s *PodMetricsService) collectAllPodsMetrics(ctx context.Context, clusterNa
 		"successPods", successPods)
 }
 
-// CleanExpiredMetricsCache 清理过期的指标缓存
+// podRef 待采集指标的 Pod
+type podRef struct {
+	namespace string
+	name      string
+}
+
+// listPodsForCollection 列出集群全部 Pod：共享缓存可用时直接读取，否则逐个命名空间 List
+func (s *PodMetricsService) listPodsForCollection(ctx context.Context, client *kubernetes.Clientset, clusterName string) ([]podRef, error) {
+	if lister, ok := s.clientManager.PodLister(ctx, clusterName); ok {
+		cached, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		sortObjects(cached)
+		pods := make([]podRef, 0, len(cached))
+		for _, pod := range cached {
+			pods = append(pods, podRef{namespace: pod.Namespace, name: pod.Name})
+		}
+		return pods, nil
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取命名空间列表失败: %w", err)
+	}
+	var pods []podRef
+	for _, ns := range namespaces.Items {
+		list, err := client.CoreV1().Pods(ns.Name).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logger.Warn("获取Pod列表失败", "namespace", ns.Name, "error", err)
+			continue
+		}
+		for _, pod := range list.Items {
+			pods = append(pods, podRef{namespace: ns.Name, name: pod.Name})
+		}
+	}
+	return pods, nil
+}
+
+// CleanExpiredMetricsCache 清理过期的指标缓存，以及已移除集群的缓存
 func (s *PodMetricsService) CleanExpiredMetricsCache() {
 	beforeCount := s.metricsCache.GetCacheSize()
 	s.metricsCache.CleanExpired()
+
+	registered := make(map[string]bool)
+	for _, clusterName := range s.clientManager.ListClusters() {
+		registered[clusterName] = true
+	}
+	// 内存中的缓存与落盘的历史都按集群清理
+	cached := s.metricsCache.GetClusterCacheSizes()
+	for _, clusterName := range s.metricsCache.StoredClusters() {
+		cached[clusterName] = 0
+	}
+	for clusterName := range cached {
+		if !registered[clusterName] {
+			s.metricsCache.RemoveCluster(clusterName)
+		}
+	}
 	afterCount := s.metricsCache.GetCacheSize()
 
 	logger.Info("清理过期的Pod指标缓存",
 		"beforeCount", beforeCount,
 		"afterCount", afterCount,
-		"removed", beforeCount-afterCount)
+		"removed", beforeCount-afterCount,
+		"clusters", s.metricsCache.GetClusterCacheSizes())
+}
+
+// EnableStorage 开启Pod指标历史落盘：设置保留时长并加载 dir 中已有的历史数据
+func (s *PodMetricsService) EnableStorage(dir string, retention time.Duration) error {
+	s.metricsCache.SetStorageRetention(retention)
+	s.storageDir = dir
+	return s.metricsCache.LoadFromStorage(dir)
+}
+
+// SaveSnapshot 将新的指标数据点写入落盘存储，未开启落盘时不做任何操作
+func (s *PodMetricsService) SaveSnapshot() error {
+	if s.storageDir == "" {
+		return nil
+	}
+	return s.metricsCache.SaveToStorage(s.storageDir)
+}
+
+// StartPeriodicSnapshots 定期保存指标快照，ctx 结束时停止
+func (s *PodMetricsService) StartPeriodicSnapshots(ctx context.Context, interval time.Duration) {
+	if s.storageDir == "" {
+		return
+	}
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.SaveSnapshot(); err != nil {
+					logger.Error("保存Pod指标快照失败", "error", err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// CloseStorage 保存最后一次快照并关闭落盘存储，用于优雅关闭
+func (s *PodMetricsService) CloseStorage() error {
+	if s.storageDir == "" {
+		return nil
+	}
+	if err := s.SaveSnapshot(); err != nil {
+		return err
+	}
+	return s.metricsCache.CloseStorage()
 }
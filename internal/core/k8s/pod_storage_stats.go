@@ -88,27 +88,43 @@ type kubeletContainerStats struct {
 }
 
 type kubeletVolumeStats struct {
-	Name      string  `json:"name"`
-	UsedBytes *uint64 `json:"usedBytes,omitempty"`
+	Name          string         `json:"name"`
+	UsedBytes     *uint64        `json:"usedBytes,omitempty"`
+	CapacityBytes *uint64        `json:"capacityBytes,omitempty"`
+	PVCRef        *kubeletPVCRef `json:"pvcRef,omitempty"`
 }
 
-func getDiskStatsFromKubelet(client *kubernetes.Clientset, config *rest.Config, pod *corev1.Pod) *PodDiskStats {
-	if pod.Spec.NodeName == "" {
-		return nil
-	}
+type kubeletPVCRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
 
+// getKubeletStatsSummary 通过 API Server 代理读取节点 kubelet 的 stats/summary
+func getKubeletStatsSummary(ctx context.Context, client kubernetes.Interface, nodeName string) (*kubeletStatsSummary, error) {
 	data, err := client.CoreV1().RESTClient().Get().
 		Resource("nodes").
-		Name(pod.Spec.NodeName).
+		Name(nodeName).
 		SubResource("proxy").
 		Suffix("stats/summary").
-		DoRaw(context.Background())
+		DoRaw(ctx)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	var summary kubeletStatsSummary
 	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func getDiskStatsFromKubelet(client *kubernetes.Clientset, config *rest.Config, pod *corev1.Pod) *PodDiskStats {
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+
+	summary, err := getKubeletStatsSummary(context.Background(), client, pod.Spec.NodeName)
+	if err != nil {
 		return nil
 	}
 
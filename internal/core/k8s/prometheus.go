@@ -144,7 +144,7 @@ func (s *PrometheusService) ResolvePrometheusURL(ctx context.Context, clusterNam
 	if u := s.clientManager.GetPrometheusURL(clusterName); u != "" {
 		return u, nil
 	}
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return "", err
 	}
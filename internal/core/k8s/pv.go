@@ -35,7 +35,7 @@ type PVInfo struct {
 
 // ListPVs 获取 PV 列表
 func (s *PVService) ListPVs(ctx context.Context, clusterName string) ([]PVInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +55,7 @@ func (s *PVService) ListPVs(ctx context.Context, clusterName string) ([]PVInfo,
 
 // GetPV 获取 PV 详情
 func (s *PVService) GetPV(ctx context.Context, clusterName, name string) (*PVInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // PVCService PVC 管理服务
@@ -30,10 +31,25 @@ type PVCInfo struct {
 	StorageClassName string            `json:"storageClassName,omitempty"`
 	AccessModes      []string          `json:"accessModes,omitempty"`
 	Capacity         string            `json:"capacity,omitempty"`
+	Requested        string            `json:"requested,omitempty"`
+	ResizeStatus     string            `json:"resizeStatus,omitempty"` // Resizing / FileSystemResizePending
+	DataSource       *PVCDataSource    `json:"dataSource,omitempty"`
 	CreationTime     time.Time         `json:"creationTime"`
 	Labels           map[string]string `json:"labels,omitempty"`
 }
 
+// PVC 数据源类型
+const (
+	PVCDataSourcePVC      = "PersistentVolumeClaim"
+	PVCDataSourceSnapshot = "VolumeSnapshot"
+)
+
+// PVCDataSource 创建 PVC 时的数据源：克隆已有 PVC 或从 VolumeSnapshot 恢复
+type PVCDataSource struct {
+	Kind string `json:"kind" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
 // CreatePVCRequest 创建 PVC 请求
 type CreatePVCRequest struct {
 	Name             string            `json:"name" binding:"required"`
@@ -41,12 +57,14 @@ type CreatePVCRequest struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	StorageClassName string            `json:"storageClassName,omitempty"`
 	AccessModes      []string          `json:"accessModes,omitempty"`
-	Storage          string            `json:"storage" binding:"required"`
+	// Storage 容量，克隆时可省略（使用源 PVC 的容量）
+	Storage    string         `json:"storage"`
+	DataSource *PVCDataSource `json:"dataSource,omitempty"`
 }
 
 // ListPVCs 获取 PVC 列表
 func (s *PVCService) ListPVCs(ctx context.Context, clusterName, namespace string) ([]PVCInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +89,7 @@ func (s *PVCService) ListPVCs(ctx context.Context, clusterName, namespace string
 
 // GetPVC 获取 PVC 详情
 func (s *PVCService) GetPVC(ctx context.Context, clusterName, namespace, name string) (*PVCInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +103,7 @@ func (s *PVCService) GetPVC(ctx context.Context, clusterName, namespace, name st
 
 // CreatePVC 创建 PVC
 func (s *PVCService) CreatePVC(ctx context.Context, clusterName, namespace string, req CreatePVCRequest) (*PVCInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +120,41 @@ func (s *PVCService) CreatePVC(ctx context.Context, clusterName, namespace strin
 			accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(m))
 		}
 	}
+	var dataSource *corev1.TypedLocalObjectReference
+	if req.DataSource != nil {
+		switch req.DataSource.Kind {
+		case PVCDataSourcePVC:
+			// CSI 克隆要求与源 PVC 位于同一命名空间、使用同一 StorageClass，且容量不小于源 PVC
+			source, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, req.DataSource.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("获取源 PVC 失败: %w", err)
+			}
+			if req.Storage == "" {
+				if qty, ok := source.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+					req.Storage = qty.String()
+				}
+			}
+			if req.StorageClassName == "" && source.Spec.StorageClassName != nil {
+				req.StorageClassName = *source.Spec.StorageClassName
+			}
+			if len(req.AccessModes) == 0 {
+				accessModes = source.Spec.AccessModes
+			}
+			dataSource = &corev1.TypedLocalObjectReference{Kind: PVCDataSourcePVC, Name: source.Name}
+		case PVCDataSourceSnapshot:
+			apiGroup := volumeSnapshotGroup
+			dataSource = &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: PVCDataSourceSnapshot, Name: req.DataSource.Name}
+		default:
+			return nil, fmt.Errorf("不支持的数据源类型: %s", req.DataSource.Kind)
+		}
+	}
+	if req.Storage == "" {
+		return nil, fmt.Errorf("未指定存储容量")
+	}
+	storage, err := resource.ParseQuantity(req.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("无效的存储容量 %s: %w", req.Storage, err)
+	}
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      req.Name,
@@ -112,9 +165,10 @@ func (s *PVCService) CreatePVC(ctx context.Context, clusterName, namespace strin
 			AccessModes: accessModes,
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: corev1.ResourceList{
-					corev1.ResourceStorage: resource.MustParse(req.Storage),
+					corev1.ResourceStorage: storage,
 				},
 			},
+			DataSource: dataSource,
 		},
 	}
 	if req.StorageClassName != "" {
@@ -128,9 +182,52 @@ func (s *PVCService) CreatePVC(ctx context.Context, clusterName, namespace strin
 	return &info, nil
 }
 
+// ResizePVC 在线扩容 PVC，要求 StorageClass 开启 allowVolumeExpansion 且新容量大于当前请求
+func (s *PVCService) ResizePVC(ctx context.Context, clusterName, namespace, name, size string) (*PVCInfo, error) {
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, fmt.Errorf("无效的存储容量 %s: %w", size, err)
+	}
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 PVC 失败: %w", err)
+	}
+	if current, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok && quantity.Cmp(current) <= 0 {
+		return nil, fmt.Errorf("新容量 %s 必须大于当前容量 %s（PVC 不支持缩容）", quantity.String(), current.String())
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return nil, fmt.Errorf("PVC %s 未指定 StorageClass，无法扩容", name)
+	}
+	// StorageClass 为集群级资源，只有命名空间权限的用户在开启用户模拟时无权读取；
+	// 这里仅用于提前给出可读的错误，改用平台凭据查询，PVC 的修改仍以用户身份进行
+	platformClient, err := s.clientManager.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := platformClient.StorageV1().StorageClasses().Get(ctx, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 StorageClass 失败: %w", err)
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		return nil, fmt.Errorf("StorageClass %s 未开启 allowVolumeExpansion", sc.Name)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":%q}}}}`, quantity.String()))
+	updated, err := client.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("扩容 PVC 失败: %w", err)
+	}
+	info := convertPVCInfo(updated)
+	return &info, nil
+}
+
 // DeletePVC 删除 PVC
 func (s *PVCService) DeletePVC(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -150,6 +247,21 @@ func convertPVCInfo(pvc *corev1.PersistentVolumeClaim) PVCInfo {
 	if pvc.Spec.StorageClassName != nil {
 		scName = *pvc.Spec.StorageClassName
 	}
+	requested := ""
+	if qty, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		requested = qty.String()
+	}
+	resizeStatus := ""
+	for _, cond := range pvc.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue &&
+			(cond.Type == corev1.PersistentVolumeClaimResizing || cond.Type == corev1.PersistentVolumeClaimFileSystemResizePending) {
+			resizeStatus = string(cond.Type)
+		}
+	}
+	var dataSource *PVCDataSource
+	if pvc.Spec.DataSource != nil {
+		dataSource = &PVCDataSource{Kind: pvc.Spec.DataSource.Kind, Name: pvc.Spec.DataSource.Name}
+	}
 	return PVCInfo{
 		Name:             pvc.Name,
 		Namespace:        pvc.Namespace,
@@ -158,6 +270,9 @@ func convertPVCInfo(pvc *corev1.PersistentVolumeClaim) PVCInfo {
 		StorageClassName: scName,
 		AccessModes:      modes,
 		Capacity:         capacity,
+		Requested:        requested,
+		ResizeStatus:     resizeStatus,
+		DataSource:       dataSource,
 		CreationTime:     pvc.CreationTimestamp.Time,
 		Labels:           pvc.Labels,
 	}
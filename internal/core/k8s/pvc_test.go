@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestResizePVC(t *testing.T) {
+	var patched string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		pvc := func(name string) corev1.PersistentVolumeClaim {
+			sc := "fast"
+			if name == "legacy" {
+				sc = "slow"
+			}
+			return corev1.PersistentVolumeClaim{
+				TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: &sc,
+					Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}},
+				},
+			}
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/apis/storage.k8s.io/v1/storageclasses/"):
+			name := strings.TrimPrefix(r.URL.Path, "/apis/storage.k8s.io/v1/storageclasses/")
+			allow := name == "fast"
+			json.NewEncoder(w).Encode(storagev1.StorageClass{
+				TypeMeta:             metav1.TypeMeta{Kind: "StorageClass", APIVersion: "storage.k8s.io/v1"},
+				ObjectMeta:           metav1.ObjectMeta{Name: name},
+				AllowVolumeExpansion: &allow,
+			})
+		case strings.HasPrefix(r.URL.Path, "/api/v1/namespaces/prod/persistentvolumeclaims/"):
+			name := strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/prod/persistentvolumeclaims/")
+			obj := pvc(name)
+			if r.Method == http.MethodPatch {
+				var body map[string]map[string]map[string]map[string]string
+				json.NewDecoder(r.Body).Decode(&body)
+				patched = body["spec"]["resources"]["requests"]["storage"]
+				obj.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse(patched)
+			}
+			json.NewEncoder(w).Encode(obj)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	cm := NewClientManager()
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = client
+	service := NewPVCService(cm)
+
+	if _, err := service.ResizePVC(context.Background(), "dev", "prod", "data", "5Gi"); err == nil {
+		t.Fatal("shrinking a PVC should be rejected")
+	}
+	if _, err := service.ResizePVC(context.Background(), "dev", "prod", "legacy", "20Gi"); err == nil || !strings.Contains(err.Error(), "allowVolumeExpansion") {
+		t.Fatalf("expected allowVolumeExpansion error, got %v", err)
+	}
+	info, err := service.ResizePVC(context.Background(), "dev", "prod", "data", "20Gi")
+	if err != nil {
+		t.Fatalf("ResizePVC: %v", err)
+	}
+	if patched != "20Gi" || info.Requested != "20Gi" {
+		t.Fatalf("unexpected resize result: patched=%s info=%+v", patched, info)
+	}
+}
@@ -73,7 +73,7 @@ type CreateClusterRoleBindingRequest struct {
 
 // ListRoles 获取 Role 列表
 func (s *RBACService) ListRoles(ctx context.Context, clusterName, namespace string) ([]RoleInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +98,7 @@ func (s *RBACService) ListRoles(ctx context.Context, clusterName, namespace stri
 
 // GetRole 获取 Role 详情
 func (s *RBACService) GetRole(ctx context.Context, clusterName, namespace, name string) (*rbacv1.Role, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +107,7 @@ func (s *RBACService) GetRole(ctx context.Context, clusterName, namespace, name
 
 // ListClusterRoles 获取 ClusterRole 列表
 func (s *RBACService) ListClusterRoles(ctx context.Context, clusterName string) ([]RoleInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +132,7 @@ func (s *RBACService) ListClusterRoles(ctx context.Context, clusterName string)
 
 // GetClusterRole 获取 ClusterRole 详情
 func (s *RBACService) GetClusterRole(ctx context.Context, clusterName, name string) (*rbacv1.ClusterRole, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +141,7 @@ func (s *RBACService) GetClusterRole(ctx context.Context, clusterName, name stri
 
 // ListRoleBindings 获取 RoleBinding 列表
 func (s *RBACService) ListRoleBindings(ctx context.Context, clusterName, namespace string) ([]RoleBindingInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +163,7 @@ func (s *RBACService) ListRoleBindings(ctx context.Context, clusterName, namespa
 
 // GetRoleBinding 获取 RoleBinding 详情
 func (s *RBACService) GetRoleBinding(ctx context.Context, clusterName, namespace, name string) (*RoleBindingInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +177,7 @@ func (s *RBACService) GetRoleBinding(ctx context.Context, clusterName, namespace
 
 // CreateRoleBinding 创建 RoleBinding
 func (s *RBACService) CreateRoleBinding(ctx context.Context, clusterName, namespace string, req CreateRoleBindingRequest) (*RoleBindingInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +203,7 @@ func (s *RBACService) CreateRoleBinding(ctx context.Context, clusterName, namesp
 
 // DeleteRoleBinding 删除 RoleBinding
 func (s *RBACService) DeleteRoleBinding(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -212,7 +212,7 @@ func (s *RBACService) DeleteRoleBinding(ctx context.Context, clusterName, namesp
 
 // ListClusterRoleBindings 获取 ClusterRoleBinding 列表
 func (s *RBACService) ListClusterRoleBindings(ctx context.Context, clusterName string) ([]RoleBindingInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +229,7 @@ func (s *RBACService) ListClusterRoleBindings(ctx context.Context, clusterName s
 
 // GetClusterRoleBinding 获取 ClusterRoleBinding 详情
 func (s *RBACService) GetClusterRoleBinding(ctx context.Context, clusterName, name string) (*RoleBindingInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -243,7 +243,7 @@ func (s *RBACService) GetClusterRoleBinding(ctx context.Context, clusterName, na
 
 // CreateClusterRoleBinding 创建 ClusterRoleBinding
 func (s *RBACService) CreateClusterRoleBinding(ctx context.Context, clusterName string, req CreateClusterRoleBindingRequest) (*RoleBindingInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -265,7 +265,7 @@ func (s *RBACService) CreateClusterRoleBinding(ctx context.Context, clusterName
 
 // DeleteClusterRoleBinding 删除 ClusterRoleBinding
 func (s *RBACService) DeleteClusterRoleBinding(ctx context.Context, clusterName, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
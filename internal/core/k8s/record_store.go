@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// recordFile 以 JSON 文件保存一组按 key 唯一的记录，供后台控制器持久化进度。
+// 文件内容为 {"<field>": [...]}，写入时先写临时文件再重命名。
+type recordFile[T any] struct {
+	path    string
+	field   string
+	key     func(T) string
+	records map[string]T
+	mutex   sync.Mutex
+}
+
+// openRecordFile 读取记录文件，文件不存在时在首次写入时创建
+func openRecordFile[T any](path, field string, key func(T) string) (*recordFile[T], error) {
+	f := &recordFile[T]{
+		path:    path,
+		field:   field,
+		key:     key,
+		records: make(map[string]T),
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var file map[string][]T
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, r := range file[field] {
+		f.records[key(r)] = r
+	}
+	return f, nil
+}
+
+// list 按 key 排序返回所有记录
+func (f *recordFile[T]) list() []T {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.sortedLocked()
+}
+
+// save 新增或覆盖记录，写入失败时恢复内存中的旧值
+func (f *recordFile[T]) save(record T) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	key := f.key(record)
+	prev, existed := f.records[key]
+	f.records[key] = record
+	if err := f.flushLocked(); err != nil {
+		if existed {
+			f.records[key] = prev
+		} else {
+			delete(f.records, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// remove 删除记录，写入失败时恢复内存中的旧值
+func (f *recordFile[T]) remove(keys ...string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	removed := make(map[string]T, len(keys))
+	for _, key := range keys {
+		if record, ok := f.records[key]; ok {
+			removed[key] = record
+			delete(f.records, key)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	if err := f.flushLocked(); err != nil {
+		for key, record := range removed {
+			f.records[key] = record
+		}
+		return err
+	}
+	return nil
+}
+
+func (f *recordFile[T]) sortedLocked() []T {
+	keys := make([]string, 0, len(f.records))
+	for k := range f.records {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	records := make([]T, 0, len(keys))
+	for _, k := range keys {
+		records = append(records, f.records[k])
+	}
+	return records
+}
+
+func (f *recordFile[T]) flushLocked() error {
+	data, err := json.MarshalIndent(map[string][]T{f.field: f.sortedLocked()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", f.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", f.path, err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.path, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace %s: %w", f.path, err)
+	}
+	return nil
+}
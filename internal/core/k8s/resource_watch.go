@@ -0,0 +1,283 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"kube-tide/internal/utils/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// 推送给客户端的事件类型，ERROR 表示订阅已中断，客户端应重新获取列表后再次订阅
+const (
+	WatchEventAdded    = "ADDED"
+	WatchEventModified = "MODIFIED"
+	WatchEventDeleted  = "DELETED"
+	WatchEventError    = "ERROR"
+)
+
+// maxWatchStreams 单个订阅允许的 watch 数量（资源类型 × 命名空间）
+const maxWatchStreams = 32
+
+// ResourceWatchEvent 资源变更事件，Object 与对应列表接口返回的结构一致
+type ResourceWatchEvent struct {
+	Type            string `json:"type"`
+	Kind            string `json:"kind,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Object          any    `json:"object,omitempty"`
+	Message         string `json:"message,omitempty"`
+}
+
+// watchKind 可订阅的资源类型
+type watchKind struct {
+	resource   string
+	namespaced bool
+	restClient func(*kubernetes.Clientset) rest.Interface
+	convert    func(runtime.Object) any
+}
+
+// convertWatched 将 watch 返回的对象交给列表接口使用的转换函数
+func convertWatched[T any, R any](convert func(*T) R) func(runtime.Object) any {
+	return func(obj runtime.Object) any {
+		typed, ok := any(obj).(*T)
+		if !ok {
+			return nil
+		}
+		return convert(typed)
+	}
+}
+
+// asIs 列表接口直接返回 K8s 对象的资源
+func asIs[T any](obj *T) *T {
+	return obj
+}
+
+// ResourceWatchService 资源变更订阅服务
+type ResourceWatchService struct {
+	clientManager *ClientManager
+	kinds         map[string]watchKind
+}
+
+// NewResourceWatchService 创建资源变更订阅服务
+func NewResourceWatchService(clientManager *ClientManager) *ResourceWatchService {
+	deployments := NewDeploymentService(clientManager)
+	core := func(c *kubernetes.Clientset) rest.Interface { return c.CoreV1().RESTClient() }
+	apps := func(c *kubernetes.Clientset) rest.Interface { return c.AppsV1().RESTClient() }
+	batch := func(c *kubernetes.Clientset) rest.Interface { return c.BatchV1().RESTClient() }
+	networking := func(c *kubernetes.Clientset) rest.Interface { return c.NetworkingV1().RESTClient() }
+	autoscaling := func(c *kubernetes.Clientset) rest.Interface { return c.AutoscalingV2().RESTClient() }
+	policy := func(c *kubernetes.Clientset) rest.Interface { return c.PolicyV1().RESTClient() }
+	storage := func(c *kubernetes.Clientset) rest.Interface { return c.StorageV1().RESTClient() }
+
+	return &ResourceWatchService{
+		clientManager: clientManager,
+		// key 与列表接口的路径一致
+		kinds: map[string]watchKind{
+			"pods":            {"pods", true, core, convertWatched(asIs[corev1.Pod])},
+			"services":        {"services", true, core, convertWatched(asIs[corev1.Service])},
+			"events":          {"events", true, core, convertWatched(asIs[corev1.Event])},
+			"configmaps":      {"configmaps", true, core, convertWatched(func(cm *corev1.ConfigMap) ConfigMapInfo { return toConfigMapInfo(*cm) })},
+			"pvcs":            {"persistentvolumeclaims", true, core, convertWatched(convertPVCInfo)},
+			"resourcequotas":  {"resourcequotas", true, core, convertWatched(convertResourceQuotaInfo)},
+			"limitranges":     {"limitranges", true, core, convertWatched(convertLimitRangeInfo)},
+			"deployments":     {"deployments", true, apps, convertWatched(deployments.convertDeployment)},
+			"statefulsets":    {"statefulsets", true, apps, convertWatched(convertStatefulSetInfo)},
+			"daemonsets":      {"daemonsets", true, apps, convertWatched(convertDaemonSetInfo)},
+			"jobs":            {"jobs", true, batch, convertWatched(convertJobInfo)},
+			"cronjobs":        {"cronjobs", true, batch, convertWatched(convertCronJobInfo)},
+			"ingresses":       {"ingresses", true, networking, convertWatched(asIs[networkingv1.Ingress])},
+			"networkpolicies": {"networkpolicies", true, networking, convertWatched(convertNetworkPolicyInfo)},
+			"hpas":            {"horizontalpodautoscalers", true, autoscaling, convertWatched(convertHPAInfo)},
+			"pdbs":            {"poddisruptionbudgets", true, policy, convertWatched(convertPDBInfo)},
+			"namespaces":      {"namespaces", false, core, convertWatched(convertNamespaceInfo)},
+			"nodes":           {"nodes", false, core, convertWatched(asIs[corev1.Node])},
+			"pvs":             {"persistentvolumes", false, core, convertWatched(convertPVInfo)},
+			"storageclasses":  {"storageclasses", false, storage, convertWatched(convertStorageClassInfo)},
+		},
+	}
+}
+
+// Kinds 返回可订阅的资源类型
+func (s *ResourceWatchService) Kinds() []string {
+	kinds := make([]string, 0, len(s.kinds))
+	for kind := range s.kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// LookupKind 返回资源类型是否受支持、是否属于命名空间
+func (s *ResourceWatchService) LookupKind(kind string) (supported, namespaced bool) {
+	spec, ok := s.kinds[kind]
+	return ok, spec.namespaced
+}
+
+// ResourceWatch 一次订阅，Events 在订阅结束后关闭
+type ResourceWatch struct {
+	events chan ResourceWatchEvent
+	cancel context.CancelFunc
+}
+
+// Events 返回变更事件
+func (w *ResourceWatch) Events() <-chan ResourceWatchEvent {
+	return w.events
+}
+
+// Stop 结束订阅
+func (w *ResourceWatch) Stop() {
+	w.cancel()
+}
+
+// watchStream 单个资源类型在单个命名空间上的 watch
+type watchStream struct {
+	kind    string
+	spec    watchKind
+	watcher *watchtools.RetryWatcher
+}
+
+// Watch 订阅资源变更。namespaces 为空时订阅全部命名空间，集群级资源忽略 namespaces。
+// 订阅从当前版本开始，只推送之后的增量；任一 watch 失效时推送 ERROR 事件并结束订阅。
+// 请求以登录用户身份访问 API Server（用户模拟），建立 watch 失败时直接返回错误。
+func (s *ResourceWatchService) Watch(ctx context.Context, clusterName string, kinds, namespaces []string) (*ResourceWatch, error) {
+	if len(kinds) == 0 {
+		return nil, fmt.Errorf("至少需要订阅一种资源")
+	}
+	for _, kind := range kinds {
+		if _, ok := s.kinds[kind]; !ok {
+			return nil, fmt.Errorf("不支持订阅的资源类型: %s", kind)
+		}
+	}
+
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	type target struct {
+		kind      string
+		namespace string
+	}
+	var targets []target
+	for _, kind := range kinds {
+		if !s.kinds[kind].namespaced || len(namespaces) == 0 {
+			targets = append(targets, target{kind: kind})
+			continue
+		}
+		for _, ns := range namespaces {
+			targets = append(targets, target{kind: kind, namespace: ns})
+		}
+	}
+	if len(targets) > maxWatchStreams {
+		return nil, fmt.Errorf("订阅数量 %d 超过上限 %d", len(targets), maxWatchStreams)
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	streams := make([]watchStream, 0, len(targets))
+	for _, t := range targets {
+		spec := s.kinds[t.kind]
+		lw := cache.NewListWatchFromClient(spec.restClient(client), spec.resource, t.namespace, fields.Everything())
+		// 只取当前版本号，不需要对象本身
+		list, err := lw.ListWithContext(watchCtx, metav1.ListOptions{Limit: 1})
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("获取 %s 列表失败: %w", t.kind, err)
+		}
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		watcher, err := watchtools.NewRetryWatcherWithContext(watchCtx, listMeta.GetResourceVersion(), lw)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("订阅 %s 失败: %w", t.kind, err)
+		}
+		streams = append(streams, watchStream{kind: t.kind, spec: spec, watcher: watcher})
+	}
+
+	w := &ResourceWatch{events: make(chan ResourceWatchEvent), cancel: cancel}
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer stream.watcher.Stop()
+			s.forward(watchCtx, cancel, stream, w.events)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(w.events)
+	}()
+
+	logger.Debug("开始订阅资源变更", "cluster", clusterName, "kinds", kinds, "namespaces", namespaces)
+	return w, nil
+}
+
+// forward 将 watch 事件转换后写入 events，watch 失效时推送 ERROR 并结束整个订阅
+func (s *ResourceWatchService) forward(ctx context.Context, cancel context.CancelFunc, stream watchStream, events chan<- ResourceWatchEvent) {
+	send := func(event ResourceWatchEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		var (
+			event watch.Event
+			ok    bool
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok = <-stream.watcher.ResultChan():
+		}
+		if !ok {
+			send(ResourceWatchEvent{Type: WatchEventError, Kind: stream.kind, Message: "watch closed"})
+			cancel()
+			return
+		}
+
+		switch event.Type {
+		case watch.Added, watch.Modified, watch.Deleted:
+			accessor, err := meta.Accessor(event.Object)
+			if err != nil {
+				continue
+			}
+			if !send(ResourceWatchEvent{
+				Type:            string(event.Type),
+				Kind:            stream.kind,
+				Namespace:       accessor.GetNamespace(),
+				Name:            accessor.GetName(),
+				ResourceVersion: accessor.GetResourceVersion(),
+				Object:          stream.spec.convert(event.Object),
+			}) {
+				return
+			}
+		case watch.Error:
+			send(ResourceWatchEvent{Type: WatchEventError, Kind: stream.kind, Message: apierrors.FromObject(event.Object).Error()})
+			cancel()
+			return
+		}
+	}
+}
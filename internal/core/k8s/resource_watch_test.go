@@ -0,0 +1,90 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeDeploymentWatchAPI list 只返回版本号，watch 从该版本开始推送一次更新
+func fakeDeploymentWatchAPI(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/apps/v1/namespaces/prod/deployments" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") != "true" {
+			w.Write([]byte(`{"kind":"DeploymentList","apiVersion":"apps/v1","metadata":{"resourceVersion":"100"},"items":[]}`))
+			return
+		}
+		if rv := r.URL.Query().Get("resourceVersion"); rv != "100" {
+			t.Errorf("watch should start from the listed version, got %q", rv)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"type":"MODIFIED","object":{"kind":"Deployment","apiVersion":"apps/v1",
+			"metadata":{"name":"web","namespace":"prod","resourceVersion":"101"},
+			"spec":{"replicas":3,"selector":{"matchLabels":{"app":"web"}},"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.27"}]}}},
+			"status":{"readyReplicas":2}}}` + "\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResourceWatchStreamsConvertedDeltas(t *testing.T) {
+	server := fakeDeploymentWatchAPI(t)
+	cm := NewClientManager()
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = client
+	service := NewResourceWatchService(cm)
+
+	if _, err := service.Watch(context.Background(), "dev", []string{"widgets"}, nil); err == nil {
+		t.Fatal("expected error for unsupported kind")
+	}
+	if supported, namespaced := service.LookupKind("nodes"); !supported || namespaced {
+		t.Fatal("nodes should be a supported cluster-scoped kind")
+	}
+
+	watcher, err := service.Watch(context.Background(), "dev", []string{"deployments"}, []string{"prod"})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer watcher.Stop()
+
+	select {
+	case event := <-watcher.Events():
+		if event.Type != WatchEventModified || event.Kind != "deployments" || event.Namespace != "prod" ||
+			event.Name != "web" || event.ResourceVersion != "101" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+		info, ok := event.Object.(DeploymentInfo)
+		if !ok {
+			t.Fatalf("object should use the list shape, got %T", event.Object)
+		}
+		if info.Replicas != 3 || info.ReadyReplicas != 2 || len(info.Images) != 1 || info.Images[0] != "nginx:1.27" {
+			t.Fatalf("unexpected deployment info: %+v", info)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no watch event received")
+	}
+
+	watcher.Stop()
+	select {
+	case _, ok := <-watcher.Events():
+		if ok {
+			t.Fatal("no events expected after Stop")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events channel should close after Stop")
+	}
+}
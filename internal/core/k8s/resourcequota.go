@@ -47,7 +47,7 @@ type UpdateResourceQuotaRequest struct {
 
 // ListResourceQuotas 获取 ResourceQuota 列表
 func (s *ResourceQuotaService) ListResourceQuotas(ctx context.Context, clusterName, namespace string) ([]ResourceQuotaInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func (s *ResourceQuotaService) ListResourceQuotas(ctx context.Context, clusterNa
 
 // GetResourceQuota 获取 ResourceQuota 详情
 func (s *ResourceQuotaService) GetResourceQuota(ctx context.Context, clusterName, namespace, name string) (*ResourceQuotaInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +86,7 @@ func (s *ResourceQuotaService) GetResourceQuota(ctx context.Context, clusterName
 
 // CreateResourceQuota 创建 ResourceQuota
 func (s *ResourceQuotaService) CreateResourceQuota(ctx context.Context, clusterName, namespace string, req CreateResourceQuotaRequest) (*ResourceQuotaInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +113,7 @@ func (s *ResourceQuotaService) CreateResourceQuota(ctx context.Context, clusterN
 
 // UpdateResourceQuota 更新 ResourceQuota
 func (s *ResourceQuotaService) UpdateResourceQuota(ctx context.Context, clusterName, namespace, name string, req UpdateResourceQuotaRequest) (*ResourceQuotaInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +137,7 @@ func (s *ResourceQuotaService) UpdateResourceQuota(ctx context.Context, clusterN
 
 // DeleteResourceQuota 删除 ResourceQuota
 func (s *ResourceQuotaService) DeleteResourceQuota(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
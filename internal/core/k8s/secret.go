@@ -2,9 +2,15 @@ package k8s
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"maps"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,28 +24,91 @@ type SecretInfo struct {
 	CreationTime string            `json:"creationTime"`
 }
 
-// SecretDetail Secret 详情（解码后的 data）
+// ErrSecretKeyNotFound Secret 中不存在指定的键
+var ErrSecretKeyNotFound = errors.New("secret key not found")
+
+// SecretKeyInfo Secret 中单个键的元信息，不包含值
+type SecretKeyInfo struct {
+	Key    string `json:"key"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"` // 值的 SHA-256 指纹，可用于比对而无需查看明文
+}
+
+// SecretDetail Secret 详情，值默认不返回，需要通过 RevealSecretKey 逐个查看；
+// TLS 类型附带证书摘要
 type SecretDetail struct {
 	SecretInfo
-	Data map[string]string `json:"data"`
+	Keys        []SecretKeyInfo  `json:"keys"`
+	Certificate *CertificateInfo `json:"certificate,omitempty"`
+}
+
+// UpdateSecretRequest 更新 Secret 请求。
+// StringData 按键合并：有值的键被设置，值为 null 的键被删除，未出现的键保持不变。
+type UpdateSecretRequest struct {
+	StringData map[string]*string `json:"stringData,omitempty"`
+	Labels     map[string]string  `json:"labels,omitempty"`
+	Type       string             `json:"type,omitempty"`
 }
 
 // SecretService Secret 管理服务
 type SecretService struct {
 	clientManager *ClientManager
+	history       *ConfigHistory
 }
 
-// NewSecretService 创建 Secret 服务
-func NewSecretService(clientManager *ClientManager) *SecretService {
-	return &SecretService{clientManager: clientManager}
+// NewSecretService 创建 Secret 服务，history 为 nil 时更新不保存历史版本
+func NewSecretService(clientManager *ClientManager, history *ConfigHistory) *SecretService {
+	return &SecretService{clientManager: clientManager, history: history}
 }
 
-func decodeSecretData(data map[string][]byte) map[string]string {
-	result := make(map[string]string, len(data))
+func secretSnapshot(clusterName string, sec *corev1.Secret, action string) ConfigSnapshot {
+	data := sec.Data
+	if data == nil {
+		data = map[string][]byte{}
+	}
+	return ConfigSnapshot{
+		ConfigVersion: ConfigVersion{
+			Cluster:         clusterName,
+			Kind:            ConfigKindSecret,
+			Namespace:       sec.Namespace,
+			Name:            sec.Name,
+			ResourceVersion: sec.ResourceVersion,
+			Keys:            sortedKeys(data),
+			Action:          action,
+		},
+		Labels:     sec.Labels,
+		SecretData: data,
+	}
+}
+
+// maskSecretData 只保留键名、大小与 SHA-256 指纹
+func maskSecretData(data map[string][]byte) []SecretKeyInfo {
+	keys := make([]SecretKeyInfo, 0, len(data))
 	for k, v := range data {
-		result[k] = string(v)
+		sum := sha256.Sum256(v)
+		keys = append(keys, SecretKeyInfo{Key: k, Size: len(v), SHA256: hex.EncodeToString(sum[:])})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return keys
+}
+
+// applySecretDataPatch 将按键合并的修改应用到 Secret 的 data
+func applySecretDataPatch(sec *corev1.Secret, patch map[string]*string) {
+	if sec.Data == nil {
+		sec.Data = make(map[string][]byte, len(patch))
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(sec.Data, k)
+			continue
+		}
+		sec.Data[k] = []byte(*v)
 	}
-	return result
+	sec.StringData = nil
+}
+
+func toSecretDetail(sec *corev1.Secret) *SecretDetail {
+	return &SecretDetail{SecretInfo: toSecretInfo(*sec), Keys: maskSecretData(sec.Data), Certificate: secretCertificate(sec)}
 }
 
 func toSecretInfo(sec corev1.Secret) SecretInfo {
@@ -47,6 +116,7 @@ func toSecretInfo(sec corev1.Secret) SecretInfo {
 	for k := range sec.Data {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 	return SecretInfo{
 		Name:         sec.Name,
 		Namespace:    sec.Namespace,
@@ -59,7 +129,7 @@ func toSecretInfo(sec corev1.Secret) SecretInfo {
 
 // ListSecrets 获取集群所有 Secret
 func (s *SecretService) ListSecrets(ctx context.Context, clusterName string) ([]SecretInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +146,7 @@ func (s *SecretService) ListSecrets(ctx context.Context, clusterName string) ([]
 
 // ListSecretsByNamespace 按命名空间获取 Secret
 func (s *SecretService) ListSecretsByNamespace(ctx context.Context, clusterName, namespace string) ([]SecretInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -91,9 +161,9 @@ func (s *SecretService) ListSecretsByNamespace(ctx context.Context, clusterName,
 	return result, nil
 }
 
-// GetSecret 获取 Secret 详情
+// GetSecret 获取 Secret 详情（值已脱敏）
 func (s *SecretService) GetSecret(ctx context.Context, clusterName, namespace, name string) (*SecretDetail, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -101,16 +171,37 @@ func (s *SecretService) GetSecret(ctx context.Context, clusterName, namespace, n
 	if err != nil {
 		return nil, fmt.Errorf("获取 Secret 失败: %w", err)
 	}
-	info := toSecretInfo(*sec)
-	return &SecretDetail{SecretInfo: info, Data: decodeSecretData(sec.Data)}, nil
+	return toSecretDetail(sec), nil
 }
 
-// CreateSecretRequest 创建 Secret 请求
+// RevealSecretKey 返回单个键的明文，调用方负责权限校验与审计
+func (s *SecretService) RevealSecretKey(ctx context.Context, clusterName, namespace, name, key string) (string, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return "", err
+	}
+	sec, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("获取 Secret 失败: %w", err)
+	}
+	value, ok := sec.Data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretKeyNotFound, key)
+	}
+	return string(value), nil
+}
+
+// CreateSecretRequest 创建 Secret 请求。
+// TLS、DockerRegistry、BasicAuth、SSHAuth 最多指定一个，指定后按对应类型校验并生成数据，StringData 中的键会一并写入。
 type CreateSecretRequest struct {
-	Name       string            `json:"name"`
-	Type       string            `json:"type,omitempty"`
-	Labels     map[string]string `json:"labels,omitempty"`
-	StringData map[string]string `json:"stringData"`
+	Name           string                    `json:"name"`
+	Type           string                    `json:"type,omitempty"`
+	Labels         map[string]string         `json:"labels,omitempty"`
+	StringData     map[string]string         `json:"stringData"`
+	TLS            *TLSSecretData            `json:"tls,omitempty"`
+	DockerRegistry *DockerRegistrySecretData `json:"dockerRegistry,omitempty"`
+	BasicAuth      *BasicAuthSecretData      `json:"basicAuth,omitempty"`
+	SSHAuth        *SSHAuthSecretData        `json:"sshAuth,omitempty"`
 }
 
 // CreateSecret 创建 Secret
@@ -118,30 +209,41 @@ func (s *SecretService) CreateSecret(ctx context.Context, clusterName, namespace
 	if req.Name == "" {
 		return nil, fmt.Errorf("Secret 名称不能为空")
 	}
-	client, err := s.clientManager.GetClient(clusterName)
+	secType, stringData, err := buildTypedSecret(req)
 	if err != nil {
 		return nil, err
 	}
-	secType := corev1.SecretTypeOpaque
-	if req.Type != "" {
-		secType = corev1.SecretType(req.Type)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
 	}
 	sec := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: namespace, Labels: req.Labels},
 		Type:       secType,
-		StringData: req.StringData,
+		StringData: stringData,
 	}
 	created, err := client.CoreV1().Secrets(namespace).Create(ctx, sec, metav1.CreateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("创建 Secret 失败: %w", err)
 	}
-	info := toSecretInfo(*created)
-	return &SecretDetail{SecretInfo: info, Data: decodeSecretData(created.Data)}, nil
+	return toSecretDetail(created), nil
 }
 
-// UpdateSecret 更新 Secret
-func (s *SecretService) UpdateSecret(ctx context.Context, clusterName, namespace, name string, stringData map[string]string, labels map[string]string, secType string) (*SecretDetail, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+// UpdateSecret 更新 Secret，值只按请求中出现的键修改，其余键不经过浏览器；更新前保存修改前的内容为历史版本
+func (s *SecretService) UpdateSecret(ctx context.Context, clusterName, namespace, name string, req UpdateSecretRequest) (*SecretDetail, error) {
+	return s.updateSecret(ctx, clusterName, namespace, name, ConfigActionUpdate, func(sec *corev1.Secret) {
+		applySecretDataPatch(sec, req.StringData)
+		if req.Labels != nil {
+			sec.Labels = req.Labels
+		}
+		if req.Type != "" {
+			sec.Type = corev1.SecretType(req.Type)
+		}
+	})
+}
+
+func (s *SecretService) updateSecret(ctx context.Context, clusterName, namespace, name, action string, mutate func(*corev1.Secret)) (*SecretDetail, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -149,32 +251,20 @@ func (s *SecretService) UpdateSecret(ctx context.Context, clusterName, namespace
 	if err != nil {
 		return nil, fmt.Errorf("获取 Secret 失败: %w", err)
 	}
-	if stringData != nil {
-		// Full replace: keys removed in the UI are deleted from the Secret
-		newData := make(map[string][]byte, len(stringData))
-		for k, v := range stringData {
-			newData[k] = []byte(v)
-		}
-		sec.Data = newData
-		sec.StringData = nil
-	}
-	if labels != nil {
-		sec.Labels = labels
-	}
-	if secType != "" {
-		sec.Type = corev1.SecretType(secType)
+	if err := s.history.record(ctx, secretSnapshot(clusterName, sec, action)); err != nil {
+		return nil, fmt.Errorf("保存历史版本失败: %w", err)
 	}
+	mutate(sec)
 	updated, err := client.CoreV1().Secrets(namespace).Update(ctx, sec, metav1.UpdateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("更新 Secret 失败: %w", err)
 	}
-	info := toSecretInfo(*updated)
-	return &SecretDetail{SecretInfo: info, Data: decodeSecretData(updated.Data)}, nil
+	return toSecretDetail(updated), nil
 }
 
 // DeleteSecret 删除 Secret
 func (s *SecretService) DeleteSecret(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -183,3 +273,42 @@ func (s *SecretService) DeleteSecret(ctx context.Context, clusterName, namespace
 	}
 	return nil
 }
+
+// ListSecretVersions 获取 Secret 的历史版本，按版本号倒序，不包含值
+func (s *SecretService) ListSecretVersions(ctx context.Context, clusterName, namespace, name string) ([]ConfigVersion, error) {
+	return s.history.versions(clusterName, ConfigKindSecret, namespace, name)
+}
+
+// DiffSecretVersions 按键对比两个版本，from / to 为版本号或 current；只返回大小与 SHA-256，不返回明文
+func (s *SecretService) DiffSecretVersions(ctx context.Context, clusterName, namespace, name, from, to string) (*ConfigVersionDiff, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	current := &ConfigSnapshot{ConfigVersion: ConfigVersion{Cluster: clusterName, Kind: ConfigKindSecret, Namespace: namespace, Name: name}}
+	sec, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		snapshot := secretSnapshot(clusterName, sec, "")
+		current = &snapshot
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("获取 Secret 失败: %w", err)
+	}
+	return s.history.diff(current, from, to)
+}
+
+// RestoreSecretVersion 以指定历史版本的数据与标签更新 Secret（类型不变），恢复前的内容同样保存为历史版本
+func (s *SecretService) RestoreSecretVersion(ctx context.Context, clusterName, namespace, name string, version int) (*SecretDetail, error) {
+	snapshot, err := s.history.snapshot(clusterName, ConfigKindSecret, namespace, name, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateSecret(ctx, clusterName, namespace, name, ConfigActionRestore, func(sec *corev1.Secret) {
+		sec.Data = maps.Clone(snapshot.SecretData)
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		sec.StringData = nil
+		sec.Labels = snapshot.Labels
+	})
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMaskSecretData(t *testing.T) {
+	keys := maskSecretData(map[string][]byte{"password": []byte("hunter2"), "empty": {}})
+	if len(keys) != 2 || keys[0].Key != "empty" || keys[1].Key != "password" {
+		t.Fatalf("keys should be sorted: %+v", keys)
+	}
+	if keys[1].Size != 7 || keys[1].SHA256 != "f52fbd32b2b3b86ff88ef6c490628285f482af15ddcb29541f94bcf526a3f6c7" {
+		t.Fatalf("unexpected password fingerprint: %+v", keys[1])
+	}
+	if keys[0].Size != 0 || keys[0].SHA256 != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Fatalf("unexpected empty fingerprint: %+v", keys[0])
+	}
+}
+
+func TestApplySecretDataPatch(t *testing.T) {
+	value := func(s string) *string { return &s }
+	sec := &corev1.Secret{Data: map[string][]byte{"user": []byte("admin"), "password": []byte("old"), "token": []byte("t")}}
+	applySecretDataPatch(sec, map[string]*string{"password": value("new"), "token": nil, "host": value("db")})
+	if string(sec.Data["user"]) != "admin" || string(sec.Data["password"]) != "new" || string(sec.Data["host"]) != "db" {
+		t.Fatalf("unexpected data: %v", sec.Data)
+	}
+	if _, ok := sec.Data["token"]; ok {
+		t.Fatal("null value should delete the key")
+	}
+
+	empty := &corev1.Secret{}
+	applySecretDataPatch(empty, map[string]*string{"a": value("1")})
+	if string(empty.Data["a"]) != "1" {
+		t.Fatalf("patch should initialize data: %v", empty.Data)
+	}
+}
@@ -0,0 +1,329 @@
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// ErrInvalidSecretData 类型化 Secret 的内容校验失败
+var ErrInvalidSecretData = errors.New("无效的 Secret 内容")
+
+// DefaultDockerRegistryServer 未指定镜像仓库地址时使用 Docker Hub，与 kubectl create secret docker-registry 一致
+const DefaultDockerRegistryServer = "https://index.docker.io/v1/"
+
+// 证书状态
+const (
+	CertificateValid    = "valid"
+	CertificateExpiring = "expiring"
+	CertificateExpired  = "expired"
+	CertificateInvalid  = "invalid"
+)
+
+// certificateExpiringDays 剩余天数不超过该值时视为即将过期
+const certificateExpiringDays = 30
+
+// TLSSecretData kubernetes.io/tls 类型的内容，证书与私钥均为 PEM
+type TLSSecretData struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+	CA   string `json:"ca,omitempty"`
+}
+
+// DockerRegistrySecretData kubernetes.io/dockerconfigjson 类型的内容
+type DockerRegistrySecretData struct {
+	Server   string `json:"server,omitempty"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+}
+
+// BasicAuthSecretData kubernetes.io/basic-auth 类型的内容
+type BasicAuthSecretData struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// SSHAuthSecretData kubernetes.io/ssh-auth 类型的内容
+type SSHAuthSecretData struct {
+	PrivateKey string `json:"privateKey"`
+}
+
+// CertificateInfo 证书摘要（证书本身是公开信息，不属于 Secret 明文）
+type CertificateInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	DNSNames      []string  `json:"dnsNames,omitempty"`
+	IPAddresses   []string  `json:"ipAddresses,omitempty"`
+	SerialNumber  string    `json:"serialNumber"`
+	NotBefore     time.Time `json:"notBefore"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+	Status        string    `json:"status"`
+	IsCA          bool      `json:"isCA"`
+	ChainLength   int       `json:"chainLength"`
+}
+
+// CertificateReportItem 证书过期报告中的一个 TLS Secret
+type CertificateReportItem struct {
+	Namespace   string           `json:"namespace"`
+	Name        string           `json:"name"`
+	Certificate *CertificateInfo `json:"certificate,omitempty"`
+	Status      string           `json:"status"`
+	Error       string           `json:"error,omitempty"`
+	Ingresses   []string         `json:"ingresses"` // 通过 spec.tls.secretName 引用该 Secret 的 Ingress
+}
+
+// buildTypedSecret 按请求中的类型化内容生成 Secret 类型与数据，未使用类型化内容时返回 StringData 原样
+func buildTypedSecret(req CreateSecretRequest) (corev1.SecretType, map[string]string, error) {
+	modes := 0
+	for _, set := range []bool{req.TLS != nil, req.DockerRegistry != nil, req.BasicAuth != nil, req.SSHAuth != nil} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return "", nil, fmt.Errorf("%w: tls、dockerRegistry、basicAuth、sshAuth 只能指定一个", ErrInvalidSecretData)
+	}
+	if modes == 0 {
+		secType := corev1.SecretTypeOpaque
+		if req.Type != "" {
+			secType = corev1.SecretType(req.Type)
+		}
+		return secType, req.StringData, nil
+	}
+
+	data := make(map[string]string, len(req.StringData)+3)
+	for k, v := range req.StringData {
+		data[k] = v
+	}
+	var secType corev1.SecretType
+	switch {
+	case req.TLS != nil:
+		if _, err := parseTLSKeyPair(req.TLS.Cert, req.TLS.Key); err != nil {
+			return "", nil, err
+		}
+		if req.TLS.CA != "" {
+			if _, err := parseCertificates([]byte(req.TLS.CA)); err != nil {
+				return "", nil, fmt.Errorf("%w: CA 证书: %v", ErrInvalidSecretData, err)
+			}
+			data["ca.crt"] = req.TLS.CA
+		}
+		secType = corev1.SecretTypeTLS
+		data[corev1.TLSCertKey] = req.TLS.Cert
+		data[corev1.TLSPrivateKeyKey] = req.TLS.Key
+	case req.DockerRegistry != nil:
+		config, err := buildDockerConfigJSON(*req.DockerRegistry)
+		if err != nil {
+			return "", nil, err
+		}
+		secType = corev1.SecretTypeDockerConfigJson
+		data[corev1.DockerConfigJsonKey] = config
+	case req.BasicAuth != nil:
+		if req.BasicAuth.Username == "" && req.BasicAuth.Password == "" {
+			return "", nil, fmt.Errorf("%w: 用户名和密码至少需要一个", ErrInvalidSecretData)
+		}
+		secType = corev1.SecretTypeBasicAuth
+		data[corev1.BasicAuthUsernameKey] = req.BasicAuth.Username
+		data[corev1.BasicAuthPasswordKey] = req.BasicAuth.Password
+	case req.SSHAuth != nil:
+		if _, err := ssh.ParseRawPrivateKey([]byte(req.SSHAuth.PrivateKey)); err != nil {
+			return "", nil, fmt.Errorf("%w: 无法解析 SSH 私钥（不支持带密码的私钥）: %v", ErrInvalidSecretData, err)
+		}
+		secType = corev1.SecretTypeSSHAuth
+		data[corev1.SSHAuthPrivateKey] = req.SSHAuth.PrivateKey
+	}
+	if req.Type != "" && corev1.SecretType(req.Type) != secType {
+		return "", nil, fmt.Errorf("%w: type %s 与内容类型 %s 不一致", ErrInvalidSecretData, req.Type, secType)
+	}
+	return secType, data, nil
+}
+
+// buildDockerConfigJSON 生成 .dockerconfigjson 内容
+func buildDockerConfigJSON(reg DockerRegistrySecretData) (string, error) {
+	if reg.Username == "" || reg.Password == "" {
+		return "", fmt.Errorf("%w: 镜像仓库用户名和密码不能为空", ErrInvalidSecretData)
+	}
+	server := strings.TrimSpace(reg.Server)
+	if server == "" {
+		server = DefaultDockerRegistryServer
+	}
+	entry := map[string]string{
+		"username": reg.Username,
+		"password": reg.Password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(reg.Username + ":" + reg.Password)),
+	}
+	if reg.Email != "" {
+		entry["email"] = reg.Email
+	}
+	data, err := json.Marshal(map[string]any{"auths": map[string]any{server: entry}})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// parseCertificates 解析 PEM 中的所有证书，忽略其他类型的块
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("未找到 PEM 格式的证书")
+	}
+	return certs, nil
+}
+
+// parseTLSKeyPair 校验证书与私钥匹配，并返回叶子证书摘要
+func parseTLSKeyPair(certPEM, keyPEM string) (*CertificateInfo, error) {
+	if _, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM)); err != nil {
+		return nil, fmt.Errorf("%w: 证书与私钥无效或不匹配: %v", ErrInvalidSecretData, err)
+	}
+	info, err := inspectCertificate([]byte(certPEM), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecretData, err)
+	}
+	return info, nil
+}
+
+// inspectCertificate 解析证书链并以第一个证书（叶子证书）生成摘要
+func inspectCertificate(certPEM []byte, now time.Time) (*CertificateInfo, error) {
+	certs, err := parseCertificates(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	leaf := certs[0]
+	info := &CertificateInfo{
+		Subject:       leaf.Subject.String(),
+		Issuer:        leaf.Issuer.String(),
+		DNSNames:      leaf.DNSNames,
+		SerialNumber:  leaf.SerialNumber.Text(16),
+		NotBefore:     leaf.NotBefore,
+		NotAfter:      leaf.NotAfter,
+		DaysRemaining: int(math.Floor(leaf.NotAfter.Sub(now).Hours() / 24)),
+		IsCA:          leaf.IsCA,
+		ChainLength:   len(certs),
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	switch {
+	case now.After(leaf.NotAfter):
+		info.Status = CertificateExpired
+	case info.DaysRemaining <= certificateExpiringDays:
+		info.Status = CertificateExpiring
+	default:
+		info.Status = CertificateValid
+	}
+	return info, nil
+}
+
+// secretCertificate TLS Secret 的证书摘要，其他类型或无法解析时返回 nil
+func secretCertificate(sec *corev1.Secret) *CertificateInfo {
+	if sec.Type != corev1.SecretTypeTLS {
+		return nil
+	}
+	info, err := inspectCertificate(sec.Data[corev1.TLSCertKey], time.Now())
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// buildCertificateReport 汇总 TLS Secret 的证书状态与引用它的 Ingress，按过期时间升序，无法解析的排在最前
+func buildCertificateReport(secrets []corev1.Secret, ingressRefs map[string][]string, withinDays int, now time.Time) []CertificateReportItem {
+	items := make([]CertificateReportItem, 0, len(secrets))
+	for i := range secrets {
+		sec := &secrets[i]
+		if sec.Type != corev1.SecretTypeTLS {
+			continue
+		}
+		key := sec.Namespace + "/" + sec.Name
+		item := CertificateReportItem{Namespace: sec.Namespace, Name: sec.Name, Ingresses: ingressRefs[key]}
+		if item.Ingresses == nil {
+			item.Ingresses = []string{}
+		}
+		info, err := inspectCertificate(sec.Data[corev1.TLSCertKey], now)
+		if err != nil {
+			item.Status = CertificateInvalid
+			item.Error = err.Error()
+		} else {
+			item.Certificate = info
+			item.Status = info.Status
+			if withinDays > 0 && info.DaysRemaining > withinDays {
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].Certificate, items[j].Certificate
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		default:
+			return a.NotAfter.Before(b.NotAfter)
+		}
+	})
+	return items
+}
+
+// CertificateReport 列出集群中所有 TLS Secret 的证书过期情况。
+// withinDays > 0 时只返回剩余天数不超过该值（含已过期与无法解析）的证书。
+func (s *SecretService) CertificateReport(ctx context.Context, clusterName string, withinDays int) ([]CertificateReportItem, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := client.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取 TLS Secret 列表失败: %w", err)
+	}
+	ingresses, err := client.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 Ingress 列表失败: %w", err)
+	}
+	refs := make(map[string][]string)
+	for _, ing := range ingresses.Items {
+		for _, t := range ing.Spec.TLS {
+			if t.SecretName == "" {
+				continue
+			}
+			key := ing.Namespace + "/" + t.SecretName
+			if !slices.Contains(refs[key], ing.Name) {
+				refs[key] = append(refs[key], ing.Name)
+			}
+		}
+	}
+	return buildCertificateReport(secrets.Items, refs, withinDays, time.Now()), nil
+}
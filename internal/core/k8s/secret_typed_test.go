@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testKeyPair 生成自签名证书与 EC 私钥（PEM）
+func testKeyPair(t *testing.T, notAfter time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "web.example.com"},
+		DNSNames:     []string{"web.example.com", "www.example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestBuildTypedSecretTLS(t *testing.T) {
+	cert, key := testKeyPair(t, time.Now().Add(90*24*time.Hour))
+	secType, data, err := buildTypedSecret(CreateSecretRequest{Name: "web-tls", TLS: &TLSSecretData{Cert: cert, Key: key}})
+	if err != nil {
+		t.Fatalf("buildTypedSecret: %v", err)
+	}
+	if secType != corev1.SecretTypeTLS || data[corev1.TLSCertKey] != cert || data[corev1.TLSPrivateKeyKey] != key {
+		t.Fatalf("unexpected tls secret: %s %v", secType, data)
+	}
+
+	_, otherKey := testKeyPair(t, time.Now().Add(time.Hour))
+	_, _, err = buildTypedSecret(CreateSecretRequest{Name: "web-tls", TLS: &TLSSecretData{Cert: cert, Key: otherKey}})
+	if !errors.Is(err, ErrInvalidSecretData) {
+		t.Fatalf("mismatched key should be rejected, got %v", err)
+	}
+
+	info, err := inspectCertificate([]byte(cert), time.Now())
+	if err != nil {
+		t.Fatalf("inspectCertificate: %v", err)
+	}
+	if info.Subject != "CN=web.example.com" || len(info.DNSNames) != 2 || info.Status != CertificateValid || info.DaysRemaining != 89 {
+		t.Fatalf("unexpected certificate info: %+v", info)
+	}
+}
+
+func TestBuildTypedSecretOtherModes(t *testing.T) {
+	secType, data, err := buildTypedSecret(CreateSecretRequest{DockerRegistry: &DockerRegistrySecretData{
+		Server: "registry.example.com", Username: "ci", Password: "s3cret",
+	}})
+	if err != nil || secType != corev1.SecretTypeDockerConfigJson {
+		t.Fatalf("docker registry: %s %v", secType, err)
+	}
+	var config struct {
+		Auths map[string]map[string]string `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(data[corev1.DockerConfigJsonKey]), &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Auths["registry.example.com"]["auth"] != "Y2k6czNjcmV0" {
+		t.Fatalf("unexpected docker config: %v", config)
+	}
+
+	secType, data, err = buildTypedSecret(CreateSecretRequest{BasicAuth: &BasicAuthSecretData{Username: "admin", Password: "pw"}})
+	if err != nil || secType != corev1.SecretTypeBasicAuth || data[corev1.BasicAuthUsernameKey] != "admin" {
+		t.Fatalf("basic auth: %s %v %v", secType, data, err)
+	}
+
+	_, key := testKeyPair(t, time.Now().Add(time.Hour))
+	secType, _, err = buildTypedSecret(CreateSecretRequest{SSHAuth: &SSHAuthSecretData{PrivateKey: key}})
+	if err != nil || secType != corev1.SecretTypeSSHAuth {
+		t.Fatalf("ssh auth: %s %v", secType, err)
+	}
+
+	invalid := []CreateSecretRequest{
+		{SSHAuth: &SSHAuthSecretData{PrivateKey: "not a key"}},
+		{BasicAuth: &BasicAuthSecretData{}},
+		{BasicAuth: &BasicAuthSecretData{Username: "a"}, SSHAuth: &SSHAuthSecretData{PrivateKey: key}},
+		{Type: string(corev1.SecretTypeOpaque), BasicAuth: &BasicAuthSecretData{Username: "a"}},
+	}
+	for i, req := range invalid {
+		if _, _, err := buildTypedSecret(req); !errors.Is(err, ErrInvalidSecretData) {
+			t.Errorf("request %d should be rejected, got %v", i, err)
+		}
+	}
+}
+
+func TestBuildCertificateReport(t *testing.T) {
+	now := time.Now()
+	expiring, _ := testKeyPair(t, now.Add(10*24*time.Hour))
+	valid, _ := testKeyPair(t, now.Add(200*24*time.Hour))
+	expired, _ := testKeyPair(t, now.Add(-24*time.Hour))
+	secret := func(name, cert string) corev1.Secret {
+		return corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: name},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte(cert)},
+		}
+	}
+	secrets := []corev1.Secret{secret("valid", valid), secret("expiring", expiring), secret("broken", "garbage"), secret("expired", expired)}
+	refs := map[string][]string{"prod/expiring": {"web"}}
+
+	items := buildCertificateReport(secrets, refs, 0, now)
+	want := []string{"broken", "expired", "expiring", "valid"}
+	for i, name := range want {
+		if items[i].Name != name {
+			t.Fatalf("unexpected order: %+v", items)
+		}
+	}
+	if items[0].Status != CertificateInvalid || items[1].Status != CertificateExpired || items[2].Status != CertificateExpiring {
+		t.Fatalf("unexpected statuses: %+v", items)
+	}
+	if len(items[2].Ingresses) != 1 || items[2].Ingresses[0] != "web" || items[3].Ingresses == nil {
+		t.Fatalf("unexpected ingress references: %+v", items)
+	}
+
+	if filtered := buildCertificateReport(secrets, refs, 30, now); len(filtered) != 3 {
+		t.Fatalf("withinDays should drop the valid certificate: %+v", filtered)
+	}
+}
@@ -24,7 +24,7 @@ func NewServiceManager(clientManager *ClientManager) *ServiceManager {
 
 // GetServices 获取所有命名空间的Service列表
 func (s *ServiceManager) GetServices(ctx context.Context, clusterName string) ([]corev1.Service, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func (s *ServiceManager) GetServices(ctx context.Context, clusterName string) ([
 
 // GetServicesByNamespace 获取指定命名空间的Service列表
 func (s *ServiceManager) GetServicesByNamespace(ctx context.Context, clusterName, namespace string) ([]corev1.Service, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (s *ServiceManager) GetServicesByNamespace(ctx context.Context, clusterName
 
 // GetServiceDetails 获取Service详情
 func (s *ServiceManager) GetServiceDetails(ctx context.Context, clusterName, namespace, serviceName string) (*corev1.Service, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +69,7 @@ func (s *ServiceManager) GetServiceDetails(ctx context.Context, clusterName, nam
 
 // GetServiceEndpoints 获取Service关联的Endpoints
 func (s *ServiceManager) GetServiceEndpoints(ctx context.Context, clusterName, namespace, serviceName string) (*corev1.Endpoints, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +84,7 @@ func (s *ServiceManager) GetServiceEndpoints(ctx context.Context, clusterName, n
 
 // CreateService 创建Service
 func (s *ServiceManager) CreateService(ctx context.Context, clusterName string, service *corev1.Service) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -99,7 +99,7 @@ func (s *ServiceManager) CreateService(ctx context.Context, clusterName string,
 
 // UpdateService 更新Service
 func (s *ServiceManager) UpdateService(ctx context.Context, clusterName string, service *corev1.Service) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -114,7 +114,7 @@ func (s *ServiceManager) UpdateService(ctx context.Context, clusterName string,
 
 // DeleteService 删除Service
 func (s *ServiceManager) DeleteService(ctx context.Context, clusterName, namespace, serviceName string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"kube-tide/internal/utils/logger"
 	"sort"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -16,12 +17,15 @@ import (
 // StatefulSetService 提供与Kubernetes StatefulSets交互的服务
 type StatefulSetService struct {
 	clientManager *ClientManager
+	stepDowns     map[string]*PartitionStepDown // 分区逐步下调任务，key 为 集群/命名空间/名称
+	stepMutex     sync.Mutex
 }
 
 // NewStatefulSetService 创建一个新的StatefulSetService实例
 func NewStatefulSetService(clientManager *ClientManager) *StatefulSetService {
 	return &StatefulSetService{
 		clientManager: clientManager,
+		stepDowns:     make(map[string]*PartitionStepDown),
 	}
 }
 
@@ -75,7 +79,7 @@ type PersistentVolumeClaim struct {
 
 // GetStatefulSets 获取指定命名空间的StatefulSet列表
 func (s *StatefulSetService) GetStatefulSets(ctx context.Context, clusterName, namespace string) ([]StatefulSetInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -92,33 +96,8 @@ func (s *StatefulSetService) GetStatefulSets(ctx context.Context, clusterName, n
 	}
 
 	var statefulsetInfos []StatefulSetInfo
-	for _, sts := range statefulsetList.Items {
-		containers := sts.Spec.Template.Spec.Containers
-		images := make([]string, len(containers))
-		for i, container := range containers {
-			images[i] = container.Image
-		}
-
-		volClaims := make([]string, len(sts.Spec.VolumeClaimTemplates))
-		for i, pvc := range sts.Spec.VolumeClaimTemplates {
-			volClaims[i] = pvc.Name
-		}
-
-		statefulsetInfo := StatefulSetInfo{
-			Name:                 sts.Name,
-			Namespace:            sts.Namespace,
-			Replicas:             *sts.Spec.Replicas,
-			ReadyReplicas:        sts.Status.ReadyReplicas,
-			ServiceName:          sts.Spec.ServiceName,
-			UpdateStrategy:       string(sts.Spec.UpdateStrategy.Type),
-			CreationTime:         sts.CreationTimestamp.Time,
-			Labels:               sts.Labels,
-			Selector:             sts.Spec.Selector.MatchLabels,
-			ContainerCount:       len(containers),
-			Images:               images,
-			VolumeClaimTemplates: volClaims,
-		}
-		statefulsetInfos = append(statefulsetInfos, statefulsetInfo)
+	for i := range statefulsetList.Items {
+		statefulsetInfos = append(statefulsetInfos, convertStatefulSetInfo(&statefulsetList.Items[i]))
 	}
 
 	// 按创建时间排序，最新的在前面
@@ -129,9 +108,38 @@ func (s *StatefulSetService) GetStatefulSets(ctx context.Context, clusterName, n
 	return statefulsetInfos, nil
 }
 
+// convertStatefulSetInfo 将K8s API的StatefulSet转换为列表摘要
+func convertStatefulSetInfo(sts *appsv1.StatefulSet) StatefulSetInfo {
+	containers := sts.Spec.Template.Spec.Containers
+	images := make([]string, len(containers))
+	for i, container := range containers {
+		images[i] = container.Image
+	}
+
+	volClaims := make([]string, len(sts.Spec.VolumeClaimTemplates))
+	for i, pvc := range sts.Spec.VolumeClaimTemplates {
+		volClaims[i] = pvc.Name
+	}
+
+	return StatefulSetInfo{
+		Name:                 sts.Name,
+		Namespace:            sts.Namespace,
+		Replicas:             *sts.Spec.Replicas,
+		ReadyReplicas:        sts.Status.ReadyReplicas,
+		ServiceName:          sts.Spec.ServiceName,
+		UpdateStrategy:       string(sts.Spec.UpdateStrategy.Type),
+		CreationTime:         sts.CreationTimestamp.Time,
+		Labels:               sts.Labels,
+		Selector:             sts.Spec.Selector.MatchLabels,
+		ContainerCount:       len(containers),
+		Images:               images,
+		VolumeClaimTemplates: volClaims,
+	}
+}
+
 // GetStatefulSetDetails 获取StatefulSet详情
 func (s *StatefulSetService) GetStatefulSetDetails(ctx context.Context, clusterName, namespace, name string) (*StatefulSetDetails, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -264,30 +272,7 @@ func (s *StatefulSetService) GetStatefulSetDetails(ctx context.Context, clusterN
 	// 处理PVC模板
 	pvcTemplates := make([]PersistentVolumeClaim, len(sts.Spec.VolumeClaimTemplates))
 	for i, pvc := range sts.Spec.VolumeClaimTemplates {
-		accessModes := make([]string, len(pvc.Spec.AccessModes))
-		for j, mode := range pvc.Spec.AccessModes {
-			accessModes[j] = string(mode)
-		}
-
-		storageClassName := ""
-		if pvc.Spec.StorageClassName != nil {
-			storageClassName = *pvc.Spec.StorageClassName
-		}
-
-		storage := ""
-		if pvc.Spec.Resources.Requests != nil {
-			if storageResource, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
-				storage = storageResource.String()
-			}
-		}
-
-		pvcTemplates[i] = PersistentVolumeClaim{
-			Name:             pvc.Name,
-			StorageClassName: storageClassName,
-			AccessModes:      accessModes,
-			Storage:          storage,
-			Labels:           pvc.Labels,
-		}
+		pvcTemplates[i] = convertClaimTemplate(pvc)
 	}
 
 	// 处理条件
@@ -354,7 +339,7 @@ func (s *StatefulSetService) GetStatefulSetDetails(ctx context.Context, clusterN
 
 // CreateStatefulSet 创建新的StatefulSet
 func (s *StatefulSetService) CreateStatefulSet(ctx context.Context, clusterName string, statefulset *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +349,7 @@ func (s *StatefulSetService) CreateStatefulSet(ctx context.Context, clusterName
 
 // UpdateStatefulSet 更新StatefulSet
 func (s *StatefulSetService) UpdateStatefulSet(ctx context.Context, clusterName, namespace, name string, updateData map[string]interface{}) (*appsv1.StatefulSet, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -536,7 +521,7 @@ func (s *StatefulSetService) UpdateStatefulSet(ctx context.Context, clusterName,
 
 // DeleteStatefulSet 删除StatefulSet
 func (s *StatefulSetService) DeleteStatefulSet(ctx context.Context, clusterName, namespace, name string) error {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return err
 	}
@@ -546,7 +531,7 @@ func (s *StatefulSetService) DeleteStatefulSet(ctx context.Context, clusterName,
 
 // ScaleStatefulSet 扩缩容StatefulSet
 func (s *StatefulSetService) ScaleStatefulSet(ctx context.Context, clusterName, namespace, name string, replicas int32) (*appsv1.StatefulSet, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -566,7 +551,7 @@ func (s *StatefulSetService) ScaleStatefulSet(ctx context.Context, clusterName,
 
 // RestartStatefulSet 重启StatefulSet（通过添加重启注解）
 func (s *StatefulSetService) RestartStatefulSet(ctx context.Context, clusterName, namespace, name string) (*appsv1.StatefulSet, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -589,7 +574,7 @@ func (s *StatefulSetService) RestartStatefulSet(ctx context.Context, clusterName
 
 // GetStatefulSetPods 获取StatefulSet关联的所有Pod
 func (s *StatefulSetService) GetStatefulSetPods(ctx context.Context, clusterName, namespace, statefulsetName string) ([]corev1.Pod, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -601,20 +586,12 @@ func (s *StatefulSetService) GetStatefulSetPods(ctx context.Context, clusterName
 	}
 
 	// 使用标签选择器查找关联的Pod
-	labelSelector := metav1.FormatLabelSelector(sts.Spec.Selector)
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return pods.Items, nil
+	return s.listStatefulSetPods(ctx, client, sts)
 }
 
 // GetStatefulSetEvents 获取与StatefulSet相关的事件
 func (s *StatefulSetService) GetStatefulSetEvents(ctx context.Context, clusterName, namespace, statefulsetName string) ([]corev1.Event, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -659,7 +636,7 @@ func (s *StatefulSetService) GetAllStatefulSetEvents(ctx context.Context, cluste
 
 	// 获取每个Pod的事件
 	var podEvents []corev1.Event
-	k8sClient, err := s.clientManager.GetClient(clusterName)
+	k8sClient, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		logger.Errorf("获取K8s客户端失败: %v", err)
 		return result, nil
@@ -0,0 +1,315 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kube-tide/internal/utils/logger"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StatefulSetPVCRetentionPolicy PVC 保留策略，取值 Retain 或 Delete
+type StatefulSetPVCRetentionPolicy struct {
+	WhenDeleted string `json:"whenDeleted"`
+	WhenScaled  string `json:"whenScaled"`
+}
+
+// StatefulSetVolumeClaim 某个序号由 volumeClaimTemplate 生成的 PVC
+type StatefulSetVolumeClaim struct {
+	Template string `json:"template"`
+	Ordinal  int    `json:"ordinal"`
+	Name     string `json:"name"`
+	// Exists 为 false 表示该序号的 PVC 尚未创建
+	Exists bool     `json:"exists"`
+	PVC    *PVCInfo `json:"pvc,omitempty"`
+	// Orphaned 序号超出当前副本数（缩容后保留）的 PVC，可按需清理
+	Orphaned bool `json:"orphaned"`
+	// InUse 仍被 Pod 挂载
+	InUse         bool    `json:"inUse"`
+	UsedBytes     *uint64 `json:"usedBytes,omitempty"`
+	CapacityBytes *uint64 `json:"capacityBytes,omitempty"`
+}
+
+// StatefulSetStorage StatefulSet 的存储视图
+type StatefulSetStorage struct {
+	Replicas        int32                         `json:"replicas"`
+	Templates       []PersistentVolumeClaim       `json:"templates"`
+	RetentionPolicy StatefulSetPVCRetentionPolicy `json:"retentionPolicy"`
+	Claims          []StatefulSetVolumeClaim      `json:"claims"`
+	OrphanedClaims  []string                      `json:"orphanedClaims"`
+}
+
+// claimOrdinal 解析 <template>-<statefulset>-<ordinal> 格式的 PVC 名称
+func claimOrdinal(template, stsName, pvcName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(pvcName, template+"-"+stsName+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+func pvcRetentionPolicy(sts *appsv1.StatefulSet) StatefulSetPVCRetentionPolicy {
+	policy := StatefulSetPVCRetentionPolicy{
+		WhenDeleted: string(appsv1.RetainPersistentVolumeClaimRetentionPolicyType),
+		WhenScaled:  string(appsv1.RetainPersistentVolumeClaimRetentionPolicyType),
+	}
+	if p := sts.Spec.PersistentVolumeClaimRetentionPolicy; p != nil {
+		if p.WhenDeleted != "" {
+			policy.WhenDeleted = string(p.WhenDeleted)
+		}
+		if p.WhenScaled != "" {
+			policy.WhenScaled = string(p.WhenScaled)
+		}
+	}
+	return policy
+}
+
+// buildStatefulSetStorage 按模板与序号汇总 PVC，标记缩容后遗留的 PVC
+func buildStatefulSetStorage(sts *appsv1.StatefulSet, pvcs []corev1.PersistentVolumeClaim, pods []corev1.Pod) *StatefulSetStorage {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	storage := &StatefulSetStorage{
+		Replicas:        replicas,
+		Templates:       make([]PersistentVolumeClaim, 0, len(sts.Spec.VolumeClaimTemplates)),
+		RetentionPolicy: pvcRetentionPolicy(sts),
+		Claims:          []StatefulSetVolumeClaim{},
+		OrphanedClaims:  []string{},
+	}
+
+	mounted := make(map[string]bool)
+	for _, pod := range pods {
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil {
+				mounted[v.PersistentVolumeClaim.ClaimName] = true
+			}
+		}
+	}
+
+	for _, tpl := range sts.Spec.VolumeClaimTemplates {
+		storage.Templates = append(storage.Templates, convertClaimTemplate(tpl))
+
+		found := make(map[int]bool)
+		for i := range pvcs {
+			ordinal, ok := claimOrdinal(tpl.Name, sts.Name, pvcs[i].Name)
+			if !ok {
+				continue
+			}
+			found[ordinal] = true
+			info := convertPVCInfo(&pvcs[i])
+			claim := StatefulSetVolumeClaim{
+				Template: tpl.Name,
+				Ordinal:  ordinal,
+				Name:     pvcs[i].Name,
+				Exists:   true,
+				PVC:      &info,
+				Orphaned: int32(ordinal) >= replicas,
+				InUse:    mounted[pvcs[i].Name],
+			}
+			storage.Claims = append(storage.Claims, claim)
+			if claim.Orphaned {
+				storage.OrphanedClaims = append(storage.OrphanedClaims, claim.Name)
+			}
+		}
+		for ordinal := 0; ordinal < int(replicas); ordinal++ {
+			if !found[ordinal] {
+				storage.Claims = append(storage.Claims, StatefulSetVolumeClaim{
+					Template: tpl.Name,
+					Ordinal:  ordinal,
+					Name:     fmt.Sprintf("%s-%s-%d", tpl.Name, sts.Name, ordinal),
+				})
+			}
+		}
+	}
+
+	sort.Slice(storage.Claims, func(i, j int) bool {
+		if storage.Claims[i].Ordinal != storage.Claims[j].Ordinal {
+			return storage.Claims[i].Ordinal < storage.Claims[j].Ordinal
+		}
+		return storage.Claims[i].Template < storage.Claims[j].Template
+	})
+	sort.Strings(storage.OrphanedClaims)
+	return storage
+}
+
+func convertClaimTemplate(tpl corev1.PersistentVolumeClaim) PersistentVolumeClaim {
+	modes := make([]string, 0, len(tpl.Spec.AccessModes))
+	for _, m := range tpl.Spec.AccessModes {
+		modes = append(modes, string(m))
+	}
+	claim := PersistentVolumeClaim{
+		Name:        tpl.Name,
+		AccessModes: modes,
+		Labels:      tpl.Labels,
+	}
+	if tpl.Spec.StorageClassName != nil {
+		claim.StorageClassName = *tpl.Spec.StorageClassName
+	}
+	if qty, ok := tpl.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		claim.Storage = qty.String()
+	}
+	return claim
+}
+
+// fillClaimUsage 从运行 Pod 的节点读取 kubelet 卷统计，填充 PVC 用量；无权限或节点不可达时跳过
+func fillClaimUsage(ctx context.Context, client kubernetes.Interface, namespace string, pods []corev1.Pod, storage *StatefulSetStorage) {
+	nodes := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+	usage := make(map[string]kubeletVolumeStats)
+	for node := range nodes {
+		summary, err := getKubeletStatsSummary(ctx, client, node)
+		if err != nil {
+			logger.Debug("读取节点卷统计失败", "node", node, "error", err.Error())
+			continue
+		}
+		for _, podStats := range summary.Pods {
+			for _, v := range podStats.VolumeStats {
+				if v.PVCRef != nil && v.PVCRef.Namespace == namespace {
+					usage[v.PVCRef.Name] = v
+				}
+			}
+		}
+	}
+	for i := range storage.Claims {
+		if v, ok := usage[storage.Claims[i].Name]; ok {
+			storage.Claims[i].UsedBytes = v.UsedBytes
+			storage.Claims[i].CapacityBytes = v.CapacityBytes
+		}
+	}
+}
+
+func (s *StatefulSetService) loadStatefulSetStorage(ctx context.Context, client kubernetes.Interface, namespace, name string) ([]corev1.Pod, *StatefulSetStorage, error) {
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取 PVC 列表失败: %w", err)
+	}
+	pods, err := s.listStatefulSetPods(ctx, client, sts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pods, buildStatefulSetStorage(sts, pvcs.Items, pods), nil
+}
+
+// GetStatefulSetStorage 获取各序号由 volumeClaimTemplates 生成的 PVC、绑定的 PV、容量与用量
+func (s *StatefulSetService) GetStatefulSetStorage(ctx context.Context, clusterName, namespace, name string) (*StatefulSetStorage, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	pods, storage, err := s.loadStatefulSetStorage(ctx, client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	fillClaimUsage(ctx, client, namespace, pods, storage)
+	return storage, nil
+}
+
+// ListOrphanedPVCs 返回序号超出当前副本数的 PVC 名称，缩容后用于提示清理。
+// whenScaled 为 Delete 时由控制器自动删除，返回空列表。
+func (s *StatefulSetService) ListOrphanedPVCs(ctx context.Context, clusterName, namespace, name string) ([]string, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	_, storage, err := s.loadStatefulSetStorage(ctx, client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if storage.RetentionPolicy.WhenScaled == string(appsv1.DeletePersistentVolumeClaimRetentionPolicyType) {
+		return []string{}, nil
+	}
+	return storage.OrphanedClaims, nil
+}
+
+// UpdatePVCRetentionPolicy 设置 persistentVolumeClaimRetentionPolicy
+func (s *StatefulSetService) UpdatePVCRetentionPolicy(ctx context.Context, clusterName, namespace, name string, policy StatefulSetPVCRetentionPolicy) (*StatefulSetPVCRetentionPolicy, error) {
+	for _, v := range []string{policy.WhenDeleted, policy.WhenScaled} {
+		switch appsv1.PersistentVolumeClaimRetentionPolicyType(v) {
+		case "", appsv1.RetainPersistentVolumeClaimRetentionPolicyType, appsv1.DeletePersistentVolumeClaimRetentionPolicyType:
+		default:
+			return nil, fmt.Errorf("不支持的 PVC 保留策略: %s", v)
+		}
+	}
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := map[string]string{}
+	if policy.WhenDeleted != "" {
+		retention["whenDeleted"] = policy.WhenDeleted
+	}
+	if policy.WhenScaled != "" {
+		retention["whenScaled"] = policy.WhenScaled
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"persistentVolumeClaimRetentionPolicy": retention}})
+	if err != nil {
+		return nil, err
+	}
+	updated, err := client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("更新 PVC 保留策略失败: %w", err)
+	}
+	result := pvcRetentionPolicy(updated)
+	return &result, nil
+}
+
+// DeleteOrphanedPVCs 删除指定的遗留 PVC，仅允许删除序号超出副本数且未被 Pod 挂载的 PVC
+func (s *StatefulSetService) DeleteOrphanedPVCs(ctx context.Context, clusterName, namespace, name string, pvcNames []string) ([]string, error) {
+	if len(pvcNames) == 0 {
+		return nil, fmt.Errorf("未指定要删除的 PVC")
+	}
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	_, storage, err := s.loadStatefulSetStorage(ctx, client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	claims := make(map[string]StatefulSetVolumeClaim, len(storage.Claims))
+	for _, claim := range storage.Claims {
+		claims[claim.Name] = claim
+	}
+	for _, pvcName := range pvcNames {
+		claim, ok := claims[pvcName]
+		if !ok || !claim.Exists || !claim.Orphaned {
+			return nil, fmt.Errorf("PVC %s 不是 StatefulSet %s 缩容后遗留的 PVC", pvcName, name)
+		}
+		if claim.InUse {
+			return nil, fmt.Errorf("PVC %s 仍被 Pod 挂载", pvcName)
+		}
+	}
+
+	deleted := make([]string, 0, len(pvcNames))
+	for _, pvcName := range pvcNames {
+		if err := client.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvcName, metav1.DeleteOptions{}); err != nil {
+			return deleted, fmt.Errorf("删除 PVC %s 失败: %w", pvcName, err)
+		}
+		deleted = append(deleted, pvcName)
+	}
+	logger.Info("清理 StatefulSet 遗留 PVC", "cluster", clusterName, "namespace", namespace, "statefulset", name, "pvcs", deleted)
+	return deleted, nil
+}
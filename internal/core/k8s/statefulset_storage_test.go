@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClaimOrdinal(t *testing.T) {
+	if ordinal, ok := claimOrdinal("data", "db", "data-db-3"); !ok || ordinal != 3 {
+		t.Fatalf("claimOrdinal(data-db-3) = %d, %v", ordinal, ok)
+	}
+	for _, name := range []string{"data-db-x", "logs-db-0", "data-db2-0", "data-db-"} {
+		if _, ok := claimOrdinal("data", "db", name); ok {
+			t.Errorf("claimOrdinal(%s) should not match", name)
+		}
+	}
+}
+
+func TestBuildStatefulSetStorage(t *testing.T) {
+	replicas := int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}},
+				},
+			}},
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenScaled: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+	pvc := func(name string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-" + name},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		}
+	}
+	pvcs := []corev1.PersistentVolumeClaim{pvc("data-db-0"), pvc("data-db-2"), pvc("other")}
+	pods := []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-2"},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-2"}},
+		}}},
+	}}
+
+	storage := buildStatefulSetStorage(sts, pvcs, pods)
+	if storage.RetentionPolicy.WhenDeleted != "Retain" || storage.RetentionPolicy.WhenScaled != "Delete" {
+		t.Fatalf("unexpected retention policy: %+v", storage.RetentionPolicy)
+	}
+	if len(storage.Templates) != 1 || storage.Templates[0].Storage != "10Gi" {
+		t.Fatalf("unexpected templates: %+v", storage.Templates)
+	}
+	if len(storage.Claims) != 3 {
+		t.Fatalf("expected 3 claims, got %+v", storage.Claims)
+	}
+	first, missing, orphan := storage.Claims[0], storage.Claims[1], storage.Claims[2]
+	if !first.Exists || first.PVC.VolumeName != "pv-data-db-0" || first.Orphaned {
+		t.Fatalf("unexpected claim for ordinal 0: %+v", first)
+	}
+	if missing.Exists || missing.Name != "data-db-1" {
+		t.Fatalf("ordinal 1 should be reported as missing: %+v", missing)
+	}
+	if !orphan.Orphaned || !orphan.InUse || len(storage.OrphanedClaims) != 1 || storage.OrphanedClaims[0] != "data-db-2" {
+		t.Fatalf("data-db-2 should be orphaned and in use: %+v", orphan)
+	}
+}
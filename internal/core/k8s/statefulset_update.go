@@ -0,0 +1,447 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kube-tide/internal/core/auth"
+	"kube-tide/internal/utils/logger"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 分区逐步下调任务的状态
+const (
+	PartitionStepRunning   = "Running"
+	PartitionStepSucceeded = "Succeeded"
+	PartitionStepFailed    = "Failed"
+	PartitionStepCancelled = "Cancelled"
+)
+
+const defaultPartitionStepTimeout = 10 * time.Minute
+
+// partitionPollInterval 逐步下调时检查 Pod 就绪的间隔
+var partitionPollInterval = 2 * time.Second
+
+// StatefulSetUpdateStrategyRequest 更新策略请求，未设置的字段保持不变
+type StatefulSetUpdateStrategyRequest struct {
+	Type           string  `json:"type,omitempty"` // RollingUpdate 或 OnDelete
+	Partition      *int32  `json:"partition,omitempty"`
+	MaxUnavailable *string `json:"maxUnavailable,omitempty"` // 整数或百分比，需要集群开启 MaxUnavailableStatefulSet
+}
+
+// StatefulSetOrdinalStatus 单个序号 Pod 的版本状态
+type StatefulSetOrdinalStatus struct {
+	Ordinal  int    `json:"ordinal"`
+	Pod      string `json:"pod"`
+	Revision string `json:"revision"`
+	Updated  bool   `json:"updated"` // 是否已是 updateRevision
+	Ready    bool   `json:"ready"`
+	Phase    string `json:"phase"`
+}
+
+// StatefulSetRevisionStatus StatefulSet 的更新策略与各序号的版本
+type StatefulSetRevisionStatus struct {
+	UpdateStrategy  string                     `json:"updateStrategy"`
+	Partition       int32                      `json:"partition"`
+	MaxUnavailable  string                     `json:"maxUnavailable,omitempty"`
+	Replicas        int32                      `json:"replicas"`
+	CurrentRevision string                     `json:"currentRevision"`
+	UpdateRevision  string                     `json:"updateRevision"`
+	UpdatedReplicas int32                      `json:"updatedReplicas"`
+	Ordinals        []StatefulSetOrdinalStatus `json:"ordinals"`
+}
+
+// PartitionStepDown 分区逐步下调任务：每次将 partition 减一，等待新序号的 Pod 更新并就绪后继续
+type PartitionStepDown struct {
+	Cluster     string     `json:"cluster"`
+	Namespace   string     `json:"namespace"`
+	StatefulSet string     `json:"statefulSet"`
+	From        int32      `json:"from"`
+	Target      int32      `json:"target"`
+	Partition   int32      `json:"partition"`
+	Phase       string     `json:"phase"`
+	Message     string     `json:"message,omitempty"`
+	StartedBy   string     `json:"startedBy,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+
+	cancel context.CancelFunc
+}
+
+// podOrdinal 从 Pod 名称解析序号，不属于该 StatefulSet 时返回 false
+func podOrdinal(stsName, podName string) (int, bool) {
+	suffix, ok := strings.CutPrefix(podName, stsName+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+func statefulSetPartition(sts *appsv1.StatefulSet) int32 {
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		return *ru.Partition
+	}
+	return 0
+}
+
+// buildRevisionStatus 汇总 StatefulSet 更新策略与各序号 Pod 的版本
+func buildRevisionStatus(sts *appsv1.StatefulSet, pods []corev1.Pod) *StatefulSetRevisionStatus {
+	status := &StatefulSetRevisionStatus{
+		UpdateStrategy:  string(sts.Spec.UpdateStrategy.Type),
+		Partition:       statefulSetPartition(sts),
+		CurrentRevision: sts.Status.CurrentRevision,
+		UpdateRevision:  sts.Status.UpdateRevision,
+		UpdatedReplicas: sts.Status.UpdatedReplicas,
+		Ordinals:        make([]StatefulSetOrdinalStatus, 0, len(pods)),
+	}
+	if status.UpdateStrategy == "" {
+		status.UpdateStrategy = string(appsv1.RollingUpdateStatefulSetStrategyType)
+	}
+	if sts.Spec.Replicas != nil {
+		status.Replicas = *sts.Spec.Replicas
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.MaxUnavailable != nil {
+		status.MaxUnavailable = ru.MaxUnavailable.String()
+	}
+	for i := range pods {
+		ordinal, ok := podOrdinal(sts.Name, pods[i].Name)
+		if !ok {
+			continue
+		}
+		revision := pods[i].Labels[appsv1.StatefulSetRevisionLabel]
+		status.Ordinals = append(status.Ordinals, StatefulSetOrdinalStatus{
+			Ordinal:  ordinal,
+			Pod:      pods[i].Name,
+			Revision: revision,
+			Updated:  revision != "" && revision == sts.Status.UpdateRevision,
+			Ready:    isPodReady(&pods[i]),
+			Phase:    string(pods[i].Status.Phase),
+		})
+	}
+	sort.Slice(status.Ordinals, func(i, j int) bool { return status.Ordinals[i].Ordinal < status.Ordinals[j].Ordinal })
+	return status
+}
+
+// ordinalsSettled 序号不小于 from 的 Pod 都已是新版本并就绪
+func ordinalsSettled(sts *appsv1.StatefulSet, status *StatefulSetRevisionStatus, from int32) bool {
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return false
+	}
+	seen := make(map[int]bool, len(status.Ordinals))
+	for _, o := range status.Ordinals {
+		seen[o.Ordinal] = true
+		if int32(o.Ordinal) >= from && int32(o.Ordinal) < status.Replicas && (!o.Updated || !o.Ready) {
+			return false
+		}
+	}
+	for ordinal := from; ordinal < status.Replicas; ordinal++ {
+		if !seen[int(ordinal)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *StatefulSetService) listStatefulSetPods(ctx context.Context, client kubernetes.Interface, sts *appsv1.StatefulSet) ([]corev1.Pod, error) {
+	pods, err := client.CoreV1().Pods(sts.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(sts.Spec.Selector),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// GetStatefulSetPodsWithRevisions 获取 StatefulSet 的 Pod 以及各序号的版本状态
+func (s *StatefulSetService) GetStatefulSetPodsWithRevisions(ctx context.Context, clusterName, namespace, name string) ([]corev1.Pod, *StatefulSetRevisionStatus, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	pods, err := s.listStatefulSetPods(ctx, client, sts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pods, buildRevisionStatus(sts, pods), nil
+}
+
+// UpdateStatefulSetStrategy 修改更新策略（类型、partition、maxUnavailable）
+func (s *StatefulSetService) UpdateStatefulSetStrategy(ctx context.Context, clusterName, namespace, name string, req StatefulSetUpdateStrategyRequest) (*StatefulSetRevisionStatus, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := sts.Spec.UpdateStrategy.DeepCopy()
+	if req.Type != "" {
+		switch appsv1.StatefulSetUpdateStrategyType(req.Type) {
+		case appsv1.RollingUpdateStatefulSetStrategyType, appsv1.OnDeleteStatefulSetStrategyType:
+			strategy.Type = appsv1.StatefulSetUpdateStrategyType(req.Type)
+		default:
+			return nil, fmt.Errorf("不支持的更新策略: %s", req.Type)
+		}
+	}
+	if strategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		if req.Partition != nil || req.MaxUnavailable != nil {
+			return nil, fmt.Errorf("OnDelete 策略不支持 partition 与 maxUnavailable")
+		}
+		strategy.RollingUpdate = nil
+	} else {
+		if strategy.RollingUpdate == nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+		}
+		if req.Partition != nil {
+			if *req.Partition < 0 {
+				return nil, fmt.Errorf("partition 不能为负数")
+			}
+			strategy.RollingUpdate.Partition = req.Partition
+		}
+		if req.MaxUnavailable != nil {
+			value := intstr.Parse(*req.MaxUnavailable)
+			if value.Type == intstr.Int && value.IntVal < 1 {
+				return nil, fmt.Errorf("maxUnavailable 至少为 1")
+			}
+			strategy.RollingUpdate.MaxUnavailable = &value
+		}
+	}
+
+	// 切换为 OnDelete 时 rollingUpdate 序列化为 null，由合并补丁删除
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"updateStrategy": map[string]any{
+		"type":          strategy.Type,
+		"rollingUpdate": strategy.RollingUpdate,
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	updated, err := client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("更新 StatefulSet 更新策略失败: %w", err)
+	}
+	pods, err := s.listStatefulSetPods(ctx, client, updated)
+	if err != nil {
+		return nil, err
+	}
+	return buildRevisionStatus(updated, pods), nil
+}
+
+// RestartStatefulSetOrdinal 删除指定序号的 Pod，由控制器按当前策略重建（OnDelete 策略下即更新该序号）
+func (s *StatefulSetService) RestartStatefulSetOrdinal(ctx context.Context, clusterName, namespace, name string, ordinal int) error {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if sts.Spec.Replicas != nil && int32(ordinal) >= *sts.Spec.Replicas {
+		return fmt.Errorf("序号 %d 超出副本数 %d", ordinal, *sts.Spec.Replicas)
+	}
+	podName := fmt.Sprintf("%s-%d", name, ordinal)
+	if err := client.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("删除 Pod %s 失败: %w", podName, err)
+	}
+	logger.Info("重启 StatefulSet 序号", "cluster", clusterName, "namespace", namespace, "statefulset", name, "pod", podName)
+	return nil
+}
+
+func partitionStepKey(clusterName, namespace, name string) string {
+	return clusterName + "/" + namespace + "/" + name
+}
+
+// StartPartitionStepDown 从当前 partition 逐个序号下调到 target，每一步等待新更新的 Pod 就绪。
+// 任务在后台以发起请求的用户身份执行，timeout 为单步等待上限。
+func (s *StatefulSetService) StartPartitionStepDown(ctx context.Context, clusterName, namespace, name string, target int32, timeout time.Duration) (*PartitionStepDown, error) {
+	if target < 0 {
+		return nil, fmt.Errorf("目标 partition 不能为负数")
+	}
+	if timeout <= 0 {
+		timeout = defaultPartitionStepTimeout
+	}
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return nil, fmt.Errorf("OnDelete 策略不支持分区更新")
+	}
+	current := statefulSetPartition(sts)
+	if current <= target {
+		return nil, fmt.Errorf("当前 partition %d 已不大于目标 %d", current, target)
+	}
+
+	key := partitionStepKey(clusterName, namespace, name)
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+	if existing, ok := s.stepDowns[key]; ok && existing.Phase == PartitionStepRunning {
+		return nil, fmt.Errorf("StatefulSet %s 已有进行中的分区下调", name)
+	}
+
+	// 后台任务脱离请求生命周期，但保留登录用户身份以继续使用用户模拟
+	runCtx := context.Background()
+	identity := auth.IdentityFromContext(ctx)
+	if identity != nil {
+		runCtx = auth.WithIdentity(runCtx, identity)
+	}
+	runCtx, cancel := context.WithCancel(runCtx)
+
+	now := time.Now()
+	task := &PartitionStepDown{
+		Cluster:     clusterName,
+		Namespace:   namespace,
+		StatefulSet: name,
+		From:        current,
+		Target:      target,
+		Partition:   current,
+		Phase:       PartitionStepRunning,
+		StartedAt:   now,
+		UpdatedAt:   now,
+		cancel:      cancel,
+	}
+	if identity != nil {
+		task.StartedBy = identity.Username
+	}
+	s.stepDowns[key] = task
+
+	go s.runPartitionStepDown(runCtx, client, task, timeout)
+	out := *task
+	return &out, nil
+}
+
+// GetPartitionStepDown 获取最近一次分区下调任务
+func (s *StatefulSetService) GetPartitionStepDown(clusterName, namespace, name string) (*PartitionStepDown, error) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+	task, ok := s.stepDowns[partitionStepKey(clusterName, namespace, name)]
+	if !ok {
+		return nil, fmt.Errorf("StatefulSet %s 没有分区下调任务", name)
+	}
+	out := *task
+	return &out, nil
+}
+
+// CancelPartitionStepDown 停止进行中的分区下调，已下调的 partition 保持不变
+func (s *StatefulSetService) CancelPartitionStepDown(clusterName, namespace, name string) (*PartitionStepDown, error) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+	task, ok := s.stepDowns[partitionStepKey(clusterName, namespace, name)]
+	if !ok || task.Phase != PartitionStepRunning {
+		return nil, fmt.Errorf("StatefulSet %s 没有进行中的分区下调", name)
+	}
+	task.cancel()
+	s.finishStepDownLocked(task, PartitionStepCancelled, fmt.Sprintf("已取消，partition 停留在 %d", task.Partition))
+	out := *task
+	return &out, nil
+}
+
+func (s *StatefulSetService) finishStepDownLocked(task *PartitionStepDown, phase, message string) {
+	if task.Phase != PartitionStepRunning {
+		return
+	}
+	now := time.Now()
+	task.Phase = phase
+	task.Message = message
+	task.UpdatedAt = now
+	task.FinishedAt = &now
+}
+
+func (s *StatefulSetService) updateStepDown(task *PartitionStepDown, update func()) {
+	s.stepMutex.Lock()
+	defer s.stepMutex.Unlock()
+	update()
+	task.UpdatedAt = time.Now()
+}
+
+func (s *StatefulSetService) runPartitionStepDown(ctx context.Context, client kubernetes.Interface, task *PartitionStepDown, timeout time.Duration) {
+	defer task.cancel()
+	statefulSets := client.AppsV1().StatefulSets(task.Namespace)
+
+	fail := func(err error) {
+		logger.Error("StatefulSet 分区下调失败", "statefulset", task.StatefulSet, "error", err.Error())
+		s.updateStepDown(task, func() { s.finishStepDownLocked(task, PartitionStepFailed, err.Error()) })
+	}
+
+	// waitSettled 等待序号不小于 from 的 Pod 全部更新并就绪
+	waitSettled := func(from int32) error {
+		deadline := time.Now().Add(timeout)
+		for {
+			sts, err := statefulSets.Get(ctx, task.StatefulSet, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			pods, err := s.listStatefulSetPods(ctx, client, sts)
+			if err != nil {
+				return err
+			}
+			if ordinalsSettled(sts, buildRevisionStatus(sts, pods), from) {
+				return nil
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("序号 %d 及以上的 Pod 未在 %s 内更新并就绪", from, timeout)
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(partitionPollInterval):
+			}
+		}
+	}
+
+	partition := task.From
+	for {
+		if err := waitSettled(partition); err != nil {
+			if ctx.Err() == nil {
+				fail(err)
+			}
+			return
+		}
+		if partition <= task.Target {
+			break
+		}
+		partition--
+		patch := []byte(fmt.Sprintf(`{"spec":{"updateStrategy":{"rollingUpdate":{"partition":%d}}}}`, partition))
+		if _, err := statefulSets.Patch(ctx, task.StatefulSet, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			if ctx.Err() == nil {
+				fail(fmt.Errorf("下调 partition 到 %d 失败: %w", partition, err))
+			}
+			return
+		}
+		s.updateStepDown(task, func() {
+			task.Partition = partition
+			task.Message = fmt.Sprintf("partition 已下调到 %d，等待 %s-%d 就绪", partition, task.StatefulSet, partition)
+		})
+	}
+
+	s.updateStepDown(task, func() {
+		s.finishStepDownLocked(task, PartitionStepSucceeded, fmt.Sprintf("partition 已下调到 %d", task.Target))
+	})
+	logger.Info("StatefulSet 分区下调完成", "statefulset", task.StatefulSet, "partition", task.Target)
+}
@@ -0,0 +1,194 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestPodOrdinal(t *testing.T) {
+	cases := []struct {
+		pod     string
+		ordinal int
+		ok      bool
+	}{
+		{"db-0", 0, true},
+		{"db-12", 12, true},
+		{"db-replica-1", 0, false},
+		{"other-1", 0, false},
+		{"db-", 0, false},
+	}
+	for _, tc := range cases {
+		ordinal, ok := podOrdinal("db", tc.pod)
+		if ordinal != tc.ordinal || ok != tc.ok {
+			t.Errorf("podOrdinal(%s) = %d, %v; want %d, %v", tc.pod, ordinal, ok, tc.ordinal, tc.ok)
+		}
+	}
+}
+
+func statefulSetPod(name, revision string, ready bool) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{appsv1.StatefulSetRevisionLabel: revision}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestBuildRevisionStatus(t *testing.T) {
+	replicas, partition := int32(3), int32(2)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 2},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{ObservedGeneration: 2, CurrentRevision: "db-old", UpdateRevision: "db-new"},
+	}
+	pods := []corev1.Pod{
+		statefulSetPod("db-2", "db-new", false),
+		statefulSetPod("db-0", "db-old", true),
+		statefulSetPod("db-1", "db-old", true),
+	}
+
+	status := buildRevisionStatus(sts, pods)
+	if status.Partition != 2 || status.UpdateStrategy != "RollingUpdate" || len(status.Ordinals) != 3 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if status.Ordinals[0].Pod != "db-0" || status.Ordinals[0].Updated || !status.Ordinals[2].Updated {
+		t.Fatalf("ordinals not sorted or revisions wrong: %+v", status.Ordinals)
+	}
+	if ordinalsSettled(sts, status, 2) {
+		t.Fatal("db-2 is not ready yet")
+	}
+	pods[0] = statefulSetPod("db-2", "db-new", true)
+	if !ordinalsSettled(sts, buildRevisionStatus(sts, pods), 2) {
+		t.Fatal("ordinal 2 should be settled")
+	}
+	if ordinalsSettled(sts, buildRevisionStatus(sts, pods), 1) {
+		t.Fatal("ordinal 1 is still on the old revision")
+	}
+}
+
+// fakeStatefulSetAPI 模拟 StatefulSet 控制器：partition 下调后立即把对应序号更新为新版本
+type fakeStatefulSetAPI struct {
+	mutex     sync.Mutex
+	replicas  int32
+	partition int32
+	patches   []int32
+}
+
+func (f *fakeStatefulSetAPI) statefulSet() appsv1.StatefulSet {
+	partition := f.partition
+	replicas := f.replicas
+	return appsv1.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{CurrentRevision: "db-old", UpdateRevision: "db-new"},
+	}
+}
+
+func (f *fakeStatefulSetAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/apis/apps/v1/namespaces/prod/statefulsets/db" && r.Method == http.MethodPatch:
+		body, _ := io.ReadAll(r.Body)
+		var patch appsv1.StatefulSet
+		json.Unmarshal(body, &patch)
+		f.partition = *patch.Spec.UpdateStrategy.RollingUpdate.Partition
+		f.patches = append(f.patches, f.partition)
+		json.NewEncoder(w).Encode(f.statefulSet())
+	case r.URL.Path == "/apis/apps/v1/namespaces/prod/statefulsets/db":
+		json.NewEncoder(w).Encode(f.statefulSet())
+	case r.URL.Path == "/api/v1/namespaces/prod/pods":
+		list := corev1.PodList{TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"}}
+		for i := int32(0); i < f.replicas; i++ {
+			revision := "db-old"
+			if i >= f.partition {
+				revision = "db-new"
+			}
+			list.Items = append(list.Items, statefulSetPod(fmt.Sprintf("db-%d", i), revision, true))
+		}
+		json.NewEncoder(w).Encode(list)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestPartitionStepDown(t *testing.T) {
+	prevInterval := partitionPollInterval
+	partitionPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { partitionPollInterval = prevInterval })
+
+	api := &fakeStatefulSetAPI{replicas: 3, partition: 3}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	cm := NewClientManager()
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+	if err != nil {
+		t.Fatalf("NewForConfig: %v", err)
+	}
+	cm.clients["dev"] = client
+	service := NewStatefulSetService(cm)
+
+	if _, err := service.StartPartitionStepDown(context.Background(), "dev", "prod", "db", 3, 0); err == nil {
+		t.Fatal("target equal to the current partition should be rejected")
+	}
+	if _, err := service.StartPartitionStepDown(context.Background(), "dev", "prod", "db", 1, time.Second); err != nil {
+		t.Fatalf("StartPartitionStepDown: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		task, err := service.GetPartitionStepDown("dev", "prod", "db")
+		if err != nil {
+			t.Fatalf("GetPartitionStepDown: %v", err)
+		}
+		if task.Phase != PartitionStepRunning {
+			if task.Phase != PartitionStepSucceeded || task.Partition != 1 {
+				t.Fatalf("unexpected task: %+v", task)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("step-down did not finish: %+v", task)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if len(api.patches) != 2 || api.patches[0] != 2 || api.patches[1] != 1 {
+		t.Fatalf("partition should step down one ordinal at a time, got %v", api.patches)
+	}
+}
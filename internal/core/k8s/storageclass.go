@@ -34,7 +34,7 @@ type StorageClassInfo struct {
 
 // ListStorageClasses 获取 StorageClass 列表
 func (s *StorageClassService) ListStorageClasses(ctx context.Context, clusterName string) ([]StorageClassInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (s *StorageClassService) ListStorageClasses(ctx context.Context, clusterNam
 
 // GetStorageClass 获取 StorageClass 详情
 func (s *StorageClassService) GetStorageClass(ctx context.Context, clusterName, name string) (*StorageClassInfo, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -7,11 +7,15 @@ import (
 	"sort"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
 )
 
 // TopologyNode 拓扑节点
@@ -77,12 +81,13 @@ func nodeID(nodeType, namespace, name string) string {
 
 // GetTrafficTopology 获取命名空间或全集群流量拓扑
 func (s *TrafficTopologyService) GetTrafficTopology(ctx context.Context, clusterName, namespace string) (*TrafficTopology, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
 
-	nsList, err := listTargetNamespaces(ctx, client, namespace)
+	reader := s.newTopologyReader(ctx, client, clusterName)
+	nsList, err := reader.namespaces(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -116,11 +121,11 @@ func (s *TrafficTopologyService) GetTrafficTopology(ctx context.Context, cluster
 	podsByNS := map[string][]corev1.Pod{}
 
 	for _, ns := range nsList {
-		services, err := client.CoreV1().Services(ns).List(ctx, metav1.ListOptions{})
+		services, err := reader.services(ns)
 		if err != nil {
 			return nil, fmt.Errorf("获取 Service 列表失败: %w", err)
 		}
-		for _, svc := range services.Items {
+		for _, svc := range services {
 			serviceByKey[ns+"/"+svc.Name] = svc
 			ports := make([]string, 0, len(svc.Spec.Ports))
 			for _, p := range svc.Spec.Ports {
@@ -134,11 +139,11 @@ func (s *TrafficTopologyService) GetTrafficTopology(ctx context.Context, cluster
 			})
 		}
 
-		ingresses, err := client.NetworkingV1().Ingresses(ns).List(ctx, metav1.ListOptions{})
+		ingresses, err := reader.ingresses(ns)
 		if err != nil {
 			return nil, fmt.Errorf("获取 Ingress 列表失败: %w", err)
 		}
-		for _, ing := range ingresses.Items {
+		for _, ing := range ingresses {
 			addNode(TopologyNode{
 				ID: nodeID("ingress", ns, ing.Name), Type: "ingress", Name: ing.Name, Namespace: ns,
 				Extra: map[string]any{"ingressClass": derefString(ing.Spec.IngressClassName)},
@@ -167,14 +172,14 @@ func (s *TrafficTopologyService) GetTrafficTopology(ctx context.Context, cluster
 			}
 		}
 
-		podList, err := client.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		pods, err := reader.pods(ns)
 		if err != nil {
 			return nil, fmt.Errorf("获取 Pod 列表失败: %w", err)
 		}
-		podsByNS[ns] = podList.Items
-		for i := range podList.Items {
-			pod := &podList.Items[i]
-			wl := resolvePodWorkload(ctx, client, pod)
+		podsByNS[ns] = pods
+		for i := range pods {
+			pod := &pods[i]
+			wl := resolvePodWorkload(reader, pod)
 			if wl.name != "" {
 				workloadByPod[ns+"/"+pod.Name] = wl
 				addNode(TopologyNode{
@@ -275,11 +280,56 @@ type serviceRef struct {
 	evidence  string
 }
 
-func listTargetNamespaces(ctx context.Context, client *kubernetes.Clientset, namespace string) ([]string, error) {
+// topologyReader 读取拓扑所需的资源：可用时从集群共享缓存读取，
+// 否则（用户模拟、缓存未同步）直接访问 API Server。缓存对象只读。
+type topologyReader struct {
+	ctx              context.Context
+	client           *kubernetes.Clientset
+	namespaceLister  corelisters.NamespaceLister
+	serviceLister    corelisters.ServiceLister
+	podLister        corelisters.PodLister
+	ingressLister    networkinglisters.IngressLister
+	replicaSetLister appslisters.ReplicaSetLister
+}
+
+func (s *TrafficTopologyService) newTopologyReader(ctx context.Context, client *kubernetes.Clientset, clusterName string) *topologyReader {
+	r := &topologyReader{ctx: ctx, client: client}
+	if lister, ok := s.clientManager.NamespaceLister(ctx, clusterName); ok {
+		r.namespaceLister = lister
+	}
+	if lister, ok := s.clientManager.ServiceLister(ctx, clusterName); ok {
+		r.serviceLister = lister
+	}
+	if lister, ok := s.clientManager.PodLister(ctx, clusterName); ok {
+		r.podLister = lister
+	}
+	if lister, ok := s.clientManager.IngressLister(ctx, clusterName); ok {
+		r.ingressLister = lister
+	}
+	if lister, ok := s.clientManager.ReplicaSetLister(ctx, clusterName); ok {
+		r.replicaSetLister = lister
+	}
+	return r
+}
+
+// namespaces 返回目标命名空间，namespace 为空或 all 时返回全部
+func (r *topologyReader) namespaces(namespace string) ([]string, error) {
 	if namespace != "" && namespace != "all" {
 		return []string{namespace}, nil
 	}
-	list, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if r.namespaceLister != nil {
+		cached, err := r.namespaceLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		sortObjects(cached)
+		names := make([]string, 0, len(cached))
+		for _, item := range cached {
+			names = append(names, item.Name)
+		}
+		return names, nil
+	}
+	list, err := r.client.CoreV1().Namespaces().List(r.ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -290,11 +340,67 @@ func listTargetNamespaces(ctx context.Context, client *kubernetes.Clientset, nam
 	return names, nil
 }
 
-func resolvePodWorkload(ctx context.Context, client *kubernetes.Clientset, pod *corev1.Pod) workloadRef {
+func (r *topologyReader) services(namespace string) ([]corev1.Service, error) {
+	if r.serviceLister != nil {
+		return derefCached(r.serviceLister.Services(namespace).List(labels.Everything()))
+	}
+	list, err := r.client.CoreV1().Services(namespace).List(r.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r *topologyReader) ingresses(namespace string) ([]networkingv1.Ingress, error) {
+	if r.ingressLister != nil {
+		return derefCached(r.ingressLister.Ingresses(namespace).List(labels.Everything()))
+	}
+	list, err := r.client.NetworkingV1().Ingresses(namespace).List(r.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r *topologyReader) pods(namespace string) ([]corev1.Pod, error) {
+	if r.podLister != nil {
+		return derefCached(r.podLister.Pods(namespace).List(labels.Everything()))
+	}
+	list, err := r.client.CoreV1().Pods(namespace).List(r.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (r *topologyReader) replicaSet(namespace, name string) (*appsv1.ReplicaSet, error) {
+	if r.replicaSetLister != nil {
+		return r.replicaSetLister.ReplicaSets(namespace).Get(name)
+	}
+	return r.client.AppsV1().ReplicaSets(namespace).Get(r.ctx, name, metav1.GetOptions{})
+}
+
+// derefCached 将缓存对象转为值切片并按名称排序，结果与缓存共享底层数据，只能读取
+func derefCached[T any, PT interface {
+	*T
+	metav1.Object
+}](cached []PT, err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	sortObjects(cached)
+	items := make([]T, 0, len(cached))
+	for _, item := range cached {
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+func resolvePodWorkload(reader *topologyReader, pod *corev1.Pod) workloadRef {
 	for _, owner := range pod.OwnerReferences {
 		switch owner.Kind {
 		case "ReplicaSet":
-			rs, err := client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+			rs, err := reader.replicaSet(pod.Namespace, owner.Name)
 			if err != nil {
 				continue
 			}
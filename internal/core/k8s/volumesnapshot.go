@@ -0,0 +1,297 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// volumeSnapshotGroup CSI 快照 CRD 所在的 API 组，需集群安装 external-snapshotter
+const volumeSnapshotGroup = "snapshot.storage.k8s.io"
+
+const defaultSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+
+var (
+	volumeSnapshotGVR      = schema.GroupVersionResource{Group: volumeSnapshotGroup, Version: "v1", Resource: "volumesnapshots"}
+	volumeSnapshotClassGVR = schema.GroupVersionResource{Group: volumeSnapshotGroup, Version: "v1", Resource: "volumesnapshotclasses"}
+)
+
+// VolumeSnapshotService VolumeSnapshot / VolumeSnapshotClass 管理服务（通过动态客户端访问 CSI 快照 CRD）
+type VolumeSnapshotService struct {
+	clientManager *ClientManager
+	pvcService    *PVCService
+}
+
+// NewVolumeSnapshotService 创建 VolumeSnapshot 服务
+func NewVolumeSnapshotService(clientManager *ClientManager, pvcService *PVCService) *VolumeSnapshotService {
+	return &VolumeSnapshotService{clientManager: clientManager, pvcService: pvcService}
+}
+
+// VolumeSnapshotInfo VolumeSnapshot 摘要
+type VolumeSnapshotInfo struct {
+	Name                    string    `json:"name"`
+	Namespace               string    `json:"namespace"`
+	SourcePVC               string    `json:"sourcePVC,omitempty"`
+	VolumeSnapshotClassName string    `json:"volumeSnapshotClassName,omitempty"`
+	SnapshotContentName     string    `json:"snapshotContentName,omitempty"`
+	ReadyToUse              bool      `json:"readyToUse"`
+	RestoreSize             string    `json:"restoreSize,omitempty"`
+	Error                   string    `json:"error,omitempty"`
+	CreationTime            time.Time `json:"creationTime"`
+}
+
+// VolumeSnapshotClassInfo VolumeSnapshotClass 摘要
+type VolumeSnapshotClassInfo struct {
+	Name           string    `json:"name"`
+	Driver         string    `json:"driver"`
+	DeletionPolicy string    `json:"deletionPolicy"`
+	IsDefault      bool      `json:"isDefault"`
+	CreationTime   time.Time `json:"creationTime"`
+}
+
+// CreateVolumeSnapshotRequest 创建快照请求
+type CreateVolumeSnapshotRequest struct {
+	Name                    string `json:"name" binding:"required"`
+	SourcePVC               string `json:"sourcePVC" binding:"required"`
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// RestoreVolumeSnapshotRequest 从快照恢复到新 PVC 的请求，未指定的容量与 StorageClass 沿用快照及源 PVC
+type RestoreVolumeSnapshotRequest struct {
+	Name             string            `json:"name" binding:"required"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	StorageClassName string            `json:"storageClassName,omitempty"`
+	AccessModes      []string          `json:"accessModes,omitempty"`
+	Storage          string            `json:"storage,omitempty"`
+}
+
+// volumeSnapshot 与 volumeSnapshotClass 只包含本服务用到的 CRD 字段
+type volumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Source struct {
+			PersistentVolumeClaimName *string `json:"persistentVolumeClaimName,omitempty"`
+		} `json:"source"`
+		VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+	} `json:"spec"`
+	Status *struct {
+		BoundVolumeSnapshotContentName *string            `json:"boundVolumeSnapshotContentName,omitempty"`
+		ReadyToUse                     *bool              `json:"readyToUse,omitempty"`
+		RestoreSize                    *resource.Quantity `json:"restoreSize,omitempty"`
+		Error                          *struct {
+			Message *string `json:"message,omitempty"`
+		} `json:"error,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+type volumeSnapshotClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Driver            string `json:"driver"`
+	DeletionPolicy    string `json:"deletionPolicy"`
+}
+
+func convertVolumeSnapshotInfo(obj *unstructured.Unstructured) (VolumeSnapshotInfo, error) {
+	var snap volumeSnapshot
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &snap); err != nil {
+		return VolumeSnapshotInfo{}, fmt.Errorf("解析 VolumeSnapshot %s 失败: %w", obj.GetName(), err)
+	}
+	info := VolumeSnapshotInfo{
+		Name:         snap.Name,
+		Namespace:    snap.Namespace,
+		CreationTime: snap.CreationTimestamp.Time,
+	}
+	if snap.Spec.Source.PersistentVolumeClaimName != nil {
+		info.SourcePVC = *snap.Spec.Source.PersistentVolumeClaimName
+	}
+	if snap.Spec.VolumeSnapshotClassName != nil {
+		info.VolumeSnapshotClassName = *snap.Spec.VolumeSnapshotClassName
+	}
+	if st := snap.Status; st != nil {
+		if st.BoundVolumeSnapshotContentName != nil {
+			info.SnapshotContentName = *st.BoundVolumeSnapshotContentName
+		}
+		info.ReadyToUse = st.ReadyToUse != nil && *st.ReadyToUse
+		if st.RestoreSize != nil {
+			info.RestoreSize = st.RestoreSize.String()
+		}
+		if st.Error != nil && st.Error.Message != nil {
+			info.Error = *st.Error.Message
+		}
+	}
+	return info, nil
+}
+
+func convertVolumeSnapshotClassInfo(obj *unstructured.Unstructured) (VolumeSnapshotClassInfo, error) {
+	var class volumeSnapshotClass
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &class); err != nil {
+		return VolumeSnapshotClassInfo{}, fmt.Errorf("解析 VolumeSnapshotClass %s 失败: %w", obj.GetName(), err)
+	}
+	return VolumeSnapshotClassInfo{
+		Name:           class.Name,
+		Driver:         class.Driver,
+		DeletionPolicy: class.DeletionPolicy,
+		IsDefault:      class.Annotations[defaultSnapshotClassAnnotation] == "true",
+		CreationTime:   class.CreationTimestamp.Time,
+	}, nil
+}
+
+// ListVolumeSnapshotClasses 获取 VolumeSnapshotClass 列表
+func (s *VolumeSnapshotService) ListVolumeSnapshotClasses(ctx context.Context, clusterName string) ([]VolumeSnapshotClassInfo, error) {
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.Resource(volumeSnapshotClassGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 VolumeSnapshotClass 列表失败（集群是否安装了快照 CRD）: %w", err)
+	}
+	result := make([]VolumeSnapshotClassInfo, 0, len(list.Items))
+	for i := range list.Items {
+		info, err := convertVolumeSnapshotClassInfo(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// ListVolumeSnapshots 获取 VolumeSnapshot 列表，namespace 为空或 all 时列出全部命名空间
+func (s *VolumeSnapshotService) ListVolumeSnapshots(ctx context.Context, clusterName, namespace string) ([]VolumeSnapshotInfo, error) {
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "all" {
+		namespace = ""
+	}
+	list, err := client.Resource(volumeSnapshotGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 VolumeSnapshot 列表失败（集群是否安装了快照 CRD）: %w", err)
+	}
+	result := make([]VolumeSnapshotInfo, 0, len(list.Items))
+	for i := range list.Items {
+		info, err := convertVolumeSnapshotInfo(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreationTime.After(result[j].CreationTime)
+	})
+	return result, nil
+}
+
+// GetVolumeSnapshot 获取 VolumeSnapshot 详情
+func (s *VolumeSnapshotService) GetVolumeSnapshot(ctx context.Context, clusterName, namespace, name string) (*VolumeSnapshotInfo, error) {
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Resource(volumeSnapshotGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取 VolumeSnapshot 失败: %w", err)
+	}
+	info, err := convertVolumeSnapshotInfo(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// CreateVolumeSnapshot 为 PVC 创建快照，未指定快照类时使用集群默认的 VolumeSnapshotClass
+func (s *VolumeSnapshotService) CreateVolumeSnapshot(ctx context.Context, clusterName, namespace string, req CreateVolumeSnapshotRequest) (*VolumeSnapshotInfo, error) {
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, req.SourcePVC, metav1.GetOptions{}); err != nil {
+		return nil, fmt.Errorf("获取源 PVC 失败: %w", err)
+	}
+	dynamicClient, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]any{
+		"source": map[string]any{"persistentVolumeClaimName": req.SourcePVC},
+	}
+	if req.VolumeSnapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = req.VolumeSnapshotClassName
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": volumeSnapshotGVR.GroupVersion().String(),
+		"kind":       "VolumeSnapshot",
+		"metadata":   map[string]any{"name": req.Name, "namespace": namespace},
+		"spec":       spec,
+	}}
+	created, err := dynamicClient.Resource(volumeSnapshotGVR).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("创建 VolumeSnapshot 失败: %w", err)
+	}
+	info, err := convertVolumeSnapshotInfo(created)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// RestoreVolumeSnapshot 以快照为数据源创建新 PVC，快照需已 readyToUse
+func (s *VolumeSnapshotService) RestoreVolumeSnapshot(ctx context.Context, clusterName, namespace, name string, req RestoreVolumeSnapshotRequest) (*PVCInfo, error) {
+	snapshot, err := s.GetVolumeSnapshot(ctx, clusterName, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !snapshot.ReadyToUse {
+		return nil, fmt.Errorf("VolumeSnapshot %s 尚未就绪", name)
+	}
+
+	create := CreatePVCRequest{
+		Name:             req.Name,
+		Labels:           req.Labels,
+		StorageClassName: req.StorageClassName,
+		AccessModes:      req.AccessModes,
+		Storage:          req.Storage,
+		DataSource:       &PVCDataSource{Kind: PVCDataSourceSnapshot, Name: name},
+	}
+	if create.Storage == "" {
+		create.Storage = snapshot.RestoreSize
+	}
+	// 源 PVC 仍存在时沿用其 StorageClass 与访问模式
+	if snapshot.SourcePVC != "" && (create.StorageClassName == "" || len(create.AccessModes) == 0) {
+		if source, err := s.pvcService.GetPVC(ctx, clusterName, namespace, snapshot.SourcePVC); err == nil {
+			if create.StorageClassName == "" {
+				create.StorageClassName = source.StorageClassName
+			}
+			if len(create.AccessModes) == 0 {
+				create.AccessModes = source.AccessModes
+			}
+		}
+	}
+	return s.pvcService.CreatePVC(ctx, clusterName, namespace, create)
+}
+
+// DeleteVolumeSnapshot 删除 VolumeSnapshot，底层快照是否保留取决于快照类的 deletionPolicy
+func (s *VolumeSnapshotService) DeleteVolumeSnapshot(ctx context.Context, clusterName, namespace, name string) error {
+	client, err := s.clientManager.GetDynamicClientFor(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := client.Resource(volumeSnapshotGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("删除 VolumeSnapshot 失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestConvertVolumeSnapshotInfo(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshot",
+		"metadata":   map[string]any{"name": "db-before-migration", "namespace": "prod"},
+		"spec": map[string]any{
+			"source":                  map[string]any{"persistentVolumeClaimName": "data-db-0"},
+			"volumeSnapshotClassName": "csi-snapclass",
+		},
+		"status": map[string]any{
+			"boundVolumeSnapshotContentName": "snapcontent-123",
+			"readyToUse":                     true,
+			"restoreSize":                    "10Gi",
+		},
+	}}
+	info, err := convertVolumeSnapshotInfo(obj)
+	if err != nil {
+		t.Fatalf("convertVolumeSnapshotInfo: %v", err)
+	}
+	if info.SourcePVC != "data-db-0" || info.VolumeSnapshotClassName != "csi-snapclass" ||
+		info.SnapshotContentName != "snapcontent-123" || !info.ReadyToUse || info.RestoreSize != "10Gi" {
+		t.Fatalf("unexpected snapshot info: %+v", info)
+	}
+
+	class, err := convertVolumeSnapshotClassInfo(&unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshotClass",
+		"metadata": map[string]any{
+			"name":        "csi-snapclass",
+			"annotations": map[string]any{defaultSnapshotClassAnnotation: "true"},
+		},
+		"driver":         "ebs.csi.aws.com",
+		"deletionPolicy": "Retain",
+	}})
+	if err != nil {
+		t.Fatalf("convertVolumeSnapshotClassInfo: %v", err)
+	}
+	if !class.IsDefault || class.Driver != "ebs.csi.aws.com" || class.DeletionPolicy != "Retain" {
+		t.Fatalf("unexpected snapshot class info: %+v", class)
+	}
+}
@@ -134,7 +134,7 @@ type WorkloadAlert struct {
 
 // GetDeploymentMetrics 获取 Deployment 应用级监控汇总
 func (s *PodMetricsService) GetDeploymentMetrics(ctx context.Context, clusterName, namespace, deploymentName string) (*WorkloadMetrics, error) {
-	client, err := s.clientManager.GetClient(clusterName)
+	client, err := s.clientManager.GetClientFor(ctx, clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,127 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"kube-tide/internal/utils/logger"
+)
+
+// castHeader asciicast v2 头部
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder 将一个终端会话写为 asciicast v2 事件流：
+// [耗时秒数, "o", 输出] / [耗时秒数, "i", 输入] / [耗时秒数, "r", "列x行"]
+type Recorder struct {
+	store       *Store
+	file        *os.File
+	meta        Meta
+	recordInput bool
+	pending     []byte // 输出中被截断的 UTF-8 字符，等下一段输出补齐
+	closed      bool
+	failed      bool
+	mutex       sync.Mutex
+}
+
+// ID 录像 ID
+func (r *Recorder) ID() string {
+	return r.meta.ID
+}
+
+// Output 记录容器输出
+func (r *Recorder) Output(data []byte) {
+	r.mutex.Lock()
+	buf := append(r.pending, data...)
+	cut := completeUTF8(buf)
+	r.pending = append([]byte(nil), buf[cut:]...)
+	r.mutex.Unlock()
+	if cut > 0 {
+		r.event("o", string(buf[:cut]))
+	}
+}
+
+// completeUTF8 返回 buf 中以完整 UTF-8 字符结尾的前缀长度
+func completeUTF8(buf []byte) int {
+	for i := len(buf) - 1; i >= 0 && i >= len(buf)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(buf[i]) {
+			if !utf8.FullRune(buf[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(buf)
+}
+
+// Input 记录用户输入（仅在配置 record_input 时）
+func (r *Recorder) Input(data []byte) {
+	if r.recordInput {
+		r.event("i", string(data))
+	}
+}
+
+// Resize 记录终端尺寸变化
+func (r *Recorder) Resize(cols, rows uint16) {
+	r.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// Close 结束录制并更新元数据
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	r.meta.EndedAt = time.Now()
+	r.meta.Duration = r.meta.EndedAt.Sub(r.meta.StartedAt).Seconds()
+	if info, err := r.file.Stat(); err == nil {
+		r.meta.Size = info.Size()
+	}
+	closeErr := r.file.Close()
+	if err := r.store.saveMeta(r.meta); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func (r *Recorder) event(kind, data string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed || r.failed {
+		return
+	}
+	elapsed := time.Since(r.meta.StartedAt).Seconds()
+	if err := r.writeLine([]any{elapsed, kind, data}); err != nil {
+		// 录制失败不中断终端会话，只记录一次错误
+		r.failed = true
+		logger.Error("failed to write terminal recording", "id", r.meta.ID, "error", err.Error())
+	}
+}
+
+func (r *Recorder) writeLine(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode recording event: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := r.file.Write(data); err != nil {
+		return fmt.Errorf("failed to write recording event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package recording
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"kube-tide/internal/utils/logger"
+)
+
+// ErrRecordingNotFound 录像不存在
+var ErrRecordingNotFound = errors.New("recording not found")
+
+// Wildcard 规则中匹配全部命名空间
+const Wildcard = "*"
+
+var idPattern = regexp.MustCompile(`^\d{8}T\d{6}-[0-9a-f]{8}$`)
+
+// Rule 录像规则：匹配的集群/命名空间中的终端会话会被录制
+type Rule struct {
+	Cluster    string   `json:"cluster"`
+	Namespaces []string `json:"namespaces"` // 为空或包含 "*" 表示整个集群
+}
+
+// Meta 录像元数据，保存在 <id>.json 中
+type Meta struct {
+	ID        string    `json:"id"`
+	User      string    `json:"user"`
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Container string    `json:"container"`
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt,omitzero"`
+	Duration  float64   `json:"duration"` // 秒
+	Size      int64     `json:"size"`     // .cast 文件字节数
+}
+
+// Filter 录像列表过滤条件
+type Filter struct {
+	User      string
+	Cluster   string
+	Namespace string
+	Pod       string
+}
+
+// Store 录像文件存储，目录下每个会话对应 <id>.cast（asciicast v2）与 <id>.json
+type Store struct {
+	dir         string
+	recordInput bool
+	rules       []Rule
+	mutex       sync.RWMutex
+}
+
+// NewStore 创建录像存储并加载 rules.json
+func NewStore(dir string, recordInput bool) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	s := &Store{dir: dir, recordInput: recordInput, rules: []Rule{}}
+	data, err := os.ReadFile(s.rulesPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read recording rules: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &s.rules); err != nil {
+			return nil, fmt.Errorf("failed to parse recording rules: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *Store) rulesPath() string {
+	return filepath.Join(s.dir, "rules.json")
+}
+
+// Rules 返回当前录像规则
+func (s *Store) Rules() []Rule {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return append([]Rule{}, s.rules...)
+}
+
+// SetRules 替换录像规则并持久化
+func (s *Store) SetRules(rules []Rule) error {
+	for _, rule := range rules {
+		if rule.Cluster == "" {
+			return fmt.Errorf("rule cluster is required")
+		}
+	}
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording rules: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	tmp := s.rulesPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write recording rules: %w", err)
+	}
+	if err := os.Rename(tmp, s.rulesPath()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace recording rules: %w", err)
+	}
+	s.rules = append([]Rule{}, rules...)
+	return nil
+}
+
+// ShouldRecord 判断集群/命名空间中的会话是否需要录制
+func (s *Store) ShouldRecord(cluster, namespace string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, rule := range s.rules {
+		if rule.Cluster != cluster && rule.Cluster != Wildcard {
+			continue
+		}
+		if len(rule.Namespaces) == 0 {
+			return true
+		}
+		for _, ns := range rule.Namespaces {
+			if ns == Wildcard || ns == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Start 开始录制一个终端会话
+func (s *Store) Start(meta Meta, width, height int) (*Recorder, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate recording id: %w", err)
+	}
+	meta.StartedAt = time.Now()
+	meta.ID = meta.StartedAt.UTC().Format("20060102T150405") + "-" + hex.EncodeToString(suffix)
+
+	file, err := os.OpenFile(s.castPath(meta.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording file: %w", err)
+	}
+	r := &Recorder{store: s, file: file, meta: meta, recordInput: s.recordInput}
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: meta.StartedAt.Unix(),
+		Title:     fmt.Sprintf("%s/%s/%s (%s) by %s", meta.Cluster, meta.Namespace, meta.Pod, meta.Container, meta.User),
+		Env:       map[string]string{"TERM": "xterm-256color", "SHELL": "/bin/sh"},
+	}
+	if err := r.writeLine(header); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	if err := s.saveMeta(meta); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return r, nil
+}
+
+// List 返回满足条件的录像，按开始时间倒序
+func (s *Store) List(filter Filter) ([]Meta, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording directory: %w", err)
+	}
+	items := make([]Meta, 0)
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || !idPattern.MatchString(id) {
+			continue
+		}
+		meta, err := s.Get(id)
+		if err != nil {
+			continue
+		}
+		if (filter.User != "" && meta.User != filter.User) ||
+			(filter.Cluster != "" && meta.Cluster != filter.Cluster) ||
+			(filter.Namespace != "" && meta.Namespace != filter.Namespace) ||
+			(filter.Pod != "" && meta.Pod != filter.Pod) {
+			continue
+		}
+		items = append(items, meta)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].StartedAt.After(items[j].StartedAt)
+	})
+	return items, nil
+}
+
+// Get 读取录像元数据
+func (s *Store) Get(id string) (Meta, error) {
+	if !idPattern.MatchString(id) {
+		return Meta{}, ErrRecordingNotFound
+	}
+	data, err := os.ReadFile(s.metaPath(id))
+	if os.IsNotExist(err) {
+		return Meta{}, ErrRecordingNotFound
+	}
+	if err != nil {
+		return Meta{}, fmt.Errorf("failed to read recording metadata: %w", err)
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Meta{}, fmt.Errorf("failed to parse recording metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// CastPath 返回录像文件路径，用于下载
+func (s *Store) CastPath(id string) (string, error) {
+	if _, err := s.Get(id); err != nil {
+		return "", err
+	}
+	return s.castPath(id), nil
+}
+
+// Prune 删除早于 maxAge 的录像
+func (s *Store) Prune(maxAge time.Duration) {
+	if maxAge <= 0 {
+		return
+	}
+	items, err := s.List(Filter{})
+	if err != nil {
+		logger.Warn("failed to list recordings for pruning", "error", err.Error())
+		return
+	}
+	cutoff := time.Now().Add(-maxAge)
+	for _, meta := range items {
+		if meta.StartedAt.Before(cutoff) {
+			os.Remove(s.castPath(meta.ID))
+			os.Remove(s.metaPath(meta.ID))
+		}
+	}
+}
+
+func (s *Store) castPath(id string) string {
+	return filepath.Join(s.dir, id+".cast")
+}
+
+func (s *Store) metaPath(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *Store) saveMeta(meta Meta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording metadata: %w", err)
+	}
+	if err := os.WriteFile(s.metaPath(meta.ID), data, 0600); err != nil {
+		return fmt.Errorf("failed to write recording metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestRecorderWritesAsciicast(t *testing.T) {
+	store, err := NewStore(t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if store.ShouldRecord("prod", "default") {
+		t.Fatal("no rules must mean no recording")
+	}
+	if err := store.SetRules([]Rule{{Cluster: "prod"}, {Cluster: "dev", Namespaces: []string{"payments"}}}); err != nil {
+		t.Fatalf("SetRules: %v", err)
+	}
+	if !store.ShouldRecord("prod", "default") || !store.ShouldRecord("dev", "payments") || store.ShouldRecord("dev", "default") {
+		t.Fatal("unexpected rule evaluation")
+	}
+
+	recorder, err := store.Start(Meta{User: "alice", Cluster: "prod", Namespace: "default", Pod: "web-0", Container: "app"}, 80, 24)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	recorder.Resize(120, 40)
+	recorder.Input([]byte("secret\n")) // 未开启 record_input，不应写入
+	recorder.Output([]byte("h\xe4\xbd"))
+	recorder.Output([]byte("\xa0\r\n"))
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	path, err := store.CastPath(recorder.ID())
+	if err != nil {
+		t.Fatalf("CastPath: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open cast: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	var lines [][]byte
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if len(lines) != 4 {
+		t.Fatalf("expected header + 3 events, got %d lines", len(lines))
+	}
+	var header castHeader
+	if err := json.Unmarshal(lines[0], &header); err != nil || header.Version != 2 || header.Width != 80 {
+		t.Fatalf("bad header %s: %v", lines[0], err)
+	}
+	var event []any
+	json.Unmarshal(lines[1], &event)
+	if event[1] != "r" || event[2] != "120x40" {
+		t.Errorf("unexpected resize event %v", event)
+	}
+	json.Unmarshal(lines[2], &event)
+	if event[1] != "o" || event[2] != "h" {
+		t.Errorf("split UTF-8 must be held back, got %v", event)
+	}
+	json.Unmarshal(lines[3], &event)
+	if event[2] != "你\r\n" {
+		t.Errorf("expected completed rune, got %v", event)
+	}
+
+	items, err := store.List(Filter{User: "alice"})
+	if err != nil || len(items) != 1 || items[0].Size == 0 || items[0].EndedAt.IsZero() {
+		t.Fatalf("List: %+v, %v", items, err)
+	}
+	if _, err := store.Get("../rules"); err != ErrRecordingNotFound {
+		t.Errorf("invalid id must be rejected, got %v", err)
+	}
+}
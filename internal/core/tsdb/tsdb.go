@@ -0,0 +1,376 @@
+// Package tsdb 是一个嵌入式的时序数据存储：每个集群一个只追加的段文件，
+// 通过压缩（去重、降采样）与保留期控制文件大小。
+package tsdb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	segmentExt = ".seg"
+
+	// DefaultCompactThreshold 自上次压缩后追加超过该字节数（且超过压缩后大小）才需要再次压缩
+	DefaultCompactThreshold = 1 << 20
+)
+
+// Key 标识一条时间序列
+type Key struct {
+	Series string // 如 namespace/pod
+	Metric string // 如 cpu、memory、disk
+}
+
+// Point 数据点
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Downsampler 压缩时对每条序列做降采样，输入按时间升序
+type Downsampler func(key Key, points []Point) []Point
+
+// Options 存储选项
+type Options struct {
+	Retention        time.Duration // 数据保留时长，0 表示不过期
+	CompactThreshold int64         // 触发压缩的追加字节数，0 使用 DefaultCompactThreshold
+}
+
+// record 段文件中的一行：一条序列的一批数据点，点为 [unix 秒, 值]
+type record struct {
+	Series string       `json:"s"`
+	Metric string       `json:"m"`
+	Points [][2]float64 `json:"p"`
+}
+
+type segment struct {
+	file      *os.File
+	size      int64
+	compacted int64 // 上次压缩（或打开）时的文件大小
+}
+
+// DB 时序存储
+type DB struct {
+	dir      string
+	opts     Options
+	segments map[string]*segment
+	mutex    sync.Mutex
+}
+
+// Open 打开（必要时创建）存储目录
+func Open(dir string, opts Options) (*DB, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create tsdb directory: %w", err)
+	}
+	if opts.CompactThreshold <= 0 {
+		opts.CompactThreshold = DefaultCompactThreshold
+	}
+	return &DB{dir: dir, opts: opts, segments: make(map[string]*segment)}, nil
+}
+
+// Dir 返回存储目录
+func (db *DB) Dir() string {
+	return db.dir
+}
+
+// Clusters 返回已有段文件的集群
+func (db *DB) Clusters() ([]string, error) {
+	entries, err := os.ReadDir(db.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tsdb directory: %w", err)
+	}
+	clusters := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), segmentExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if cluster, err := url.PathUnescape(name); err == nil {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Strings(clusters)
+	return clusters, nil
+}
+
+// Append 将数据点追加到集群的段文件
+func (db *DB) Append(cluster string, data map[Key][]Point) error {
+	var buf bytes.Buffer
+	for key, points := range data {
+		if len(points) == 0 {
+			continue
+		}
+		if err := encodeRecord(&buf, key, points); err != nil {
+			return err
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	seg, err := db.openSegment(cluster)
+	if err != nil {
+		return err
+	}
+	n, err := seg.file.Write(buf.Bytes())
+	seg.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to append to tsdb segment: %w", err)
+	}
+	if err := seg.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync tsdb segment: %w", err)
+	}
+	return nil
+}
+
+// Load 读取集群的全部序列，按时间升序、同一时刻只保留最后写入的值，并丢弃超出保留期的数据。
+// 进程崩溃导致的末尾不完整行会被忽略。
+func (db *DB) Load(cluster string) (map[Key][]Point, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.load(cluster)
+}
+
+// NeedsCompaction 判断集群段文件自上次压缩后是否增长到需要压缩
+func (db *DB) NeedsCompaction(cluster string) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	seg, err := db.openSegment(cluster)
+	if err != nil {
+		return false
+	}
+	grown := seg.size - seg.compacted
+	return grown > db.opts.CompactThreshold && grown > seg.compacted
+}
+
+// Compact 重写集群段文件：去重、按保留期裁剪并对每条序列降采样（downsample 可为 nil）
+func (db *DB) Compact(cluster string, downsample Downsampler) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	data, err := db.load(cluster)
+	if err != nil {
+		return err
+	}
+	keys := make([]Key, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Series != keys[j].Series {
+			return keys[i].Series < keys[j].Series
+		}
+		return keys[i].Metric < keys[j].Metric
+	})
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		points := data[key]
+		if downsample != nil {
+			points = normalize(downsample(key, points), time.Time{})
+		}
+		if len(points) == 0 {
+			continue
+		}
+		if err := encodeRecord(&buf, key, points); err != nil {
+			return err
+		}
+	}
+
+	path := db.segmentPath(cluster)
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, buf.Bytes()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write compacted tsdb segment: %w", err)
+	}
+	if seg, ok := db.segments[cluster]; ok {
+		seg.file.Close()
+		delete(db.segments, cluster)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace tsdb segment: %w", err)
+	}
+	_, err = db.openSegment(cluster)
+	return err
+}
+
+// Delete 删除集群的段文件
+func (db *DB) Delete(cluster string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if seg, ok := db.segments[cluster]; ok {
+		seg.file.Close()
+		delete(db.segments, cluster)
+	}
+	if err := os.Remove(db.segmentPath(cluster)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete tsdb segment: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭所有段文件
+func (db *DB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	var firstErr error
+	for cluster, seg := range db.segments {
+		if err := seg.file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(db.segments, cluster)
+	}
+	return firstErr
+}
+
+func (db *DB) segmentPath(cluster string) string {
+	return filepath.Join(db.dir, url.PathEscape(cluster)+segmentExt)
+}
+
+func (db *DB) openSegment(cluster string) (*segment, error) {
+	if seg, ok := db.segments[cluster]; ok {
+		return seg, nil
+	}
+	file, err := os.OpenFile(db.segmentPath(cluster), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tsdb segment: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat tsdb segment: %w", err)
+	}
+	seg := &segment{file: file, size: info.Size(), compacted: info.Size()}
+	// 上次崩溃可能留下不完整的末行，补一个换行避免与新记录粘连
+	if seg.size > 0 && !endsWithNewline(file.Name(), seg.size) {
+		if _, err := file.Write([]byte{'\n'}); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to repair tsdb segment: %w", err)
+		}
+		seg.size++
+	}
+	db.segments[cluster] = seg
+	return seg, nil
+}
+
+func (db *DB) load(cluster string) (map[Key][]Point, error) {
+	data := make(map[Key][]Point)
+	file, err := os.Open(db.segmentPath(cluster))
+	if os.IsNotExist(err) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tsdb segment: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		key := Key{Series: r.Series, Metric: r.Metric}
+		for _, p := range r.Points {
+			data[key] = append(data[key], Point{Time: time.Unix(int64(p[0]), 0), Value: p[1]})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tsdb segment: %w", err)
+	}
+
+	var cutoff time.Time
+	if db.opts.Retention > 0 {
+		cutoff = time.Now().Add(-db.opts.Retention)
+	}
+	for key, points := range data {
+		points = normalize(points, cutoff)
+		if len(points) == 0 {
+			delete(data, key)
+			continue
+		}
+		data[key] = points
+	}
+	return data, nil
+}
+
+// normalize 按时间排序、同一秒只保留最后一个值，并丢弃早于 cutoff 的点
+func normalize(points []Point, cutoff time.Time) []Point {
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+	result := points[:0]
+	for _, p := range points {
+		if p.Time.Before(cutoff) {
+			continue
+		}
+		if n := len(result); n > 0 && result[n-1].Time.Equal(p.Time) {
+			result[n-1] = p
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+func encodeRecord(buf *bytes.Buffer, key Key, points []Point) error {
+	r := record{Series: key.Series, Metric: key.Metric, Points: make([][2]float64, 0, len(points))}
+	for _, p := range points {
+		// JSON 无法表示 NaN/Inf
+		if math.IsNaN(p.Value) || math.IsInf(p.Value, 0) {
+			continue
+		}
+		r.Points = append(r.Points, [2]float64{float64(p.Time.Unix()), p.Value})
+	}
+	if len(r.Points) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode tsdb record: %w", err)
+	}
+	buf.Write(data)
+	buf.WriteByte('\n')
+	return nil
+}
+
+func endsWithNewline(path string, size int64) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return true
+	}
+	defer file.Close()
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, size-1); err != nil {
+		return true
+	}
+	return last[0] == '\n'
+}
+
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
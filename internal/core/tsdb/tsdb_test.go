@@ -0,0 +1,100 @@
+package tsdb
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAppendLoadCompact(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir, Options{Retention: 48 * time.Hour, CompactThreshold: 1})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().Truncate(time.Second)
+	cpu := Key{Series: "default/web-0", Metric: "cpu"}
+	if err := db.Append("prod/eu", map[Key][]Point{cpu: {
+		{Time: now.Add(-72 * time.Hour), Value: 1}, // 超出保留期
+		{Time: now.Add(-2 * time.Minute), Value: 2},
+	}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	// 同一时刻的重复写入以最后一次为准
+	if err := db.Append("prod/eu", map[Key][]Point{cpu: {
+		{Time: now.Add(-2 * time.Minute), Value: 3},
+		{Time: now.Add(-time.Minute), Value: 4},
+	}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	data, err := db.Load("prod/eu")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	points := data[cpu]
+	if len(points) != 2 || points[0].Value != 3 || points[1].Value != 4 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+
+	clusters, err := db.Clusters()
+	if err != nil || len(clusters) != 1 || clusters[0] != "prod/eu" {
+		t.Fatalf("unexpected clusters %v (%v)", clusters, err)
+	}
+
+	if !db.NeedsCompaction("prod/eu") {
+		t.Fatal("expected compaction to be needed")
+	}
+	if err := db.Compact("prod/eu", func(key Key, points []Point) []Point {
+		return points[len(points)-1:]
+	}); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	data, _ = db.Load("prod/eu")
+	if points := data[cpu]; len(points) != 1 || points[0].Value != 4 {
+		t.Fatalf("unexpected points after compaction: %+v", points)
+	}
+	if db.NeedsCompaction("prod/eu") {
+		t.Fatal("compaction should reset the growth counter")
+	}
+}
+
+func TestTornTail(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir, Options{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	key := Key{Series: "ns/pod", Metric: "memory"}
+	now := time.Now().Truncate(time.Second)
+	if err := db.Append("dev", map[Key][]Point{key: {{Time: now.Add(-time.Minute), Value: 1}}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	db.Close()
+
+	// 模拟写入过程中崩溃留下的半行
+	f, err := os.OpenFile(db.segmentPath("dev"), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("open segment: %v", err)
+	}
+	f.WriteString(`{"s":"ns/pod","m":"mem`)
+	f.Close()
+
+	db, err = Open(dir, Options{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	if err := db.Append("dev", map[Key][]Point{key: {{Time: now, Value: 2}}}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	data, err := db.Load("dev")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if points := data[key]; len(points) != 2 || points[1].Value != 2 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+}
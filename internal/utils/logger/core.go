@@ -108,6 +108,20 @@ func ensureLogDir(path string) {
 	}
 }
 
+// NewRotatingFile 创建按大小滚动的 JSON-lines 文件写入器，文件名保持固定，
+// 备份文件由 lumberjack 以 <name>-<时间戳><ext> 命名，便于审计等场景回读。
+func NewRotatingFile(path string, config lumberjackConfig) io.WriteCloser {
+	_ = os.MkdirAll(filepath.Dir(path), 0755)
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    config.MaxSize,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge,
+		Compress:   config.Compression == "immediate",
+		LocalTime:  config.LocalTime,
+	}
+}
+
 // 创建日志写入器，支持滚动
 func getWriter(path string, config lumberjackConfig) zapcore.WriteSyncer {
 	if !config.Enabled {
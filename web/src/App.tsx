@@ -30,14 +30,17 @@ import ResourceQuotas from './pages/governance/ResourceQuotas';
 import LimitRanges from './pages/governance/LimitRanges';
 import PDBs from './pages/governance/PDBs';
 import RBAC from './pages/governance/RBAC';
+import TerminalRecordings from './pages/governance/TerminalRecordings';
 import LabelLogs from './pages/observability/LabelLogs';
 import ServiceTopology from './pages/observability/ServiceTopology';
 import Dashboard from './pages/Dashboard';
+import Login from './pages/Login';
 
 const App: React.FC = () => {
   return (
     <BrowserRouter>
       <Routes>
+        <Route path="/login" element={<Login />} />
         <Route path="/workloads/pods/:clusterName/:namespace/:podName/logs" element={<PodLogsPage />} />
         <Route path="/workloads/pods/:clusterName/:namespace/:podName/terminal" element={<PodTerminalPage />} />
 
@@ -83,6 +86,7 @@ const App: React.FC = () => {
             <Route path="limitranges" element={<LimitRanges />} />
             <Route path="pdbs" element={<PDBs />} />
             <Route path="rbac" element={<RBAC />} />
+            <Route path="recordings" element={<TerminalRecordings />} />
           </Route>
           <Route path="observability">
             <Route path="label-logs" element={<LabelLogs />} />
@@ -0,0 +1,53 @@
+import api from './axios';
+
+export interface AuditRecord {
+  time: string;
+  user: string;
+  groups?: string[];
+  authMethod?: string;
+  clientIp?: string;
+  action: 'request' | 'exec.open' | 'exec.close';
+  method: string;
+  path: string;
+  route?: string;
+  cluster?: string;
+  namespace?: string;
+  resource?: string;
+  name?: string;
+  container?: string;
+  bodySha256?: string;
+  bodySize?: number;
+  status?: number;
+  latencyMs: number;
+  detail?: string;
+}
+
+export interface AuditQuery {
+  user?: string;
+  cluster?: string;
+  namespace?: string;
+  resource?: string;
+  action?: string;
+  method?: string;
+  status?: number;
+  since?: string; // RFC3339
+  until?: string; // RFC3339
+  page?: number;
+  limit?: number;
+}
+
+export interface AuditQueryResponse {
+  code: number;
+  message: string;
+  data: {
+    items: AuditRecord[];
+    total: number;
+    page: number;
+    limit: number;
+  };
+}
+
+// Query the audit log (admin only), newest first
+export const queryAuditLogs = (params: AuditQuery) => {
+  return api.get<AuditQueryResponse>('/audit', { params });
+};
@@ -0,0 +1,60 @@
+import api from './axios';
+
+export interface Identity {
+  username: string;
+  groups: string[];
+  method: 'local' | 'token' | 'oidc' | 'anonymous';
+}
+
+export interface AuthConfig {
+  enabled: boolean;
+  oidcEnabled: boolean;
+}
+
+export interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data: T;
+}
+
+export const getAuthConfig = () =>
+  api.get<ApiResponse<AuthConfig>>('/auth/config');
+
+export const login = (username: string, password: string) =>
+  api.post<ApiResponse<{ user: Identity }>>('/auth/login', { username, password });
+
+export const logout = () => api.post<ApiResponse<null>>('/auth/logout');
+
+export const getCurrentUser = () =>
+  api.get<ApiResponse<{ user: Identity; isAdmin: boolean }>>('/auth/me');
+
+// OIDC login is a full-page redirect handled by the backend
+export const oidcLoginURL = '/api/auth/oidc/login';
+
+export type Verb = 'read' | 'write' | 'exec' | 'drain' | 'secret-reveal';
+
+export interface PolicyBinding {
+  name: string;
+  subjects: { kind: 'user' | 'group'; name: string }[];
+  clusters: string[];
+  namespaces: string[];
+  verbs: (Verb | '*')[];
+}
+
+// Verbs the current user holds on a cluster/namespace, used to hide actions
+export const getPermissions = (cluster: string, namespace?: string) =>
+  api.get<ApiResponse<{ verbs: Verb[]; isAdmin: boolean }>>('/auth/permissions', {
+    params: { cluster, namespace },
+  });
+
+export const listPolicyBindings = () =>
+  api.get<ApiResponse<{ bindings: PolicyBinding[]; verbs: Verb[] }>>('/auth/policy/bindings');
+
+export const savePolicyBinding = (binding: PolicyBinding) =>
+  api.put<ApiResponse<{ binding: PolicyBinding }>>(
+    `/auth/policy/bindings/${encodeURIComponent(binding.name)}`,
+    binding,
+  );
+
+export const deletePolicyBinding = (name: string) =>
+  api.delete<ApiResponse<null>>(`/auth/policy/bindings/${encodeURIComponent(name)}`);
@@ -27,8 +27,10 @@ apiClient.interceptors.response.use(
       // handle error response
       const { status, data } = error.response;
       if (status === 401) {
-        // handle unauthorized error  
-        console.error('Unauthorized access - perhaps you need to log in?');
+        // session missing or expired: send the user to the login page
+        if (window.location.pathname !== '/login' && !error.config?.url?.startsWith('/auth/')) {
+          window.location.assign('/login');
+        }
         return Promise.reject(new Error('Unauthorized access'));
       } else if (status === 500) {
         // handle server error
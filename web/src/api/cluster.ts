@@ -6,6 +6,7 @@ interface Cluster {
   kubeconfigContent?: string;
   addType?: 'path' | 'content'; // addType: 'path' (file path) or 'content' (content)
   prometheusUrl?: string;
+  impersonate?: boolean; // call the cluster as the logged-in user
 }
 
 export interface ClusterResponse {
@@ -26,6 +27,7 @@ export interface ClusterDetail {
   totalMemory: string;
   platform: string;
   addType?: 'path' | 'content' | 'unknown'; // addType: 'path' (file path) or 'content' (content) or 'unknown'
+  impersonate?: boolean;
 }
 
 export interface ClusterDetailResponse {
@@ -59,6 +61,8 @@ export interface ClusterMetrics {
     memoryUsage: Array<{ timestamp: string; value: number }>;
     podCount: Array<{ timestamp: string; value: number }>;
   };
+  // where historicalData comes from: the cluster's Prometheus or kube-tide's own sampling
+  historySource: 'prometheus' | 'local';
 }
 
 export interface ClusterMetricsResponse {
@@ -123,4 +127,25 @@ export interface ClusterAddTypeResponse {
 
 export const getClusterAddType = (clusterName: string) => {
   return api.get<ClusterAddTypeResponse>(`/clusters/${clusterName}/add-type`);
-};
\ No newline at end of file
+};
+
+export interface ClusterCacheStatus {
+  cluster: string;
+  started: boolean;
+  startedAt?: string;
+  synced: boolean; // every started resource has completed its initial sync
+  resources: Array<{ resource: string; synced: boolean; items: number; resourceVersion?: string }>;
+}
+
+// Shared informer cache sync status
+export const getClusterCacheStatus = (clusterName: string) => {
+  return api.get<{code: number; message: string; data: {cache: ClusterCacheStatus}}>(`/clusters/${clusterName}/cache`);
+};
+
+// Toggle Kubernetes impersonation of the logged-in user (admin only)
+export const setClusterImpersonation = (clusterName: string, enabled: boolean) => {
+  return api.put<{code: number; message: string; data: {name: string; impersonate: boolean}}>(
+    `/clusters/${clusterName}/impersonation`,
+    { enabled }
+  );
+};
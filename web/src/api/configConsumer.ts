@@ -0,0 +1,43 @@
+import api from './axios';
+
+export type ConfigKind = 'configmaps' | 'secrets';
+
+export interface ConfigUsage {
+  type: 'envFrom' | 'env' | 'volume' | 'projected' | 'imagePullSecret';
+  container?: string;
+  key?: string;
+  env?: string;
+  volume?: string;
+  optional?: boolean;
+}
+
+export interface ConfigConsumer {
+  kind: string;
+  name: string;
+  namespace: string;
+  restartable: boolean;
+  usages: ConfigUsage[];
+}
+
+export interface ConsumerRestartResult {
+  kind: string;
+  name: string;
+  status: 'restarted' | 'skipped' | 'failed';
+  message?: string;
+}
+
+export interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data: T;
+}
+
+export const listConfigConsumers = (clusterName: string, namespace: string, kind: ConfigKind, name: string) =>
+  api.get<ApiResponse<{ consumers: ConfigConsumer[] }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/${kind}/${name}/consumers`,
+  );
+
+export const restartConfigConsumers = (clusterName: string, namespace: string, kind: ConfigKind, name: string) =>
+  api.post<ApiResponse<{ results: ConsumerRestartResult[] }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/${kind}/${name}/consumers/restart`,
+  );
@@ -0,0 +1,56 @@
+import api from './axios';
+import { ApiResponse, ConfigKind } from './configConsumer';
+
+export interface ConfigVersion {
+  cluster: string;
+  kind: string;
+  namespace: string;
+  name: string;
+  version: number;
+  resourceVersion: string;
+  keys: string[];
+  action: 'update' | 'restore';
+  user?: string;
+  createdAt: string;
+}
+
+export interface ConfigKeyDiff {
+  key: string;
+  change: 'added' | 'removed' | 'modified';
+  oldSize: number;
+  newSize: number;
+  oldSha256?: string;
+  newSha256?: string;
+  diff?: string;
+}
+
+export interface ConfigVersionDiff {
+  from: string;
+  to: string;
+  keys: ConfigKeyDiff[];
+  labelsChanged: boolean;
+  fromLabels?: Record<string, string>;
+  toLabels?: Record<string, string>;
+}
+
+export const listConfigVersions = (clusterName: string, namespace: string, kind: ConfigKind, name: string) =>
+  api.get<ApiResponse<{ versions: ConfigVersion[] }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/${kind}/${name}/versions`,
+  );
+
+// from / to 为版本号或 'current'
+export const diffConfigVersions = (
+  clusterName: string,
+  namespace: string,
+  kind: ConfigKind,
+  name: string,
+  from: string,
+  to = 'current',
+) =>
+  api.get<ApiResponse<{ diff: ConfigVersionDiff }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/${kind}/${name}/versions/diff`,
+    { params: { from, to } },
+  );
+
+export const restoreConfigVersion = (clusterName: string, namespace: string, kind: ConfigKind, name: string, version: number) =>
+  api.post<ApiResponse<unknown>>(`/clusters/${clusterName}/namespaces/${namespace}/${kind}/${name}/restore`, { version });
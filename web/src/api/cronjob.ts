@@ -4,6 +4,7 @@ export interface CronJobInfo {
   name: string;
   namespace: string;
   schedule: string;
+  timeZone?: string;
   suspend: boolean;
   lastScheduleTime?: string;
   lastSuccessfulTime?: string;
@@ -11,6 +12,30 @@ export interface CronJobInfo {
   creationTime: string;
   labels?: Record<string, string>;
   concurrencyPolicy?: string;
+  nextRuns?: string[];
+}
+
+export interface CronJobRun {
+  jobName: string;
+  status: 'Running' | 'Suspended' | 'Complete' | 'Failed';
+  message?: string;
+  manual: boolean;
+  scheduledTime?: string;
+  startTime?: string;
+  finishTime?: string;
+  durationSeconds: number;
+  succeeded: number;
+  failed: number;
+  active: number;
+}
+
+export interface CronJobHistory {
+  runs: CronJobRun[];
+  succeeded: number;
+  failed: number;
+  running: number;
+  successfulJobsHistoryLimit?: number;
+  failedJobsHistoryLimit?: number;
 }
 
 export interface ApiResponse<T> {
@@ -42,3 +67,16 @@ export const suspendCronJob = (clusterName: string, namespace: string, name: str
 
 export const deleteCronJob = (clusterName: string, namespace: string, name: string) =>
   api.delete(`/clusters/${clusterName}/namespaces/${namespace}/cronjobs/${name}`);
+
+export const triggerCronJob = (clusterName: string, namespace: string, name: string, jobName?: string) =>
+  api.post(`/clusters/${clusterName}/namespaces/${namespace}/cronjobs/${name}/trigger`, jobName ? { name: jobName } : {});
+
+export const getCronJobHistory = (clusterName: string, namespace: string, name: string) =>
+  api.get<ApiResponse<{ history: CronJobHistory }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/cronjobs/${name}/history`,
+  );
+
+export const previewCronSchedule = (clusterName: string, schedule: string, timeZone?: string, count = 5) =>
+  api.get<ApiResponse<{ nextRuns: string[] }>>(`/clusters/${clusterName}/cronjobs/schedule-preview`, {
+    params: { schedule, timeZone, count },
+  });
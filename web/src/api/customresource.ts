@@ -0,0 +1,98 @@
+import api from './axios';
+
+export interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data: T;
+}
+
+export interface PrinterColumn {
+  name: string;
+  type: string;
+  format?: string;
+  description?: string;
+  jsonPath: string;
+  priority?: number;
+}
+
+export interface CRDVersion {
+  name: string;
+  served: boolean;
+  storage: boolean;
+  deprecated?: boolean;
+  printerColumns: PrinterColumn[];
+}
+
+export interface CRDInfo {
+  name: string;
+  group: string;
+  kind: string;
+  plural: string;
+  singular?: string;
+  shortNames?: string[];
+  categories?: string[];
+  scope: 'Namespaced' | 'Cluster';
+  versions: CRDVersion[];
+  established: boolean;
+  creationTime: string;
+}
+
+export interface ResourceCondition {
+  type: string;
+  status: string;
+  reason?: string;
+  message?: string;
+  lastTransitionTime?: string;
+}
+
+export interface CustomResourceItem {
+  name: string;
+  namespace?: string;
+  values: unknown[];
+  conditions?: ResourceCondition[];
+  creationTime: string;
+}
+
+export interface CustomResourceList {
+  crd: CRDInfo;
+  version: string;
+  columns: PrinterColumn[];
+  items: CustomResourceItem[];
+}
+
+export interface CustomResourceDetail extends CustomResourceItem {
+  crd: CRDInfo;
+  version: string;
+  columns: PrinterColumn[];
+  manifest: string;
+  events: Record<string, unknown>[];
+}
+
+const customResourcePath = (clusterName: string, crd: string, namespace?: string) =>
+  namespace
+    ? `/clusters/${clusterName}/namespaces/${namespace}/customresources/${crd}`
+    : `/clusters/${clusterName}/customresources/${crd}`;
+
+export const listCRDs = (clusterName: string) =>
+  api.get<ApiResponse<{ crds: CRDInfo[] }>>(`/clusters/${clusterName}/customresourcedefinitions`);
+
+export const getCRD = (clusterName: string, crd: string) =>
+  api.get<ApiResponse<{ crd: CRDInfo }>>(`/clusters/${clusterName}/customresourcedefinitions/${crd}`);
+
+export const listCustomResources = (clusterName: string, crd: string, namespace?: string, version?: string) =>
+  api.get<ApiResponse<{ customResources: CustomResourceList }>>(customResourcePath(clusterName, crd, namespace), {
+    params: { version },
+  });
+
+export const getCustomResource = (clusterName: string, crd: string, name: string, namespace?: string, version?: string) =>
+  api.get<ApiResponse<{ customResource: CustomResourceDetail }>>(`${customResourcePath(clusterName, crd, namespace)}/${name}`, {
+    params: { version },
+  });
+
+export const updateCustomResource = (clusterName: string, crd: string, name: string, manifest: string, namespace?: string) =>
+  api.put<ApiResponse<{ customResource: CustomResourceDetail }>>(`${customResourcePath(clusterName, crd, namespace)}/${name}`, {
+    manifest,
+  });
+
+export const deleteCustomResource = (clusterName: string, crd: string, name: string, namespace?: string) =>
+  api.delete(`${customResourcePath(clusterName, crd, namespace)}/${name}`);
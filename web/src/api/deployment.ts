@@ -655,6 +655,7 @@ export interface RolloutStatus {
   replicas: number;
   observedGeneration: number;
   paused: boolean;
+  complete: boolean;
   conditions?: Array<{
     type: string;
     status: string;
@@ -671,6 +672,7 @@ export interface CreateCanaryDeploymentRequest {
   labels?: Record<string, string>;
   canaryLabelKey?: string;
   canaryLabelValue?: string;
+  images?: Record<string, string>;
 }
 
 export const pauseRollout = (clusterName: string, namespace: string, deploymentName: string) =>
@@ -693,4 +695,201 @@ export const createCanaryDeployment = (
   api.post(
     `/clusters/${clusterName}/namespaces/${namespace}/deployments/${deploymentName}/canary`,
     data,
-  );
\ No newline at end of file
+  );
+
+export interface CanaryStep {
+  weight: number;
+  pauseSeconds?: number;
+}
+
+export interface CanaryAnalysis {
+  name?: string;
+  query: string;
+  min?: number;
+  max?: number;
+}
+
+export interface CanaryRolloutSpec {
+  canaryName?: string;
+  images: Record<string, string>;
+  steps?: CanaryStep[];
+  analysis?: CanaryAnalysis[];
+  failureLimit?: number;
+  progressDeadlineSeconds?: number;
+}
+
+export interface CanaryAnalysisResult {
+  name: string;
+  query: string;
+  value?: number;
+  passed: boolean;
+  error?: string;
+}
+
+export interface CanaryEvent {
+  time: string;
+  step: number;
+  weight: number;
+  message: string;
+  results?: CanaryAnalysisResult[];
+}
+
+export type CanaryPhase = 'Progressing' | 'Promoting' | 'Promoted' | 'RolledBack' | 'Failed';
+
+export interface CanaryRollout {
+  cluster: string;
+  namespace: string;
+  deployment: string;
+  spec: CanaryRolloutSpec;
+  phase: CanaryPhase;
+  message?: string;
+  totalReplicas: number;
+  currentStep: number;
+  stepStartedAt: string;
+  stepReadyAt?: string;
+  failures: number;
+  startedBy?: string;
+  startedAt: string;
+  updatedAt: string;
+  finishedAt?: string;
+  history?: CanaryEvent[];
+}
+
+const canaryRolloutPath = (clusterName: string, namespace: string, deploymentName: string) =>
+  `/clusters/${clusterName}/namespaces/${namespace}/deployments/${deploymentName}/canary/rollout`;
+
+export const startCanaryRollout = (
+  clusterName: string,
+  namespace: string,
+  deploymentName: string,
+  spec: CanaryRolloutSpec,
+) =>
+  api.post<{ code: number; message: string; data: { rollout: CanaryRollout } }>(
+    canaryRolloutPath(clusterName, namespace, deploymentName),
+    spec,
+  );
+
+export const getCanaryRollout = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.get<{ code: number; message: string; data: { rollout: CanaryRollout } }>(
+    canaryRolloutPath(clusterName, namespace, deploymentName),
+  );
+
+export const abortCanaryRollout = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.post<{ code: number; message: string; data: { rollout: CanaryRollout } }>(
+    `${canaryRolloutPath(clusterName, namespace, deploymentName)}/abort`,
+  );
+
+export const promoteCanaryRollout = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.post<{ code: number; message: string; data: { rollout: CanaryRollout } }>(
+    `${canaryRolloutPath(clusterName, namespace, deploymentName)}/promote`,
+  );
+
+export const listCanaryRollouts = (clusterName: string, namespace?: string) =>
+  api.get<{ code: number; message: string; data: { rollouts: CanaryRollout[] } }>(
+    `/clusters/${clusterName}/canary-rollouts`,
+    { params: namespace ? { namespace } : undefined },
+  );
+
+export interface BlueGreenRequest {
+  images: Record<string, string>;
+  colorLabelKey?: string;
+  retentionSeconds?: number;
+  progressDeadlineSeconds?: number;
+  autoSwitch?: boolean;
+}
+
+export interface BlueGreenServiceChange {
+  name: string;
+  selector: Record<string, string>;
+  newSelector: Record<string, string>;
+  pinned: boolean;
+}
+
+export interface BlueGreenIngressPath {
+  ingress: string;
+  host?: string;
+  path?: string;
+  service: string;
+  port?: string;
+}
+
+export interface BlueGreenPreview {
+  activeDeployment: string;
+  activeColor: string;
+  previewDeployment: string;
+  previewColor: string;
+  services: BlueGreenServiceChange[];
+  ingressPaths: BlueGreenIngressPath[];
+}
+
+export type BlueGreenPhase =
+  | 'Pinning'
+  | 'Deploying'
+  | 'Ready'
+  | 'Switched'
+  | 'RolledBack'
+  | 'Completed'
+  | 'Aborted'
+  | 'Failed';
+
+export interface BlueGreenDeployment {
+  cluster: string;
+  namespace: string;
+  deployment: string;
+  spec: BlueGreenRequest;
+  activeColor: string;
+  previewDeployment: string;
+  previewColor: string;
+  servingColor: string;
+  services: string[];
+  phase: BlueGreenPhase;
+  message?: string;
+  startedBy?: string;
+  startedAt: string;
+  phaseStartedAt: string;
+  updatedAt: string;
+  switchedAt?: string;
+  retainUntil?: string;
+  finishedAt?: string;
+}
+
+const blueGreenPath = (clusterName: string, namespace: string, deploymentName: string) =>
+  `/clusters/${clusterName}/namespaces/${namespace}/deployments/${deploymentName}/bluegreen`;
+
+type BlueGreenResponse = { code: number; message: string; data: { blueGreen: BlueGreenDeployment } };
+
+export const previewBlueGreenSwitch = (
+  clusterName: string,
+  namespace: string,
+  deploymentName: string,
+  colorLabelKey?: string,
+) =>
+  api.get<{ code: number; message: string; data: { preview: BlueGreenPreview } }>(
+    `${blueGreenPath(clusterName, namespace, deploymentName)}/preview`,
+    { params: colorLabelKey ? { colorLabelKey } : undefined },
+  );
+
+export const prepareBlueGreen = (
+  clusterName: string,
+  namespace: string,
+  deploymentName: string,
+  data: BlueGreenRequest,
+) => api.post<BlueGreenResponse>(blueGreenPath(clusterName, namespace, deploymentName), data);
+
+export const getBlueGreen = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.get<BlueGreenResponse>(blueGreenPath(clusterName, namespace, deploymentName));
+
+export const switchBlueGreen = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.post<BlueGreenResponse>(`${blueGreenPath(clusterName, namespace, deploymentName)}/switch`);
+
+export const rollbackBlueGreen = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.post<BlueGreenResponse>(`${blueGreenPath(clusterName, namespace, deploymentName)}/rollback`);
+
+export const abortBlueGreen = (clusterName: string, namespace: string, deploymentName: string) =>
+  api.post<BlueGreenResponse>(`${blueGreenPath(clusterName, namespace, deploymentName)}/abort`);
+
+export const listBlueGreenDeployments = (clusterName: string, namespace?: string) =>
+  api.get<{ code: number; message: string; data: { blueGreens: BlueGreenDeployment[] } }>(
+    `/clusters/${clusterName}/bluegreen-deployments`,
+    { params: namespace ? { namespace } : undefined },
+  );
@@ -0,0 +1,62 @@
+import api from './axios';
+import { ApiResponse } from './configConsumer';
+
+export type HealthStatus = 'ok' | 'warning' | 'critical' | 'unknown';
+
+export interface HealthCheck {
+  name: string;
+  status: HealthStatus;
+  message: string;
+  details?: string[];
+  since: string;
+  checkedAt: string;
+}
+
+export interface ClusterHealth {
+  cluster: string;
+  status: HealthStatus;
+  checkedAt: string;
+  checks: HealthCheck[];
+}
+
+export interface Alert {
+  id: string;
+  rule: string;
+  cluster: string;
+  check: string;
+  status: HealthStatus;
+  message: string;
+  details?: string[];
+  state: 'firing' | 'resolved';
+  startsAt: string;
+  endsAt?: string;
+}
+
+export interface AlertList {
+  firing: Alert[];
+  resolved: Alert[];
+}
+
+export interface AlertRule {
+  name: string;
+  enabled: boolean;
+  clusters?: string[];
+  checks?: string[];
+  severity: 'warning' | 'critical';
+  forSeconds?: number;
+}
+
+// refresh 为 true 时立即重新检查
+export const getClusterHealth = (clusterName: string, refresh = false) =>
+  api.get<ApiResponse<{ health: ClusterHealth; alerts: AlertList }>>(`/clusters/${clusterName}/health`, {
+    params: refresh ? { refresh: true } : undefined,
+  });
+
+export const listAlerts = () => api.get<ApiResponse<{ alerts: AlertList }>>('/alerts');
+
+export const listAlertRules = () => api.get<ApiResponse<{ rules: AlertRule[]; checks: string[] }>>('/alerts/rules');
+
+export const saveAlertRule = (rule: AlertRule) =>
+  api.put<ApiResponse<{ rule: AlertRule }>>(`/alerts/rules/${rule.name}`, rule);
+
+export const deleteAlertRule = (name: string) => api.delete<ApiResponse<unknown>>(`/alerts/rules/${name}`);
@@ -13,6 +13,35 @@ export interface JobInfo {
   creationTime: string;
   labels?: Record<string, string>;
   images?: string[];
+  status: 'Running' | 'Suspended' | 'Complete' | 'Failed';
+  message?: string;
+  suspended: boolean;
+  completionMode?: 'NonIndexed' | 'Indexed';
+  backoffLimit?: number;
+  ttlSecondsAfterFinished?: number;
+  activeDeadlineSeconds?: number;
+  podFailurePolicy?: Record<string, unknown>;
+  retryOf?: string;
+}
+
+export interface JobSettings {
+  ttlSecondsAfterFinished?: number;
+  activeDeadlineSeconds?: number;
+  backoffLimit?: number;
+  podFailurePolicy?: Record<string, unknown>;
+}
+
+export interface JobIndexStatus {
+  index: number;
+  status: 'Succeeded' | 'Failed' | 'Active' | 'Pending';
+  pods: Array<{ name: string; phase: string }>;
+}
+
+export interface JobPodLog {
+  podName: string;
+  container?: string;
+  logs?: string;
+  error?: string;
 }
 
 export interface ApiResponse<T> {
@@ -36,3 +65,23 @@ export const createJob = (clusterName: string, namespace: string, data: Record<s
 
 export const deleteJob = (clusterName: string, namespace: string, name: string) =>
   api.delete(`/clusters/${clusterName}/namespaces/${namespace}/jobs/${name}`);
+
+const jobPath = (clusterName: string, namespace: string, name: string) =>
+  `/clusters/${clusterName}/namespaces/${namespace}/jobs/${name}`;
+
+export const getJobLogs = (clusterName: string, namespace: string, name: string, tailLines = 100, container?: string) =>
+  api.get<ApiResponse<{ logs: JobPodLog[] }>>(`${jobPath(clusterName, namespace, name)}/logs`, {
+    params: { tailLines, container },
+  });
+
+export const retryJob = (clusterName: string, namespace: string, name: string, data?: { name?: string; settings?: JobSettings }) =>
+  api.post<ApiResponse<{ job: JobInfo }>>(`${jobPath(clusterName, namespace, name)}/retry`, data ?? {});
+
+export const suspendJob = (clusterName: string, namespace: string, name: string, suspend: boolean) =>
+  api.put<ApiResponse<{ job: JobInfo }>>(`${jobPath(clusterName, namespace, name)}/suspend`, { suspend });
+
+export const updateJob = (clusterName: string, namespace: string, name: string, settings: JobSettings) =>
+  api.put<ApiResponse<{ job: JobInfo }>>(jobPath(clusterName, namespace, name), settings);
+
+export const getJobIndexes = (clusterName: string, namespace: string, name: string) =>
+  api.get<ApiResponse<{ indexes: JobIndexStatus[] }>>(`${jobPath(clusterName, namespace, name)}/indexes`);
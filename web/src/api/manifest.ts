@@ -0,0 +1,34 @@
+import api from './axios';
+
+export interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data: T;
+}
+
+export interface ManifestResult {
+  apiVersion: string;
+  kind: string;
+  namespace?: string;
+  name: string;
+  action: 'created' | 'configured' | 'unchanged' | 'failed';
+  diff?: string;
+  error?: string;
+}
+
+export interface ApplyManifestRequest {
+  manifest: string;
+  namespace?: string;
+  force?: boolean;
+}
+
+export const getManifest = (
+  clusterName: string,
+  params: { apiVersion: string; kind: string; name: string; namespace?: string },
+) => api.get<ApiResponse<{ manifest: string }>>(`/clusters/${clusterName}/manifests`, { params });
+
+export const diffManifest = (clusterName: string, data: ApplyManifestRequest) =>
+  api.post<ApiResponse<{ results: ManifestResult[] }>>(`/clusters/${clusterName}/manifests/diff`, data);
+
+export const applyManifest = (clusterName: string, data: ApplyManifestRequest) =>
+  api.post<ApiResponse<{ results: ManifestResult[] }>>(`/clusters/${clusterName}/manifests/apply`, data);
@@ -8,10 +8,18 @@ export interface PVCInfo {
   storageClassName?: string;
   accessModes?: string[];
   capacity?: string;
+  requested?: string;
+  resizeStatus?: 'Resizing' | 'FileSystemResizePending';
+  dataSource?: PVCDataSource;
   creationTime: string;
   labels?: Record<string, string>;
 }
 
+export interface PVCDataSource {
+  kind: 'PersistentVolumeClaim' | 'VolumeSnapshot';
+  name: string;
+}
+
 export interface ApiResponse<T> {
   code: number;
   message: string;
@@ -33,3 +41,13 @@ export const createPVC = (clusterName: string, namespace: string, data: Record<s
 
 export const deletePVC = (clusterName: string, namespace: string, name: string) =>
   api.delete(`/clusters/${clusterName}/namespaces/${namespace}/pvcs/${name}`);
+
+export const resizePVC = (clusterName: string, namespace: string, name: string, storage: string) =>
+  api.put<ApiResponse<{ pvc: PVCInfo }>>(`/clusters/${clusterName}/namespaces/${namespace}/pvcs/${name}/resize`, { storage });
+
+export const clonePVC = (clusterName: string, namespace: string, source: string, name: string, storage?: string) =>
+  api.post<ApiResponse<{ pvc: PVCInfo }>>(`/clusters/${clusterName}/namespaces/${namespace}/pvcs`, {
+    name,
+    storage,
+    dataSource: { kind: 'PersistentVolumeClaim', name: source },
+  });
@@ -0,0 +1,40 @@
+import api from './axios';
+
+export interface RecordingMeta {
+  id: string;
+  user: string;
+  cluster: string;
+  namespace: string;
+  pod: string;
+  container: string;
+  startedAt: string;
+  endedAt?: string;
+  duration: number; // seconds
+  size: number; // bytes
+}
+
+export interface RecordingRule {
+  cluster: string;
+  namespaces?: string[]; // empty or ['*'] records the whole cluster
+}
+
+export interface ApiResponse<T> {
+  code: number;
+  message: string;
+  data: T;
+}
+
+export const listRecordings = (params?: { user?: string; cluster?: string; namespace?: string; pod?: string }) =>
+  api.get<ApiResponse<{ recordings: RecordingMeta[] }>>('/recordings', { params });
+
+export const getRecordingRules = () =>
+  api.get<ApiResponse<{ rules: RecordingRule[] }>>('/recordings/rules');
+
+export const updateRecordingRules = (rules: RecordingRule[]) =>
+  api.put<ApiResponse<{ rules: RecordingRule[] }>>('/recordings/rules', { rules });
+
+// Raw asciicast v2 content for in-browser replay
+export const fetchRecordingCast = (id: string) =>
+  api.get<string>(`/recordings/${id}/download`, { responseType: 'text', timeout: 60000 });
+
+export const recordingDownloadURL = (id: string) => `/api/recordings/${id}/download`;
@@ -9,8 +9,49 @@ export interface SecretInfo {
   creationTime: string;
 }
 
+export interface SecretKeyInfo {
+  key: string;
+  size: number;
+  sha256: string;
+}
+
+export interface CertificateInfo {
+  subject: string;
+  issuer: string;
+  dnsNames?: string[];
+  ipAddresses?: string[];
+  serialNumber: string;
+  notBefore: string;
+  notAfter: string;
+  daysRemaining: number;
+  status: 'valid' | 'expiring' | 'expired';
+  isCA: boolean;
+  chainLength: number;
+}
+
 export interface SecretDetail extends SecretInfo {
-  data: Record<string, string>;
+  keys: SecretKeyInfo[];
+  certificate?: CertificateInfo;
+}
+
+export interface CertificateReportItem {
+  namespace: string;
+  name: string;
+  certificate?: CertificateInfo;
+  status: 'valid' | 'expiring' | 'expired' | 'invalid';
+  error?: string;
+  ingresses: string[];
+}
+
+export interface CreateSecretPayload {
+  name: string;
+  type?: string;
+  labels?: Record<string, string>;
+  stringData?: Record<string, string>;
+  tls?: { cert: string; key: string; ca?: string };
+  dockerRegistry?: { server?: string; username: string; password: string; email?: string };
+  basicAuth?: { username?: string; password?: string };
+  sshAuth?: { privateKey: string };
 }
 
 export interface ApiResponse<T> {
@@ -32,18 +73,26 @@ export const getSecret = (clusterName: string, namespace: string, name: string)
     `/clusters/${clusterName}/namespaces/${namespace}/secrets/${name}`,
   );
 
-export const createSecret = (
-  clusterName: string,
-  namespace: string,
-  data: { name: string; type?: string; labels?: Record<string, string>; stringData: Record<string, string> },
-) => api.post(`/clusters/${clusterName}/namespaces/${namespace}/secrets`, data);
+export const createSecret = (clusterName: string, namespace: string, data: CreateSecretPayload) =>
+  api.post<ApiResponse<{ secret: SecretDetail }>>(`/clusters/${clusterName}/namespaces/${namespace}/secrets`, data);
+
+export const getCertificateReport = (clusterName: string, withinDays = 0) =>
+  api.get<ApiResponse<{ certificates: CertificateReportItem[] }>>(`/clusters/${clusterName}/secrets/certificates`, {
+    params: { withinDays },
+  });
 
 export const updateSecret = (
   clusterName: string,
   namespace: string,
   name: string,
-  data: { stringData: Record<string, string>; labels?: Record<string, string> },
+  data: { stringData: Record<string, string | null>; labels?: Record<string, string>; type?: string },
 ) => api.put(`/clusters/${clusterName}/namespaces/${namespace}/secrets/${name}`, data);
 
+export const revealSecretKey = (clusterName: string, namespace: string, name: string, key: string) =>
+  api.post<ApiResponse<{ key: string; value: string }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/secrets/${name}/reveal`,
+    { key },
+  );
+
 export const deleteSecret = (clusterName: string, namespace: string, name: string) =>
   api.delete(`/clusters/${clusterName}/namespaces/${namespace}/secrets/${name}`);
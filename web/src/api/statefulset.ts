@@ -367,7 +367,202 @@ export const getAllStatefulSetEvents = (clusterName: string, namespace: string,
  * @returns Pod list
  */
 export const getStatefulSetPods = (clusterName: string, namespace: string, statefulsetName: string) => {
-  return api.get<PodListResponse>(
+  return api.get<StatefulSetPodsResponse>(
     `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/pods`
   );
 };
+
+export interface StatefulSetOrdinalStatus {
+  ordinal: number;
+  pod: string;
+  revision: string;
+  updated: boolean;
+  ready: boolean;
+  phase: string;
+}
+
+export interface StatefulSetRevisionStatus {
+  updateStrategy: 'RollingUpdate' | 'OnDelete';
+  partition: number;
+  maxUnavailable?: string;
+  replicas: number;
+  currentRevision: string;
+  updateRevision: string;
+  updatedReplicas: number;
+  ordinals: StatefulSetOrdinalStatus[];
+}
+
+export interface StatefulSetPodsResponse extends PodListResponse {
+  data: PodListResponse['data'] & {
+    revisions: StatefulSetRevisionStatus;
+  };
+}
+
+export interface StatefulSetUpdateStrategyRequest {
+  type?: 'RollingUpdate' | 'OnDelete';
+  partition?: number;
+  maxUnavailable?: string;
+}
+
+export interface PartitionStepDown {
+  cluster: string;
+  namespace: string;
+  statefulSet: string;
+  from: number;
+  target: number;
+  partition: number;
+  phase: 'Running' | 'Succeeded' | 'Failed' | 'Cancelled';
+  message?: string;
+  startedBy?: string;
+  startedAt: string;
+  updatedAt: string;
+  finishedAt?: string;
+}
+
+/**
+ * Update StatefulSet update strategy (type, partition, maxUnavailable)
+ * @param clusterName cluster name
+ * @param namespace namespace
+ * @param statefulsetName StatefulSet name
+ * @param strategy fields to change, omitted fields are kept
+ * @returns revision status after the change
+ */
+export const updateStatefulSetStrategy = (
+  clusterName: string,
+  namespace: string,
+  statefulsetName: string,
+  strategy: StatefulSetUpdateStrategyRequest,
+) => {
+  return api.put<{ code: number; message: string; data: { revisions: StatefulSetRevisionStatus } }>(
+    `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/update-strategy`,
+    strategy
+  );
+};
+
+const stepDownPath = (clusterName: string, namespace: string, statefulsetName: string) =>
+  `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/partition/step-down`;
+
+type StepDownResponse = { code: number; message: string; data: { stepDown: PartitionStepDown } };
+
+/**
+ * Step the partition down one ordinal at a time until target, waiting for each pod to be ready
+ * @param clusterName cluster name
+ * @param namespace namespace
+ * @param statefulsetName StatefulSet name
+ * @param target target partition
+ * @param stepTimeoutSeconds max wait per ordinal (default 600)
+ * @returns step-down task
+ */
+export const startPartitionStepDown = (
+  clusterName: string,
+  namespace: string,
+  statefulsetName: string,
+  target: number,
+  stepTimeoutSeconds?: number,
+) => {
+  return api.post<StepDownResponse>(stepDownPath(clusterName, namespace, statefulsetName), { target, stepTimeoutSeconds });
+};
+
+export const getPartitionStepDown = (clusterName: string, namespace: string, statefulsetName: string) => {
+  return api.get<StepDownResponse>(stepDownPath(clusterName, namespace, statefulsetName));
+};
+
+export const cancelPartitionStepDown = (clusterName: string, namespace: string, statefulsetName: string) => {
+  return api.delete<StepDownResponse>(stepDownPath(clusterName, namespace, statefulsetName));
+};
+
+/**
+ * Restart a single StatefulSet ordinal by deleting its pod
+ * @param clusterName cluster name
+ * @param namespace namespace
+ * @param statefulsetName StatefulSet name
+ * @param ordinal pod ordinal
+ * @returns operation result
+ */
+export const restartStatefulSetOrdinal = (clusterName: string, namespace: string, statefulsetName: string, ordinal: number) => {
+  return api.post<OperationResponse>(
+    `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/pods/${ordinal}/restart`
+  );
+};
+
+export interface StatefulSetPVCRetentionPolicy {
+  whenDeleted: 'Retain' | 'Delete';
+  whenScaled: 'Retain' | 'Delete';
+}
+
+export interface StatefulSetVolumeClaim {
+  template: string;
+  ordinal: number;
+  name: string;
+  exists: boolean;
+  pvc?: {
+    name: string;
+    namespace: string;
+    status: string;
+    volumeName?: string;
+    storageClassName?: string;
+    accessModes?: string[];
+    capacity?: string;
+    creationTime: string;
+  };
+  orphaned: boolean;
+  inUse: boolean;
+  usedBytes?: number;
+  capacityBytes?: number;
+}
+
+export interface StatefulSetStorage {
+  replicas: number;
+  templates: Array<{ name: string; storageClassName?: string; accessModes: string[]; storage: string }>;
+  retentionPolicy: StatefulSetPVCRetentionPolicy;
+  claims: StatefulSetVolumeClaim[];
+  orphanedClaims: string[];
+}
+
+/**
+ * Get PVCs generated from volumeClaimTemplates for each ordinal, with bound PV, capacity and usage
+ * @param clusterName cluster name
+ * @param namespace namespace
+ * @param statefulsetName StatefulSet name
+ * @returns storage view
+ */
+export const getStatefulSetStorage = (clusterName: string, namespace: string, statefulsetName: string) => {
+  return api.get<{ code: number; message: string; data: { storage: StatefulSetStorage } }>(
+    `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/storage`
+  );
+};
+
+/**
+ * Set persistentVolumeClaimRetentionPolicy
+ * @param clusterName cluster name
+ * @param namespace namespace
+ * @param statefulsetName StatefulSet name
+ * @param policy whenDeleted / whenScaled
+ * @returns effective policy
+ */
+export const updatePVCRetentionPolicy = (
+  clusterName: string,
+  namespace: string,
+  statefulsetName: string,
+  policy: Partial<StatefulSetPVCRetentionPolicy>,
+) => {
+  return api.put<{ code: number; message: string; data: { retentionPolicy: StatefulSetPVCRetentionPolicy } }>(
+    `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/pvc-retention-policy`,
+    policy
+  );
+};
+
+/**
+ * Delete PVCs left behind by a scale-down
+ * @param clusterName cluster name
+ * @param namespace namespace
+ * @param statefulsetName StatefulSet name
+ * @param pvcs PVC names, must be orphaned and not mounted
+ * @returns deleted PVC names
+ */
+export const cleanupOrphanedPVCs = (clusterName: string, namespace: string, statefulsetName: string, pvcs: string[]) => {
+  return api.post<{ code: number; message: string; data: { deleted: string[] } }>(
+    `/clusters/${clusterName}/namespaces/${namespace}/statefulsets/${statefulsetName}/storage/cleanup`,
+    { pvcs }
+  );
+};
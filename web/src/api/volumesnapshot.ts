@@ -0,0 +1,69 @@
+import api from './axios';
+import { ApiResponse, PVCInfo } from './pvc';
+
+export interface VolumeSnapshotInfo {
+  name: string;
+  namespace: string;
+  sourcePVC?: string;
+  volumeSnapshotClassName?: string;
+  snapshotContentName?: string;
+  readyToUse: boolean;
+  restoreSize?: string;
+  error?: string;
+  creationTime: string;
+}
+
+export interface VolumeSnapshotClassInfo {
+  name: string;
+  driver: string;
+  deletionPolicy: string;
+  isDefault: boolean;
+  creationTime: string;
+}
+
+export interface RestoreVolumeSnapshotRequest {
+  name: string;
+  labels?: Record<string, string>;
+  storageClassName?: string;
+  accessModes?: string[];
+  storage?: string;
+}
+
+export const listVolumeSnapshotClasses = (clusterName: string) =>
+  api.get<ApiResponse<{ volumeSnapshotClasses: VolumeSnapshotClassInfo[] }>>(`/clusters/${clusterName}/volumesnapshotclasses`);
+
+export const listVolumeSnapshots = (clusterName: string, namespace?: string) => {
+  const path = namespace
+    ? `/clusters/${clusterName}/namespaces/${namespace}/volumesnapshots`
+    : `/clusters/${clusterName}/volumesnapshots`;
+  return api.get<ApiResponse<{ volumeSnapshots: VolumeSnapshotInfo[] }>>(path);
+};
+
+export const getVolumeSnapshot = (clusterName: string, namespace: string, name: string) =>
+  api.get<ApiResponse<{ volumeSnapshot: VolumeSnapshotInfo }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/volumesnapshots/${name}`,
+  );
+
+export const createVolumeSnapshot = (
+  clusterName: string,
+  namespace: string,
+  data: { name: string; sourcePVC: string; volumeSnapshotClassName?: string },
+) =>
+  api.post<ApiResponse<{ volumeSnapshot: VolumeSnapshotInfo }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/volumesnapshots`,
+    data,
+  );
+
+export const restoreVolumeSnapshot = (
+  clusterName: string,
+  namespace: string,
+  name: string,
+  data: RestoreVolumeSnapshotRequest,
+) =>
+  api.post<ApiResponse<{ pvc: PVCInfo }>>(
+    `/clusters/${clusterName}/namespaces/${namespace}/volumesnapshots/${name}/restore`,
+    data,
+  );
+
+export const deleteVolumeSnapshot = (clusterName: string, namespace: string, name: string) =>
+  api.delete(`/clusters/${clusterName}/namespaces/${namespace}/volumesnapshots/${name}`);
@@ -0,0 +1,48 @@
+import api from './axios';
+
+export type WatchEventType = 'ADDED' | 'MODIFIED' | 'DELETED' | 'ERROR';
+
+// One change pushed by /clusters/:cluster/watch; object has the same shape as the list endpoint of that kind
+export interface ResourceWatchEvent<T = any> {
+  type: WatchEventType;
+  kind?: string;
+  namespace?: string;
+  name?: string;
+  resourceVersion?: string;
+  object?: T;
+  message?: string; // set on ERROR: the watch ended, re-list before watching again
+}
+
+/**
+ * watch resource changes over SSE
+ * @param clusterName cluster name
+ * @param kinds resource kinds, e.g. ['pods', 'deployments']
+ * @param namespaces namespaces to watch, empty for all
+ * @param onEvent callback for each change
+ * @returns returns an object containing a close method to close the EventSource connection
+ */
+export const watchResources = (
+  clusterName: string,
+  kinds: string[],
+  namespaces: string[],
+  onEvent: (event: ResourceWatchEvent) => void
+) => {
+  const baseUrl = window.location.origin + (api.defaults.baseURL || '/api');
+  const url = new URL(`${baseUrl}/clusters/${clusterName}/watch`);
+  url.searchParams.append('kinds', kinds.join(','));
+  if (namespaces.length > 0) {
+    url.searchParams.append('namespaces', namespaces.join(','));
+  }
+
+  // the session cookie authenticates the stream
+  const eventSource = new EventSource(url.toString(), { withCredentials: true });
+  eventSource.onmessage = (event) => {
+    onEvent(JSON.parse(event.data));
+  };
+
+  return {
+    close: () => {
+      eventSource.close();
+    }
+  };
+};
@@ -0,0 +1,175 @@
+import React, { useEffect, useState } from 'react';
+import { Card, Button, Table, Tag, Tooltip, Typography, message } from 'antd';
+import { ReloadOutlined } from '@ant-design/icons';
+import { useTranslation } from 'react-i18next';
+import { getClusterHealth } from '../../../api/health';
+import type { Alert, AlertList, ClusterHealth, HealthCheck, HealthStatus } from '../../../api/health';
+
+const statusColors: Record<HealthStatus, string> = {
+  ok: 'green',
+  warning: 'orange',
+  critical: 'red',
+  unknown: 'default',
+};
+
+interface ClusterHealthCardProps {
+  clusterName: string;
+}
+
+// 集群健康检查结果与告警
+const ClusterHealthCard: React.FC<ClusterHealthCardProps> = ({ clusterName }) => {
+  const { t } = useTranslation();
+  const [loading, setLoading] = useState(false);
+  const [health, setHealth] = useState<ClusterHealth | null>(null);
+  const [alerts, setAlerts] = useState<AlertList>({ firing: [], resolved: [] });
+
+  const fetchHealth = async (refresh = false) => {
+    try {
+      setLoading(true);
+      const response = await getClusterHealth(clusterName, refresh);
+      if (response.data.code === 0) {
+        setHealth(response.data.data.health);
+        setAlerts(response.data.data.alerts);
+      } else {
+        message.error(response.data.message || t('clusterHealth.fetchFailed'));
+      }
+    } catch (err) {
+      message.error(t('clusterHealth.fetchFailed'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  useEffect(() => {
+    fetchHealth();
+    // 后台每分钟检查一次，这里跟随刷新
+    const timer = setInterval(() => fetchHealth(), 60000);
+    return () => clearInterval(timer);
+  }, [clusterName]);
+
+  const renderStatus = (status: HealthStatus) => (
+    <Tag color={statusColors[status]}>{t(`clusterHealth.status.${status}`)}</Tag>
+  );
+
+  const checkColumns = [
+    {
+      title: t('clusterHealth.columns.check'),
+      dataIndex: 'name',
+      key: 'name',
+      width: 160,
+      render: (name: string) => t(`clusterHealth.checks.${name}`),
+    },
+    {
+      title: t('clusterHealth.columns.status'),
+      dataIndex: 'status',
+      key: 'status',
+      width: 100,
+      render: renderStatus,
+    },
+    {
+      title: t('clusterHealth.columns.message'),
+      key: 'message',
+      render: (_: unknown, check: HealthCheck) => (
+        <>
+          <div>{check.message}</div>
+          {check.details?.map(detail => (
+            <Typography.Text key={detail} type="secondary" style={{ display: 'block' }}>
+              {detail}
+            </Typography.Text>
+          ))}
+        </>
+      ),
+    },
+    {
+      title: t('clusterHealth.columns.since'),
+      dataIndex: 'since',
+      key: 'since',
+      width: 180,
+      render: (since: string) => new Date(since).toLocaleString(),
+    },
+  ];
+
+  const alertColumns = [
+    {
+      title: t('clusterHealth.columns.rule'),
+      dataIndex: 'rule',
+      key: 'rule',
+      width: 140,
+    },
+    {
+      title: t('clusterHealth.columns.check'),
+      dataIndex: 'check',
+      key: 'check',
+      width: 160,
+      render: (check: string) => t(`clusterHealth.checks.${check}`),
+    },
+    {
+      title: t('clusterHealth.columns.status'),
+      key: 'status',
+      width: 160,
+      render: (_: unknown, alert: Alert) => (
+        <>
+          {renderStatus(alert.status)}
+          {alert.state === 'resolved' && <Tag>{t('clusterHealth.resolved')}</Tag>}
+        </>
+      ),
+    },
+    {
+      title: t('clusterHealth.columns.message'),
+      dataIndex: 'message',
+      key: 'message',
+    },
+    {
+      title: t('clusterHealth.columns.startsAt'),
+      key: 'startsAt',
+      width: 180,
+      render: (_: unknown, alert: Alert) => (
+        <Tooltip title={alert.endsAt ? `${t('clusterHealth.columns.endsAt')}: ${new Date(alert.endsAt).toLocaleString()}` : undefined}>
+          {new Date(alert.startsAt).toLocaleString()}
+        </Tooltip>
+      ),
+    },
+  ];
+
+  return (
+    <Card
+      title={
+        <>
+          {t('clusterHealth.title')} {health && renderStatus(health.status)}
+        </>
+      }
+      extra={
+        <Button icon={<ReloadOutlined />} loading={loading} onClick={() => fetchHealth(true)}>
+          {t('clusterHealth.recheck')}
+        </Button>
+      }
+    >
+      <Table
+        dataSource={health?.checks || []}
+        columns={checkColumns}
+        rowKey="name"
+        loading={loading && !health}
+        pagination={false}
+        size="small"
+      />
+      {health && (
+        <Typography.Text type="secondary" style={{ display: 'block', marginTop: 8 }}>
+          {t('clusterHealth.checkedAt', { time: new Date(health.checkedAt).toLocaleString() })}
+        </Typography.Text>
+      )}
+      <Typography.Title level={5} style={{ marginTop: 16 }}>
+        {t('clusterHealth.alerts')}
+      </Typography.Title>
+      <Table
+        dataSource={[...alerts.firing, ...alerts.resolved]}
+        columns={alertColumns}
+        rowKey={alert => `${alert.id}/${alert.startsAt}`}
+        locale={{ emptyText: t('clusterHealth.noAlerts') }}
+        pagination={{ pageSize: 5 }}
+        size="small"
+      />
+    </Card>
+  );
+};
+
+export default ClusterHealthCard;
@@ -0,0 +1,178 @@
+import React, { useEffect, useState } from 'react';
+import { Modal, Table, Tag, Space, Button, Popconfirm, Alert, message } from 'antd';
+import { ReloadOutlined } from '@ant-design/icons';
+import { useTranslation } from 'react-i18next';
+import {
+  listConfigConsumers,
+  restartConfigConsumers,
+  ConfigConsumer,
+  ConfigKind,
+  ConfigUsage,
+  ConsumerRestartResult,
+} from '@/api/configConsumer';
+
+interface ConfigConsumersModalProps {
+  open: boolean;
+  onClose: () => void;
+  clusterName: string;
+  namespace: string;
+  kind: ConfigKind;
+  name: string;
+  // 配置刚被修改时提示重启引用方
+  updated?: boolean;
+}
+
+const restartStatusColors: Record<string, string> = {
+  restarted: 'green',
+  skipped: 'default',
+  failed: 'red',
+};
+
+const describeUsage = (usage: ConfigUsage) => {
+  switch (usage.type) {
+    case 'env':
+      return `${usage.container}: ${usage.env} ← ${usage.key}`;
+    case 'envFrom':
+      return `${usage.container}: envFrom`;
+    case 'volume':
+    case 'projected':
+      return `${usage.type}: ${usage.volume}`;
+    default:
+      return usage.type;
+  }
+};
+
+const ConfigConsumersModal: React.FC<ConfigConsumersModalProps> = ({
+  open,
+  onClose,
+  clusterName,
+  namespace,
+  kind,
+  name,
+  updated = false,
+}) => {
+  const { t } = useTranslation();
+  const [consumers, setConsumers] = useState<ConfigConsumer[]>([]);
+  const [results, setResults] = useState<Record<string, ConsumerRestartResult>>({});
+  const [loading, setLoading] = useState(false);
+  const [restarting, setRestarting] = useState(false);
+
+  const fetchConsumers = async () => {
+    setLoading(true);
+    try {
+      const response = await listConfigConsumers(clusterName, namespace, kind, name);
+      if (response.data.code === 0) {
+        setConsumers(response.data.data.consumers || []);
+      } else {
+        message.error(response.data.message || t('configConsumers.fetchFailed'));
+      }
+    } catch {
+      message.error(t('configConsumers.fetchFailed'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  useEffect(() => {
+    if (open && name) {
+      setResults({});
+      fetchConsumers();
+    }
+  }, [open, clusterName, namespace, kind, name]);
+
+  const handleRestart = async () => {
+    setRestarting(true);
+    try {
+      const response = await restartConfigConsumers(clusterName, namespace, kind, name);
+      if (response.data.code !== 0) {
+        message.error(response.data.message || t('configConsumers.restartFailed'));
+        return;
+      }
+      const byWorkload: Record<string, ConsumerRestartResult> = {};
+      (response.data.data.results || []).forEach((r) => {
+        byWorkload[`${r.kind}/${r.name}`] = r;
+      });
+      setResults(byWorkload);
+      message.success(t('configConsumers.restartSubmitted'));
+    } catch {
+      message.error(t('configConsumers.restartFailed'));
+    } finally {
+      setRestarting(false);
+    }
+  };
+
+  const restartable = consumers.filter((c) => c.restartable).length;
+
+  return (
+    <Modal
+      title={`${t('configConsumers.title')}: ${name}`}
+      open={open}
+      onCancel={onClose}
+      width={900}
+      footer={
+        <Space>
+          <Popconfirm
+            title={t('configConsumers.restartConfirm', { count: restartable })}
+            onConfirm={handleRestart}
+            disabled={restartable === 0}
+          >
+            <Button icon={<ReloadOutlined />} loading={restarting} disabled={restartable === 0}>
+              {t('configConsumers.restart')}
+            </Button>
+          </Popconfirm>
+          <Button type="primary" onClick={onClose}>
+            {t('common.close')}
+          </Button>
+        </Space>
+      }
+    >
+      {updated && consumers.length > 0 && (
+        <Alert type="info" showIcon style={{ marginBottom: 12 }} message={t('configConsumers.updatedHint')} />
+      )}
+      <Table
+        size="small"
+        loading={loading}
+        dataSource={consumers}
+        rowKey={(r) => `${r.kind}/${r.name}`}
+        pagination={false}
+        locale={{ emptyText: t('configConsumers.empty') }}
+        columns={[
+          { title: t('configConsumers.kind'), dataIndex: 'kind', key: 'kind', render: (k: string) => <Tag>{k}</Tag> },
+          { title: t('common.name'), dataIndex: 'name', key: 'name' },
+          {
+            title: t('configConsumers.usages'),
+            dataIndex: 'usages',
+            key: 'usages',
+            render: (usages: ConfigUsage[]) => (
+              <Space direction="vertical" size={0}>
+                {usages.map((u, i) => (
+                  <span key={i}>
+                    {describeUsage(u)}
+                    {u.optional && <Tag style={{ marginLeft: 4 }}>optional</Tag>}
+                  </span>
+                ))}
+              </Space>
+            ),
+          },
+          {
+            title: t('common.status'),
+            key: 'status',
+            render: (_: unknown, r: ConfigConsumer) => {
+              const result = results[`${r.kind}/${r.name}`];
+              if (result) {
+                return (
+                  <Tag color={restartStatusColors[result.status]} title={result.message}>
+                    {t(`configConsumers.status.${result.status}`)}
+                  </Tag>
+                );
+              }
+              return r.restartable ? null : <Tag>{t('configConsumers.notRestartable')}</Tag>;
+            },
+          },
+        ]}
+      />
+    </Modal>
+  );
+};
+
+export default ConfigConsumersModal;
@@ -0,0 +1,219 @@
+import React, { useEffect, useState } from 'react';
+import { Modal, Table, Tag, Space, Button, Popconfirm, Alert, Typography, message } from 'antd';
+import { DiffOutlined, RollbackOutlined } from '@ant-design/icons';
+import { useTranslation } from 'react-i18next';
+import { ConfigKind } from '@/api/configConsumer';
+import {
+  listConfigVersions,
+  diffConfigVersions,
+  restoreConfigVersion,
+  ConfigKeyDiff,
+  ConfigVersion,
+  ConfigVersionDiff,
+} from '@/api/configHistory';
+
+interface ConfigVersionsModalProps {
+  open: boolean;
+  onClose: () => void;
+  clusterName: string;
+  namespace: string;
+  kind: ConfigKind;
+  name: string;
+  // 恢复成功后回调，用于刷新列表并提示重启引用方
+  onRestored?: () => void;
+}
+
+const changeColors: Record<string, string> = {
+  added: 'green',
+  removed: 'red',
+  modified: 'orange',
+};
+
+const formatLabels = (labels?: Record<string, string>) =>
+  Object.entries(labels || {})
+    .map(([k, v]) => `${k}=${v}`)
+    .join(', ') || '-';
+
+const ConfigVersionsModal: React.FC<ConfigVersionsModalProps> = ({
+  open,
+  onClose,
+  clusterName,
+  namespace,
+  kind,
+  name,
+  onRestored,
+}) => {
+  const { t } = useTranslation();
+  const [versions, setVersions] = useState<ConfigVersion[]>([]);
+  const [loading, setLoading] = useState(false);
+  const [diff, setDiff] = useState<ConfigVersionDiff | null>(null);
+  const [diffLoading, setDiffLoading] = useState<number | null>(null);
+  const [restoring, setRestoring] = useState<number | null>(null);
+
+  const fetchVersions = async () => {
+    setLoading(true);
+    try {
+      const response = await listConfigVersions(clusterName, namespace, kind, name);
+      if (response.data.code === 0) {
+        setVersions(response.data.data.versions || []);
+      } else {
+        message.error(response.data.message || t('configVersions.fetchFailed'));
+      }
+    } catch {
+      message.error(t('configVersions.fetchFailed'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  useEffect(() => {
+    if (open && name) {
+      setDiff(null);
+      fetchVersions();
+    }
+  }, [open, clusterName, namespace, kind, name]);
+
+  const handleDiff = async (version: number) => {
+    setDiffLoading(version);
+    try {
+      const response = await diffConfigVersions(clusterName, namespace, kind, name, String(version));
+      if (response.data.code === 0) {
+        setDiff(response.data.data.diff);
+      } else {
+        message.error(response.data.message || t('configVersions.diffFailed'));
+      }
+    } catch {
+      message.error(t('configVersions.diffFailed'));
+    } finally {
+      setDiffLoading(null);
+    }
+  };
+
+  const handleRestore = async (version: number) => {
+    setRestoring(version);
+    try {
+      const response = await restoreConfigVersion(clusterName, namespace, kind, name, version);
+      if (response.data.code !== 0) {
+        message.error(response.data.message || t('configVersions.restoreFailed'));
+        return;
+      }
+      message.success(t('configVersions.restoreSuccess', { version }));
+      onRestored?.();
+    } catch {
+      message.error(t('configVersions.restoreFailed'));
+    } finally {
+      setRestoring(null);
+    }
+  };
+
+  return (
+    <Modal
+      title={`${t('configVersions.title')}: ${name}`}
+      open={open}
+      onCancel={onClose}
+      width={960}
+      footer={
+        <Button type="primary" onClick={onClose}>
+          {t('common.close')}
+        </Button>
+      }
+    >
+      <Alert type="info" showIcon style={{ marginBottom: 12 }} message={t('configVersions.hint')} />
+      <Table
+        size="small"
+        loading={loading}
+        dataSource={versions}
+        rowKey="version"
+        pagination={false}
+        locale={{ emptyText: t('configVersions.empty') }}
+        columns={[
+          { title: t('configVersions.version'), dataIndex: 'version', key: 'version', render: (v: number) => `#${v}` },
+          {
+            title: t('configVersions.keys'),
+            dataIndex: 'keys',
+            key: 'keys',
+            render: (keys: string[]) => (keys || []).map((k) => <Tag key={k}>{k}</Tag>),
+          },
+          {
+            title: t('configVersions.replacedBy'),
+            dataIndex: 'action',
+            key: 'action',
+            render: (action: string) => <Tag>{t(`configVersions.action.${action}`)}</Tag>,
+          },
+          { title: t('configVersions.user'), dataIndex: 'user', key: 'user', render: (u?: string) => u || '-' },
+          {
+            title: t('configVersions.time'),
+            dataIndex: 'createdAt',
+            key: 'createdAt',
+            render: (v: string) => new Date(v).toLocaleString(),
+          },
+          {
+            title: t('common.operations'),
+            key: 'actions',
+            render: (_: unknown, r: ConfigVersion) => (
+              <Space>
+                <Button type="link" icon={<DiffOutlined />} loading={diffLoading === r.version} onClick={() => handleDiff(r.version)}>
+                  {t('configVersions.compare')}
+                </Button>
+                <Popconfirm
+                  title={t('configVersions.restoreConfirm', { version: r.version })}
+                  onConfirm={() => handleRestore(r.version)}
+                >
+                  <Button type="link" icon={<RollbackOutlined />} loading={restoring === r.version}>
+                    {t('configVersions.restore')}
+                  </Button>
+                </Popconfirm>
+              </Space>
+            ),
+          },
+        ]}
+      />
+      {diff && (
+        <div style={{ marginTop: 16 }}>
+          <Typography.Title level={5}>
+            {t('configVersions.diffTitle', { from: `#${diff.from}`, to: t('configVersions.current') })}
+          </Typography.Title>
+          {diff.labelsChanged && (
+            <Alert
+              type="warning"
+              style={{ marginBottom: 12 }}
+              message={t('configVersions.labelsChanged')}
+              description={`${formatLabels(diff.fromLabels)} → ${formatLabels(diff.toLabels)}`}
+            />
+          )}
+          <Table
+            size="small"
+            dataSource={diff.keys}
+            rowKey="key"
+            pagination={false}
+            locale={{ emptyText: t('configVersions.noChanges') }}
+            columns={[
+              { title: t('common.key'), dataIndex: 'key', key: 'key' },
+              {
+                title: t('configVersions.change'),
+                dataIndex: 'change',
+                key: 'change',
+                render: (c: string) => <Tag color={changeColors[c]}>{t(`configVersions.changes.${c}`)}</Tag>,
+              },
+              {
+                title: t('configVersions.detail'),
+                key: 'detail',
+                render: (_: unknown, d: ConfigKeyDiff) =>
+                  d.diff ? (
+                    <pre style={{ margin: 0, maxHeight: 240, overflow: 'auto', fontSize: 12 }}>{d.diff}</pre>
+                  ) : (
+                    <Space direction="vertical" size={0}>
+                      {d.oldSha256 && <Typography.Text code>{`- ${d.oldSize}B ${d.oldSha256.slice(0, 16)}`}</Typography.Text>}
+                      {d.newSha256 && <Typography.Text code>{`+ ${d.newSize}B ${d.newSha256.slice(0, 16)}`}</Typography.Text>}
+                    </Space>
+                  ),
+              },
+            ]}
+          />
+        </div>
+      )}
+    </Modal>
+  );
+};
+
+export default ConfigVersionsModal;
@@ -0,0 +1,191 @@
+import React, { useEffect, useRef, useState } from 'react';
+import { Button, Select, Space, Spin, Alert, Progress } from 'antd';
+import { PauseCircleOutlined, PlayCircleOutlined, ReloadOutlined } from '@ant-design/icons';
+import { useTranslation } from 'react-i18next';
+import { Terminal } from '@xterm/xterm';
+import '@xterm/xterm/css/xterm.css';
+import { fetchRecordingCast } from '@/api/recording';
+
+interface CastHeader {
+  version: number;
+  width: number;
+  height: number;
+}
+
+// [elapsed seconds, event type ("o" | "i" | "r"), data]
+type CastEvent = [number, string, string];
+
+interface TerminalReplayProps {
+  recordingId: string;
+}
+
+// Parse an asciicast v2 file: first line is the header, then one JSON event per line
+const parseCast = (content: string): { header: CastHeader; events: CastEvent[] } => {
+  const lines = content.split('\n').filter((line) => line.trim() !== '');
+  const header = JSON.parse(lines[0]) as CastHeader;
+  const events: CastEvent[] = [];
+  for (const line of lines.slice(1)) {
+    try {
+      events.push(JSON.parse(line) as CastEvent);
+    } catch {
+      // ignore a truncated last line of an interrupted session
+    }
+  }
+  return { header, events };
+};
+
+const TerminalReplay: React.FC<TerminalReplayProps> = ({ recordingId }) => {
+  const { t } = useTranslation();
+  const containerRef = useRef<HTMLDivElement>(null);
+  const termRef = useRef<Terminal | null>(null);
+  const castRef = useRef<{ header: CastHeader; events: CastEvent[] } | null>(null);
+  const timerRef = useRef<number | null>(null);
+  const indexRef = useRef(0);
+  const speedRef = useRef(1);
+
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+  const [playing, setPlaying] = useState(false);
+  const [speed, setSpeed] = useState(1);
+  const [progress, setProgress] = useState(0);
+
+  const stopTimer = () => {
+    if (timerRef.current !== null) {
+      window.clearTimeout(timerRef.current);
+      timerRef.current = null;
+    }
+  };
+
+  // Play events from the current index, scheduling each one relative to the previous
+  const scheduleNext = () => {
+    const cast = castRef.current;
+    const term = termRef.current;
+    if (!cast || !term) return;
+
+    const index = indexRef.current;
+    if (index >= cast.events.length) {
+      setPlaying(false);
+      setProgress(100);
+      return;
+    }
+    const previous = index > 0 ? cast.events[index - 1][0] : 0;
+    // cap idle gaps so long pauses in the session do not stall the replay
+    const delay = Math.min(cast.events[index][0] - previous, 2) * 1000 / speedRef.current;
+
+    timerRef.current = window.setTimeout(() => {
+      const [, type, data] = cast.events[index];
+      if (type === 'o') {
+        term.write(data);
+      } else if (type === 'r') {
+        const [cols, rows] = data.split('x').map(Number);
+        if (cols > 0 && rows > 0) term.resize(cols, rows);
+      }
+      indexRef.current = index + 1;
+      setProgress(Math.round(((index + 1) / cast.events.length) * 100));
+      scheduleNext();
+    }, Math.max(delay, 0));
+  };
+
+  const play = () => {
+    if (!castRef.current) return;
+    if (indexRef.current >= castRef.current.events.length) {
+      restart();
+      return;
+    }
+    setPlaying(true);
+    scheduleNext();
+  };
+
+  const pause = () => {
+    stopTimer();
+    setPlaying(false);
+  };
+
+  const restart = () => {
+    stopTimer();
+    const cast = castRef.current;
+    const term = termRef.current;
+    if (!cast || !term) return;
+    term.reset();
+    term.resize(cast.header.width || 80, cast.header.height || 24);
+    indexRef.current = 0;
+    setProgress(0);
+    setPlaying(true);
+    scheduleNext();
+  };
+
+  useEffect(() => {
+    speedRef.current = speed;
+  }, [speed]);
+
+  useEffect(() => {
+    let disposed = false;
+
+    const load = async () => {
+      setLoading(true);
+      setError(null);
+      try {
+        const response = await fetchRecordingCast(recordingId);
+        if (disposed || !containerRef.current) return;
+        const cast = parseCast(response.data);
+        castRef.current = cast;
+
+        const term = new Terminal({
+          cols: cast.header.width || 80,
+          rows: cast.header.height || 24,
+          disableStdin: true,
+          fontFamily: 'Menlo, Monaco, "Courier New", monospace',
+          fontSize: 14,
+          theme: {
+            background: '#1e1e1e'
+          }
+        });
+        term.open(containerRef.current);
+        termRef.current = term;
+        setPlaying(true);
+        scheduleNext();
+      } catch (err: any) {
+        if (!disposed) setError(err?.message || t('recordings.loadFailed'));
+      } finally {
+        if (!disposed) setLoading(false);
+      }
+    };
+    load();
+
+    return () => {
+      disposed = true;
+      stopTimer();
+      termRef.current?.dispose();
+      termRef.current = null;
+      castRef.current = null;
+      indexRef.current = 0;
+    };
+  }, [recordingId]);
+
+  if (error) {
+    return <Alert type="error" message={t('recordings.loadFailed')} description={error} showIcon />;
+  }
+
+  return (
+    <Spin spinning={loading}>
+      <Space style={{ marginBottom: 12 }}>
+        {playing ? (
+          <Button icon={<PauseCircleOutlined />} onClick={pause}>{t('recordings.pause')}</Button>
+        ) : (
+          <Button type="primary" icon={<PlayCircleOutlined />} onClick={play}>{t('recordings.play')}</Button>
+        )}
+        <Button icon={<ReloadOutlined />} onClick={restart}>{t('recordings.restart')}</Button>
+        <Select
+          value={speed}
+          onChange={setSpeed}
+          style={{ width: 90 }}
+          options={[1, 2, 4, 8].map((v) => ({ value: v, label: `${v}x` }))}
+        />
+        <Progress percent={progress} size="small" style={{ width: 200, margin: 0 }} />
+      </Space>
+      <div ref={containerRef} style={{ background: '#1e1e1e', padding: 8, overflow: 'auto' }} />
+    </Spin>
+  );
+};
+
+export default TerminalReplay;
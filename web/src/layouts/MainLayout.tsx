@@ -1,9 +1,10 @@
 import React, { useEffect, useMemo, useState } from 'react';
-import { Layout, Menu } from 'antd';
-import { Outlet, useLocation } from 'react-router-dom';
+import { Button, Layout, Menu, Space } from 'antd';
+import { Outlet, useLocation, useNavigate } from 'react-router-dom';
 import { useTranslation } from 'react-i18next';
-import { MenuFoldOutlined, MenuUnfoldOutlined } from '@ant-design/icons';
+import { LogoutOutlined, MenuFoldOutlined, MenuUnfoldOutlined, UserOutlined } from '@ant-design/icons';
 import LanguageSwitcher from '../components/common/LanguageSwitcher';
+import { getCurrentUser, logout, Identity } from '../api/auth';
 import { buildMenuItems, createMenuConfig, resolveMenuState } from './menuConfig';
 
 const { Header, Sider, Content } = Layout;
@@ -11,7 +12,20 @@ const { Header, Sider, Content } = Layout;
 const MainLayout: React.FC = () => {
   const { t } = useTranslation();
   const [collapsed, setCollapsed] = useState(false);
+  const [user, setUser] = useState<Identity | null>(null);
   const location = useLocation();
+  const navigate = useNavigate();
+
+  useEffect(() => {
+    getCurrentUser()
+      .then((res) => setUser(res.data.data.user))
+      .catch(() => setUser(null));
+  }, []);
+
+  const handleLogout = async () => {
+    await logout().catch(() => undefined);
+    navigate('/login', { replace: true });
+  };
 
   const toggleCollapsed = () => {
     setCollapsed(!collapsed);
@@ -46,9 +60,17 @@ const MainLayout: React.FC = () => {
             {collapsed ? <MenuUnfoldOutlined /> : <MenuFoldOutlined />}
           </div>
         </div>
-        <div style={{ padding: '0 24px' }}>
+        <Space style={{ padding: '0 24px' }}>
+          {user && user.method !== 'anonymous' && (
+            <>
+              <span><UserOutlined /> {user.username}</span>
+              <Button type="text" icon={<LogoutOutlined />} onClick={handleLogout}>
+                {t('auth.logout')}
+              </Button>
+            </>
+          )}
           <LanguageSwitcher />
-        </div>
+        </Space>
       </Header>
       <Layout>
         <Sider 
@@ -19,6 +19,7 @@ import {
   ThunderboltOutlined,
   FileProtectOutlined,
   KeyOutlined,
+  VideoCameraOutlined,
 } from '@ant-design/icons';
 
 export type MenuConfigItem = {
@@ -232,6 +233,13 @@ export const createMenuConfig = (
         path: '/governance/rbac',
         match: (pathname) => pathname.startsWith('/governance/rbac'),
       },
+      {
+        key: 'recordings',
+        icon: <VideoCameraOutlined />,
+        label: t('navigation.recordings'),
+        path: '/governance/recordings',
+        match: (pathname) => pathname.startsWith('/governance/recordings'),
+      },
     ],
   },
   {
@@ -1,15 +1,17 @@
 import React, { useState, useEffect } from 'react';
-import { Card, Descriptions, Space, Button, message, Spin, Table, Tag, Tabs, Progress, Row, Col, Statistic } from 'antd';
+import { Card, Descriptions, Space, Button, message, Spin, Table, Tag, Tabs, Progress, Row, Col, Statistic, Switch } from 'antd';
 import { useParams, useNavigate } from 'react-router-dom';
 import { useTranslation } from 'react-i18next';
 import {
   testClusterConnection,
   getClusterDetails,
   getClusterMetrics,
-  getClusterEvents
+  getClusterEvents,
+  setClusterImpersonation
 } from '../api/cluster';
 import type { ClusterDetail, ClusterMetrics } from '../api/cluster';
 import K8sEvents from '../components/k8s/common/K8sEvents';
+import ClusterHealthCard from '../components/k8s/cluster/ClusterHealthCard';
 import {
   LineChart,
   Line,
@@ -97,6 +99,23 @@ const ClusterDetailPage: React.FC = () => {
     }
   };
 
+  // 开启/关闭用户模拟（仅管理员可操作）
+  const handleImpersonationChange = async (enabled: boolean) => {
+    if (!clusterName) return;
+
+    try {
+      const response = await setClusterImpersonation(clusterName, enabled);
+      if (response.data.code === 0) {
+        setClusterInfo(prev => (prev ? { ...prev, impersonate: enabled } : prev));
+        message.success(t('clusterDetail.impersonationUpdated'));
+      } else {
+        message.error(response.data.message || t('clusterDetail.impersonationUpdateFailed'));
+      }
+    } catch (err) {
+      message.error(t('clusterDetail.impersonationUpdateFailed'));
+    }
+  };
+
   // 获取集群监控指标
   const fetchClusterMetrics = async () => {
     if (!clusterName || connectionStatus !== 'connected') return;
@@ -229,9 +248,18 @@ const ClusterDetailPage: React.FC = () => {
               {clusterInfo?.addType === 'content' && (t('clusters.addTypeContent') || '通过内容填写')}
               {(!clusterInfo?.addType || clusterInfo?.addType === 'unknown') && (t('clusters.addTypeUnknown') || '未知方式')}
             </Descriptions.Item>
+            <Descriptions.Item label={t('clusterDetail.basicInfo.impersonate')}>
+              <Switch
+                checked={!!clusterInfo?.impersonate}
+                onChange={handleImpersonationChange}
+              />
+            </Descriptions.Item>
           </Descriptions>
         </Card>
 
+        {/* 健康检查与告警 */}
+        <ClusterHealthCard clusterName={clusterName} />
+
         {/* 监控仪表板 */}
         {connectionStatus === 'connected' && (
           <Card
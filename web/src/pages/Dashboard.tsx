@@ -1,5 +1,5 @@
 import React, { useState, useEffect, useMemo } from 'react';
-import { Card, Row, Col, Statistic, Spin, Select, Button, Space, Progress, Tabs, Alert, List } from 'antd';
+import { Card, Row, Col, Statistic, Spin, Select, Button, Space, Progress, Tabs, Alert, List, Tag, Tooltip as AntTooltip } from 'antd';
 import {
   LineChart,
   Line,
@@ -156,6 +156,14 @@ const Dashboard: React.FC = () => {
 
   const clusterAlerts = useMemo(() => buildClusterAlerts(metrics, t), [metrics, t]);
 
+  const historySourceTag = metrics?.historySource && (
+    <AntTooltip title={t(`dashboard.historySource.${metrics.historySource}Hint`)}>
+      <Tag color={metrics.historySource === 'prometheus' ? 'blue' : 'default'}>
+        {t(`dashboard.historySource.${metrics.historySource}`)}
+      </Tag>
+    </AntTooltip>
+  );
+
   return (
     <div>
       <Card
@@ -283,7 +291,7 @@ const Dashboard: React.FC = () => {
 
                     <Row gutter={[16, 16]}>
                       <Col span={12}>
-                        <Card title={t('dashboard.cpuUsageHistory')}>
+                        <Card title={t('dashboard.cpuUsageHistory')} extra={historySourceTag}>
                           <ResponsiveContainer width="100%" height={300}>
                             <LineChart
                               data={metrics?.historicalData?.cpuUsage?.map((item: any) => ({
@@ -309,7 +317,7 @@ const Dashboard: React.FC = () => {
                         </Card>
                       </Col>
                       <Col span={12}>
-                        <Card title={t('dashboard.memoryUsageHistory')}>
+                        <Card title={t('dashboard.memoryUsageHistory')} extra={historySourceTag}>
                           <ResponsiveContainer width="100%" height={300}>
                             <LineChart
                               data={metrics?.historicalData?.memoryUsage?.map((item: any) => ({
@@ -419,7 +427,7 @@ const Dashboard: React.FC = () => {
                         </Card>
                       </Col>
                       <Col span={12}>
-                        <Card title={t('dashboard.podCountHistory')}>
+                        <Card title={t('dashboard.podCountHistory')} extra={historySourceTag}>
                           <ResponsiveContainer width="100%" height={300}>
                             <LineChart
                               data={metrics?.historicalData?.podCount?.map((item: any) => ({
@@ -0,0 +1,67 @@
+import React, { useEffect, useState } from 'react';
+import { Button, Card, Divider, Form, Input, message } from 'antd';
+import { LockOutlined, UserOutlined } from '@ant-design/icons';
+import { useNavigate } from 'react-router-dom';
+import { useTranslation } from 'react-i18next';
+import { getAuthConfig, login, oidcLoginURL } from '../api/auth';
+
+const Login: React.FC = () => {
+  const { t } = useTranslation();
+  const navigate = useNavigate();
+  const [loading, setLoading] = useState(false);
+  const [oidcEnabled, setOidcEnabled] = useState(false);
+
+  useEffect(() => {
+    getAuthConfig()
+      .then((res) => {
+        if (!res.data.data.enabled) {
+          navigate('/', { replace: true });
+          return;
+        }
+        setOidcEnabled(res.data.data.oidcEnabled);
+      })
+      .catch(() => setOidcEnabled(false));
+  }, [navigate]);
+
+  const onFinish = async (values: { username: string; password: string }) => {
+    setLoading(true);
+    try {
+      await login(values.username, values.password);
+      navigate('/', { replace: true });
+    } catch {
+      message.error(t('auth.invalidCredentials'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div style={{ minHeight: '100vh', display: 'flex', alignItems: 'center', justifyContent: 'center', background: '#f0f2f5' }}>
+      <Card title={t('app.title')} style={{ width: 360 }}>
+        <Form onFinish={onFinish} autoComplete="on">
+          <Form.Item name="username" rules={[{ required: true, message: t('auth.usernameRequired') }]}>
+            <Input prefix={<UserOutlined />} placeholder={t('auth.username')} autoComplete="username" />
+          </Form.Item>
+          <Form.Item name="password" rules={[{ required: true, message: t('auth.passwordRequired') }]}>
+            <Input.Password prefix={<LockOutlined />} placeholder={t('auth.password')} autoComplete="current-password" />
+          </Form.Item>
+          <Form.Item style={{ marginBottom: 0 }}>
+            <Button type="primary" htmlType="submit" loading={loading} block>
+              {t('auth.login')}
+            </Button>
+          </Form.Item>
+        </Form>
+        {oidcEnabled && (
+          <>
+            <Divider plain>{t('auth.or')}</Divider>
+            <Button block href={oidcLoginURL}>
+              {t('auth.loginWithSSO')}
+            </Button>
+          </>
+        )}
+      </Card>
+    </div>
+  );
+};
+
+export default Login;
@@ -1,9 +1,11 @@
 import React, { useState, useEffect } from 'react';
 import { Card, Table, Tag, Space, message, Button, Popconfirm, Modal, Form, Input } from 'antd';
-import { PlusOutlined, EditOutlined, DeleteOutlined, EyeOutlined } from '@ant-design/icons';
+import { PlusOutlined, EditOutlined, DeleteOutlined, EyeOutlined, ApartmentOutlined, HistoryOutlined } from '@ant-design/icons';
 import { useTranslation } from 'react-i18next';
 import { useClusterNamespace } from '@/hooks/useClusterNamespace';
 import ClusterNamespaceToolbar from '@/components/k8s/common/ClusterNamespaceToolbar';
+import ConfigConsumersModal from '@/components/k8s/common/ConfigConsumersModal';
+import ConfigVersionsModal from '@/components/k8s/common/ConfigVersionsModal';
 import {
   listConfigMapsByNamespace,
   createConfigMap,
@@ -23,6 +25,8 @@ const ConfigMaps: React.FC = () => {
   const [viewModalVisible, setViewModalVisible] = useState(false);
   const [editing, setEditing] = useState<ConfigMapInfo | null>(null);
   const [viewData, setViewData] = useState<Record<string, string>>({});
+  const [consumersOf, setConsumersOf] = useState<{ name: string; updated: boolean } | null>(null);
+  const [versionsOf, setVersionsOf] = useState<string | null>(null);
   const [form] = Form.useForm();
 
   const fetchItems = async () => {
@@ -108,6 +112,7 @@ const ConfigMaps: React.FC = () => {
       if (editing) {
         await updateConfigMap(selectedCluster, namespace, editing.name, { data });
         message.success(t('configMaps.updateSuccess'));
+        setConsumersOf({ name: editing.name, updated: true });
       } else {
         await createConfigMap(selectedCluster, namespace, { name: values.name, data });
         message.success(t('configMaps.createSuccess'));
@@ -152,6 +157,12 @@ const ConfigMaps: React.FC = () => {
           <Button type="link" icon={<EditOutlined />} onClick={() => openEdit(record)}>
             {t('common.edit')}
           </Button>
+          <Button type="link" icon={<ApartmentOutlined />} onClick={() => setConsumersOf({ name: record.name, updated: false })}>
+            {t('configConsumers.title')}
+          </Button>
+          <Button type="link" icon={<HistoryOutlined />} onClick={() => setVersionsOf(record.name)}>
+            {t('configVersions.title')}
+          </Button>
           <Popconfirm title={t('configMaps.deleteConfirm')} onConfirm={() => handleDelete(record.name)}>
             <Button type="link" danger icon={<DeleteOutlined />}>
               {t('common.delete')}
@@ -220,6 +231,30 @@ const ConfigMaps: React.FC = () => {
       >
         <pre style={{ maxHeight: 400, overflow: 'auto' }}>{JSON.stringify(viewData, null, 2)}</pre>
       </Modal>
+
+      <ConfigConsumersModal
+        open={!!consumersOf}
+        onClose={() => setConsumersOf(null)}
+        clusterName={selectedCluster}
+        namespace={namespace}
+        kind="configmaps"
+        name={consumersOf?.name || ''}
+        updated={consumersOf?.updated}
+      />
+
+      <ConfigVersionsModal
+        open={!!versionsOf}
+        onClose={() => setVersionsOf(null)}
+        clusterName={selectedCluster}
+        namespace={namespace}
+        kind="configmaps"
+        name={versionsOf || ''}
+        onRestored={() => {
+          setConsumersOf({ name: versionsOf || '', updated: true });
+          setVersionsOf(null);
+          fetchItems();
+        }}
+      />
     </Card>
   );
 };
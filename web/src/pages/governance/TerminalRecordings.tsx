@@ -0,0 +1,219 @@
+import React, { useEffect, useState } from 'react';
+import { Card, Table, Button, Space, Select, Input, Modal, Tag, message } from 'antd';
+import { DeleteOutlined, DownloadOutlined, PlayCircleOutlined, PlusOutlined, SaveOutlined } from '@ant-design/icons';
+import { useTranslation } from 'react-i18next';
+import { useClusterNamespace } from '@/hooks/useClusterNamespace';
+import TerminalReplay from '@/components/k8s/recording/TerminalReplay';
+import {
+  listRecordings,
+  getRecordingRules,
+  updateRecordingRules,
+  recordingDownloadURL,
+  RecordingMeta,
+  RecordingRule,
+} from '@/api/recording';
+import { formatFileSize } from '@/utils/format';
+
+const TerminalRecordings: React.FC = () => {
+  const { t } = useTranslation();
+  const { clusters } = useClusterNamespace(t);
+  const [items, setItems] = useState<RecordingMeta[]>([]);
+  const [loading, setLoading] = useState(false);
+  const [filters, setFilters] = useState<{ cluster?: string; user?: string }>({});
+  const [rules, setRules] = useState<RecordingRule[]>([]);
+  const [rulesSaving, setRulesSaving] = useState(false);
+  const [replayId, setReplayId] = useState<string | null>(null);
+
+  const fetchItems = async () => {
+    setLoading(true);
+    try {
+      const response = await listRecordings(filters);
+      if (response.data.code === 0) {
+        setItems(response.data.data.recordings || []);
+      } else {
+        message.error(response.data.message || t('recordings.fetchFailed'));
+      }
+    } catch {
+      message.error(t('recordings.fetchFailed'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const fetchRules = async () => {
+    try {
+      const response = await getRecordingRules();
+      if (response.data.code === 0) {
+        setRules(response.data.data.rules || []);
+      }
+    } catch {
+      message.error(t('recordings.rulesFetchFailed'));
+    }
+  };
+
+  useEffect(() => {
+    fetchItems();
+  }, [filters]);
+
+  useEffect(() => {
+    fetchRules();
+  }, []);
+
+  const saveRules = async () => {
+    setRulesSaving(true);
+    try {
+      const response = await updateRecordingRules(rules.filter((rule) => rule.cluster));
+      if (response.data.code === 0) {
+        message.success(t('recordings.rulesSaved'));
+        setRules(response.data.data.rules);
+      } else {
+        message.error(response.data.message || t('recordings.rulesSaveFailed'));
+      }
+    } catch {
+      message.error(t('recordings.rulesSaveFailed'));
+    } finally {
+      setRulesSaving(false);
+    }
+  };
+
+  const updateRule = (index: number, rule: RecordingRule) => {
+    setRules(rules.map((r, i) => (i === index ? rule : r)));
+  };
+
+  const ruleColumns = [
+    {
+      title: t('recordings.cluster'),
+      key: 'cluster',
+      render: (_: unknown, rule: RecordingRule, index: number) => (
+        <Select
+          value={rule.cluster || undefined}
+          style={{ width: 200 }}
+          placeholder={t('recordings.selectCluster')}
+          options={[{ value: '*', label: '*' }, ...clusters.map((c) => ({ value: c, label: c }))]}
+          onChange={(cluster) => updateRule(index, { ...rule, cluster })}
+        />
+      ),
+    },
+    {
+      title: t('common.namespace'),
+      key: 'namespaces',
+      render: (_: unknown, rule: RecordingRule, index: number) => (
+        <Select
+          mode="tags"
+          value={rule.namespaces || []}
+          style={{ minWidth: 300 }}
+          placeholder={t('recordings.allNamespaces')}
+          onChange={(namespaces) => updateRule(index, { ...rule, namespaces })}
+        />
+      ),
+    },
+    {
+      title: t('common.operations'),
+      key: 'action',
+      render: (_: unknown, _rule: RecordingRule, index: number) => (
+        <Button danger icon={<DeleteOutlined />} onClick={() => setRules(rules.filter((_, i) => i !== index))} />
+      ),
+    },
+  ];
+
+  const columns = [
+    {
+      title: t('recordings.startedAt'),
+      dataIndex: 'startedAt',
+      key: 'startedAt',
+      render: (v: string) => new Date(v).toLocaleString(),
+    },
+    { title: t('recordings.user'), dataIndex: 'user', key: 'user' },
+    { title: t('recordings.cluster'), dataIndex: 'cluster', key: 'cluster' },
+    { title: t('common.namespace'), dataIndex: 'namespace', key: 'namespace' },
+    {
+      title: t('recordings.target'),
+      key: 'target',
+      render: (_: unknown, r: RecordingMeta) => (
+        <span>
+          {r.pod} <Tag>{r.container}</Tag>
+        </span>
+      ),
+    },
+    {
+      title: t('recordings.duration'),
+      dataIndex: 'duration',
+      key: 'duration',
+      render: (v: number, r: RecordingMeta) => (r.endedAt ? `${Math.round(v)}s` : <Tag color="processing">{t('recordings.inProgress')}</Tag>),
+    },
+    {
+      title: t('recordings.size'),
+      dataIndex: 'size',
+      key: 'size',
+      render: (v: number) => formatFileSize(v),
+    },
+    {
+      title: t('common.operations'),
+      key: 'action',
+      render: (_: unknown, r: RecordingMeta) => (
+        <Space>
+          <Button size="small" icon={<PlayCircleOutlined />} onClick={() => setReplayId(r.id)}>
+            {t('recordings.replay')}
+          </Button>
+          <Button size="small" icon={<DownloadOutlined />} href={recordingDownloadURL(r.id)}>
+            {t('recordings.download')}
+          </Button>
+        </Space>
+      ),
+    },
+  ];
+
+  return (
+    <Space direction="vertical" style={{ width: '100%' }} size="large">
+      <Card
+        title={t('recordings.rulesTitle')}
+        extra={
+          <Space>
+            <Button icon={<PlusOutlined />} onClick={() => setRules([...rules, { cluster: '', namespaces: [] }])}>
+              {t('recordings.addRule')}
+            </Button>
+            <Button type="primary" icon={<SaveOutlined />} loading={rulesSaving} onClick={saveRules}>
+              {t('common.save')}
+            </Button>
+          </Space>
+        }
+      >
+        <Table rowKey={(_, index) => String(index)} columns={ruleColumns} dataSource={rules} pagination={false} size="small" />
+      </Card>
+      <Card
+        title={t('recordings.title')}
+        extra={
+          <Space>
+            <Select
+              allowClear
+              placeholder={t('recordings.cluster')}
+              style={{ width: 180 }}
+              options={clusters.map((c) => ({ value: c, label: c }))}
+              onChange={(cluster) => setFilters({ ...filters, cluster })}
+            />
+            <Input.Search
+              allowClear
+              placeholder={t('recordings.user')}
+              style={{ width: 180 }}
+              onSearch={(user) => setFilters({ ...filters, user: user || undefined })}
+            />
+          </Space>
+        }
+      >
+        <Table rowKey="id" columns={columns} dataSource={items} loading={loading} />
+      </Card>
+      <Modal
+        open={replayId !== null}
+        title={t('recordings.replayTitle')}
+        width={1000}
+        footer={null}
+        destroyOnHidden
+        onCancel={() => setReplayId(null)}
+      >
+        {replayId && <TerminalReplay recordingId={replayId} />}
+      </Modal>
+    </Space>
+  );
+};
+
+export default TerminalRecordings;